@@ -0,0 +1,79 @@
+// Export streams users straight from Postgres as CSV, NDJSON or Parquet, to a file or to
+// stdout. Filters use the query string syntax of GET /users, e.g. -filter "role=admin".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"usermanager/internal/config"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+	"usermanager/internal/infrastructure/logger"
+	"usermanager/internal/registry"
+	"usermanager/internal/utils"
+)
+
+const dotEnv = "./configs/.env"
+
+func main() {
+	output := flag.String("output", "", "file to write, stdout by default")
+	format := flag.String("format", "", "csv, ndjson or parquet, taken from the output file extension by default")
+	fields := flag.String("fields", "", "comma separated fields, every user field without votes by default")
+	filter := flag.String("filter", "", "filters of the users listing as a query string")
+	flag.Parse()
+
+	logger := logger.NewLogger()
+	if *format == "" {
+		*format = model.UserExportFormatCSV
+		if *output != "" {
+			*format = *output
+		}
+	}
+	params, err := url.ParseQuery(*filter)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	userFilter, err := utils.GetUserFilterFromCtx(params)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if userFilter.IsEmpty() {
+		userFilter = nil
+	}
+	exportQuery, err := model.NewUserExportQuery(*format, *fields, userFilter)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	cfg, err := config.NewConfig(dotEnv)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	db, err := datastore.NewDB(cfg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	// The export reads Postgres only, so no Redis connection is made.
+	reg := registry.NewRegistry(db, nil, cfg)
+	exported, err := reg.NewUserExportUsecase().ExportUsers(context.Background(), exportQuery, w)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "%d users exported\n", exported)
+}
@@ -54,7 +54,7 @@ func main() {
 	}
 	fmt.Println(user)
 
-	err = grpcService.DeleteUser(ctx, &user.UserID)
+	err = grpcService.DeleteUser(ctx, &user.UserID, user.Version)
 	if err != nil {
 		logger.Fatal(err)
 	}
@@ -3,14 +3,17 @@ package main
 import (
 	"fmt"
 	"net"
+	"time"
 
 	usergrpc "usermanager/grpc"
 	usergrpcServer "usermanager/grpc/server"
 	"usermanager/internal/config"
+	"usermanager/internal/domain/model"
 	"usermanager/internal/infrastructure/datastore"
 	"usermanager/internal/infrastructure/logger"
 	"usermanager/internal/interface/repository"
 	"usermanager/internal/usecase/usecase"
+	"usermanager/internal/utils"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -38,15 +41,53 @@ func main() {
 	}
 
 	userUsecase := usecase.NewUserUsecase(
-		repository.NewUserRepository(db),
+		repository.NewUserRepository(db, utils.NewCursorCodec(cfg.Pagination.CursorSecret)),
 		repository.NewVoteRepository(db),
 		repository.NewUserRedisRepository(redisClient),
 		repository.NewVoteRedisRepository(redisClient),
+		repository.NewUserAttributeSchemaRepository(db),
+		repository.NewNicknameRepository(db),
+		repository.NewAuditRepository(db),
+		&model.NicknamePolicy{ChangeCooldown: time.Duration(cfg.Nickname.ChangeCooldown) * time.Second},
+		newBlobStore(cfg),
 	)
 
-	userGrpcController := usergrpcServer.NewUserManagerGrpcController(userUsecase)
+	userHierarchyUsecase := usecase.NewUserHierarchyUsecase(
+		repository.NewUserRepository(db, utils.NewCursorCodec(cfg.Pagination.CursorSecret)),
+		repository.NewUserRedisRepository(redisClient),
+		repository.NewAuditRepository(db),
+		userUsecase,
+		&model.UserHierarchyPolicy{
+			MaxDepth:  cfg.Hierarchy.MaxDepth,
+			OnDelete:  cfg.Hierarchy.DeletePolicy,
+			OnSuspend: cfg.Hierarchy.SuspendPolicy,
+		},
+	)
+
+	userSettingUsecase := usecase.NewUserSettingUsecase(
+		repository.NewUserRepository(db, utils.NewCursorCodec(cfg.Pagination.CursorSecret)),
+		repository.NewUserSettingRepository(db),
+		repository.NewUserSettingRedisRepository(redisClient, time.Duration(cfg.Settings.CacheTtl)*time.Second),
+		repository.NewAuditRepository(db),
+	)
+
+	roleGrantUsecase := usecase.NewRoleGrantUsecase(
+		repository.NewUserRepository(db, utils.NewCursorCodec(cfg.Pagination.CursorSecret)),
+		repository.NewRoleGrantRepository(db),
+		repository.NewEventRedisRepository(redisClient),
+		repository.NewAuditRepository(db),
+		&model.RoleGrantPolicy{
+			MaxDuration:            cfg.Role.GrantMaxDuration,
+			AutoApproveRoles:       cfg.Role.GrantAutoApproveRoles,
+			AutoApproveMaxDuration: cfg.Role.GrantAutoApproveMaxDuration,
+		},
+	)
 
-	grpcServer := grpc.NewServer()
+	activeUserUsecase := usecase.NewActiveUserUsecase(userUsecase, roleGrantUsecase)
+
+	userGrpcController := usergrpcServer.NewUserManagerGrpcController(userUsecase, userHierarchyUsecase, userSettingUsecase, cfg.Profile.PrivateMode)
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(usergrpcServer.NewAuthInterceptor(cfg.Jwt.Secret, activeUserUsecase)))
 	usergrpc.RegisterUserUsecaseServer(grpcServer, userGrpcController)
 	reflection.Register(grpcServer)
 	listener, err := net.Listen("tcp", ":"+cfg.PortGrpc)
@@ -60,3 +101,18 @@ func main() {
 		logger.Fatal(err)
 	}
 }
+
+func newBlobStore(cfg *config.Config) repository.BlobStore {
+	if cfg.Blob.Storage == config.BlobStorageS3 {
+		return repository.NewS3BlobStore(repository.S3BlobStoreConfig{
+			Endpoint:  cfg.Blob.S3Endpoint,
+			Region:    cfg.Blob.S3Region,
+			Bucket:    cfg.Blob.S3Bucket,
+			AccessKey: cfg.Blob.S3AccessKey,
+			SecretKey: cfg.Blob.S3SecretKey,
+			PathStyle: cfg.Blob.S3PathStyle,
+			PublicURL: cfg.Blob.PublicURL,
+		})
+	}
+	return repository.NewLocalBlobStore(cfg.Blob.LocalDir, cfg.Blob.PublicURL)
+}
@@ -0,0 +1,81 @@
+// Import runs a bulk user import in the foreground, prints the per-row report as NDJSON
+// to stdout and exits with status 1 when any row failed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"usermanager/internal/config"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+	"usermanager/internal/infrastructure/logger"
+	"usermanager/internal/registry"
+)
+
+const dotEnv = "./configs/.env"
+
+func main() {
+	file := flag.String("file", "", "CSV or NDJSON file with the users")
+	format := flag.String("format", "", "csv or ndjson, taken from the file extension by default")
+	dryRun := flag.Bool("dry-run", false, "validate the rows without writing anything")
+	upsert := flag.Bool("upsert", false, "update users whose nickname already exists")
+	generatePasswords := flag.Bool("generate-passwords", false, "generate a password for new users without one")
+	createdBy := flag.String("created-by", "", "stored as created_by of the new users")
+	flag.Parse()
+
+	logger := logger.NewLogger()
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = *file
+	}
+	importFormat, err := model.UserImportFormat(*format)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	payload, err := os.ReadFile(*file)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	cfg, err := config.NewConfig(dotEnv)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	db, err := datastore.NewDB(cfg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	redisClient, err := datastore.NewRedisClient(cfg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	reg := registry.NewRegistry(db, redisClient, cfg)
+	job := model.NewUserImportJob(importFormat, model.UserImportOptions{
+		DryRun:            *dryRun,
+		Upsert:            *upsert,
+		GeneratePasswords: *generatePasswords,
+	}, *createdBy)
+	job, err = reg.NewUserImportUsecase().RunImportJob(context.Background(), job, payload)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, result := range job.Report {
+		if err = encoder.Encode(result); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "job %s: %d rows, %d imported, %d failed\n", job.JobID, job.Total, job.Succeeded, job.Failed)
+	if job.Failed > 0 {
+		os.Exit(1)
+	}
+}
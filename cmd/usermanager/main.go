@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"usermanager/internal/apperrors"
 	"usermanager/internal/config"
 	"usermanager/internal/infrastructure/datastore"
@@ -31,9 +33,14 @@ func main() {
 	}
 
 	reg := registry.NewRegistry(db, redisClient, cfg)
+	for _, w := range reg.NewWorkers(logger) {
+		go w.Run(context.Background())
+	}
 
+	// the trusted proxies have been checked when the config was loaded
+	trustedProxies, _ := cfg.TrustedProxyRanges()
 	e := echo.New()
-	e = router.NewRouter(e, reg.NewAppController())
+	e = router.NewRouter(e, reg.NewAppController(), trustedProxies)
 
 	logger.Println("app starting")
 
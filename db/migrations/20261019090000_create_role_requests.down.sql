@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS role_history;
+DROP TABLE IF EXISTS role_requests;
\ No newline at end of file
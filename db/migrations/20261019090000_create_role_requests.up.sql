@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS role_requests (
+    request_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    role VARCHAR(255) NOT NULL,
+    previous_role VARCHAR(255),
+    reason TEXT,
+    status VARCHAR(32) NOT NULL,
+    requested_by UUID NOT NULL,
+    reviewed_by UUID,
+    review_reason TEXT,
+    created_at TIMESTAMP NOT NULL,
+    reviewed_at TIMESTAMP
+);
+CREATE INDEX idx_role_requests_user_id ON role_requests (user_id);
+CREATE INDEX idx_role_requests_status ON role_requests (status);
+
+CREATE TABLE IF NOT EXISTS role_history (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    previous_role VARCHAR(255),
+    role VARCHAR(255) NOT NULL,
+    changed_by UUID NOT NULL,
+    request_id UUID,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_role_history_user_id ON role_history (user_id);
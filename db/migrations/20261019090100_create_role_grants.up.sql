@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS role_grants (
+    grant_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    role VARCHAR(255) NOT NULL,
+    justification TEXT NOT NULL,
+    duration INTEGER NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    auto_approved BOOLEAN NOT NULL DEFAULT FALSE,
+    reviewed_by UUID,
+    revoked_by UUID,
+    created_at TIMESTAMP NOT NULL,
+    reviewed_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+CREATE INDEX idx_role_grants_user_id_status ON role_grants (user_id, status);
+CREATE INDEX idx_role_grants_status_expires_at ON role_grants (status, expires_at);
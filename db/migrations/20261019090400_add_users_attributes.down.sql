@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_attribute_schemas;
+DROP INDEX IF EXISTS idx_users_attributes;
+ALTER TABLE users DROP COLUMN IF EXISTS attributes;
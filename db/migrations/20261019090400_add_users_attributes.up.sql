@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX idx_users_attributes ON users USING GIN (attributes);
+CREATE TABLE IF NOT EXISTS user_attribute_schemas (
+    schema_id BIGSERIAL PRIMARY KEY,
+    schema JSONB NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+INSERT INTO user_attribute_schemas (schema, created_at)
+VALUES ('{"type": "object", "properties": {}, "additionalProperties": false}', now());
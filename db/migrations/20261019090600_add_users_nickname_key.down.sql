@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS nickname_history;
+DROP TABLE IF EXISTS reserved_nicknames;
+DROP INDEX IF EXISTS idx_users_nickname;
+DROP INDEX IF EXISTS idx_users_nickname_key;
+ALTER TABLE users DROP COLUMN IF EXISTS nickname_key;
@@ -0,0 +1,45 @@
+-- nickname_key is written by the application with utils.NicknameKey. The backfill approximates
+-- it with NFKC, lower() and the same look-alike pairs.
+ALTER TABLE users ADD COLUMN nickname_key VARCHAR(250);
+UPDATE users SET nickname_key = translate(
+    lower(normalize(coalesce(nickname, ''), NFKC)),
+    '01|ıаеорсухіјѕԁһԛԝѵαονρικχυ',
+    'olliaeopcyxijsdhqwvaovpikxu'
+);
+
+-- Users that already share a key keep their nicknames; only the oldest one keeps the key, the
+-- others are still found by their exact nickname.
+WITH duplicates AS (
+    SELECT user_id, row_number() OVER (PARTITION BY nickname_key ORDER BY created_at, user_id) AS position
+    FROM users
+    WHERE deleted_at IS NULL
+)
+UPDATE users SET nickname_key = NULL
+FROM duplicates
+WHERE duplicates.user_id = users.user_id AND duplicates.position > 1;
+
+CREATE UNIQUE INDEX idx_users_nickname_key ON users (nickname_key) WHERE deleted_at IS NULL;
+CREATE INDEX idx_users_nickname ON users (nickname);
+
+CREATE TABLE IF NOT EXISTS reserved_nicknames (
+    nickname_key VARCHAR(250) PRIMARY KEY,
+    nickname VARCHAR(250) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+INSERT INTO reserved_nicknames (nickname_key, nickname, created_at)
+SELECT nickname, nickname, now()
+FROM unnest(ARRAY['admin', 'administrator', 'root', 'system', 'support', 'help', 'moderator', 'api',
+    'me', 'settings', 'login', 'logout', 'register', 'signup', 'user', 'users', 'null', 'undefined']) AS nickname;
+
+CREATE TABLE IF NOT EXISTS nickname_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    nickname VARCHAR(250) NOT NULL,
+    nickname_key VARCHAR(250) NOT NULL,
+    new_nickname VARCHAR(250) NOT NULL,
+    changed_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_nickname_history_user_id ON nickname_history (user_id, changed_at);
+CREATE INDEX idx_nickname_history_nickname_key ON nickname_history (nickname_key, changed_at);
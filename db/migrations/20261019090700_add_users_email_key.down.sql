@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_changes;
+DROP INDEX IF EXISTS idx_users_email_key;
+ALTER TABLE users DROP COLUMN IF EXISTS email_key;
@@ -0,0 +1,30 @@
+-- email_key is written by the application with utils.EmailKey.
+ALTER TABLE users ADD COLUMN email_key VARCHAR(250);
+UPDATE users SET email_key = lower(normalize(trim(email), NFKC)) WHERE coalesce(trim(email), '') <> '';
+
+-- Users that already share an email keep it; only the oldest one keeps the key and can log in
+-- with it.
+WITH duplicates AS (
+    SELECT user_id, row_number() OVER (PARTITION BY email_key ORDER BY created_at, user_id) AS position
+    FROM users
+    WHERE deleted_at IS NULL AND email_key IS NOT NULL
+)
+UPDATE users SET email_key = NULL
+FROM duplicates
+WHERE duplicates.user_id = users.user_id AND duplicates.position > 1;
+
+CREATE UNIQUE INDEX idx_users_email_key ON users (email_key) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS email_changes (
+    change_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    old_email VARCHAR(250) NOT NULL DEFAULT '',
+    new_email VARCHAR(250) NOT NULL,
+    confirm_token_hash CHAR(64) NOT NULL UNIQUE,
+    cancel_token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    cancelled_at TIMESTAMP
+);
+CREATE INDEX idx_email_changes_user_id ON email_changes (user_id, created_at);
@@ -0,0 +1,15 @@
+-- audit_log is append-only: the application never updates or deletes an entry.
+CREATE TABLE IF NOT EXISTS audit_log (
+    entry_id BIGSERIAL PRIMARY KEY,
+    actor_id UUID,
+    actor_nickname VARCHAR(250) NOT NULL DEFAULT '',
+    action VARCHAR(64) NOT NULL,
+    target_id UUID NOT NULL,
+    changes JSONB,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_audit_log_target_id ON audit_log (target_id, entry_id);
+CREATE INDEX idx_audit_log_actor_id ON audit_log (actor_id, entry_id);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
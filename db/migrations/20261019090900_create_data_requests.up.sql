@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS data_requests (
+    data_request_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    format VARCHAR(16) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    requested_by_id UUID,
+    requested_by VARCHAR(250) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    archive_key VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+CREATE INDEX idx_data_requests_status ON data_requests (status, created_at);
+CREATE INDEX idx_data_requests_user_id ON data_requests (user_id, created_at);
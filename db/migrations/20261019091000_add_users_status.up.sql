@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN status_by UUID;
+ALTER TABLE users ADD COLUMN status_until TIMESTAMP;
+
+CREATE INDEX idx_users_status_until ON users (status_until) WHERE status <> 'active';
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN parent_id UUID;
+
+CREATE INDEX idx_users_parent_id ON users (parent_id) WHERE parent_id IS NOT NULL;
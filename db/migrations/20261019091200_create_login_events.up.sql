@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS login_events (
+    login_event_id BIGSERIAL PRIMARY KEY,
+    user_id UUID,
+    login VARCHAR(255) NOT NULL DEFAULT '',
+    method VARCHAR(16) NOT NULL,
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(32) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    new_client BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_login_events_user_id ON login_events (user_id, login_event_id);
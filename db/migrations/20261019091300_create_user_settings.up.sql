@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id UUID NOT NULL,
+    key VARCHAR(64) NOT NULL,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
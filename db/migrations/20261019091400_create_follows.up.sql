@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS follows (
+    follow_id BIGSERIAL UNIQUE,
+    follower_id UUID NOT NULL,
+    followee_id UUID NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    PRIMARY KEY (follower_id, followee_id)
+);
+CREATE INDEX idx_follows_followee_id ON follows (followee_id, status, follow_id);
+CREATE INDEX idx_follows_follower_id ON follows (follower_id, status, follow_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS invites (
+    invite_id UUID PRIMARY KEY,
+    email VARCHAR(250) NOT NULL,
+    email_key VARCHAR(250) NOT NULL,
+    user_role VARCHAR(50) NOT NULL,
+    invited_by_id UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_user_id UUID,
+    revoked_at TIMESTAMP
+);
+CREATE INDEX idx_invites_invited_by_id ON invites (invited_by_id, created_at);
+CREATE INDEX idx_invites_email_key ON invites (email_key) WHERE accepted_at IS NULL AND revoked_at IS NULL;
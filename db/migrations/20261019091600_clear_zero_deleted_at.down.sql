@@ -0,0 +1,2 @@
+-- nothing to undo, NULL is what the zero time stood for
+SELECT 1;
@@ -0,0 +1,3 @@
+-- The seeded admin and root users were inserted with the zero time rather than NULL. Since only
+-- NULL means not deleted, they looked deleted and the retention purge would have removed them.
+UPDATE users SET deleted_at = NULL WHERE deleted_at <= '0001-01-02';
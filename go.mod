@@ -11,6 +11,8 @@ require (
 	github.com/labstack/echo-jwt v0.0.0-20221127215225-c84d41a71003
 	github.com/labstack/echo/v4 v4.11.3
 	github.com/sirupsen/logrus v1.9.3
+	golang.org/x/image v0.18.0
+	golang.org/x/text v0.16.0
 	google.golang.org/protobuf v1.31.0
 	gotest.tools/v3 v3.5.1
 )
@@ -53,6 +55,5 @@ require (
 	golang.org/x/crypto v0.14.0
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/sys v0.13.0 // indirect
-	golang.org/x/text v0.13.0 // indirect
 	google.golang.org/grpc v1.60.0
 )
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	grpcUsermanager "usermanager/grpc"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type grpcService struct {
@@ -27,8 +30,50 @@ func NewGrpcService(connectionStr string) (usecase.IUserUsecase, error) {
 	}, nil
 }
 
+// UserTreeService walks the account hierarchy of the user manager.
+type UserTreeService interface {
+	GetUserChildren(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error)
+	GetUserDescendants(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error)
+	GetUserAncestors(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error)
+}
+
+func NewGrpcUserTreeService(connectionStr string) (UserTreeService, error) {
+	conn, err := grpc.Dial(connectionStr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return &grpcService{
+		client: grpcUsermanager.NewUserUsecaseClient(conn),
+	}, nil
+}
+
+// UserSettingsService reads and changes the settings of a user in the user manager.
+type UserSettingsService interface {
+	GetUserSettings(ctx context.Context, userID uuid.UUID) (*model.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any) (*model.UserSettings, error)
+}
+
+func NewGrpcUserSettingsService(connectionStr string) (UserSettingsService, error) {
+	conn, err := grpc.Dial(connectionStr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return &grpcService{
+		client: grpcUsermanager.NewUserUsecaseClient(conn),
+	}, nil
+}
+
+// WithToken authenticates the calls made with the returned context, so that
+// private profiles and emails are visible to their owner and admins.
+func WithToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
 func (s *grpcService) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
-	createUserRequest := marshalUserToCreateUserRequest(user)
+	createUserRequest, err := marshalUserToCreateUserRequest(user)
+	if err != nil {
+		return nil, err
+	}
 	createUserResponse, err := s.client.CreateUser(ctx, createUserRequest)
 	if err != nil {
 		return nil, err
@@ -38,7 +83,10 @@ func (s *grpcService) CreateUser(ctx context.Context, user *model.User) (*model.
 }
 
 func (s *grpcService) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
-	userUpdateRequest := marshalUserToUpdateUserRequest(user)
+	userUpdateRequest, err := marshalUserToUpdateUserRequest(user)
+	if err != nil {
+		return nil, err
+	}
 	userUpdateResponse, err := s.client.UpdateUser(ctx, userUpdateRequest)
 	if err != nil {
 		return nil, err
@@ -47,20 +95,116 @@ func (s *grpcService) UpdateUser(ctx context.Context, user *model.User) (*model.
 	return user, nil
 }
 
-func (s *grpcService) DeleteUser(ctx context.Context, userID *uuid.UUID) error {
+func (s *grpcService) DeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error {
 	deleteUserRequest := &grpcUsermanager.DeleteUserRequest{
-		UserId: userID.String(),
+		UserId:          userID.String(),
+		ExpectedVersion: expectedVersion,
+	}
+
+	_, err := s.client.DeleteUser(ctx, deleteUserRequest)
+	return err
+}
+
+func (s *grpcService) HardDeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error {
+	deleteUserRequest := &grpcUsermanager.DeleteUserRequest{
+		UserId:          userID.String(),
+		Hard:            true,
+		ExpectedVersion: expectedVersion,
 	}
 
 	_, err := s.client.DeleteUser(ctx, deleteUserRequest)
 	return err
 }
 
+func (s *grpcService) RestoreUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	restoreUserRequest := &grpcUsermanager.RestoreUserRequest{
+		UserId: userID.String(),
+	}
+
+	restoreUserResponse, err := s.client.RestoreUser(ctx, restoreUserRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserToUser(restoreUserResponse.User)
+}
+
+func (s *grpcService) PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error) {
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	patchUserRequest := &grpcUsermanager.PatchUserRequest{
+		UserId:          userID.String(),
+		Changes:         changesJSON,
+		ExpectedVersion: expectedVersion,
+	}
+
+	patchUserResponse, err := s.client.PatchUser(ctx, patchUserRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserToUser(patchUserResponse.User)
+}
+
+func (s *grpcService) GetUserChildren(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	getUserChildrenResponse, err := s.client.GetUserChildren(ctx, &grpcUsermanager.GetUserChildrenRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserTree(getUserChildrenResponse.Nodes)
+}
+
+func (s *grpcService) GetUserDescendants(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	getUserDescendantsResponse, err := s.client.GetUserDescendants(ctx, &grpcUsermanager.GetUserDescendantsRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserTree(getUserDescendantsResponse.Nodes)
+}
+
+func (s *grpcService) GetUserAncestors(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	getUserAncestorsResponse, err := s.client.GetUserAncestors(ctx, &grpcUsermanager.GetUserAncestorsRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserTree(getUserAncestorsResponse.Nodes)
+}
+
+func (s *grpcService) GetUserSettings(ctx context.Context, userID uuid.UUID) (*model.UserSettings, error) {
+	getUserSettingsResponse, err := s.client.GetUserSettings(ctx, &grpcUsermanager.GetUserSettingsRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserSettings(getUserSettingsResponse.Settings)
+}
+
+func (s *grpcService) UpdateUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any) (*model.UserSettings, error) {
+	changesStruct, err := structpb.NewStruct(changes)
+	if err != nil {
+		return nil, err
+	}
+	updateUserSettingsResponse, err := s.client.UpdateUserSettings(ctx, &grpcUsermanager.UpdateUserSettingsRequest{UserId: userID.String(), Changes: changesStruct})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUserSettings(updateUserSettingsResponse.Settings)
+}
+
 func (s *grpcService) GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	getUsersRequest := &grpcUsermanager.GetUsersRequest{
 		PaginationQuery: &grpcUsermanager.PaginationQuery{
-			Page: int32(paginationQuery.Page),
-			Size: int32(paginationQuery.Size),
+			Page:      int32(paginationQuery.Page),
+			Size:      int32(paginationQuery.Size),
+			OrderBy:   paginationQuery.OrderBy,
+			PageToken: paginationQuery.Cursor,
+			Count:     paginationQuery.Count,
 		},
 	}
 
@@ -69,29 +213,35 @@ func (s *grpcService) GetUsers(ctx context.Context, paginationQuery *utils.Pagin
 		return nil, err
 	}
 
-	users := &model.Users{}
-	for _, user := range getUsersResponse.Users.Users {
-		uuid, err := uuid.Parse(user.UserId)
-		if err != nil {
-			return nil, err
-		}
-		users.Users = append(users.Users, &model.User{
-			UserID:    uuid,
-			Nickname:  user.Nickname,
-			LastName:  user.LastName,
-			FirstName: user.FirstName,
-			Email:     user.Email,
-		})
+	return marshalGrpcUsersToUsers(getUsersResponse.Users)
+}
+
+// SearchUsers ignores viewer, the server resolves it from the token passed with WithToken.
+func (s *grpcService) SearchUsers(ctx context.Context, viewer *model.User, query string, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
+	searchUsersRequest := &grpcUsermanager.SearchUsersRequest{
+		Query: query,
+		PaginationQuery: &grpcUsermanager.PaginationQuery{
+			Page: int32(paginationQuery.Page),
+			Size: int32(paginationQuery.Size),
+		},
 	}
 
-	return users, nil
+	searchUsersResponse, err := s.client.SearchUsers(ctx, searchUsersRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGrpcUsersToUsers(searchUsersResponse.Users)
 }
 
 func (s *grpcService) GetUsersByPaginationQuery(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	getUsersByPaginationQueryRequest := &grpcUsermanager.GetUsersByPaginationQueryRequest{
 		PaginationQuery: &grpcUsermanager.PaginationQuery{
-			Page: int32(paginationQuery.Page),
-			Size: int32(paginationQuery.Size),
+			Page:      int32(paginationQuery.Page),
+			Size:      int32(paginationQuery.Size),
+			OrderBy:   paginationQuery.OrderBy,
+			PageToken: paginationQuery.Cursor,
+			Count:     paginationQuery.Count,
 		},
 	}
 
@@ -100,22 +250,7 @@ func (s *grpcService) GetUsersByPaginationQuery(ctx context.Context, paginationQ
 		return nil, err
 	}
 
-	users := &model.Users{}
-	for _, user := range getUsersByPaginationQueryResponse.Users.Users {
-		uuid, err := uuid.Parse(user.UserId)
-		if err != nil {
-			return nil, err
-		}
-		users.Users = append(users.Users, &model.User{
-			UserID:    uuid,
-			Nickname:  user.Nickname,
-			LastName:  user.LastName,
-			FirstName: user.FirstName,
-			Email:     user.Email,
-		})
-	}
-
-	return users, nil
+	return marshalGrpcUsersToUsers(getUsersByPaginationQueryResponse.Users)
 }
 
 func (s *grpcService) GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
@@ -463,28 +598,60 @@ func (s *grpcService) Vote(ctx context.Context, vote *model.Vote, userVote *mode
 	return vote, userVote, nil
 }
 
-func marshalUserToCreateUserRequest(user *model.User) *grpcUsermanager.CreateUserRequest {
+func marshalUserToCreateUserRequest(user *model.User) (*grpcUsermanager.CreateUserRequest, error) {
+	attributes, err := marshalAttributes(user.Attributes)
+	if err != nil {
+		return nil, err
+	}
 	return &grpcUsermanager.CreateUserRequest{
 		User: &grpcUsermanager.User{
-			UserId:    user.UserID.String(),
-			Nickname:  user.Nickname,
-			LastName:  user.LastName,
-			FirstName: user.FirstName,
-			Email:     user.Email,
+			UserId:     user.UserID.String(),
+			Nickname:   user.Nickname,
+			LastName:   user.LastName,
+			FirstName:  user.FirstName,
+			Email:      user.Email,
+			Attributes: attributes,
 		},
-	}
+	}, nil
 }
 
-func marshalUserToUpdateUserRequest(user *model.User) *grpcUsermanager.UpdateUserRequest {
+func marshalUserToUpdateUserRequest(user *model.User) (*grpcUsermanager.UpdateUserRequest, error) {
+	attributes, err := marshalAttributes(user.Attributes)
+	if err != nil {
+		return nil, err
+	}
 	return &grpcUsermanager.UpdateUserRequest{
 		User: &grpcUsermanager.User{
-			UserId:    user.UserID.String(),
-			Nickname:  user.Nickname,
-			LastName:  user.LastName,
-			FirstName: user.FirstName,
-			Email:     user.Email,
+			UserId:     user.UserID.String(),
+			Nickname:   user.Nickname,
+			LastName:   user.LastName,
+			FirstName:  user.FirstName,
+			Email:      user.Email,
+			Attributes: attributes,
 		},
+		ExpectedVersion: user.Version,
+	}, nil
+}
+
+func marshalAttributes(attributes model.UserAttributes) (*structpb.Struct, error) {
+	if attributes == nil {
+		return nil, nil
+	}
+	return structpb.NewStruct(attributes)
+}
+
+func marshalGrpcAttributes(attributes *structpb.Struct) model.UserAttributes {
+	if attributes == nil {
+		return nil
+	}
+	return attributes.AsMap()
+}
+
+func marshalGrpcAvatarURL(avatarURL string) *model.UserAvatar {
+	if avatarURL == "" {
+		return nil
 	}
+	return &model.UserAvatar{URL: avatarURL}
 }
 
 func marshalUpdateUserResponseToUser(userResponse *grpcUsermanager.UpdateUserResponse) (*model.User, error) {
@@ -493,11 +660,14 @@ func marshalUpdateUserResponseToUser(userResponse *grpcUsermanager.UpdateUserRes
 		return nil, err
 	}
 	return &model.User{
-		UserID:    uuid,
-		Nickname:  userResponse.User.Nickname,
-		LastName:  userResponse.User.LastName,
-		FirstName: userResponse.User.FirstName,
-		Email:     userResponse.User.Email,
+		UserID:     uuid,
+		Nickname:   userResponse.User.Nickname,
+		LastName:   userResponse.User.LastName,
+		FirstName:  userResponse.User.FirstName,
+		Email:      userResponse.User.Email,
+		Version:    userResponse.User.Version,
+		Attributes: marshalGrpcAttributes(userResponse.User.Attributes),
+		Avatar:     marshalGrpcAvatarURL(userResponse.User.AvatarUrl),
 	}, nil
 }
 
@@ -507,10 +677,83 @@ func marshalUserReponseToUser(userResponse *grpcUsermanager.CreateUserResponse)
 		return nil, err
 	}
 	return &model.User{
-		UserID:    uuid,
-		Nickname:  userResponse.User.Nickname,
-		LastName:  userResponse.User.LastName,
-		FirstName: userResponse.User.FirstName,
-		Email:     userResponse.User.Email,
+		UserID:     uuid,
+		Nickname:   userResponse.User.Nickname,
+		LastName:   userResponse.User.LastName,
+		FirstName:  userResponse.User.FirstName,
+		Email:      userResponse.User.Email,
+		Attributes: marshalGrpcAttributes(userResponse.User.Attributes),
+	}, nil
+}
+
+func marshalGrpcUserToUser(grpcUser *grpcUsermanager.User) (*model.User, error) {
+	uuid, err := uuid.Parse(grpcUser.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &model.User{
+		UserID:     uuid,
+		Nickname:   grpcUser.Nickname,
+		LastName:   grpcUser.LastName,
+		FirstName:  grpcUser.FirstName,
+		Email:      grpcUser.Email,
+		Version:    grpcUser.Version,
+		Attributes: marshalGrpcAttributes(grpcUser.Attributes),
+		Avatar:     marshalGrpcAvatarURL(grpcUser.AvatarUrl),
+		ParentID:   marshalGrpcParentID(grpcUser.ParentId),
 	}, nil
 }
+
+func marshalGrpcParentID(parentID string) *uuid.UUID {
+	parentUUID, err := uuid.Parse(parentID)
+	if err != nil {
+		return nil
+	}
+	return &parentUUID
+}
+
+func marshalGrpcUserTree(grpcNodes []*grpcUsermanager.UserTreeNode) ([]*model.UserTreeNode, error) {
+	nodes := make([]*model.UserTreeNode, 0, len(grpcNodes))
+	for _, grpcNode := range grpcNodes {
+		user, err := marshalGrpcUserToUser(grpcNode.User)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &model.UserTreeNode{User: *user, Depth: int(grpcNode.Depth)})
+	}
+	return nodes, nil
+}
+
+// marshalGrpcUserSettings reads the settings as decoded from JSON, numbers are float64.
+func marshalGrpcUserSettings(grpcSettings *grpcUsermanager.UserSettings) (*model.UserSettings, error) {
+	userID, err := uuid.Parse(grpcSettings.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UserSettings{
+		UserID:    userID,
+		Settings:  grpcSettings.Settings.AsMap(),
+		Overrides: grpcSettings.Overrides,
+	}, nil
+}
+
+func marshalGrpcUsersToUsers(grpcUsers *grpcUsermanager.Users) (*model.Users, error) {
+	users := &model.Users{
+		Page:       int(grpcUsers.Page),
+		HasMore:    grpcUsers.HasMore,
+		NextCursor: grpcUsers.NextCursor,
+		PrevCursor: grpcUsers.PrevCursor,
+	}
+	if grpcUsers.HasTotal {
+		users.SetTotal(int(grpcUsers.Total), int(grpcUsers.Size), grpcUsers.TotalEstimated)
+	}
+	for _, grpcUser := range grpcUsers.Users {
+		user, err := marshalGrpcUserToUser(grpcUser)
+		if err != nil {
+			return nil, err
+		}
+		users.Users = append(users.Users, user)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/usecase/usecase"
+	"usermanager/internal/utils"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+	requestIDHeader     = "x-request-id"
+)
+
+type viewerCtxKey struct{}
+
+// NewAuthInterceptor reads an optional bearer token from the request metadata
+// and stores the caller in the context. Calls without a token are anonymous. The caller goes
+// through the active user check of the HTTP middleware, so a suspended user or a token whose
+// role has since been revoked is refused. The peer address and the x-request-id metadata are
+// kept for the audit log.
+func NewAuthInterceptor(jwtSecret string, activeUserUsecase usecase.IActiveUserUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		ctx = model.ContextWithAuditRequest(ctx, auditRequest(ctx, md))
+		if !ok || len(md.Get(authorizationHeader)) == 0 {
+			return handler(ctx, req)
+		}
+
+		tokenString := strings.TrimPrefix(md.Get(authorizationHeader)[0], bearerPrefix)
+		claims := &model.JwtCustomClaims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(jwtSecret), nil
+		})
+		if err != nil || !token.Valid {
+			return nil, apperrors.UserGrpcAuthInterceptorParseToken.AppendMessage(err)
+		}
+
+		viewer, err := activeUserUsecase.VerifyActiveUser(ctx, claims.UserID, claims.Role)
+		if err != nil {
+			return nil, activeUserError(err)
+		}
+		return handler(model.ContextWithAuditActor(ContextWithViewer(ctx, viewer), viewer), req)
+	}
+}
+
+// activeUserError maps a failed active user check to its gRPC status.
+func activeUserError(err error) error {
+	appError := err.(*apperrors.AppError)
+	switch appError.HTTPCode {
+	case http.StatusUnauthorized:
+		return status.Error(codes.Unauthenticated, appError.Error())
+	case http.StatusForbidden:
+		return status.Error(codes.PermissionDenied, appError.Error())
+	default:
+		return status.Error(codes.Internal, appError.Error())
+	}
+}
+
+func auditRequest(ctx context.Context, md metadata.MD) *model.AuditRequest {
+	request := &model.AuditRequest{}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		request.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(request.IP); err == nil {
+			request.IP = host
+		}
+	}
+	if requestIDs := md.Get(requestIDHeader); len(requestIDs) > 0 {
+		request.RequestID = requestIDs[0]
+	}
+	return request
+}
+
+func ContextWithViewer(ctx context.Context, viewer *model.User) context.Context {
+	return context.WithValue(ctx, viewerCtxKey{}, viewer)
+}
+
+func viewerFromContext(ctx context.Context) *model.User {
+	viewer, _ := ctx.Value(viewerCtxKey{}).(*model.User)
+	return viewer
+}
+
+// authorize is what the CanUpdateUser and CanDeleteUser middlewares do over HTTP: callers
+// manage themselves, their child accounts and whoever the permission lets them manage.
+func (umg *UserManagerGrpcController) authorize(ctx context.Context, userID uuid.UUID, permission string) error {
+	viewer, err := requireViewer(ctx)
+	if err != nil {
+		return err
+	}
+	if viewer.UserID == userID {
+		return nil
+	}
+
+	err = viewer.Can(permission)
+	if err == nil {
+		return nil
+	}
+	isAncestor, ancestorErr := umg.userHierarchyUsecase.IsUserAncestor(ctx, viewer.UserID, userID)
+	if ancestorErr != nil {
+		return apperrors.UserGrpcControllerAuthorizeIsUserAncestor.AppendMessage(ancestorErr)
+	}
+	if isAncestor {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+// authorizeRight checks a permission that isn't tied to the user called on, like hasRight.
+func authorizeRight(ctx context.Context, permission string) (*model.User, error) {
+	viewer, err := requireViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = viewer.Can(permission)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return viewer, nil
+}
+
+// authorizePrivateSort keeps the orders that give away hidden data, like when users logged
+// in, to admins. An anonymous call can't be one, so it is an invalid argument.
+func authorizePrivateSort(ctx context.Context, paginationQuery *utils.PaginationQuery) error {
+	if !paginationQuery.HasPrivateSort() {
+		return nil
+	}
+	viewer := viewerFromContext(ctx)
+	if viewer == nil {
+		return status.Error(codes.InvalidArgument, apperrors.UserGrpcControllerPrivateSort.Error())
+	}
+	if !viewer.IsAdmin() {
+		return status.Error(codes.PermissionDenied, apperrors.UserGrpcControllerPrivateSort.Error())
+	}
+	return nil
+}
+
+func requireViewer(ctx context.Context) (*model.User, error) {
+	viewer := viewerFromContext(ctx)
+	if viewer == nil {
+		return nil, status.Error(codes.Unauthenticated, apperrors.UserGrpcControllerUnauthenticated.Error())
+	}
+	return viewer, nil
+}
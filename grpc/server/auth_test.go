@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/usecase/usecase"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testJwtSecret = "secret"
+
+func TestNewAuthInterceptor(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		user      *model.User
+		grants    []*model.RoleGrant
+		tokenRole string
+		wantCode  codes.Code
+		wantRole  string
+	}{
+		{"active user", &model.User{Role: model.RoleUser}, []*model.RoleGrant{}, model.RoleUser, codes.OK, model.RoleUser},
+		{"active grant", &model.User{Role: model.RoleUser}, []*model.RoleGrant{{Role: model.RoleAdmin, Status: model.RoleGrantStatusActive}}, model.RoleAdmin, codes.OK, model.RoleAdmin},
+		{"revoked grant", &model.User{Role: model.RoleUser}, []*model.RoleGrant{}, model.RoleAdmin, codes.Unauthenticated, ""},
+		{"suspended user", &model.User{Role: model.RoleUser, UserStatus: model.UserStatus{Status: model.UserStatusSuspended, StatusReason: "spam", StatusUntil: &until}}, []*model.RoleGrant{}, model.RoleUser, codes.PermissionDenied, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.user.UserID = uuid.New()
+			tt.user.Nickname = "nickname"
+			userUsecaseMock := &UserUsecaseMock{}
+			userUsecaseMock.On("GetUser", mock.Anything, tt.user.UserID).Return(tt.user, nil)
+			roleGrantRepoMock := &usecase.RoleGrantRepositoryMock{}
+			roleGrantRepoMock.On("FindActiveRoleGrantsByUserID", mock.Anything, tt.user.UserID, mock.Anything).Return(tt.grants, nil)
+			roleGrantUsecase := usecase.NewRoleGrantUsecase(nil, roleGrantRepoMock, nil, nil, &model.RoleGrantPolicy{})
+			interceptor := NewAuthInterceptor(testJwtSecret, usecase.NewActiveUserUsecase(userUsecaseMock, roleGrantUsecase))
+
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &model.JwtCustomClaims{
+				UserID:   tt.user.UserID,
+				Nickname: tt.user.Nickname,
+				Role:     tt.tokenRole,
+				RegisteredClaims: jwt.RegisteredClaims{
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				},
+			}).SignedString([]byte(testJwtSecret))
+			assert.NoError(t, err)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, bearerPrefix+token))
+
+			var viewer *model.User
+			_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				viewer = viewerFromContext(ctx)
+				return nil, nil
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				assert.Nil(t, viewer)
+				return
+			}
+			assert.Equal(t, tt.wantRole, viewer.Role)
+		})
+	}
+}
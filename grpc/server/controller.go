@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 
 	grpcUsermanager "usermanager/grpc"
 	"usermanager/internal/apperrors"
@@ -10,26 +11,33 @@ import (
 	"usermanager/internal/utils"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type UserManagerGrpcController struct {
-	userUscase usecase.IUserUsecase
+	userUscase           usecase.IUserUsecase
+	userHierarchyUsecase usecase.IUserHierarchyUsecase
+	userSettingUsecase   usecase.IUserSettingUsecase
+	privateProfileMode   string
 	grpcUsermanager.UnimplementedUserUsecaseServer
 }
 
-func NewUserManagerGrpcController(userUscase usecase.IUserUsecase) *UserManagerGrpcController {
-	return &UserManagerGrpcController{userUscase: userUscase}
+func NewUserManagerGrpcController(userUscase usecase.IUserUsecase, userHierarchyUsecase usecase.IUserHierarchyUsecase, userSettingUsecase usecase.IUserSettingUsecase, privateProfileMode string) *UserManagerGrpcController {
+	return &UserManagerGrpcController{userUscase: userUscase, userHierarchyUsecase: userHierarchyUsecase, userSettingUsecase: userSettingUsecase, privateProfileMode: privateProfileMode}
 }
 
 func (umg *UserManagerGrpcController) CreateUser(ctx context.Context, userRequest *grpcUsermanager.CreateUserRequest) (*grpcUsermanager.CreateUserResponse, error) {
 	user := &model.User{
-		Nickname:  userRequest.User.Nickname,
-		FirstName: userRequest.User.FirstName,
-		LastName:  userRequest.User.LastName,
-		Email:     userRequest.User.Email,
-		Password:  userRequest.User.Password,
-		IsPublic:  userRequest.User.IsPublic,
-		Role:      userRequest.User.UserRole,
+		Nickname:   userRequest.User.Nickname,
+		FirstName:  userRequest.User.FirstName,
+		LastName:   userRequest.User.LastName,
+		Email:      userRequest.User.Email,
+		Password:   userRequest.User.Password,
+		IsPublic:   userRequest.User.IsPublic,
+		Role:       userRequest.User.UserRole,
+		Attributes: marshalGrpcAttributes(userRequest.User.Attributes),
 	}
 	createdUser, err := umg.userUscase.CreateUser(ctx, user)
 	if err != nil {
@@ -46,15 +54,21 @@ func (umg *UserManagerGrpcController) UpdateUser(ctx context.Context, userReques
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerUpdateUserUuidParse.AppendMessage(err)
 	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
 	user := &model.User{
-		UserID:    userId,
-		Nickname:  userRequest.User.Nickname,
-		FirstName: userRequest.User.FirstName,
-		LastName:  userRequest.User.LastName,
-		Email:     userRequest.User.Email,
-		Password:  userRequest.User.Password,
-		IsPublic:  userRequest.User.IsPublic,
-		Role:      userRequest.User.UserRole,
+		UserID:     userId,
+		Nickname:   userRequest.User.Nickname,
+		FirstName:  userRequest.User.FirstName,
+		LastName:   userRequest.User.LastName,
+		Email:      userRequest.User.Email,
+		Password:   userRequest.User.Password,
+		IsPublic:   userRequest.User.IsPublic,
+		Role:       userRequest.User.UserRole,
+		Attributes: marshalGrpcAttributes(userRequest.User.Attributes),
+		Version:    userRequest.ExpectedVersion,
 	}
 	updatedUser, err := umg.userUscase.UpdateUser(ctx, user)
 	if err != nil {
@@ -71,7 +85,14 @@ func (umg *UserManagerGrpcController) DeleteUser(ctx context.Context, userReques
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerDeleteUserUuidParse.AppendMessage(err)
 	}
-	err = umg.userUscase.DeleteUser(ctx, &userId)
+	err = umg.authorize(ctx, userId, model.PermissionDelete)
+	if err != nil {
+		return nil, err
+	}
+	if userRequest.Hard && !viewerFromContext(ctx).IsAdmin() {
+		return nil, status.Error(codes.PermissionDenied, apperrors.UserGrpcControllerDeleteUserHardForbidden.Error())
+	}
+	err = umg.userHierarchyUsecase.DeleteUser(ctx, &userId, userRequest.ExpectedVersion, userRequest.Hard)
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerDeleteUser.AppendMessage(err)
 	}
@@ -79,25 +100,75 @@ func (umg *UserManagerGrpcController) DeleteUser(ctx context.Context, userReques
 	return &grpcUsermanager.DeleteUserResponse{}, nil
 }
 
+func (umg *UserManagerGrpcController) RestoreUser(ctx context.Context, userRequest *grpcUsermanager.RestoreUserRequest) (*grpcUsermanager.RestoreUserResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerRestoreUserUuidParse.AppendMessage(err)
+	}
+	_, err = authorizeRight(ctx, model.PermissionRestore)
+	if err != nil {
+		return nil, err
+	}
+	user, err := umg.userUscase.RestoreUser(ctx, userId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerRestoreUser.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.RestoreUserResponse{
+		User: marshalUser(user),
+	}, nil
+}
+
+func (umg *UserManagerGrpcController) PatchUser(ctx context.Context, userRequest *grpcUsermanager.PatchUserRequest) (*grpcUsermanager.PatchUserResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerPatchUserUuidParse.AppendMessage(err)
+	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]any{}
+	if err = json.Unmarshal(userRequest.Changes, &changes); err != nil {
+		return nil, apperrors.UserGrpcControllerPatchUserChanges.AppendMessage(err)
+	}
+	// only what the HTTP patch document has, status, role and parent have their own calls
+	for column := range changes {
+		if !model.PatchUserColumns[column] {
+			return nil, apperrors.UserGrpcControllerPatchUserColumn.AppendMessage(column)
+		}
+	}
+	user, err := umg.userUscase.PatchUser(ctx, userId, userRequest.ExpectedVersion, changes)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerPatchUser.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.PatchUserResponse{
+		User: marshalUser(user),
+	}, nil
+}
+
 func (umg *UserManagerGrpcController) GetUsers(ctx context.Context, usersRequest *grpcUsermanager.GetUsersRequest) (*grpcUsermanager.GetUsersResponse, error) {
 	paginationQuery := &utils.PaginationQuery{
 		Size:    int(usersRequest.PaginationQuery.Size),
 		Page:    int(usersRequest.PaginationQuery.Page),
 		OrderBy: usersRequest.PaginationQuery.OrderBy,
+		Cursor:  usersRequest.PaginationQuery.PageToken,
+		Count:   usersRequest.PaginationQuery.Count,
 	}
+	err := authorizePrivateSort(ctx, paginationQuery)
+	if err != nil {
+		return nil, err
+	}
+	viewer := viewerFromContext(ctx)
+	model.HidePrivateProfiles(paginationQuery, viewer, umg.privateProfileMode)
 	users, err := umg.userUscase.GetUsers(ctx, paginationQuery)
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerGetUsers.AppendMessage(err)
 	}
+	users = users.VisibleTo(viewer, umg.privateProfileMode)
 
-	grpcUsers := &grpcUsermanager.Users{
-		Page:    int32(users.Page),
-		Users:   []*grpcUsermanager.User{},
-		HasMore: users.HasMore,
-	}
-	for _, user := range users.Users {
-		grpcUsers.Users = append(grpcUsers.Users, marshalUser(user))
-	}
+	grpcUsers := marshalUsers(users)
 
 	return &grpcUsermanager.GetUsersResponse{
 		Users: grpcUsers,
@@ -109,22 +180,43 @@ func (umg *UserManagerGrpcController) GetUsersByPaginationQuery(ctx context.Cont
 		Size:    int(usersRequest.PaginationQuery.Size),
 		Page:    int(usersRequest.PaginationQuery.Page),
 		OrderBy: usersRequest.PaginationQuery.OrderBy,
+		Cursor:  usersRequest.PaginationQuery.PageToken,
+		Count:   usersRequest.PaginationQuery.Count,
+	}
+	err := authorizePrivateSort(ctx, paginationQuery)
+	if err != nil {
+		return nil, err
 	}
+	viewer := viewerFromContext(ctx)
+	model.HidePrivateProfiles(paginationQuery, viewer, umg.privateProfileMode)
 	users, err := umg.userUscase.GetUsersByPaginationQuery(ctx, paginationQuery)
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerGetUsersByPaginationQuery.AppendMessage(err)
 	}
+	users = users.VisibleTo(viewer, umg.privateProfileMode)
 
-	grpcUsers := &grpcUsermanager.Users{
-		Page:    int32(users.Page),
-		Users:   []*grpcUsermanager.User{},
-		HasMore: users.HasMore,
+	grpcUsers := marshalUsers(users)
+
+	return &grpcUsermanager.GetUsersByPaginationQueryResponse{
+		Users: grpcUsers,
+	}, nil
+}
+
+func (umg *UserManagerGrpcController) SearchUsers(ctx context.Context, searchRequest *grpcUsermanager.SearchUsersRequest) (*grpcUsermanager.SearchUsersResponse, error) {
+	paginationQuery := &utils.PaginationQuery{
+		Size: int(searchRequest.GetPaginationQuery().GetSize()),
+		Page: int(searchRequest.GetPaginationQuery().GetPage()),
 	}
-	for _, user := range users.Users {
-		grpcUsers.Users = append(grpcUsers.Users, marshalUser(user))
+	viewer := viewerFromContext(ctx)
+	users, err := umg.userUscase.SearchUsers(ctx, viewer, searchRequest.Query, paginationQuery)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerSearchUsers.AppendMessage(err)
 	}
+	users = users.VisibleTo(viewer, umg.privateProfileMode)
 
-	return &grpcUsermanager.GetUsersByPaginationQueryResponse{
+	grpcUsers := marshalUsers(users)
+
+	return &grpcUsermanager.SearchUsersResponse{
 		Users: grpcUsers,
 	}, nil
 }
@@ -138,6 +230,10 @@ func (umg *UserManagerGrpcController) GetUser(ctx context.Context, userRequest *
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerGetUser.AppendMessage(err)
 	}
+	user = user.VisibleTo(viewerFromContext(ctx), umg.privateProfileMode)
+	if user == nil {
+		return nil, apperrors.UserGrpcControllerGetUserNotFound.AppendMessage(userId)
+	}
 
 	return &grpcUsermanager.GetUserResponse{
 		User: marshalUser(user),
@@ -153,6 +249,10 @@ func (umg *UserManagerGrpcController) GetUserByID(ctx context.Context, userReque
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerGetUserByID.AppendMessage(err)
 	}
+	user = user.VisibleTo(viewerFromContext(ctx), umg.privateProfileMode)
+	if user == nil {
+		return nil, apperrors.UserGrpcControllerGetUserByIDNotFound.AppendMessage(userId)
+	}
 
 	return &grpcUsermanager.GetUserByIDResponse{
 		User: marshalUser(user),
@@ -164,6 +264,10 @@ func (umg *UserManagerGrpcController) GetUserByNickname(ctx context.Context, use
 	if err != nil {
 		return nil, apperrors.UserGrpcControllerGetUserByNickname.AppendMessage(err)
 	}
+	user = user.VisibleTo(viewerFromContext(ctx), umg.privateProfileMode)
+	if user == nil {
+		return nil, apperrors.UserGrpcControllerGetUserByNicknameNotFound.AppendMessage(userRequest.Nickname)
+	}
 
 	return &grpcUsermanager.GetUserByNicknameResponse{
 		User: marshalUser(user),
@@ -297,6 +401,119 @@ func (umg *UserManagerGrpcController) Vote(ctx context.Context, userRequest *grp
 	}, nil
 }
 
+func (umg *UserManagerGrpcController) GetUserChildren(ctx context.Context, userRequest *grpcUsermanager.GetUserChildrenRequest) (*grpcUsermanager.GetUserChildrenResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserChildrenUuidParse.AppendMessage(err)
+	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	children, err := umg.userHierarchyUsecase.GetUserChildren(ctx, userId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserChildren.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.GetUserChildrenResponse{
+		Nodes: umg.marshalUserTree(ctx, children),
+	}, nil
+}
+
+func (umg *UserManagerGrpcController) GetUserDescendants(ctx context.Context, userRequest *grpcUsermanager.GetUserDescendantsRequest) (*grpcUsermanager.GetUserDescendantsResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserDescendantsUuidParse.AppendMessage(err)
+	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := umg.userHierarchyUsecase.GetUserDescendants(ctx, userId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserDescendants.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.GetUserDescendantsResponse{
+		Nodes: umg.marshalUserTree(ctx, descendants),
+	}, nil
+}
+
+func (umg *UserManagerGrpcController) GetUserAncestors(ctx context.Context, userRequest *grpcUsermanager.GetUserAncestorsRequest) (*grpcUsermanager.GetUserAncestorsResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserAncestorsUuidParse.AppendMessage(err)
+	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	ancestors, err := umg.userHierarchyUsecase.GetUserAncestors(ctx, userId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserAncestors.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.GetUserAncestorsResponse{
+		Nodes: umg.marshalUserTree(ctx, ancestors),
+	}, nil
+}
+
+func (umg *UserManagerGrpcController) GetUserSettings(ctx context.Context, userRequest *grpcUsermanager.GetUserSettingsRequest) (*grpcUsermanager.GetUserSettingsResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserSettingsUuidParse.AppendMessage(err)
+	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := umg.userSettingUsecase.GetUserSettings(ctx, userId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerGetUserSettings.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.GetUserSettingsResponse{
+		Settings: marshalUserSettings(settings),
+	}, nil
+}
+
+// UpdateUserSettings takes the changes as a Struct, a null value resets the setting.
+func (umg *UserManagerGrpcController) UpdateUserSettings(ctx context.Context, userRequest *grpcUsermanager.UpdateUserSettingsRequest) (*grpcUsermanager.UpdateUserSettingsResponse, error) {
+	userId, err := uuid.Parse(userRequest.UserId)
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerUpdateUserSettingsUuidParse.AppendMessage(err)
+	}
+	err = umg.authorize(ctx, userId, model.PermissionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := umg.userSettingUsecase.UpdateUserSettings(ctx, userId, userRequest.Changes.AsMap())
+	if err != nil {
+		return nil, apperrors.UserGrpcControllerUpdateUserSettings.AppendMessage(err)
+	}
+
+	return &grpcUsermanager.UpdateUserSettingsResponse{
+		Settings: marshalUserSettings(settings),
+	}, nil
+}
+
+// marshalUserTree leaves out the users the caller isn't allowed to see.
+func (umg *UserManagerGrpcController) marshalUserTree(ctx context.Context, nodes []*model.UserTreeNode) []*grpcUsermanager.UserTreeNode {
+	viewer := viewerFromContext(ctx)
+	grpcNodes := []*grpcUsermanager.UserTreeNode{}
+	for _, node := range nodes {
+		visibleNode := node.VisibleTo(viewer, umg.privateProfileMode)
+		if visibleNode == nil {
+			continue
+		}
+		grpcNodes = append(grpcNodes, &grpcUsermanager.UserTreeNode{
+			User:  marshalUser(&visibleNode.User),
+			Depth: int32(visibleNode.Depth),
+		})
+	}
+	return grpcNodes
+}
+
 func marshalGrpcUsersToUsers(users []*model.User) []*grpcUsermanager.User {
 	var grpcUsers []*grpcUsermanager.User
 	for _, user := range users {
@@ -342,16 +559,25 @@ func marshalUserVoteToGrpcUserVote(userVote *model.UserVote) *grpcUsermanager.Us
 
 func marshalGrpcUserToUser(user *grpcUsermanager.User) *model.User {
 	return &model.User{
-		UserID:    uuid.MustParse(user.UserId),
-		Nickname:  user.Nickname,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Password:  user.Password,
-		IsPublic:  user.IsPublic,
-		Role:      user.UserRole,
-		Votes:     marshalGrpcVotesToVotes(user.Votes),
+		UserID:     uuid.MustParse(user.UserId),
+		Nickname:   user.Nickname,
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		Email:      user.Email,
+		Password:   user.Password,
+		IsPublic:   user.IsPublic,
+		Role:       user.UserRole,
+		Votes:      marshalGrpcVotesToVotes(user.Votes),
+		Version:    user.Version,
+		Attributes: marshalGrpcAttributes(user.Attributes),
+	}
+}
+
+func marshalGrpcAttributes(attributes *structpb.Struct) model.UserAttributes {
+	if attributes == nil {
+		return nil
 	}
+	return attributes.AsMap()
 }
 
 func marshalGrpcVotesToVotes(votes []*grpcUsermanager.Vote) []*model.Vote {
@@ -368,17 +594,72 @@ func marshalGrpcVotesToVotes(votes []*grpcUsermanager.Vote) []*model.Vote {
 	return modelVotes
 }
 
+func marshalUsers(users *model.Users) *grpcUsermanager.Users {
+	grpcUsers := &grpcUsermanager.Users{
+		Page:           int32(users.Page),
+		Users:          []*grpcUsermanager.User{},
+		HasMore:        users.HasMore,
+		NextCursor:     users.NextCursor,
+		PrevCursor:     users.PrevCursor,
+		Size:           int32(users.Size),
+		HasTotal:       users.Total != nil,
+		TotalEstimated: users.TotalEstimated,
+	}
+	if users.Total != nil {
+		grpcUsers.Total = int64(*users.Total)
+	}
+	if users.TotalPages != nil {
+		grpcUsers.TotalPages = int32(*users.TotalPages)
+	}
+	for _, user := range users.Users {
+		grpcUsers.Users = append(grpcUsers.Users, marshalUser(user))
+	}
+
+	return grpcUsers
+}
+
+// marshalUser never sets Password, the field is only read from requests.
 func marshalUser(user *model.User) *grpcUsermanager.User {
 	return &grpcUsermanager.User{
-		UserId:    user.UserID.String(),
-		Nickname:  user.Nickname,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Password:  user.Password,
-		IsPublic:  user.IsPublic,
-		UserRole:  user.Role,
-		Votes:     marshalVotes(user.Votes),
+		UserId:     user.UserID.String(),
+		Nickname:   user.Nickname,
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		Email:      user.Email,
+		IsPublic:   user.IsPublic,
+		UserRole:   user.Role,
+		Votes:      marshalVotes(user.Votes),
+		Version:    user.Version,
+		Attributes: marshalAttributes(user.Attributes),
+		AvatarUrl:  user.AvatarURL(),
+		ParentId:   marshalParentID(user.ParentID),
+	}
+}
+
+func marshalParentID(parentID *uuid.UUID) string {
+	if parentID == nil {
+		return ""
+	}
+	return parentID.String()
+}
+
+// marshalAttributes ignores the conversion error: attributes are decoded from JSON, and
+// structpb accepts every value encoding/json produces.
+func marshalAttributes(attributes model.UserAttributes) *structpb.Struct {
+	if attributes == nil {
+		return nil
+	}
+	attributesStruct, _ := structpb.NewStruct(attributes)
+	return attributesStruct
+}
+
+// marshalUserSettings ignores the conversion error, settings only hold strings, ints and bools.
+func marshalUserSettings(settings *model.UserSettings) *grpcUsermanager.UserSettings {
+	settingsStruct, _ := structpb.NewStruct(settings.Settings)
+	return &grpcUsermanager.UserSettings{
+		UserId:    settings.UserID.String(),
+		Settings:  settingsStruct,
+		Overrides: settings.Overrides,
 	}
 }
 
@@ -24,16 +24,36 @@ func (uum *UserUsecaseMock) UpdateUser(ctx context.Context, user *model.User) (*
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (uum *UserUsecaseMock) DeleteUser(ctx context.Context, userID *uuid.UUID) error {
-	args := uum.Called(ctx, userID)
+func (uum *UserUsecaseMock) DeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error {
+	args := uum.Called(ctx, userID, expectedVersion)
+	return args.Error(0)
+}
+
+func (uum *UserUsecaseMock) HardDeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error {
+	args := uum.Called(ctx, userID, expectedVersion)
 	return args.Error(0)
 }
 
+func (uum *UserUsecaseMock) RestoreUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	args := uum.Called(ctx, userID)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (uum *UserUsecaseMock) PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error) {
+	args := uum.Called(ctx, userID, expectedVersion, changes)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
 func (uum *UserUsecaseMock) GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	args := uum.Called(ctx, paginationQuery)
 	return args.Get(0).(*model.Users), args.Error(1)
 }
 
+func (uum *UserUsecaseMock) SearchUsers(ctx context.Context, viewer *model.User, query string, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
+	args := uum.Called(ctx, viewer, query, paginationQuery)
+	return args.Get(0).(*model.Users), args.Error(1)
+}
+
 func (uum *UserUsecaseMock) GetUsersByPaginationQuery(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	args := uum.Called(ctx, paginationQuery)
 	return args.Get(0).(*model.Users), args.Error(1)
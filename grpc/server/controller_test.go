@@ -6,12 +6,17 @@ import (
 	"testing"
 
 	grpcUsermanager "usermanager/grpc"
+	"usermanager/internal/apperrors"
 	"usermanager/internal/domain/model"
 	"usermanager/internal/usecase/usecase"
+	"usermanager/internal/utils"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestUserManagerGrpcController_GetUser(t *testing.T) {
@@ -50,7 +55,7 @@ func TestUserManagerGrpcController_GetUser(t *testing.T) {
 				usecase: mockUserUsecase,
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ContextWithViewer(context.Background(), &model.User{UserID: uuid.New(), Role: model.RoleAdmin}),
 				userRequest: &grpcUsermanager.GetUserRequest{
 					UserId: user.UserID.String(),
 				},
@@ -62,7 +67,30 @@ func TestUserManagerGrpcController_GetUser(t *testing.T) {
 					FirstName: user.FirstName,
 					LastName:  user.LastName,
 					Email:     user.Email,
-					Password:  user.Password,
+					IsPublic:  user.IsPublic,
+					UserRole:  user.Role,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success anonymous",
+			fields: fields{
+				usecase: mockUserUsecase,
+			},
+			args: args{
+				ctx: context.Background(),
+				userRequest: &grpcUsermanager.GetUserRequest{
+					UserId: user.UserID.String(),
+				},
+			},
+			want: &grpcUsermanager.GetUserResponse{
+				User: &grpcUsermanager.User{
+					UserId:    user.UserID.String(),
+					Nickname:  user.Nickname,
+					FirstName: user.FirstName,
+					LastName:  user.LastName,
+					Email:     "t***@test.com",
 					IsPublic:  user.IsPublic,
 					UserRole:  user.Role,
 				},
@@ -86,7 +114,7 @@ func TestUserManagerGrpcController_GetUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctrl := NewUserManagerGrpcController(tt.fields.usecase)
+			ctrl := NewUserManagerGrpcController(tt.fields.usecase, nil, nil, model.PrivateProfileModeCard)
 			got, err := ctrl.GetUser(tt.args.ctx, tt.args.userRequest)
 
 			assert.Equal(t, got, tt.want)
@@ -94,3 +122,123 @@ func TestUserManagerGrpcController_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserManagerGrpcController_PatchUser(t *testing.T) {
+	owner := &model.User{UserID: uuid.New(), Nickname: "owner", Role: model.RoleUser}
+	mockUserUsecase := &UserUsecaseMock{}
+	mockUserUsecase.On("PatchUser", mock.Anything, owner.UserID, int64(0), map[string]any{"first_name": "Owner"}).Return(owner, nil)
+	ctrl := NewUserManagerGrpcController(mockUserUsecase, nil, nil, model.PrivateProfileModeCard)
+
+	_, err := ctrl.PatchUser(context.Background(), &grpcUsermanager.PatchUserRequest{UserId: owner.UserID.String(), Changes: []byte(`{"first_name":"Owner"}`)})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	ctx := ContextWithViewer(context.Background(), owner)
+	_, err = ctrl.PatchUser(ctx, &grpcUsermanager.PatchUserRequest{UserId: owner.UserID.String(), Changes: []byte(`{"user_role":"admin"}`)})
+	assert.True(t, apperrors.Is(err, &apperrors.UserGrpcControllerPatchUserColumn), err)
+	mockUserUsecase.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	got, err := ctrl.PatchUser(ctx, &grpcUsermanager.PatchUserRequest{UserId: owner.UserID.String(), Changes: []byte(`{"first_name":"Owner"}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, got.User.UserId, owner.UserID.String())
+}
+
+func TestUserManagerGrpcController_DeleteAndRestoreUser_Permissions(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	ctrl := NewUserManagerGrpcController(&UserUsecaseMock{}, nil, nil, model.PrivateProfileModeCard)
+	ctx := ContextWithViewer(context.Background(), user)
+
+	_, err := ctrl.DeleteUser(context.Background(), &grpcUsermanager.DeleteUserRequest{UserId: user.UserID.String()})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+	_, err = ctrl.DeleteUser(ctx, &grpcUsermanager.DeleteUserRequest{UserId: user.UserID.String(), Hard: true})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	_, err = ctrl.RestoreUser(context.Background(), &grpcUsermanager.RestoreUserRequest{UserId: user.UserID.String()})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+	_, err = ctrl.RestoreUser(ctx, &grpcUsermanager.RestoreUserRequest{UserId: user.UserID.String()})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+}
+
+func TestUserManagerGrpcController_GetUserByNickname(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "private", Email: "private@test.com", Password: "hash", Role: model.RoleUser}
+	mockUserUsecase := &UserUsecaseMock{}
+	mockUserUsecase.On("GetUserByNickname", mock.Anything, "private").Return(user, nil)
+
+	_, err := NewUserManagerGrpcController(mockUserUsecase, nil, nil, model.PrivateProfileModeNotFound).GetUserByNickname(context.Background(), &grpcUsermanager.GetUserByNicknameRequest{Nickname: "private"})
+	assert.True(t, apperrors.Is(err, &apperrors.UserGrpcControllerGetUserByNicknameNotFound), err)
+
+	got, err := NewUserManagerGrpcController(mockUserUsecase, nil, nil, model.PrivateProfileModeNotFound).GetUserByNickname(ContextWithViewer(context.Background(), user), &grpcUsermanager.GetUserByNicknameRequest{Nickname: "private"})
+	assert.NoError(t, err)
+	assert.Equal(t, got.User.Email, "private@test.com")
+	assert.Empty(t, got.User.Password)
+}
+
+func TestUserManagerGrpcController_GetUsersByPaginationQuery_HidesPrivate(t *testing.T) {
+	viewer := &model.User{UserID: uuid.New(), Nickname: "viewer", Role: model.RoleUser}
+	mockUserUsecase := &UserUsecaseMock{}
+	mockUserUsecase.On("GetUsersByPaginationQuery", mock.Anything, mock.Anything).Return(&model.Users{Users: []*model.User{viewer}, HasMore: true}, nil)
+
+	_, err := NewUserManagerGrpcController(mockUserUsecase, nil, nil, model.PrivateProfileModeNotFound).GetUsersByPaginationQuery(ContextWithViewer(context.Background(), viewer), &grpcUsermanager.GetUsersByPaginationQueryRequest{PaginationQuery: &grpcUsermanager.PaginationQuery{Size: 2}})
+	assert.NoError(t, err)
+	paginationQuery := mockUserUsecase.Calls[0].Arguments.Get(1).(*utils.PaginationQuery)
+	assert.Equal(t, &viewer.UserID, paginationQuery.Filter.PublicOrUserID)
+}
+
+func TestUserManagerGrpcController_GetUsers_LoginDateSort(t *testing.T) {
+	admin := &model.User{UserID: uuid.New(), Nickname: "admin", Role: model.RoleAdmin}
+	user := &model.User{UserID: uuid.New(), Nickname: "user", Role: model.RoleUser}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"anonymous", context.Background(), codes.InvalidArgument},
+		{"user", ContextWithViewer(context.Background(), user), codes.PermissionDenied},
+		{"admin", ContextWithViewer(context.Background(), admin), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserUsecase := &UserUsecaseMock{}
+			mockUserUsecase.On("GetUsers", mock.Anything, mock.Anything).Return(&model.Users{}, nil)
+
+			_, err := NewUserManagerGrpcController(mockUserUsecase, nil, nil, model.PrivateProfileModeCard).GetUsers(tt.ctx, &grpcUsermanager.GetUsersRequest{PaginationQuery: &grpcUsermanager.PaginationQuery{OrderBy: "login_date:desc"}})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestUserManagerGrpcController_UpdateUserSettings_Unauthenticated(t *testing.T) {
+	changes, err := structpb.NewStruct(map[string]any{model.SettingFollowsApproval: false})
+	assert.NoError(t, err)
+
+	_, err = NewUserManagerGrpcController(nil, nil, nil, model.PrivateProfileModeCard).UpdateUserSettings(context.Background(), &grpcUsermanager.UpdateUserSettingsRequest{UserId: uuid.NewString(), Changes: changes})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+}
+
+func TestUserManagerGrpcController_UserHierarchy_Permissions(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	stranger := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	userRepoMock := &usecase.UserRepositoryMock{}
+	userRepoMock.On("GetUserAncestors", mock.Anything, user.UserID, mock.Anything).Return([]*model.UserTreeNode{}, nil)
+	userHierarchyUsecase := usecase.NewUserHierarchyUsecase(userRepoMock, nil, nil, nil, &model.UserHierarchyPolicy{MaxDepth: 5})
+	ctrl := NewUserManagerGrpcController(nil, userHierarchyUsecase, nil, model.PrivateProfileModeCard)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"anonymous", context.Background(), codes.Unauthenticated},
+		{"not an ancestor", ContextWithViewer(context.Background(), stranger), codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ctrl.GetUserChildren(tt.ctx, &grpcUsermanager.GetUserChildrenRequest{UserId: user.UserID.String()})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			_, err = ctrl.GetUserDescendants(tt.ctx, &grpcUsermanager.GetUserDescendantsRequest{UserId: user.UserID.String()})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			_, err = ctrl.GetUserAncestors(tt.ctx, &grpcUsermanager.GetUserAncestorsRequest{UserId: user.UserID.String()})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+	userRepoMock.AssertNotCalled(t, "GetUserDescendants", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -38,6 +38,14 @@ type UserUsecaseClient interface {
 	LoadVotesToUsers(ctx context.Context, in *LoadVotesToUsersRequest, opts ...grpc.CallOption) (*LoadVotesToUsersResponse, error)
 	GetLastVoteForUser(ctx context.Context, in *GetLastVoteForUserRequest, opts ...grpc.CallOption) (*GetLastVoteForUserResponse, error)
 	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	PatchUser(ctx context.Context, in *PatchUserRequest, opts ...grpc.CallOption) (*PatchUserResponse, error)
+	GetUserChildren(ctx context.Context, in *GetUserChildrenRequest, opts ...grpc.CallOption) (*GetUserChildrenResponse, error)
+	GetUserDescendants(ctx context.Context, in *GetUserDescendantsRequest, opts ...grpc.CallOption) (*GetUserDescendantsResponse, error)
+	GetUserAncestors(ctx context.Context, in *GetUserAncestorsRequest, opts ...grpc.CallOption) (*GetUserAncestorsResponse, error)
+	GetUserSettings(ctx context.Context, in *GetUserSettingsRequest, opts ...grpc.CallOption) (*GetUserSettingsResponse, error)
+	UpdateUserSettings(ctx context.Context, in *UpdateUserSettingsRequest, opts ...grpc.CallOption) (*UpdateUserSettingsResponse, error)
 }
 
 type userUsecaseClient struct {
@@ -192,6 +200,78 @@ func (c *userUsecaseClient) Vote(ctx context.Context, in *VoteRequest, opts ...g
 	return out, nil
 }
 
+func (c *userUsecaseClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error) {
+	out := new(SearchUsersResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/SearchUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) PatchUser(ctx context.Context, in *PatchUserRequest, opts ...grpc.CallOption) (*PatchUserResponse, error) {
+	out := new(PatchUserResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/PatchUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) GetUserChildren(ctx context.Context, in *GetUserChildrenRequest, opts ...grpc.CallOption) (*GetUserChildrenResponse, error) {
+	out := new(GetUserChildrenResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/GetUserChildren", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) GetUserDescendants(ctx context.Context, in *GetUserDescendantsRequest, opts ...grpc.CallOption) (*GetUserDescendantsResponse, error) {
+	out := new(GetUserDescendantsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/GetUserDescendants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) GetUserAncestors(ctx context.Context, in *GetUserAncestorsRequest, opts ...grpc.CallOption) (*GetUserAncestorsResponse, error) {
+	out := new(GetUserAncestorsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/GetUserAncestors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) GetUserSettings(ctx context.Context, in *GetUserSettingsRequest, opts ...grpc.CallOption) (*GetUserSettingsResponse, error) {
+	out := new(GetUserSettingsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/GetUserSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userUsecaseClient) UpdateUserSettings(ctx context.Context, in *UpdateUserSettingsRequest, opts ...grpc.CallOption) (*UpdateUserSettingsResponse, error) {
+	out := new(UpdateUserSettingsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.UserUsecase/UpdateUserSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserUsecaseServer is the server API for UserUsecase service.
 // All implementations must embed UnimplementedUserUsecaseServer
 // for forward compatibility
@@ -212,6 +292,14 @@ type UserUsecaseServer interface {
 	LoadVotesToUsers(context.Context, *LoadVotesToUsersRequest) (*LoadVotesToUsersResponse, error)
 	GetLastVoteForUser(context.Context, *GetLastVoteForUserRequest) (*GetLastVoteForUserResponse, error)
 	Vote(context.Context, *VoteRequest) (*VoteResponse, error)
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	PatchUser(context.Context, *PatchUserRequest) (*PatchUserResponse, error)
+	GetUserChildren(context.Context, *GetUserChildrenRequest) (*GetUserChildrenResponse, error)
+	GetUserDescendants(context.Context, *GetUserDescendantsRequest) (*GetUserDescendantsResponse, error)
+	GetUserAncestors(context.Context, *GetUserAncestorsRequest) (*GetUserAncestorsResponse, error)
+	GetUserSettings(context.Context, *GetUserSettingsRequest) (*GetUserSettingsResponse, error)
+	UpdateUserSettings(context.Context, *UpdateUserSettingsRequest) (*UpdateUserSettingsResponse, error)
 	mustEmbedUnimplementedUserUsecaseServer()
 }
 
@@ -267,6 +355,30 @@ func (UnimplementedUserUsecaseServer) GetLastVoteForUser(context.Context, *GetLa
 func (UnimplementedUserUsecaseServer) Vote(context.Context, *VoteRequest) (*VoteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
 }
+func (UnimplementedUserUsecaseServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedUserUsecaseServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedUserUsecaseServer) PatchUser(context.Context, *PatchUserRequest) (*PatchUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchUser not implemented")
+}
+func (UnimplementedUserUsecaseServer) GetUserChildren(context.Context, *GetUserChildrenRequest) (*GetUserChildrenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserChildren not implemented")
+}
+func (UnimplementedUserUsecaseServer) GetUserDescendants(context.Context, *GetUserDescendantsRequest) (*GetUserDescendantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserDescendants not implemented")
+}
+func (UnimplementedUserUsecaseServer) GetUserAncestors(context.Context, *GetUserAncestorsRequest) (*GetUserAncestorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserAncestors not implemented")
+}
+func (UnimplementedUserUsecaseServer) GetUserSettings(context.Context, *GetUserSettingsRequest) (*GetUserSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserSettings not implemented")
+}
+func (UnimplementedUserUsecaseServer) UpdateUserSettings(context.Context, *UpdateUserSettingsRequest) (*UpdateUserSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserSettings not implemented")
+}
 func (UnimplementedUserUsecaseServer) mustEmbedUnimplementedUserUsecaseServer() {}
 
 // UnsafeUserUsecaseServer may be embedded to opt out of forward compatibility for this service.
@@ -568,6 +680,150 @@ func _UserUsecase_Vote_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserUsecase_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/SearchUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).SearchUsers(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_PatchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).PatchUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/PatchUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).PatchUser(ctx, req.(*PatchUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_GetUserChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserChildrenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).GetUserChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/GetUserChildren",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).GetUserChildren(ctx, req.(*GetUserChildrenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_GetUserDescendants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserDescendantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).GetUserDescendants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/GetUserDescendants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).GetUserDescendants(ctx, req.(*GetUserDescendantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_GetUserAncestors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserAncestorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).GetUserAncestors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/GetUserAncestors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).GetUserAncestors(ctx, req.(*GetUserAncestorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_GetUserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).GetUserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/GetUserSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).GetUserSettings(ctx, req.(*GetUserSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserUsecase_UpdateUserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserUsecaseServer).UpdateUserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.UserUsecase/UpdateUserSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserUsecaseServer).UpdateUserSettings(ctx, req.(*UpdateUserSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserUsecase_ServiceDesc is the grpc.ServiceDesc for UserUsecase service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -639,6 +895,38 @@ var UserUsecase_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Vote",
 			Handler:    _UserUsecase_Vote_Handler,
 		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UserUsecase_RestoreUser_Handler,
+		},
+		{
+			MethodName: "SearchUsers",
+			Handler:    _UserUsecase_SearchUsers_Handler,
+		},
+		{
+			MethodName: "PatchUser",
+			Handler:    _UserUsecase_PatchUser_Handler,
+		},
+		{
+			MethodName: "GetUserChildren",
+			Handler:    _UserUsecase_GetUserChildren_Handler,
+		},
+		{
+			MethodName: "GetUserDescendants",
+			Handler:    _UserUsecase_GetUserDescendants_Handler,
+		},
+		{
+			MethodName: "GetUserAncestors",
+			Handler:    _UserUsecase_GetUserAncestors_Handler,
+		},
+		{
+			MethodName: "GetUserSettings",
+			Handler:    _UserUsecase_GetUserSettings_Handler,
+		},
+		{
+			MethodName: "UpdateUserSettings",
+			Handler:    _UserUsecase_UpdateUserSettings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "usecase_user.proto",
@@ -42,6 +42,102 @@ var (
 		HTTPCode: http.StatusInternalServerError,
 	}
 
+	EnvConfigRoleParseError = AppError{
+		Message:  "Failed to parse role env file",
+		Code:     "ENV_CONFIG_ROLE_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigProfileParseError = AppError{
+		Message:  "Failed to parse profile env file",
+		Code:     "ENV_CONFIG_PROFILE_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigRetentionParseError = AppError{
+		Message:  "Failed to parse retention env file",
+		Code:     "ENV_CONFIG_RETENTION_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigPaginationParseError = AppError{
+		Message:  "Failed to parse pagination env file",
+		Code:     "ENV_CONFIG_PAGINATION_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigImportParseError = AppError{
+		Message:  "Failed to parse import env file",
+		Code:     "ENV_CONFIG_IMPORT_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigBlobParseError = AppError{
+		Message:  "Failed to parse blob env file",
+		Code:     "ENV_CONFIG_BLOB_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigAvatarParseError = AppError{
+		Message:  "Failed to parse avatar env file",
+		Code:     "ENV_CONFIG_AVATAR_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigNicknameParseError = AppError{
+		Message:  "Failed to parse nickname env file",
+		Code:     "ENV_CONFIG_NICKNAME_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigMailParseError = AppError{
+		Message:  "Failed to parse mail env file",
+		Code:     "ENV_CONFIG_MAIL_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigEmailParseError = AppError{
+		Message:  "Failed to parse email env file",
+		Code:     "ENV_CONFIG_EMAIL_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigDataRequestParseError = AppError{
+		Message:  "Failed to parse data request env file",
+		Code:     "ENV_CONFIG_DATA_REQUEST_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigSuspensionParseError = AppError{
+		Message:  "Failed to parse suspension env file",
+		Code:     "ENV_CONFIG_SUSPENSION_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigHierarchyParseError = AppError{
+		Message:  "Failed to parse hierarchy env file",
+		Code:     "ENV_CONFIG_HIERARCHY_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigSettingsParseError = AppError{
+		Message:  "Failed to parse settings env file",
+		Code:     "ENV_CONFIG_SETTINGS_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigInviteParseError = AppError{
+		Message:  "Failed to parse invite env file",
+		Code:     "ENV_CONFIG_INVITE_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EnvConfigRegistrationParseError = AppError{
+		Message:  "Failed to parse registration env file",
+		Code:     "ENV_CONFIG_REGISTRATION_PARSE_ERROR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
 	SqlOpenError = AppError{
 		Message:  "Failed to connect database",
 		Code:     "SQL_OPEN_ERR",
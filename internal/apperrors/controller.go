@@ -224,4 +224,549 @@ var (
 		Code:     "USER_CONTROLLER_VOTE_USER_VOTE_INTERVAL",
 		HTTPCode: http.StatusBadRequest,
 	}
+
+	RoleControllerAssignRoleUuidParse = AppError{
+		Message:  "The assign role operation has been failed",
+		Code:     "ROLE_CONTROLLER_ASSIGN_ROLE_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerAssignRoleBind = AppError{
+		Message:  "The assign role operation has been failed",
+		Code:     "ROLE_CONTROLLER_ASSIGN_ROLE_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerGetRoleHistoryUuidParse = AppError{
+		Message:  "The get role history operation has been failed",
+		Code:     "ROLE_CONTROLLER_GET_ROLE_HISTORY_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerReviewRoleRequestUuidParse = AppError{
+		Message:  "The review role request operation has been failed",
+		Code:     "ROLE_CONTROLLER_REVIEW_ROLE_REQUEST_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerReviewRoleRequestBind = AppError{
+		Message:  "The review role request operation has been failed",
+		Code:     "ROLE_CONTROLLER_REVIEW_ROLE_REQUEST_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerGetRoleRequestsStatus = AppError{
+		Message:  "The get role requests operation has been failed, unknown status",
+		Code:     "ROLE_CONTROLLER_GET_ROLE_REQUESTS_STATUS",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerRequestRoleGrantBind = AppError{
+		Message:  "The request role grant operation has been failed",
+		Code:     "ROLE_CONTROLLER_REQUEST_ROLE_GRANT_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerGetRoleGrantsStatus = AppError{
+		Message:  "The get role grants operation has been failed, unknown status",
+		Code:     "ROLE_CONTROLLER_GET_ROLE_GRANTS_STATUS",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerGetUserRoleGrantsUuidParse = AppError{
+		Message:  "The get user role grants operation has been failed",
+		Code:     "ROLE_CONTROLLER_GET_USER_ROLE_GRANTS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleControllerRoleGrantUuidParse = AppError{
+		Message:  "The role grant operation has been failed",
+		Code:     "ROLE_CONTROLLER_ROLE_GRANT_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerLoginApplyRoleGrants = AppError{
+		Message:  "The login operation has been failed",
+		Code:     "USER_CONTROLLER_LOGIN_APPLY_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserControllerDeleteUserHardParse = AppError{
+		Message:  "The delete user operation has been failed. Parse hard param has been failed",
+		Code:     "USER_CONTROLLER_DELETE_USER_HARD_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerDeleteUserHardForbidden = AppError{
+		Message:  "The delete user operation has been failed. Only admin can hard delete users",
+		Code:     "USER_CONTROLLER_DELETE_USER_HARD_FORBIDDEN",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserControllerRestoreUserUuidParse = AppError{
+		Message:  "The restore user operation has been failed",
+		Code:     "USER_CONTROLLER_RESTORE_USER_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUsersGetUsersQueryFromCtx = AppError{
+		Message:  "The get users operation has been failed. Invalid query params",
+		Code:     "USER_CONTROLLER_GET_USERS_GET_USERS_QUERY_FROM_CTX",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUsersPrivateFilter = AppError{
+		Message:  "The get users operation has been failed. Only admin can filter by email domain or login date",
+		Code:     "USER_CONTROLLER_GET_USERS_PRIVATE_FILTER",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserControllerGetUsersPrivateSortAnonymous = AppError{
+		Message:  "The get users operation has been failed. Sorting by login date needs an authenticated admin",
+		Code:     "USER_CONTROLLER_GET_USERS_PRIVATE_SORT_ANONYMOUS",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUsersPrivateSort = AppError{
+		Message:  "The get users operation has been failed. Only admin can sort by login date",
+		Code:     "USER_CONTROLLER_GET_USERS_PRIVATE_SORT",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserControllerSearchUsersGetPaginationFromCtx = AppError{
+		Message:  "The search users operation has been failed. Invalid pagination params",
+		Code:     "USER_CONTROLLER_SEARCH_USERS_GET_PAGINATION_FROM_CTX",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerPatchUserUuidParse = AppError{
+		Message:  "The patch user operation has been failed. Parse uuid has been failed",
+		Code:     "USER_CONTROLLER_PATCH_USER_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerPatchUserContentType = AppError{
+		Message:  "The patch user operation has been failed. Content type must be application/merge-patch+json or application/json-patch+json",
+		Code:     "USER_CONTROLLER_PATCH_USER_CONTENT_TYPE",
+		HTTPCode: http.StatusUnsupportedMediaType,
+	}
+
+	UserControllerPatchUserReadBody = AppError{
+		Message:  "The patch user operation has been failed. Read body has been failed",
+		Code:     "USER_CONTROLLER_PATCH_USER_READ_BODY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerPatchUserUserNotExist = AppError{
+		Message:  "The patch user operation has been failed. User doesn't exist",
+		Code:     "USER_CONTROLLER_PATCH_USER_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserControllerPatchUserApplyPatch = AppError{
+		Message:  "The patch user operation has been failed. Apply patch has been failed",
+		Code:     "USER_CONTROLLER_PATCH_USER_APPLY_PATCH",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerPatchUserTestFailed = AppError{
+		Message:  "The patch user operation has been failed. Test operation has been failed",
+		Code:     "USER_CONTROLLER_PATCH_USER_TEST_FAILED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserControllerIfMatchPreconditionFailed = AppError{
+		Message:  "The user has been changed since it was read. If-Match doesn't match the current ETag",
+		Code:     "USER_CONTROLLER_IF_MATCH_PRECONDITION_FAILED",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserControllerImportUsersFormat = AppError{
+		Message:  "The import users operation has been failed. Format must be csv or ndjson",
+		Code:     "USER_CONTROLLER_IMPORT_USERS_FORMAT",
+		HTTPCode: http.StatusUnsupportedMediaType,
+	}
+
+	UserControllerImportUsersOptionParse = AppError{
+		Message:  "The import users operation has been failed. Parse option has been failed",
+		Code:     "USER_CONTROLLER_IMPORT_USERS_OPTION_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerImportUsersReadBody = AppError{
+		Message:  "The import users operation has been failed. Read body has been failed",
+		Code:     "USER_CONTROLLER_IMPORT_USERS_READ_BODY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerImportUsersTooLarge = AppError{
+		Message:  "The import users operation has been failed. The file is too large",
+		Code:     "USER_CONTROLLER_IMPORT_USERS_TOO_LARGE",
+		HTTPCode: http.StatusRequestEntityTooLarge,
+	}
+
+	UserControllerGetImportJobUuidParse = AppError{
+		Message:  "The get import job operation has been failed. Parse uuid has been failed",
+		Code:     "USER_CONTROLLER_GET_IMPORT_JOB_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerExportUsersQuery = AppError{
+		Message:  "wrong export format or fields",
+		Code:     "USER_CONTROLLER_EXPORT_USERS_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerExportUsersFilter = AppError{
+		Message:  "wrong filter",
+		Code:     "USER_CONTROLLER_EXPORT_USERS_FILTER",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSetAttributeSchemaBody = AppError{
+		Message:  "can't read the attribute schema",
+		Code:     "USER_CONTROLLER_SET_ATTRIBUTE_SCHEMA_BODY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSetAvatarUuidParse = AppError{
+		Message:  "The set avatar operation has been failed. Parse user id has been failed",
+		Code:     "USER_CONTROLLER_SET_AVATAR_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSetAvatarUserNotExist = AppError{
+		Message:  "The set avatar operation has been failed. User doesn't exist",
+		Code:     "USER_CONTROLLER_SET_AVATAR_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserControllerSetAvatarReadBody = AppError{
+		Message:  "The set avatar operation has been failed. Read body has been failed",
+		Code:     "USER_CONTROLLER_SET_AVATAR_READ_BODY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSetAvatarTooLarge = AppError{
+		Message:  "The set avatar operation has been failed. The file is too large",
+		Code:     "USER_CONTROLLER_SET_AVATAR_TOO_LARGE",
+		HTTPCode: http.StatusRequestEntityTooLarge,
+	}
+
+	UserControllerDeleteAvatarUuidParse = AppError{
+		Message:  "The delete avatar operation has been failed. Parse user id has been failed",
+		Code:     "USER_CONTROLLER_DELETE_AVATAR_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerDeleteAvatarUserNotExist = AppError{
+		Message:  "The delete avatar operation has been failed. User doesn't exist",
+		Code:     "USER_CONTROLLER_DELETE_AVATAR_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserControllerGetUserByNicknameUserNotExist = AppError{
+		Message:  "The get user by nickname operation has been failed, user is not exist",
+		Code:     "USER_CONTROLLER_GET_USER_BY_NICKNAME_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserControllerGetNicknameHistoryUuidParse = AppError{
+		Message:  "The get nickname history operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_NICKNAME_HISTORY_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerReserveNicknameBind = AppError{
+		Message:  "The reserve nickname operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_RESERVE_NICKNAME_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRequestEmailChangeUuidParse = AppError{
+		Message:  "The request email change operation has been failed, uuid parse error",
+		Code:     "USER_CONTROLLER_REQUEST_EMAIL_CHANGE_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRequestEmailChangeBind = AppError{
+		Message:  "The request email change operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_REQUEST_EMAIL_CHANGE_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerEmailTokenBind = AppError{
+		Message:  "The email change operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_EMAIL_TOKEN_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerLoginGetUserByEmailEmpty = AppError{
+		Message:  "The login operation has been failed, get user by email, user not exist",
+		Code:     "USER_CONTROLLER_LOGIN_GET_USER_BY_EMAIL_EMPTY",
+		HTTPCode: http.StatusUnauthorized,
+	}
+
+	UserControllerGetUserHistoryUuidParse = AppError{
+		Message:  "The get user history operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_HISTORY_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserHistoryQuery = AppError{
+		Message:  "The get user history operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_HISTORY_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSearchAuditLogQuery = AppError{
+		Message:  "The search audit log operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_SEARCH_AUDIT_LOG_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerExportAuditLogQuery = AppError{
+		Message:  "The export audit log operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_EXPORT_AUDIT_LOG_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRequestDataExportUuidParse = AppError{
+		Message:  "The request data export operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_REQUEST_DATA_EXPORT_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRequestErasureUuidParse = AppError{
+		Message:  "The request erasure operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_REQUEST_ERASURE_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserDataRequestUuidParse = AppError{
+		Message:  "The get data request operation has been failed, an id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_DATA_REQUEST_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetDataExportArchiveUuidParse = AppError{
+		Message:  "The get data export archive operation has been failed, an id is not valid",
+		Code:     "USER_CONTROLLER_GET_DATA_EXPORT_ARCHIVE_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetDataRequestsQuery = AppError{
+		Message:  "The get data requests operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_GET_DATA_REQUESTS_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetDataRequestUuidParse = AppError{
+		Message:  "The get data request operation has been failed, data request id is not valid",
+		Code:     "USER_CONTROLLER_GET_DATA_REQUEST_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSuspendUserUuidParse = AppError{
+		Message:  "The suspend user operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_SUSPEND_USER_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSuspendUserBind = AppError{
+		Message:  "The suspend user operation has been failed, the body is not valid",
+		Code:     "USER_CONTROLLER_SUSPEND_USER_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerUnsuspendUserUuidParse = AppError{
+		Message:  "The unsuspend user operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_UNSUSPEND_USER_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	HasPermissionIsUserAncestor = AppError{
+		Message:  "The permission check has been failed, the owners of the user can't be read",
+		Code:     "HAS_PERMISSION_IS_USER_ANCESTOR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserControllerGetUserChildrenUuidParse = AppError{
+		Message:  "The get user children operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_CHILDREN_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserDescendantsUuidParse = AppError{
+		Message:  "The get user descendants operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_DESCENDANTS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserAncestorsUuidParse = AppError{
+		Message:  "The get user ancestors operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_ANCESTORS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerCreateUserChildUuidParse = AppError{
+		Message:  "The create child user operation has been failed, parent id is not valid",
+		Code:     "USER_CONTROLLER_CREATE_USER_CHILD_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerCreateUserChildBind = AppError{
+		Message:  "The create child user operation has been failed, the body is not valid",
+		Code:     "USER_CONTROLLER_CREATE_USER_CHILD_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSetUserParentUuidParse = AppError{
+		Message:  "The set user parent operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_SET_USER_PARENT_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerSetUserParentBind = AppError{
+		Message:  "The set user parent operation has been failed, the body is not valid",
+		Code:     "USER_CONTROLLER_SET_USER_PARENT_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerLoginRecordLogin = AppError{
+		Message:  "The login operation has been failed",
+		Code:     "USER_CONTROLLER_LOGIN_RECORD_LOGIN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserControllerGetUserLoginsUuidParse = AppError{
+		Message:  "The get user logins operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_LOGINS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserLoginsQuery = AppError{
+		Message:  "The get user logins operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_LOGINS_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserSettingsUuidParse = AppError{
+		Message:  "The get user settings operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_USER_SETTINGS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerUpdateUserSettingsUuidParse = AppError{
+		Message:  "The update user settings operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_UPDATE_USER_SETTINGS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerUpdateUserSettingsBody = AppError{
+		Message:  "The update user settings operation has been failed, the body must be a JSON object",
+		Code:     "USER_CONTROLLER_UPDATE_USER_SETTINGS_BODY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerFollowUserUuidParse = AppError{
+		Message:  "The follow user operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_FOLLOW_USER_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerUnfollowUserUuidParse = AppError{
+		Message:  "The unfollow user operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_UNFOLLOW_USER_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetFollowsUuidParse = AppError{
+		Message:  "The get follows operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_FOLLOWS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetFollowsQuery = AppError{
+		Message:  "The get follows operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_GET_FOLLOWS_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetFollowRequestsUuidParse = AppError{
+		Message:  "The get follow requests operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_FOLLOW_REQUESTS_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetFollowRequestsQuery = AppError{
+		Message:  "The get follow requests operation has been failed, the query is not valid",
+		Code:     "USER_CONTROLLER_GET_FOLLOW_REQUESTS_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerApproveFollowRequestUuidParse = AppError{
+		Message:  "The approve follow request operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_APPROVE_FOLLOW_REQUEST_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRejectFollowRequestUuidParse = AppError{
+		Message:  "The reject follow request operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_REJECT_FOLLOW_REQUEST_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetUserGetFollowCounts = AppError{
+		Message:  "The get user operation has been failed, counting the follows has been failed",
+		Code:     "USER_CONTROLLER_GET_USER_GET_FOLLOW_COUNTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+	UserControllerGetUserMarshal = AppError{
+		Message:  "The get user operation has been failed, encoding the user has been failed",
+		Code:     "USER_CONTROLLER_GET_USER_MARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserControllerCreateInviteBind = AppError{
+		Message:  "The create invite operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_CREATE_INVITE_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerAcceptInviteBind = AppError{
+		Message:  "The accept invite operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_ACCEPT_INVITE_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerGetInvitesUuidParse = AppError{
+		Message:  "The get invites operation has been failed, user id is not valid",
+		Code:     "USER_CONTROLLER_GET_INVITES_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRevokeInviteUuidParse = AppError{
+		Message:  "The revoke invite operation has been failed, invite id is not valid",
+		Code:     "USER_CONTROLLER_REVOKE_INVITE_UUID_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerRegisterUserBind = AppError{
+		Message:  "The register user operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_REGISTER_USER_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerVerifyRegistrationBind = AppError{
+		Message:  "The verify registration operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_VERIFY_REGISTRATION_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserControllerResendVerificationBind = AppError{
+		Message:  "The resend verification operation has been failed, bind error",
+		Code:     "USER_CONTROLLER_RESEND_VERIFICATION_BIND",
+		HTTPCode: http.StatusBadRequest,
+	}
 )
@@ -132,4 +132,154 @@ var (
 		Code:     "USER_GRPC_CONTROLLER_VOTE",
 		HTTPCode: 500,
 	}
+
+	UserGrpcAuthInterceptorParseToken = AppError{
+		Message:  "The authorization has been failed. Parse token has been failed",
+		Code:     "USER_GRPC_AUTH_INTERCEPTOR_PARSE_TOKEN",
+		HTTPCode: 401,
+	}
+
+	UserGrpcControllerGetUserNotFound = AppError{
+		Message:  "The get user operation has been failed. User not found",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_NOT_FOUND",
+		HTTPCode: 404,
+	}
+
+	UserGrpcControllerGetUserByIDNotFound = AppError{
+		Message:  "The get user by id operation has been failed. User not found",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_BY_ID_NOT_FOUND",
+		HTTPCode: 404,
+	}
+
+	UserGrpcControllerRestoreUserUuidParse = AppError{
+		Message:  "The restore user operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_RESTORE_USER_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerRestoreUser = AppError{
+		Message:  "The restore user operation has been failed. Restore user has been failed",
+		Code:     "USER_GRPC_CONTROLLER_RESTORE_USER",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerSearchUsers = AppError{
+		Message:  "The search users operation has been failed",
+		Code:     "USER_GRPC_CONTROLLER_SEARCH_USERS",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerPatchUserUuidParse = AppError{
+		Message:  "The patch user operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_PATCH_USER_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerPatchUserChanges = AppError{
+		Message:  "The patch user operation has been failed. Unmarshal changes has been failed",
+		Code:     "USER_GRPC_CONTROLLER_PATCH_USER_CHANGES",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerPatchUser = AppError{
+		Message:  "The patch user operation has been failed. Patch user has been failed",
+		Code:     "USER_GRPC_CONTROLLER_PATCH_USER",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserChildrenUuidParse = AppError{
+		Message:  "The get user children operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_CHILDREN_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserChildren = AppError{
+		Message:  "The get user children operation has been failed. Get user children has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_CHILDREN",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserDescendantsUuidParse = AppError{
+		Message:  "The get user descendants operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_DESCENDANTS_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserDescendants = AppError{
+		Message:  "The get user descendants operation has been failed. Get user descendants has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_DESCENDANTS",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserAncestorsUuidParse = AppError{
+		Message:  "The get user ancestors operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_ANCESTORS_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserAncestors = AppError{
+		Message:  "The get user ancestors operation has been failed. Get user ancestors has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_ANCESTORS",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserSettingsUuidParse = AppError{
+		Message:  "The get user settings operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_SETTINGS_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerGetUserSettings = AppError{
+		Message:  "The get user settings operation has been failed. Get user settings has been failed",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_SETTINGS",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerUpdateUserSettingsUuidParse = AppError{
+		Message:  "The update user settings operation has been failed. Parse uuid has been failed",
+		Code:     "USER_GRPC_CONTROLLER_UPDATE_USER_SETTINGS_UUID_PARSE",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerUpdateUserSettings = AppError{
+		Message:  "The update user settings operation has been failed. Update user settings has been failed",
+		Code:     "USER_GRPC_CONTROLLER_UPDATE_USER_SETTINGS",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerUnauthenticated = AppError{
+		Message:  "The operation has been failed. The call has no valid token",
+		Code:     "USER_GRPC_CONTROLLER_UNAUTHENTICATED",
+		HTTPCode: 401,
+	}
+
+	UserGrpcControllerAuthorizeIsUserAncestor = AppError{
+		Message:  "The authorization has been failed. Is user ancestor has been failed",
+		Code:     "USER_GRPC_CONTROLLER_AUTHORIZE_IS_USER_ANCESTOR",
+		HTTPCode: 500,
+	}
+
+	UserGrpcControllerPatchUserColumn = AppError{
+		Message:  "The patch user operation has been failed. The column can't be patched",
+		Code:     "USER_GRPC_CONTROLLER_PATCH_USER_COLUMN",
+		HTTPCode: 400,
+	}
+
+	UserGrpcControllerDeleteUserHardForbidden = AppError{
+		Message:  "The delete user operation has been failed. Only admins can hard delete users",
+		Code:     "USER_GRPC_CONTROLLER_DELETE_USER_HARD_FORBIDDEN",
+		HTTPCode: 403,
+	}
+
+	UserGrpcControllerGetUserByNicknameNotFound = AppError{
+		Message:  "The get user by nickname operation has been failed. User not found",
+		Code:     "USER_GRPC_CONTROLLER_GET_USER_BY_NICKNAME_NOT_FOUND",
+		HTTPCode: 404,
+	}
+
+	UserGrpcControllerPrivateSort = AppError{
+		Message:  "The get users operation has been failed. Only admin can sort by login date",
+		Code:     "USER_GRPC_CONTROLLER_PRIVATE_SORT",
+		HTTPCode: 403,
+	}
 )
@@ -32,4 +32,82 @@ var (
 		Code:     "HAS_PERMISSIONS_DELETE_USER",
 		HTTPCode: http.StatusBadRequest,
 	}
+
+	HasPermissionsAssignRole = AppError{
+		Message:  "Auth user doesn't have permission to assign role",
+		Code:     "HAS_PERMISSIONS_ASSIGN_ROLE",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsRestoreUser = AppError{
+		Message:  "Auth user doesn't have permission to restore user",
+		Code:     "HAS_PERMISSIONS_RESTORE_USER",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsImportUsers = AppError{
+		Message:  "Auth user doesn't have permission to import users",
+		Code:     "HAS_PERMISSIONS_IMPORT_USERS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsExportUsers = AppError{
+		Message:  "you don't have permissions to export users",
+		Code:     "HAS_PERMISSIONS_EXPORT_USERS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsManageAttributeSchema = AppError{
+		Message:  "Auth user doesn't have permission to manage the attribute schema",
+		Code:     "HAS_PERMISSIONS_MANAGE_ATTRIBUTE_SCHEMA",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsManageReservedNicknames = AppError{
+		Message:  "Auth user doesn't have permission to manage reserved nicknames",
+		Code:     "HAS_PERMISSIONS_MANAGE_RESERVED_NICKNAMES",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsReadAuditLog = AppError{
+		Message:  "Auth user doesn't have permission to read the audit log",
+		Code:     "HAS_PERMISSIONS_READ_AUDIT_LOG",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsManageDataRequests = AppError{
+		Message:  "Auth user doesn't have permission to manage data requests",
+		Code:     "HAS_PERMISSIONS_MANAGE_DATA_REQUESTS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserCheckActiveSuspended = AppError{
+		Message:  "The user is suspended",
+		Code:     "USER_CHECK_ACTIVE_SUSPENDED",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserCheckActiveBanned = AppError{
+		Message:  "The user is banned",
+		Code:     "USER_CHECK_ACTIVE_BANNED",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserCheckActiveUnverified = AppError{
+		Message:  "The user hasn't verified its email",
+		Code:     "USER_CHECK_ACTIVE_UNVERIFIED",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsSuspendUsers = AppError{
+		Message:  "Auth user doesn't have permission to suspend users",
+		Code:     "HAS_PERMISSIONS_SUSPEND_USERS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	HasPermissionsInviteUsers = AppError{
+		Message:  "Auth user doesn't have permission to invite users",
+		Code:     "HAS_PERMISSIONS_INVITE_USERS",
+		HTTPCode: http.StatusForbidden,
+	}
 )
@@ -488,4 +488,1198 @@ var (
 		Code:     "VOTE_REDIS_REPO_SET_FIND_USER_VOTE_BY_ID_SET",
 		HTTPCode: http.StatusInternalServerError,
 	}
+
+	RoleRepoChangeUserRoleBeginTxx = AppError{
+		Message:  "ChangeUserRole operation has been failed. Begin transaction error",
+		Code:     "ROLE_REPO_CHANGE_USER_ROLE_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoChangeUserRoleExecContext = AppError{
+		Message:  "ChangeUserRole operation has been failed. Update user role error",
+		Code:     "ROLE_REPO_CHANGE_USER_ROLE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoChangeUserRoleEmptyRowsAffected = AppError{
+		Message:  "ChangeUserRole operation has been failed. User not found",
+		Code:     "ROLE_REPO_CHANGE_USER_ROLE_EMPTY_ROWS_AFFECTED",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleRepoChangeUserRoleQueryRowxContext = AppError{
+		Message:  "ChangeUserRole operation has been failed. Add role history error",
+		Code:     "ROLE_REPO_CHANGE_USER_ROLE_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoChangeUserRoleCommit = AppError{
+		Message:  "ChangeUserRole operation has been failed. Commit transaction error",
+		Code:     "ROLE_REPO_CHANGE_USER_ROLE_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoFindRoleHistoryByUserIDSelectContext = AppError{
+		Message:  "FindRoleHistoryByUserID operation has been failed",
+		Code:     "ROLE_REPO_FIND_ROLE_HISTORY_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoSaveRoleRequestExecContext = AppError{
+		Message:  "SaveRoleRequest operation has been failed",
+		Code:     "ROLE_REPO_SAVE_ROLE_REQUEST_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoUpdateRoleRequestExecContext = AppError{
+		Message:  "UpdateRoleRequest operation has been failed",
+		Code:     "ROLE_REPO_UPDATE_ROLE_REQUEST_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoUpdateRoleRequestEmptyRowsAffected = AppError{
+		Message:  "UpdateRoleRequest operation has been failed. Pending request not found",
+		Code:     "ROLE_REPO_UPDATE_ROLE_REQUEST_EMPTY_ROWS_AFFECTED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RoleRepoFindRoleRequestByIDGetContext = AppError{
+		Message:  "FindRoleRequestByID operation has been failed",
+		Code:     "ROLE_REPO_FIND_ROLE_REQUEST_BY_ID_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoFindRoleRequestByIDGetDataNotFound = AppError{
+		Message:  "FindRoleRequestByID operation has been failed. Data not found",
+		Code:     "ROLE_REPO_FIND_ROLE_REQUEST_BY_ID_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleRepoFindRoleRequestsByStatusSelectContext = AppError{
+		Message:  "FindRoleRequestsByStatus operation has been failed",
+		Code:     "ROLE_REPO_FIND_ROLE_REQUESTS_BY_STATUS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRedisRepoDeleteFindUserByUUIDDel = AppError{
+		Message:  "DeleteFindUserByUUID operation has been failed",
+		Code:     "USER_REDIS_REPO_DELETE_FIND_USER_BY_UUID_DEL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRedisRepoDeleteFindUserByNicknameDel = AppError{
+		Message:  "DeleteFindUserByNickname operation has been failed",
+		Code:     "USER_REDIS_REPO_DELETE_FIND_USER_BY_NICKNAME_DEL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoApproveRoleRequestBeginTxx = AppError{
+		Message:  "ApproveRoleRequest operation has been failed. Begin transaction error",
+		Code:     "ROLE_REPO_APPROVE_ROLE_REQUEST_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleRepoApproveRoleRequestCommit = AppError{
+		Message:  "ApproveRoleRequest operation has been failed. Commit transaction error",
+		Code:     "ROLE_REPO_APPROVE_ROLE_REQUEST_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoSaveRoleGrantExecContext = AppError{
+		Message:  "The save role grant operation has been failed",
+		Code:     "ROLE_GRANT_REPO_SAVE_ROLE_GRANT_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoUpdateRoleGrantExecContext = AppError{
+		Message:  "The update role grant operation has been failed",
+		Code:     "ROLE_GRANT_REPO_UPDATE_ROLE_GRANT_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoUpdateRoleGrantEmptyRowsAffected = AppError{
+		Message:  "The update role grant operation has been failed. Role grant has been already changed",
+		Code:     "ROLE_GRANT_REPO_UPDATE_ROLE_GRANT_EMPTY_ROWS_AFFECTED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RoleGrantRepoFindRoleGrantByIDGetDataNotFound = AppError{
+		Message:  "The find role grant operation has been failed. Role grant not found",
+		Code:     "ROLE_GRANT_REPO_FIND_ROLE_GRANT_BY_ID_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleGrantRepoFindRoleGrantByIDGetContext = AppError{
+		Message:  "The find role grant operation has been failed",
+		Code:     "ROLE_GRANT_REPO_FIND_ROLE_GRANT_BY_ID_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoFindRoleGrantsByStatusSelectContext = AppError{
+		Message:  "The find role grants operation has been failed",
+		Code:     "ROLE_GRANT_REPO_FIND_ROLE_GRANTS_BY_STATUS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoFindRoleGrantsByUserIDSelectContext = AppError{
+		Message:  "The find role grants operation has been failed",
+		Code:     "ROLE_GRANT_REPO_FIND_ROLE_GRANTS_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoFindActiveRoleGrantsByUserIDSelectContext = AppError{
+		Message:  "The find active role grants operation has been failed",
+		Code:     "ROLE_GRANT_REPO_FIND_ACTIVE_ROLE_GRANTS_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantRepoExpireRoleGrantsSelectContext = AppError{
+		Message:  "The expire role grants operation has been failed",
+		Code:     "ROLE_GRANT_REPO_EXPIRE_ROLE_GRANTS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EventRedisRepoPublishMarshal = AppError{
+		Message:  "The publish event operation has been failed",
+		Code:     "EVENT_REDIS_REPO_PUBLISH_MARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EventRedisRepoPublishPublish = AppError{
+		Message:  "The publish event operation has been failed",
+		Code:     "EVENT_REDIS_REPO_PUBLISH_PUBLISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoSoftDeleteUserByUserIDDataNotFound = AppError{
+		Message:  "SoftDeleteUserByUserID operation has been failed. Data not found",
+		Code:     "USER_REPO_SOFT_DELETE_USER_BY_USER_ID_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoFindDeletedUserByUUIDGetDataNotFound = AppError{
+		Message:  "FindDeletedUserByUUID operation has been failed. Data not found",
+		Code:     "USER_REPO_FIND_DELETED_USER_BY_UUID_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoFindDeletedUserByUUIDGetContext = AppError{
+		Message:  "FindDeletedUserByUUID operation has been failed",
+		Code:     "USER_REPO_FIND_DELETED_USER_BY_UUID_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoRestoreUserByUserIDDataNotFound = AppError{
+		Message:  "RestoreUserByUserID operation has been failed. Data not found",
+		Code:     "USER_REPO_RESTORE_USER_BY_USER_ID_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoRestoreUserByUserIDQueryRowxContext = AppError{
+		Message:  "RestoreUserByUserID operation has been failed",
+		Code:     "USER_REPO_RESTORE_USER_BY_USER_ID_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersBeginTxx = AppError{
+		Message:  "PurgeDeletedUsers operation has been failed",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteVotes = AppError{
+		Message:  "PurgeDeletedUsers operation has been failed. Delete votes has been failed",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_VOTES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteUsers = AppError{
+		Message:  "PurgeDeletedUsers operation has been failed. Delete users has been failed",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersCommit = AppError{
+		Message:  "PurgeDeletedUsers operation has been failed",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDBeginTxx = AppError{
+		Message:  "DeleteUserByUserID operation has been failed",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteVotes = AppError{
+		Message:  "DeleteUserByUserID operation has been failed. Delete votes has been failed",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_VOTES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDCommit = AppError{
+		Message:  "DeleteUserByUserID operation has been failed",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoGetUsersBuildQuery = AppError{
+		Message:  "The get users operation has been failed. Invalid query",
+		Code:     "USER_REPO_GET_USERS_BUILD_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserRepoSearchUsersQueryxContext = AppError{
+		Message:  "The search users operation has been failed. Query has been failed",
+		Code:     "USER_REPO_SEARCH_USERS_QUERYX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoSearchUsersStructScan = AppError{
+		Message:  "The search users operation has been failed. Scan has been failed",
+		Code:     "USER_REPO_SEARCH_USERS_STRUCT_SCAN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoSearchUsersRows = AppError{
+		Message:  "The search users operation has been failed. Rows has been failed",
+		Code:     "USER_REPO_SEARCH_USERS_ROWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoGetUsersDecodeCursor = AppError{
+		Message:  "The get users operation has been failed. Invalid cursor",
+		Code:     "USER_REPO_GET_USERS_DECODE_CURSOR",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserRepoGetUsersEncodeCursor = AppError{
+		Message:  "The get users operation has been failed. Encode cursor has been failed",
+		Code:     "USER_REPO_GET_USERS_ENCODE_CURSOR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoGetUsersCountUsers = AppError{
+		Message:  "The get users operation has been failed. Count users has been failed",
+		Code:     "USER_REPO_GET_USERS_COUNT_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPatchUserBuildQuery = AppError{
+		Message:  "The patch user operation has been failed. Build query has been failed",
+		Code:     "USER_REPO_PATCH_USER_BUILD_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserRepoPatchUserDataNotFound = AppError{
+		Message:  "The patch user operation has been failed. User doesn't exist",
+		Code:     "USER_REPO_PATCH_USER_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoPatchUserQueryRowxContext = AppError{
+		Message:  "The patch user operation has been failed",
+		Code:     "USER_REPO_PATCH_USER_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoUpdateUserVersionMismatch = AppError{
+		Message:  "UpdateUser operation has been failed. Version mismatch",
+		Code:     "USER_REPO_UPDATE_USER_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserRepoUpdateUserDataNotFound = AppError{
+		Message:  "UpdateUser operation has been failed. Data not found",
+		Code:     "USER_REPO_UPDATE_USER_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoPatchUserVersionMismatch = AppError{
+		Message:  "The patch user operation has been failed. Version mismatch",
+		Code:     "USER_REPO_PATCH_USER_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserRepoSoftDeleteUserByUserIDVersionMismatch = AppError{
+		Message:  "SoftDeleteUserByUserID operation has been failed. Version mismatch",
+		Code:     "USER_REPO_SOFT_DELETE_USER_BY_USER_ID_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserImportRedisRepoSaveJobMarshal = AppError{
+		Message:  "SaveJob operation has been failed. Marshal has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_SAVE_JOB_MARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoSaveJobSet = AppError{
+		Message:  "SaveJob operation has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_SAVE_JOB_SET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoFindJobGetDataNotFound = AppError{
+		Message:  "FindJob operation has been failed. Data not found",
+		Code:     "USER_IMPORT_REDIS_REPO_FIND_JOB_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserImportRedisRepoFindJobGet = AppError{
+		Message:  "FindJob operation has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_FIND_JOB_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoFindJobUnmarshal = AppError{
+		Message:  "FindJob operation has been failed. Unmarshal has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_FIND_JOB_UNMARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoEnqueueJobMarshal = AppError{
+		Message:  "EnqueueJob operation has been failed. Marshal has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_ENQUEUE_JOB_MARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoEnqueueJobTxPipelined = AppError{
+		Message:  "EnqueueJob operation has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_ENQUEUE_JOB_TX_PIPELINED",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoDequeueJobRPop = AppError{
+		Message:  "DequeueJob operation has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_DEQUEUE_JOB_R_POP",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoDequeueJobUuidParse = AppError{
+		Message:  "DequeueJob operation has been failed. Parse uuid has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_DEQUEUE_JOB_UUID_PARSE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoDequeueJobFindJob = AppError{
+		Message:  "DequeueJob operation has been failed. Find job has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_DEQUEUE_JOB_FIND_JOB",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportRedisRepoDequeueJobGetPayload = AppError{
+		Message:  "DequeueJob operation has been failed. Get payload has been failed",
+		Code:     "USER_IMPORT_REDIS_REPO_DEQUEUE_JOB_GET_PAYLOAD",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoStreamUsersQueryxContext = AppError{
+		Message:  "could not stream users",
+		Code:     "USER_REPO_STREAM_USERS_QUERYX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoStreamUsersStructScan = AppError{
+		Message:  "could not scan exported user",
+		Code:     "USER_REPO_STREAM_USERS_STRUCT_SCAN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoStreamUsersUnmarshalVotes = AppError{
+		Message:  "could not read votes of exported user",
+		Code:     "USER_REPO_STREAM_USERS_UNMARSHAL_VOTES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoStreamUsersRows = AppError{
+		Message:  "could not stream users",
+		Code:     "USER_REPO_STREAM_USERS_ROWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAttributeSchemaRepoFindSchemaGetDataNotFound = AppError{
+		Message:  "attributes schema not found",
+		Code:     "USER_ATTRIBUTE_SCHEMA_REPO_FIND_SCHEMA_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserAttributeSchemaRepoFindSchemaGetContext = AppError{
+		Message:  "could not get attributes schema",
+		Code:     "USER_ATTRIBUTE_SCHEMA_REPO_FIND_SCHEMA_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAttributeSchemaRepoSaveSchemaQueryRowxContext = AppError{
+		Message:  "could not save attributes schema",
+		Code:     "USER_ATTRIBUTE_SCHEMA_REPO_SAVE_SCHEMA_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	BlobStoreInvalidKey = AppError{
+		Message:  "invalid blob key",
+		Code:     "BLOB_STORE_INVALID_KEY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	BlobStorePut = AppError{
+		Message:  "could not store blob",
+		Code:     "BLOB_STORE_PUT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	BlobStoreGet = AppError{
+		Message:  "could not read blob",
+		Code:     "BLOB_STORE_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	BlobStoreGetNotFound = AppError{
+		Message:  "blob not found",
+		Code:     "BLOB_STORE_GET_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	BlobStoreDelete = AppError{
+		Message:  "could not delete blob",
+		Code:     "BLOB_STORE_DELETE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoFindReservedNicknameGetDataNotFound = AppError{
+		Message:  "reserved nickname not found",
+		Code:     "NICKNAME_REPO_FIND_RESERVED_NICKNAME_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	NicknameRepoFindReservedNicknameGetContext = AppError{
+		Message:  "could not get reserved nickname",
+		Code:     "NICKNAME_REPO_FIND_RESERVED_NICKNAME_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoGetReservedNicknamesSelectContext = AppError{
+		Message:  "could not get reserved nicknames",
+		Code:     "NICKNAME_REPO_GET_RESERVED_NICKNAMES_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoSaveReservedNicknameQueryRowxContext = AppError{
+		Message:  "could not save reserved nickname",
+		Code:     "NICKNAME_REPO_SAVE_RESERVED_NICKNAME_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoDeleteReservedNicknameExecContext = AppError{
+		Message:  "could not delete reserved nickname",
+		Code:     "NICKNAME_REPO_DELETE_RESERVED_NICKNAME_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoDeleteReservedNicknameDataNotFound = AppError{
+		Message:  "reserved nickname not found",
+		Code:     "NICKNAME_REPO_DELETE_RESERVED_NICKNAME_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	NicknameRepoSaveNicknameChangeQueryRowxContext = AppError{
+		Message:  "could not save nickname change",
+		Code:     "NICKNAME_REPO_SAVE_NICKNAME_CHANGE_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoFindLastNicknameChangeGetDataNotFound = AppError{
+		Message:  "nickname change not found",
+		Code:     "NICKNAME_REPO_FIND_LAST_NICKNAME_CHANGE_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	NicknameRepoFindLastNicknameChangeGetContext = AppError{
+		Message:  "could not get nickname change",
+		Code:     "NICKNAME_REPO_FIND_LAST_NICKNAME_CHANGE_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameRepoGetNicknameChangesByUserIDSelectContext = AppError{
+		Message:  "could not get nickname history",
+		Code:     "NICKNAME_REPO_GET_NICKNAME_CHANGES_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoFindUserByEmailGetContext = AppError{
+		Message:  "FindUserByEmail user by email operation has been failed",
+		Code:     "USER_REPO_FIND_USER_BY_EMAIL_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoFindUserByEmailGetDataNotFound = AppError{
+		Message:  "FindUserByEmail user by email operation has been failed. Data not found",
+		Code:     "USER_REPO_FIND_USER_BY_EMAIL_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteEmailChanges = AppError{
+		Message:  "PurgeDeletedUsers operation has been failed. Delete email changes has been failed",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_EMAIL_CHANGES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteEmailChanges = AppError{
+		Message:  "DeleteUserByUserID operation has been failed. Delete email changes has been failed",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_EMAIL_CHANGES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoSaveEmailChangeExecContext = AppError{
+		Message:  "could not save email change",
+		Code:     "EMAIL_CHANGE_REPO_SAVE_EMAIL_CHANGE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoFindEmailChangeGetDataNotFound = AppError{
+		Message:  "email change not found",
+		Code:     "EMAIL_CHANGE_REPO_FIND_EMAIL_CHANGE_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	EmailChangeRepoFindEmailChangeGetContext = AppError{
+		Message:  "could not get email change",
+		Code:     "EMAIL_CHANGE_REPO_FIND_EMAIL_CHANGE_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoConfirmEmailChangeBeginTxx = AppError{
+		Message:  "could not begin the email change confirmation",
+		Code:     "EMAIL_CHANGE_REPO_CONFIRM_EMAIL_CHANGE_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoConfirmEmailChangeExecContext = AppError{
+		Message:  "could not confirm email change",
+		Code:     "EMAIL_CHANGE_REPO_CONFIRM_EMAIL_CHANGE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoConfirmEmailChangeNotPending = AppError{
+		Message:  "email change is not pending",
+		Code:     "EMAIL_CHANGE_REPO_CONFIRM_EMAIL_CHANGE_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	EmailChangeRepoConfirmEmailChangePatchUser = AppError{
+		Message:  "could not write the confirmed email to the user",
+		Code:     "EMAIL_CHANGE_REPO_CONFIRM_EMAIL_CHANGE_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoConfirmEmailChangeUserNotFound = AppError{
+		Message:  "user of the email change not found",
+		Code:     "EMAIL_CHANGE_REPO_CONFIRM_EMAIL_CHANGE_USER_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	EmailChangeRepoConfirmEmailChangeCommit = AppError{
+		Message:  "could not commit the email change confirmation",
+		Code:     "EMAIL_CHANGE_REPO_CONFIRM_EMAIL_CHANGE_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoCancelEmailChangeExecContext = AppError{
+		Message:  "could not cancel email change",
+		Code:     "EMAIL_CHANGE_REPO_CANCEL_EMAIL_CHANGE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoCancelEmailChangeNotPending = AppError{
+		Message:  "email change is not pending",
+		Code:     "EMAIL_CHANGE_REPO_CANCEL_EMAIL_CHANGE_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	EmailChangeRepoCancelPendingEmailChangesExecContext = AppError{
+		Message:  "could not cancel pending email changes",
+		Code:     "EMAIL_CHANGE_REPO_CANCEL_PENDING_EMAIL_CHANGES_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	MailerSend = AppError{
+		Message:  "could not send email",
+		Code:     "MAILER_SEND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditRepoSaveAuditEntryQueryRowxContext = AppError{
+		Message:  "could not save audit entry",
+		Code:     "AUDIT_REPO_SAVE_AUDIT_ENTRY_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditRepoFindAuditEntriesSelectContext = AppError{
+		Message:  "could not find audit entries",
+		Code:     "AUDIT_REPO_FIND_AUDIT_ENTRIES_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditRepoStreamAuditEntriesQueryxContext = AppError{
+		Message:  "could not query audit entries",
+		Code:     "AUDIT_REPO_STREAM_AUDIT_ENTRIES_QUERYX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditRepoStreamAuditEntriesStructScan = AppError{
+		Message:  "could not scan audit entry",
+		Code:     "AUDIT_REPO_STREAM_AUDIT_ENTRIES_STRUCT_SCAN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditRepoStreamAuditEntriesRows = AppError{
+		Message:  "could not read audit entries",
+		Code:     "AUDIT_REPO_STREAM_AUDIT_ENTRIES_ROWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoSaveDataRequestExecContext = AppError{
+		Message:  "could not save data request",
+		Code:     "DATA_REQUEST_REPO_SAVE_DATA_REQUEST_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoFindDataRequestGetDataNotFound = AppError{
+		Message:  "data request not found",
+		Code:     "DATA_REQUEST_REPO_FIND_DATA_REQUEST_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	DataRequestRepoFindDataRequestGetContext = AppError{
+		Message:  "could not find data request",
+		Code:     "DATA_REQUEST_REPO_FIND_DATA_REQUEST_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoGetDataRequestsSelectContext = AppError{
+		Message:  "could not get data requests",
+		Code:     "DATA_REQUEST_REPO_GET_DATA_REQUESTS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoClaimNextDataRequestGetContext = AppError{
+		Message:  "could not claim data request",
+		Code:     "DATA_REQUEST_REPO_CLAIM_NEXT_DATA_REQUEST_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoFinishDataRequestExecContext = AppError{
+		Message:  "could not finish data request",
+		Code:     "DATA_REQUEST_REPO_FINISH_DATA_REQUEST_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoGetExpiredDataExportsSelectContext = AppError{
+		Message:  "could not get expired data exports",
+		Code:     "DATA_REQUEST_REPO_GET_EXPIRED_DATA_EXPORTS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestRepoClearDataExportArchiveExecContext = AppError{
+		Message:  "could not clear data export archive",
+		Code:     "DATA_REQUEST_REPO_CLEAR_DATA_EXPORT_ARCHIVE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserBeginTxx = AppError{
+		Message:  "could not begin transaction",
+		Code:     "USER_REPO_ERASE_USER_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDataNotFound = AppError{
+		Message:  "user not found",
+		Code:     "USER_REPO_ERASE_USER_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserRepoEraseUserQueryRowxContext = AppError{
+		Message:  "could not erase user",
+		Code:     "USER_REPO_ERASE_USER_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDeleteEmailChanges = AppError{
+		Message:  "could not delete email changes of user",
+		Code:     "USER_REPO_ERASE_USER_DELETE_EMAIL_CHANGES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDeleteNicknameHistory = AppError{
+		Message:  "could not delete nickname history of user",
+		Code:     "USER_REPO_ERASE_USER_DELETE_NICKNAME_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserCommit = AppError{
+		Message:  "could not commit transaction",
+		Code:     "USER_REPO_ERASE_USER_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	VoteRepoGetVotesCastByUserIDSelectContext = AppError{
+		Message:  "could not get votes cast by user",
+		Code:     "VOTE_REPO_GET_VOTES_CAST_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	VoteRepoGetVotesReceivedByUserIDSelectContext = AppError{
+		Message:  "could not get votes received by user",
+		Code:     "VOTE_REPO_GET_VOTES_RECEIVED_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailChangeRepoGetEmailChangesByUserIDSelectContext = AppError{
+		Message:  "could not get email changes of user",
+		Code:     "EMAIL_CHANGE_REPO_GET_EMAIL_CHANGES_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoGetUsersWithExpiredStatusSelectContext = AppError{
+		Message:  "could not get the users with an expired status",
+		Code:     "USER_REPO_GET_USERS_WITH_EXPIRED_STATUS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoGetUserAncestorsSelectContext = AppError{
+		Message:  "could not get the ancestors of the user",
+		Code:     "USER_REPO_GET_USER_ANCESTORS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoGetUserDescendantsSelectContext = AppError{
+		Message:  "could not get the descendants of the user",
+		Code:     "USER_REPO_GET_USER_DESCENDANTS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventRepoSaveLoginEventQueryRowxContext = AppError{
+		Message:  "could not save the login event",
+		Code:     "LOGIN_EVENT_REPO_SAVE_LOGIN_EVENT_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventRepoFindLoginEventsSelectContext = AppError{
+		Message:  "could not find the login events",
+		Code:     "LOGIN_EVENT_REPO_FIND_LOGIN_EVENTS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventRepoGetLoginEventsByUserIDSelectContext = AppError{
+		Message:  "could not get the login events of the user",
+		Code:     "LOGIN_EVENT_REPO_GET_LOGIN_EVENTS_BY_USER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventRepoGetLoginClientHistoryGetContext = AppError{
+		Message:  "could not get the login client history of the user",
+		Code:     "LOGIN_EVENT_REPO_GET_LOGIN_CLIENT_HISTORY_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoUpdateLoginDateExecContext = AppError{
+		Message:  "could not update the login date",
+		Code:     "USER_REPO_UPDATE_LOGIN_DATE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteLoginEvents = AppError{
+		Message:  "could not delete the login events of the purged users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_LOGIN_EVENTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteLoginEvents = AppError{
+		Message:  "could not delete the login events of the user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_LOGIN_EVENTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDeleteLoginEvents = AppError{
+		Message:  "could not delete the login events of the erased user",
+		Code:     "USER_REPO_ERASE_USER_DELETE_LOGIN_EVENTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRepoGetUserSettingsSelectContext = AppError{
+		Message:  "could not get the user settings",
+		Code:     "USER_SETTING_REPO_GET_USER_SETTINGS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRepoSaveUserSettingsBeginTxx = AppError{
+		Message:  "could not begin the user settings transaction",
+		Code:     "USER_SETTING_REPO_SAVE_USER_SETTINGS_BEGIN_TXX",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRepoSaveUserSettingsDelete = AppError{
+		Message:  "could not reset the user setting",
+		Code:     "USER_SETTING_REPO_SAVE_USER_SETTINGS_DELETE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRepoSaveUserSettingsMarshal = AppError{
+		Message:  "could not encode the user setting",
+		Code:     "USER_SETTING_REPO_SAVE_USER_SETTINGS_MARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRepoSaveUserSettingsUpsert = AppError{
+		Message:  "could not save the user setting",
+		Code:     "USER_SETTING_REPO_SAVE_USER_SETTINGS_UPSERT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRepoSaveUserSettingsCommit = AppError{
+		Message:  "could not commit the user settings",
+		Code:     "USER_SETTING_REPO_SAVE_USER_SETTINGS_COMMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRedisRepoGetUserSettingsGet = AppError{
+		Message:  "The get user settings operation has been failed. Redis get has been failed",
+		Code:     "USER_SETTING_REDIS_REPO_GET_USER_SETTINGS_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRedisRepoGetUserSettingsGetDataNotFound = AppError{
+		Message:  "The get user settings operation has been failed. Data not found",
+		Code:     "USER_SETTING_REDIS_REPO_GET_USER_SETTINGS_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRedisRepoGetUserSettingsUnmarshal = AppError{
+		Message:  "The get user settings operation has been failed. Unmarshal has been failed",
+		Code:     "USER_SETTING_REDIS_REPO_GET_USER_SETTINGS_UNMARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRedisRepoSetUserSettingsMarshal = AppError{
+		Message:  "The set user settings operation has been failed. Marshal has been failed",
+		Code:     "USER_SETTING_REDIS_REPO_SET_USER_SETTINGS_MARSHAL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRedisRepoSetUserSettingsSet = AppError{
+		Message:  "The set user settings operation has been failed. Redis set has been failed",
+		Code:     "USER_SETTING_REDIS_REPO_SET_USER_SETTINGS_SET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingRedisRepoDeleteUserSettingsDel = AppError{
+		Message:  "The delete user settings operation has been failed. Redis del has been failed",
+		Code:     "USER_SETTING_REDIS_REPO_DELETE_USER_SETTINGS_DEL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteSettings = AppError{
+		Message:  "could not delete the settings of the purged users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_SETTINGS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteSettings = AppError{
+		Message:  "could not delete the settings of the user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_SETTINGS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDeleteSettings = AppError{
+		Message:  "could not delete the settings of the erased user",
+		Code:     "USER_REPO_ERASE_USER_DELETE_SETTINGS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoSaveFollowQueryRowxContext = AppError{
+		Message:  "could not save the follow",
+		Code:     "FOLLOW_REPO_SAVE_FOLLOW_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoFindFollowGetContext = AppError{
+		Message:  "could not get the follow",
+		Code:     "FOLLOW_REPO_FIND_FOLLOW_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoFindFollowGetDataNotFound = AppError{
+		Message:  "the follow does not exist",
+		Code:     "FOLLOW_REPO_FIND_FOLLOW_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	FollowRepoAcceptFollowQueryRowxContext = AppError{
+		Message:  "could not accept the follow",
+		Code:     "FOLLOW_REPO_ACCEPT_FOLLOW_QUERY_ROWX_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoAcceptFollowDataNotFound = AppError{
+		Message:  "there is no pending follow to accept",
+		Code:     "FOLLOW_REPO_ACCEPT_FOLLOW_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	FollowRepoDeleteFollowExecContext = AppError{
+		Message:  "could not delete the follow",
+		Code:     "FOLLOW_REPO_DELETE_FOLLOW_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoDeleteFollowRowsAffected = AppError{
+		Message:  "could not count the deleted follows",
+		Code:     "FOLLOW_REPO_DELETE_FOLLOW_ROWS_AFFECTED",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoFindFollowsSelectContext = AppError{
+		Message:  "could not list the follows",
+		Code:     "FOLLOW_REPO_FIND_FOLLOWS_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoGetFollowCountsGetContext = AppError{
+		Message:  "could not count the follows",
+		Code:     "FOLLOW_REPO_GET_FOLLOW_COUNTS_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowRepoGetFollowsByFollowerIDSelectContext = AppError{
+		Message:  "could not get the follows of the user",
+		Code:     "FOLLOW_REPO_GET_FOLLOWS_BY_FOLLOWER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteFollows = AppError{
+		Message:  "could not delete the follows of the purged users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_FOLLOWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteFollows = AppError{
+		Message:  "could not delete the follows of the user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_FOLLOWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDeleteFollows = AppError{
+		Message:  "could not delete the follows of the erased user",
+		Code:     "USER_REPO_ERASE_USER_DELETE_FOLLOWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteRepoSaveInviteExecContext = AppError{
+		Message:  "could not save invite",
+		Code:     "INVITE_REPO_SAVE_INVITE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteRepoFindInviteGetDataNotFound = AppError{
+		Message:  "invite not found",
+		Code:     "INVITE_REPO_FIND_INVITE_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	InviteRepoFindInviteGetContext = AppError{
+		Message:  "could not get invite",
+		Code:     "INVITE_REPO_FIND_INVITE_GET_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteRepoGetInvitesByInviterIDSelectContext = AppError{
+		Message:  "could not get invites",
+		Code:     "INVITE_REPO_GET_INVITES_BY_INVITER_ID_SELECT_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteRepoAcceptInviteExecContext = AppError{
+		Message:  "could not accept invite",
+		Code:     "INVITE_REPO_ACCEPT_INVITE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteRepoAcceptInviteNotPending = AppError{
+		Message:  "invite is not pending",
+		Code:     "INVITE_REPO_ACCEPT_INVITE_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	InviteRepoRevokeInviteExecContext = AppError{
+		Message:  "could not revoke invite",
+		Code:     "INVITE_REPO_REVOKE_INVITE_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteRepoRevokeInviteNotPending = AppError{
+		Message:  "invite is not pending",
+		Code:     "INVITE_REPO_REVOKE_INVITE_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	InviteRepoRevokePendingInvitesExecContext = AppError{
+		Message:  "could not revoke pending invites",
+		Code:     "INVITE_REPO_REVOKE_PENDING_INVITES_EXEC_CONTEXT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteInvites = AppError{
+		Message:  "could not delete invites of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_INVITES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteInvites = AppError{
+		Message:  "could not delete invites of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_INVITES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserDeleteInvites = AppError{
+		Message:  "could not delete invites of user",
+		Code:     "USER_REPO_ERASE_USER_DELETE_INVITES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserRedactAuditChanges = AppError{
+		Message:  "could not redact audit changes of user",
+		Code:     "USER_REPO_ERASE_USER_REDACT_AUDIT_CHANGES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoEraseUserRedactAuditActor = AppError{
+		Message:  "could not redact audit actor of user",
+		Code:     "USER_REPO_ERASE_USER_REDACT_AUDIT_ACTOR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoSaveChallengeSet = AppError{
+		Message:  "The save challenge operation has been failed. Redis set has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_SAVE_CHALLENGE_SET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoTakeChallengeDel = AppError{
+		Message:  "The take challenge operation has been failed. Redis del has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_TAKE_CHALLENGE_DEL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoIncrementIPHitsIncr = AppError{
+		Message:  "The increment ip hits operation has been failed. Redis incr has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_INCREMENT_IP_HITS_INCR",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoIncrementIPHitsExpire = AppError{
+		Message:  "The increment ip hits operation has been failed. Redis expire has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_INCREMENT_IP_HITS_EXPIRE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoSaveVerificationSet = AppError{
+		Message:  "The save verification operation has been failed. Redis set has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_SAVE_VERIFICATION_SET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoFindVerificationGet = AppError{
+		Message:  "The find verification operation has been failed. Redis get has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_FIND_VERIFICATION_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoFindVerificationGetDataNotFound = AppError{
+		Message:  "The find verification operation has been failed. Data not found",
+		Code:     "REGISTRATION_REDIS_REPO_FIND_VERIFICATION_GET_DATA_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RegistrationRedisRepoFindVerificationParse = AppError{
+		Message:  "The find verification operation has been failed. User id is not valid",
+		Code:     "REGISTRATION_REDIS_REPO_FIND_VERIFICATION_PARSE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationRedisRepoDeleteVerificationDel = AppError{
+		Message:  "The delete verification operation has been failed. Redis del has been failed",
+		Code:     "REGISTRATION_REDIS_REPO_DELETE_VERIFICATION_DEL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteRoleRequests = AppError{
+		Message:  "could not delete role requests of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_ROLE_REQUESTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteRoleHistory = AppError{
+		Message:  "could not delete role history of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_ROLE_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteRoleGrants = AppError{
+		Message:  "could not delete role grants of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteNicknameHistory = AppError{
+		Message:  "could not delete nickname history of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_NICKNAME_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersDeleteDataRequests = AppError{
+		Message:  "could not delete data requests of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_DELETE_DATA_REQUESTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoPurgeDeletedUsersGetAvatarKeys = AppError{
+		Message:  "could not get avatar keys of deleted users",
+		Code:     "USER_REPO_PURGE_DELETED_USERS_GET_AVATAR_KEYS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteRoleRequests = AppError{
+		Message:  "could not delete role requests of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_ROLE_REQUESTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteRoleHistory = AppError{
+		Message:  "could not delete role history of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_ROLE_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteRoleGrants = AppError{
+		Message:  "could not delete role grants of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteNicknameHistory = AppError{
+		Message:  "could not delete nickname history of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_NICKNAME_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDDeleteDataRequests = AppError{
+		Message:  "could not delete data requests of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_DELETE_DATA_REQUESTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRepoDeleteUserByUserIDGetAvatarKeys = AppError{
+		Message:  "could not get avatar keys of user",
+		Code:     "USER_REPO_DELETE_USER_BY_USER_ID_GET_AVATAR_KEYS",
+		HTTPCode: http.StatusInternalServerError,
+	}
 )
@@ -236,4 +236,1870 @@ var (
 		Code:     "USER_USECASE_VOTE_WITHDRAW_VOTE_USER",
 		HTTPCode: http.StatusInternalServerError,
 	}
+
+	RoleUsecaseAssignRoleHasPermissions = AppError{
+		Message:  "The assign role operation has been failed. Auth user doesn't have permission",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_HAS_PERMISSIONS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	RoleUsecaseAssignRoleRoleNotExist = AppError{
+		Message:  "The assign role operation has been failed. Role doesn't exist",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_ROLE_NOT_EXIST",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleUsecaseAssignRoleYourself = AppError{
+		Message:  "The assign role operation has been failed. User can't change own role",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_YOURSELF",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleUsecaseAssignRoleUserNotExist = AppError{
+		Message:  "The assign role operation has been failed. User doesn't exist",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleUsecaseAssignRoleFindUserByUUID = AppError{
+		Message:  "The assign role operation has been failed. Find user by uuid has been failed",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_FIND_USER_BY_UUID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseAssignRoleRoleAlreadySet = AppError{
+		Message:  "The assign role operation has been failed. User already has this role",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_ROLE_ALREADY_SET",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RoleUsecaseAssignRoleSaveRoleRequest = AppError{
+		Message:  "The assign role operation has been failed. Save role request has been failed",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_SAVE_ROLE_REQUEST",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseAssignRoleChangeUserRole = AppError{
+		Message:  "The assign role operation has been failed. Change user role has been failed",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_CHANGE_USER_ROLE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseAssignRoleDropUserCache = AppError{
+		Message:  "The assign role operation has been failed. Drop user cache has been failed",
+		Code:     "ROLE_USECASE_ASSIGN_ROLE_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseGetRoleRequests = AppError{
+		Message:  "The get role requests operation has been failed",
+		Code:     "ROLE_USECASE_GET_ROLE_REQUESTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseApproveRoleRequestSameAdmin = AppError{
+		Message:  "The approve role request operation has been failed. Request must be approved by another admin",
+		Code:     "ROLE_USECASE_APPROVE_ROLE_REQUEST_SAME_ADMIN",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	RoleUsecaseApproveRoleRequestUserNotExist = AppError{
+		Message:  "The approve role request operation has been failed. User doesn't exist",
+		Code:     "ROLE_USECASE_APPROVE_ROLE_REQUEST_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleUsecaseApproveRoleRequestFindUserByUUID = AppError{
+		Message:  "The approve role request operation has been failed. Find user by uuid has been failed",
+		Code:     "ROLE_USECASE_APPROVE_ROLE_REQUEST_FIND_USER_BY_UUID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseApproveRoleRequestApprove = AppError{
+		Message:  "The approve role request operation has been failed",
+		Code:     "ROLE_USECASE_APPROVE_ROLE_REQUEST_APPROVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseApproveRoleRequestDropUserCache = AppError{
+		Message:  "The approve role request operation has been failed. Drop user cache has been failed",
+		Code:     "ROLE_USECASE_APPROVE_ROLE_REQUEST_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseRejectRoleRequestUpdateRoleRequest = AppError{
+		Message:  "The reject role request operation has been failed. Update role request has been failed",
+		Code:     "ROLE_USECASE_REJECT_ROLE_REQUEST_UPDATE_ROLE_REQUEST",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseGetRoleHistory = AppError{
+		Message:  "The get role history operation has been failed",
+		Code:     "ROLE_USECASE_GET_ROLE_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseReviewRoleRequestHasPermissions = AppError{
+		Message:  "The review role request operation has been failed. Auth user doesn't have permission",
+		Code:     "ROLE_USECASE_REVIEW_ROLE_REQUEST_HAS_PERMISSIONS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	RoleUsecaseReviewRoleRequestNotExist = AppError{
+		Message:  "The review role request operation has been failed. Role request doesn't exist",
+		Code:     "ROLE_USECASE_REVIEW_ROLE_REQUEST_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleUsecaseReviewRoleRequestFindRoleRequestByID = AppError{
+		Message:  "The review role request operation has been failed. Find role request has been failed",
+		Code:     "ROLE_USECASE_REVIEW_ROLE_REQUEST_FIND_ROLE_REQUEST_BY_ID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseReviewRoleRequestNotPending = AppError{
+		Message:  "The review role request operation has been failed. Role request has been already reviewed",
+		Code:     "ROLE_USECASE_REVIEW_ROLE_REQUEST_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantRoleNotExist = AppError{
+		Message:  "The request role grant operation has been failed. Role doesn't exist",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_ROLE_NOT_EXIST",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantFindUserByUUID = AppError{
+		Message:  "The request role grant operation has been failed. Find user by uuid has been failed",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_FIND_USER_BY_UUID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantRoleNotHigher = AppError{
+		Message:  "The request role grant operation has been failed. Requested role must be higher than the current one",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_ROLE_NOT_HIGHER",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantDuration = AppError{
+		Message:  "The request role grant operation has been failed. Duration exceeds the allowed maximum",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_DURATION",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantFindRoleGrantsByUserID = AppError{
+		Message:  "The request role grant operation has been failed. Find role grants has been failed",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_FIND_ROLE_GRANTS_BY_USER_ID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantAlreadyExist = AppError{
+		Message:  "The request role grant operation has been failed. User already has a pending or active grant for this role",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_ALREADY_EXIST",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantSaveRoleGrant = AppError{
+		Message:  "The request role grant operation has been failed. Save role grant has been failed",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_SAVE_ROLE_GRANT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRequestRoleGrantPublish = AppError{
+		Message:  "The request role grant operation has been failed. Publish event has been failed",
+		Code:     "ROLE_GRANT_USECASE_REQUEST_ROLE_GRANT_PUBLISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseApproveRoleGrantYourself = AppError{
+		Message:  "The approve role grant operation has been failed. User can't approve own grant",
+		Code:     "ROLE_GRANT_USECASE_APPROVE_ROLE_GRANT_YOURSELF",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	RoleGrantUsecaseApproveRoleGrantUpdateRoleGrant = AppError{
+		Message:  "The approve role grant operation has been failed. Update role grant has been failed",
+		Code:     "ROLE_GRANT_USECASE_APPROVE_ROLE_GRANT_UPDATE_ROLE_GRANT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseApproveRoleGrantPublish = AppError{
+		Message:  "The approve role grant operation has been failed. Publish event has been failed",
+		Code:     "ROLE_GRANT_USECASE_APPROVE_ROLE_GRANT_PUBLISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRejectRoleGrantUpdateRoleGrant = AppError{
+		Message:  "The reject role grant operation has been failed. Update role grant has been failed",
+		Code:     "ROLE_GRANT_USECASE_REJECT_ROLE_GRANT_UPDATE_ROLE_GRANT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRevokeRoleGrantHasPermissions = AppError{
+		Message:  "The revoke role grant operation has been failed. Auth user doesn't have permission",
+		Code:     "ROLE_GRANT_USECASE_REVOKE_ROLE_GRANT_HAS_PERMISSIONS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	RoleGrantUsecaseRevokeRoleGrantNotRevocable = AppError{
+		Message:  "The revoke role grant operation has been failed. Role grant is neither pending nor active",
+		Code:     "ROLE_GRANT_USECASE_REVOKE_ROLE_GRANT_NOT_REVOCABLE",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RoleGrantUsecaseRevokeRoleGrantUpdateRoleGrant = AppError{
+		Message:  "The revoke role grant operation has been failed. Update role grant has been failed",
+		Code:     "ROLE_GRANT_USECASE_REVOKE_ROLE_GRANT_UPDATE_ROLE_GRANT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRevokeRoleGrantPublish = AppError{
+		Message:  "The revoke role grant operation has been failed. Publish event has been failed",
+		Code:     "ROLE_GRANT_USECASE_REVOKE_ROLE_GRANT_PUBLISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseGetRoleGrants = AppError{
+		Message:  "The get role grants operation has been failed",
+		Code:     "ROLE_GRANT_USECASE_GET_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseGetUserRoleGrants = AppError{
+		Message:  "The get user role grants operation has been failed",
+		Code:     "ROLE_GRANT_USECASE_GET_USER_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseApplyRoleGrantsFindActiveRoleGrantsByUserID = AppError{
+		Message:  "The apply role grants operation has been failed. Find active role grants has been failed",
+		Code:     "ROLE_GRANT_USECASE_APPLY_ROLE_GRANTS_FIND_ACTIVE_ROLE_GRANTS_BY_USER_ID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRevokeExpiredRoleGrantsExpireRoleGrants = AppError{
+		Message:  "The revoke expired role grants operation has been failed",
+		Code:     "ROLE_GRANT_USECASE_REVOKE_EXPIRED_ROLE_GRANTS_EXPIRE_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRevokeExpiredRoleGrantsPublish = AppError{
+		Message:  "The revoke expired role grants operation has been failed. Publish event has been failed",
+		Code:     "ROLE_GRANT_USECASE_REVOKE_EXPIRED_ROLE_GRANTS_PUBLISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseFindRoleGrantNotExist = AppError{
+		Message:  "The find role grant operation has been failed. Role grant doesn't exist",
+		Code:     "ROLE_GRANT_USECASE_FIND_ROLE_GRANT_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RoleGrantUsecaseFindRoleGrantFindRoleGrantByID = AppError{
+		Message:  "The find role grant operation has been failed",
+		Code:     "ROLE_GRANT_USECASE_FIND_ROLE_GRANT_FIND_ROLE_GRANT_BY_ID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseReviewRoleGrantHasPermissions = AppError{
+		Message:  "The review role grant operation has been failed. Auth user doesn't have permission",
+		Code:     "ROLE_GRANT_USECASE_REVIEW_ROLE_GRANT_HAS_PERMISSIONS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	RoleGrantUsecaseReviewRoleGrantNotPending = AppError{
+		Message:  "The review role grant operation has been failed. Role grant has been already reviewed",
+		Code:     "ROLE_GRANT_USECASE_REVIEW_ROLE_GRANT_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserUsecaseDeleteUserDropUserCache = AppError{
+		Message:  "The delete user operation has been failed. Drop user cache has been failed",
+		Code:     "USER_USECASE_DELETE_USER_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseHardDeleteUserFindUser = AppError{
+		Message:  "The hard delete user operation has been failed. Find user has been failed",
+		Code:     "USER_USECASE_HARD_DELETE_USER_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseHardDeleteUser = AppError{
+		Message:  "The hard delete user operation has been failed",
+		Code:     "USER_USECASE_HARD_DELETE_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseHardDeleteUserDropUserCache = AppError{
+		Message:  "The hard delete user operation has been failed. Drop user cache has been failed",
+		Code:     "USER_USECASE_HARD_DELETE_USER_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseRestoreUserNotExist = AppError{
+		Message:  "The restore user operation has been failed. Deleted user doesn't exist",
+		Code:     "USER_USECASE_RESTORE_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserUsecaseRestoreUserFindDeletedUserByUUID = AppError{
+		Message:  "The restore user operation has been failed. Find deleted user has been failed",
+		Code:     "USER_USECASE_RESTORE_USER_FIND_DELETED_USER_BY_UUID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseRestoreUserNicknameBusy = AppError{
+		Message:  "The restore user operation has been failed. Nickname is already taken",
+		Code:     "USER_USECASE_RESTORE_USER_NICKNAME_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserUsecaseRestoreUserFindUserByNickname = AppError{
+		Message:  "The restore user operation has been failed. Find user by nickname has been failed",
+		Code:     "USER_USECASE_RESTORE_USER_FIND_USER_BY_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseRestoreUser = AppError{
+		Message:  "The restore user operation has been failed",
+		Code:     "USER_USECASE_RESTORE_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseRestoreUserDropUserCache = AppError{
+		Message:  "The restore user operation has been failed, drop user cache error",
+		Code:     "USER_USECASE_RESTORE_USER_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserRetentionUsecasePurgeDeletedUsers = AppError{
+		Message:  "The purge deleted users operation has been failed",
+		Code:     "USER_RETENTION_USECASE_PURGE_DELETED_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseHardDeleteUserNotExist = AppError{
+		Message:  "The hard delete user operation has been failed. User doesn't exist",
+		Code:     "USER_USECASE_HARD_DELETE_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserUsecaseSearchUsersQueryTooShort = AppError{
+		Message:  "The search users operation has been failed. Search query must be at least 2 characters",
+		Code:     "USER_USECASE_SEARCH_USERS_QUERY_TOO_SHORT",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserUsecaseSearchUsers = AppError{
+		Message:  "The search users operation has been failed",
+		Code:     "USER_USECASE_SEARCH_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseSearchUsersLoadVotesToUsers = AppError{
+		Message:  "The search users operation has been failed. Load votes has been failed",
+		Code:     "USER_USECASE_SEARCH_USERS_LOAD_VOTES_TO_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseGetUsersInvalidQuery = AppError{
+		Message:  "The get users operation has been failed. Invalid query",
+		Code:     "USER_USECASE_GET_USERS_INVALID_QUERY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserUsecasePatchUserNotExist = AppError{
+		Message:  "The patch user operation has been failed. User doesn't exist",
+		Code:     "USER_USECASE_PATCH_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserUsecasePatchUserFindUserByUUID = AppError{
+		Message:  "The patch user operation has been failed. Find user has been failed",
+		Code:     "USER_USECASE_PATCH_USER_FIND_USER_BY_UUID",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecasePatchUserHashPassword = AppError{
+		Message:  "The patch user operation has been failed. Hash password has been failed",
+		Code:     "USER_USECASE_PATCH_USER_HASH_PASSWORD",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecasePatchUser = AppError{
+		Message:  "The patch user operation has been failed",
+		Code:     "USER_USECASE_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecasePatchUserDropUserCache = AppError{
+		Message:  "The patch user operation has been failed. Drop user cache has been failed",
+		Code:     "USER_USECASE_PATCH_USER_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecasePatchUserNicknameBusy = AppError{
+		Message:  "The patch user operation has been failed. Nickname is already taken",
+		Code:     "USER_USECASE_PATCH_USER_NICKNAME_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserUsecasePatchUserCheckUserByNickname = AppError{
+		Message:  "The patch user operation has been failed. Check nickname has been failed",
+		Code:     "USER_USECASE_PATCH_USER_CHECK_USER_BY_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseUpdateUserVersionMismatch = AppError{
+		Message:  "The update user operation has been failed. The user has been changed by another request",
+		Code:     "USER_USECASE_UPDATE_USER_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserUsecaseUpdateUserNotExist = AppError{
+		Message:  "The update user operation has been failed. User doesn't exist",
+		Code:     "USER_USECASE_UPDATE_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserUsecaseUpdateUserDropUserCache = AppError{
+		Message:  "The update user operation has been failed. Drop user cache has been failed",
+		Code:     "USER_USECASE_UPDATE_USER_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecasePatchUserVersionMismatch = AppError{
+		Message:  "The patch user operation has been failed. The user has been changed by another request",
+		Code:     "USER_USECASE_PATCH_USER_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserUsecaseDeleteUserVersionMismatch = AppError{
+		Message:  "The delete user operation has been failed. The user has been changed by another request",
+		Code:     "USER_USECASE_DELETE_USER_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserUsecaseHardDeleteUserVersionMismatch = AppError{
+		Message:  "The hard delete user operation has been failed. The user has been changed by another request",
+		Code:     "USER_USECASE_HARD_DELETE_USER_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserImportUsecaseCreateImportJobParse = AppError{
+		Message:  "The create import job operation has been failed. The file can't be read",
+		Code:     "USER_IMPORT_USECASE_CREATE_IMPORT_JOB_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserImportUsecaseCreateImportJobEnqueueJob = AppError{
+		Message:  "The create import job operation has been failed. Enqueue job has been failed",
+		Code:     "USER_IMPORT_USECASE_CREATE_IMPORT_JOB_ENQUEUE_JOB",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseGetImportJobNotExist = AppError{
+		Message:  "The get import job operation has been failed. Job doesn't exist",
+		Code:     "USER_IMPORT_USECASE_GET_IMPORT_JOB_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserImportUsecaseGetImportJobFindJob = AppError{
+		Message:  "The get import job operation has been failed. Find job has been failed",
+		Code:     "USER_IMPORT_USECASE_GET_IMPORT_JOB_FIND_JOB",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRunNextImportJobDequeueJob = AppError{
+		Message:  "The run import job operation has been failed. Dequeue job has been failed",
+		Code:     "USER_IMPORT_USECASE_RUN_NEXT_IMPORT_JOB_DEQUEUE_JOB",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRunImportJobParse = AppError{
+		Message:  "The run import job operation has been failed. The file can't be read",
+		Code:     "USER_IMPORT_USECASE_RUN_IMPORT_JOB_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserImportUsecaseRunImportJobSaveJob = AppError{
+		Message:  "The run import job operation has been failed. Save job has been failed",
+		Code:     "USER_IMPORT_USECASE_RUN_IMPORT_JOB_SAVE_JOB",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowParse = AppError{
+		Message:  "The row can't be parsed",
+		Code:     "USER_IMPORT_USECASE_ROW_PARSE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserImportUsecaseRowNicknameRepeated = AppError{
+		Message:  "The nickname has been used by an earlier row of the file",
+		Code:     "USER_IMPORT_USECASE_ROW_NICKNAME_REPEATED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserImportUsecaseRowFindUserByNickname = AppError{
+		Message:  "Find user by nickname has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_FIND_USER_BY_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowNicknameBusy = AppError{
+		Message:  "The nickname is busy",
+		Code:     "USER_IMPORT_USECASE_ROW_NICKNAME_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserImportUsecaseRowPasswordRequired = AppError{
+		Message:  "The password is required unless passwords are generated",
+		Code:     "USER_IMPORT_USECASE_ROW_PASSWORD_REQUIRED",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserImportUsecaseRowGeneratePassword = AppError{
+		Message:  "Generate password has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_GENERATE_PASSWORD",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowValidate = AppError{
+		Message:  "The row is invalid",
+		Code:     "USER_IMPORT_USECASE_ROW_VALIDATE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserImportUsecaseRowHashPassword = AppError{
+		Message:  "Hash password has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_HASH_PASSWORD",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowSaveUser = AppError{
+		Message:  "Save user has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_SAVE_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowUpdateUser = AppError{
+		Message:  "Update user has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_UPDATE_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowDropUserCache = AppError{
+		Message:  "Drop user cache has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserExportUsecaseExportUsersStreamUsers = AppError{
+		Message:  "could not export users",
+		Code:     "USER_EXPORT_USECASE_EXPORT_USERS_STREAM_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserExportUsecaseExportUsersWrite = AppError{
+		Message:  "could not write exported user",
+		Code:     "USER_EXPORT_USECASE_EXPORT_USERS_WRITE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserExportUsecaseExportUsersClose = AppError{
+		Message:  "could not finish export",
+		Code:     "USER_EXPORT_USECASE_EXPORT_USERS_CLOSE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseValidateAttributesFindSchema = AppError{
+		Message:  "could not get attributes schema",
+		Code:     "USER_USECASE_VALIDATE_ATTRIBUTES_FIND_SCHEMA",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseValidateAttributes = AppError{
+		Message:  "attributes don't match the schema",
+		Code:     "USER_USECASE_VALIDATE_ATTRIBUTES",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserAttributeSchemaUsecaseGetSchemaFindSchema = AppError{
+		Message:  "could not get attributes schema",
+		Code:     "USER_ATTRIBUTE_SCHEMA_USECASE_GET_SCHEMA_FIND_SCHEMA",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAttributeSchemaUsecaseSetSchemaCompile = AppError{
+		Message:  "wrong attributes schema",
+		Code:     "USER_ATTRIBUTE_SCHEMA_USECASE_SET_SCHEMA_COMPILE",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserAttributeSchemaUsecaseSetSchemaSaveSchema = AppError{
+		Message:  "could not save attributes schema",
+		Code:     "USER_ATTRIBUTE_SCHEMA_USECASE_SET_SCHEMA_SAVE_SCHEMA",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseSetAvatarFindUser = AppError{
+		Message:  "The set avatar operation has been failed. Could not load the user",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseSetAvatarNotExist = AppError{
+		Message:  "The set avatar operation has been failed. User doesn't exist",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserAvatarUsecaseSetAvatarDecode = AppError{
+		Message:  "The set avatar operation has been failed. The avatar must be a PNG, JPEG or WebP image",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_DECODE",
+		HTTPCode: http.StatusUnsupportedMediaType,
+	}
+
+	UserAvatarUsecaseSetAvatarTooLarge = AppError{
+		Message:  "The set avatar operation has been failed. The image is too large",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_TOO_LARGE",
+		HTTPCode: http.StatusRequestEntityTooLarge,
+	}
+
+	UserAvatarUsecaseSetAvatarEncode = AppError{
+		Message:  "The set avatar operation has been failed. Could not encode the image",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_ENCODE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseSetAvatarPut = AppError{
+		Message:  "The set avatar operation has been failed. Could not store the image",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_PUT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseSetAvatarVersionMismatch = AppError{
+		Message:  "The set avatar operation has been failed. The user has been changed by another request",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserAvatarUsecaseSetAvatarPatchUser = AppError{
+		Message:  "The set avatar operation has been failed. Could not save the avatar",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseSetAvatarDropUserCache = AppError{
+		Message:  "The set avatar operation has been failed. Drop user cache has been failed",
+		Code:     "USER_AVATAR_USECASE_SET_AVATAR_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseDeleteAvatarFindUser = AppError{
+		Message:  "The delete avatar operation has been failed. Could not load the user",
+		Code:     "USER_AVATAR_USECASE_DELETE_AVATAR_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseDeleteAvatarNotExist = AppError{
+		Message:  "The delete avatar operation has been failed. User doesn't exist",
+		Code:     "USER_AVATAR_USECASE_DELETE_AVATAR_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserAvatarUsecaseDeleteAvatarVersionMismatch = AppError{
+		Message:  "The delete avatar operation has been failed. The user has been changed by another request",
+		Code:     "USER_AVATAR_USECASE_DELETE_AVATAR_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserAvatarUsecaseDeleteAvatarPatchUser = AppError{
+		Message:  "The delete avatar operation has been failed. Could not save the user",
+		Code:     "USER_AVATAR_USECASE_DELETE_AVATAR_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseDeleteAvatarDropUserCache = AppError{
+		Message:  "The delete avatar operation has been failed. Drop user cache has been failed",
+		Code:     "USER_AVATAR_USECASE_DELETE_AVATAR_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseGetAvatarFileNotExist = AppError{
+		Message:  "Avatar file doesn't exist",
+		Code:     "USER_AVATAR_USECASE_GET_AVATAR_FILE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserAvatarUsecaseGetAvatarFileGet = AppError{
+		Message:  "Could not read the avatar file",
+		Code:     "USER_AVATAR_USECASE_GET_AVATAR_FILE_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseUpdateUserFindUser = AppError{
+		Message:  "The update user operation has been failed. Find user has been failed",
+		Code:     "USER_USECASE_UPDATE_USER_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseNicknameReserved = AppError{
+		Message:  "The nickname is reserved",
+		Code:     "USER_USECASE_NICKNAME_RESERVED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserUsecaseFindReservedNickname = AppError{
+		Message:  "Find reserved nickname has been failed",
+		Code:     "USER_USECASE_FIND_RESERVED_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseNicknameCooldown = AppError{
+		Message:  "The nickname has been changed recently. It can be changed again after",
+		Code:     "USER_USECASE_NICKNAME_COOLDOWN",
+		HTTPCode: http.StatusTooManyRequests,
+	}
+
+	UserUsecaseFindLastNicknameChange = AppError{
+		Message:  "Find the last nickname change has been failed",
+		Code:     "USER_USECASE_FIND_LAST_NICKNAME_CHANGE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseSaveNicknameChange = AppError{
+		Message:  "Save the nickname change has been failed",
+		Code:     "USER_USECASE_SAVE_NICKNAME_CHANGE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameUsecaseGetReservedNicknames = AppError{
+		Message:  "Get reserved nicknames has been failed",
+		Code:     "NICKNAME_USECASE_GET_RESERVED_NICKNAMES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameUsecaseReserveNicknameEmpty = AppError{
+		Message:  "The nickname to reserve is empty",
+		Code:     "NICKNAME_USECASE_RESERVE_NICKNAME_EMPTY",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	NicknameUsecaseReserveNickname = AppError{
+		Message:  "Reserve nickname has been failed",
+		Code:     "NICKNAME_USECASE_RESERVE_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameUsecaseReleaseNicknameNotExist = AppError{
+		Message:  "The nickname isn't reserved",
+		Code:     "NICKNAME_USECASE_RELEASE_NICKNAME_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	NicknameUsecaseReleaseNickname = AppError{
+		Message:  "Release nickname has been failed",
+		Code:     "NICKNAME_USECASE_RELEASE_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameUsecaseResolveNicknameNotExist = AppError{
+		Message:  "No user has or had this nickname",
+		Code:     "NICKNAME_USECASE_RESOLVE_NICKNAME_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	NicknameUsecaseResolveNicknameFindUser = AppError{
+		Message:  "Resolve nickname has been failed. Find user has been failed",
+		Code:     "NICKNAME_USECASE_RESOLVE_NICKNAME_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameUsecaseResolveNicknameFindNicknameChange = AppError{
+		Message:  "Resolve nickname has been failed. Find nickname change has been failed",
+		Code:     "NICKNAME_USECASE_RESOLVE_NICKNAME_FIND_NICKNAME_CHANGE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	NicknameUsecaseGetNicknameHistory = AppError{
+		Message:  "Get nickname history has been failed",
+		Code:     "NICKNAME_USECASE_GET_NICKNAME_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowNicknameReserved = AppError{
+		Message:  "The nickname is reserved",
+		Code:     "USER_IMPORT_USECASE_ROW_NICKNAME_RESERVED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserImportUsecaseRowFindReservedNickname = AppError{
+		Message:  "Find reserved nickname has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_FIND_RESERVED_NICKNAME",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseRequestEmailChangeNotExist = AppError{
+		Message:  "The user doesn't exist",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	EmailUsecaseRequestEmailChangeFindUser = AppError{
+		Message:  "Find user has been failed",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseRequestEmailChangeSameEmail = AppError{
+		Message:  "The user already has this email",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_SAME_EMAIL",
+		HTTPCode: http.StatusConflict,
+	}
+
+	EmailUsecaseRequestEmailChangeGenerateToken = AppError{
+		Message:  "Generate email change token has been failed",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_GENERATE_TOKEN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseRequestEmailChangeCancelPending = AppError{
+		Message:  "Cancel pending email changes has been failed",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_CANCEL_PENDING",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseRequestEmailChangeSave = AppError{
+		Message:  "Save email change has been failed",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseRequestEmailChangeSend = AppError{
+		Message:  "Send email change message has been failed",
+		Code:     "EMAIL_USECASE_REQUEST_EMAIL_CHANGE_SEND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseConfirmEmailChangeNotExist = AppError{
+		Message:  "The email change doesn't exist",
+		Code:     "EMAIL_USECASE_CONFIRM_EMAIL_CHANGE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	EmailUsecaseConfirmEmailChangeFind = AppError{
+		Message:  "Find email change has been failed",
+		Code:     "EMAIL_USECASE_CONFIRM_EMAIL_CHANGE_FIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseConfirmEmailChangeNotPending = AppError{
+		Message:  "The email change has already been confirmed, cancelled or has expired",
+		Code:     "EMAIL_USECASE_CONFIRM_EMAIL_CHANGE_NOT_PENDING",
+		HTTPCode: http.StatusGone,
+	}
+
+	EmailUsecaseConfirmEmailChange = AppError{
+		Message:  "Confirm email change has been failed",
+		Code:     "EMAIL_USECASE_CONFIRM_EMAIL_CHANGE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseConfirmEmailChangeDropUserCache = AppError{
+		Message:  "Drop user cache has been failed",
+		Code:     "EMAIL_USECASE_CONFIRM_EMAIL_CHANGE_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseCancelEmailChangeNotExist = AppError{
+		Message:  "The email change doesn't exist",
+		Code:     "EMAIL_USECASE_CANCEL_EMAIL_CHANGE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	EmailUsecaseCancelEmailChangeFind = AppError{
+		Message:  "Find email change has been failed",
+		Code:     "EMAIL_USECASE_CANCEL_EMAIL_CHANGE_FIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseCancelEmailChangeNotPending = AppError{
+		Message:  "The email change has already been confirmed or cancelled",
+		Code:     "EMAIL_USECASE_CANCEL_EMAIL_CHANGE_NOT_PENDING",
+		HTTPCode: http.StatusGone,
+	}
+
+	EmailUsecaseCancelEmailChange = AppError{
+		Message:  "Cancel email change has been failed",
+		Code:     "EMAIL_USECASE_CANCEL_EMAIL_CHANGE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseGetUserByEmail = AppError{
+		Message:  "Find user by email has been failed",
+		Code:     "EMAIL_USECASE_GET_USER_BY_EMAIL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseEmailBusy = AppError{
+		Message:  "The email is already taken",
+		Code:     "EMAIL_USECASE_EMAIL_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	EmailUsecaseFindUserByEmail = AppError{
+		Message:  "Find user by email has been failed",
+		Code:     "EMAIL_USECASE_FIND_USER_BY_EMAIL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseEmailBusy = AppError{
+		Message:  "The email is already taken",
+		Code:     "USER_USECASE_EMAIL_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserUsecaseFindUserByEmail = AppError{
+		Message:  "Find user by email has been failed",
+		Code:     "USER_USECASE_FIND_USER_BY_EMAIL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseEmailChangeNotConfirmed = AppError{
+		Message:  "The email can't be changed directly. Request an email change and confirm it from the new address",
+		Code:     "USER_USECASE_EMAIL_CHANGE_NOT_CONFIRMED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserUsecaseRestoreUserEmailBusy = AppError{
+		Message:  "The restore user operation has been failed. Email is already taken",
+		Code:     "USER_USECASE_RESTORE_USER_EMAIL_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserImportUsecaseRowEmailRepeated = AppError{
+		Message:  "The email has been used by an earlier row of the file",
+		Code:     "USER_IMPORT_USECASE_ROW_EMAIL_REPEATED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserImportUsecaseRowEmailBusy = AppError{
+		Message:  "The email is busy",
+		Code:     "USER_IMPORT_USECASE_ROW_EMAIL_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserImportUsecaseRowFindUserByEmail = AppError{
+		Message:  "Find user by email has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_FIND_USER_BY_EMAIL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "USER_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	EmailUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "EMAIL_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditUsecaseGetUserHistoryFindAuditEntries = AppError{
+		Message:  "Get the user history has been failed",
+		Code:     "AUDIT_USECASE_GET_USER_HISTORY_FIND_AUDIT_ENTRIES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditUsecaseSearchAuditLogFindAuditEntries = AppError{
+		Message:  "Search the audit log has been failed",
+		Code:     "AUDIT_USECASE_SEARCH_AUDIT_LOG_FIND_AUDIT_ENTRIES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditUsecaseExportAuditLogStreamAuditEntries = AppError{
+		Message:  "Export the audit log has been failed",
+		Code:     "AUDIT_USECASE_EXPORT_AUDIT_LOG_STREAM_AUDIT_ENTRIES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditUsecaseExportAuditLogWrite = AppError{
+		Message:  "Write the audit log export has been failed",
+		Code:     "AUDIT_USECASE_EXPORT_AUDIT_LOG_WRITE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	AuditUsecaseExportAuditLogFlush = AppError{
+		Message:  "Flush the audit log export has been failed",
+		Code:     "AUDIT_USECASE_EXPORT_AUDIT_LOG_FLUSH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRequestDataExportFormat = AppError{
+		Message:  "The data export format is not supported",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_DATA_EXPORT_FORMAT",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	DataRequestUsecaseRequestDataExportNotExist = AppError{
+		Message:  "The user does not exist",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_DATA_EXPORT_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	DataRequestUsecaseRequestDataExportFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_DATA_EXPORT_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRequestDataExportSave = AppError{
+		Message:  "Request the data export has been failed",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_DATA_EXPORT_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRequestErasureNotExist = AppError{
+		Message:  "The user does not exist",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_ERASURE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	DataRequestUsecaseRequestErasureFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_ERASURE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRequestErasureSave = AppError{
+		Message:  "Request the erasure has been failed",
+		Code:     "DATA_REQUEST_USECASE_REQUEST_ERASURE_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseGetDataRequestNotExist = AppError{
+		Message:  "The data request does not exist",
+		Code:     "DATA_REQUEST_USECASE_GET_DATA_REQUEST_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	DataRequestUsecaseGetDataRequestFind = AppError{
+		Message:  "Get the data request has been failed",
+		Code:     "DATA_REQUEST_USECASE_GET_DATA_REQUEST_FIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseGetDataRequests = AppError{
+		Message:  "Get the data requests has been failed",
+		Code:     "DATA_REQUEST_USECASE_GET_DATA_REQUESTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseGetDataExportArchiveUnavailable = AppError{
+		Message:  "The data export archive is not available",
+		Code:     "DATA_REQUEST_USECASE_GET_DATA_EXPORT_ARCHIVE_UNAVAILABLE",
+		HTTPCode: http.StatusGone,
+	}
+
+	DataRequestUsecaseGetDataExportArchiveGet = AppError{
+		Message:  "Get the data export archive has been failed",
+		Code:     "DATA_REQUEST_USECASE_GET_DATA_EXPORT_ARCHIVE_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunNextDataRequestClaim = AppError{
+		Message:  "Claim the next data request has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_NEXT_DATA_REQUEST_CLAIM",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunNextDataRequestKind = AppError{
+		Message:  "The data request kind is unknown",
+		Code:     "DATA_REQUEST_USECASE_RUN_NEXT_DATA_REQUEST_KIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunNextDataRequestFinish = AppError{
+		Message:  "Finish the data request has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_NEXT_DATA_REQUEST_FINISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseDeleteExpiredDataExportsGet = AppError{
+		Message:  "Get the expired data exports has been failed",
+		Code:     "DATA_REQUEST_USECASE_DELETE_EXPIRED_DATA_EXPORTS_GET",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseDeleteExpiredDataExportsDelete = AppError{
+		Message:  "Delete the expired data export has been failed",
+		Code:     "DATA_REQUEST_USECASE_DELETE_EXPIRED_DATA_EXPORTS_DELETE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunDataExportWrite = AppError{
+		Message:  "Write the data export archive has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_DATA_EXPORT_WRITE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunDataExportPut = AppError{
+		Message:  "Store the data export archive has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_DATA_EXPORT_PUT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportVotes = AppError{
+		Message:  "Get the votes of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_VOTES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportNicknameHistory = AppError{
+		Message:  "Get the nickname history of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_NICKNAME_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportEmailChanges = AppError{
+		Message:  "Get the email changes of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_EMAIL_CHANGES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportAuditEntries = AppError{
+		Message:  "Get the audit entries of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_AUDIT_ENTRIES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunErasureFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_ERASURE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunErasureEraseUser = AppError{
+		Message:  "Erase the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_ERASURE_ERASE_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunErasureGetDataExports = AppError{
+		Message:  "Get the data exports of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_ERASURE_GET_DATA_EXPORTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunErasureDeleteDataExport = AppError{
+		Message:  "Delete the data export archive has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_ERASURE_DELETE_DATA_EXPORT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRunErasureDropUserCache = AppError{
+		Message:  "Drop the user cache has been failed",
+		Code:     "DATA_REQUEST_USECASE_RUN_ERASURE_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "DATA_REQUEST_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserStatusUsecaseSuspendUserUntilPassed = AppError{
+		Message:  "The suspension must end in the future",
+		Code:     "USER_STATUS_USECASE_SUSPEND_USER_UNTIL_PASSED",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserStatusUsecaseSuspendUserOutranked = AppError{
+		Message:  "Auth user can only change the status of users with a lower role",
+		Code:     "USER_STATUS_USECASE_SUSPEND_USER_OUTRANKED",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserStatusUsecaseUnsuspendUserNotSuspended = AppError{
+		Message:  "The user is not suspended",
+		Code:     "USER_STATUS_USECASE_UNSUSPEND_USER_NOT_SUSPENDED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserStatusUsecaseLiftExpiredSuspensionsGetUsers = AppError{
+		Message:  "Get the users with an expired suspension has been failed",
+		Code:     "USER_STATUS_USECASE_LIFT_EXPIRED_SUSPENSIONS_GET_USERS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserStatusUsecaseFindUserNotExist = AppError{
+		Message:  "The user does not exist",
+		Code:     "USER_STATUS_USECASE_FIND_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserStatusUsecaseFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "USER_STATUS_USECASE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserStatusUsecaseSetStatusVersionMismatch = AppError{
+		Message:  "The user has been changed in the meantime",
+		Code:     "USER_STATUS_USECASE_SET_STATUS_VERSION_MISMATCH",
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+
+	UserStatusUsecaseSetStatusPatchUser = AppError{
+		Message:  "Set the user status has been failed",
+		Code:     "USER_STATUS_USECASE_SET_STATUS_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserStatusUsecaseSetStatusDropUserCache = AppError{
+		Message:  "Drop the user cache has been failed",
+		Code:     "USER_STATUS_USECASE_SET_STATUS_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserStatusUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "USER_STATUS_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserUsecaseVoteVoterInactive = AppError{
+		Message:  "Suspended and banned users can't vote",
+		Code:     "USER_USECASE_VOTE_VOTER_INACTIVE",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserUsecaseVoteUserInactive = AppError{
+		Message:  "Suspended and banned users can't be voted on",
+		Code:     "USER_USECASE_VOTE_USER_INACTIVE",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserUsecaseVoteGetUser = AppError{
+		Message:  "Get the voting users has been failed",
+		Code:     "USER_USECASE_VOTE_GET_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "USER_HIERARCHY_USECASE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseFindUserNotExist = AppError{
+		Message:  "The user does not exist",
+		Code:     "USER_HIERARCHY_USECASE_FIND_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserHierarchyUsecaseGetUserAncestors = AppError{
+		Message:  "Get the ancestors of the user has been failed",
+		Code:     "USER_HIERARCHY_USECASE_GET_USER_ANCESTORS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseGetUserDescendants = AppError{
+		Message:  "Get the descendants of the user has been failed",
+		Code:     "USER_HIERARCHY_USECASE_GET_USER_DESCENDANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseTooDeep = AppError{
+		Message:  "The hierarchy would get deeper than allowed",
+		Code:     "USER_HIERARCHY_USECASE_TOO_DEEP",
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+
+	UserHierarchyUsecaseNotOwner = AppError{
+		Message:  "Auth user doesn't own this user",
+		Code:     "USER_HIERARCHY_USECASE_NOT_OWNER",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	UserHierarchyUsecaseSetUserParentCycle = AppError{
+		Message:  "A user can't be moved under itself or one of its descendants",
+		Code:     "USER_HIERARCHY_USECASE_SET_USER_PARENT_CYCLE",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserHierarchyUsecaseSetUserParentPatchUser = AppError{
+		Message:  "Set the parent of the user has been failed",
+		Code:     "USER_HIERARCHY_USECASE_SET_USER_PARENT_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseSetUserParentDropUserCache = AppError{
+		Message:  "Drop the cached user has been failed",
+		Code:     "USER_HIERARCHY_USECASE_SET_USER_PARENT_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseRecordAudit = AppError{
+		Message:  "Record the parent change has been failed",
+		Code:     "USER_HIERARCHY_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserHierarchyUsecaseDeleteUserHasChildren = AppError{
+		Message:  "The user owns child accounts, transfer or delete them first",
+		Code:     "USER_HIERARCHY_USECASE_DELETE_USER_HAS_CHILDREN",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserHierarchyUsecaseDeleteUserDescendant = AppError{
+		Message:  "Delete a child account has been failed",
+		Code:     "USER_HIERARCHY_USECASE_DELETE_USER_DESCENDANT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserStatusUsecaseSuspendUserHasChildren = AppError{
+		Message:  "The user owns active child accounts, transfer or suspend them first",
+		Code:     "USER_STATUS_USECASE_SUSPEND_USER_HAS_CHILDREN",
+		HTTPCode: http.StatusConflict,
+	}
+
+	UserStatusUsecaseSuspendUserGetDescendants = AppError{
+		Message:  "Get the child accounts of the user has been failed",
+		Code:     "USER_STATUS_USECASE_SUSPEND_USER_GET_DESCENDANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventUsecaseRecordLoginGetHistory = AppError{
+		Message:  "Get the login history of the user has been failed",
+		Code:     "LOGIN_EVENT_USECASE_RECORD_LOGIN_GET_HISTORY",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventUsecaseRecordLoginSave = AppError{
+		Message:  "Record the login has been failed",
+		Code:     "LOGIN_EVENT_USECASE_RECORD_LOGIN_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventUsecaseRecordLoginUpdateLoginDate = AppError{
+		Message:  "Update the login date has been failed",
+		Code:     "LOGIN_EVENT_USECASE_RECORD_LOGIN_UPDATE_LOGIN_DATE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventUsecaseRecordLoginDropUserCache = AppError{
+		Message:  "Drop the cached user has been failed",
+		Code:     "LOGIN_EVENT_USECASE_RECORD_LOGIN_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventUsecaseRecordLoginPublish = AppError{
+		Message:  "Publish the new client event has been failed",
+		Code:     "LOGIN_EVENT_USECASE_RECORD_LOGIN_PUBLISH",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	LoginEventUsecaseGetUserLogins = AppError{
+		Message:  "Get the logins of the user has been failed",
+		Code:     "LOGIN_EVENT_USECASE_GET_USER_LOGINS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportSessions = AppError{
+		Message:  "Get the logins of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_SESSIONS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "USER_SETTING_USECASE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseFindUserNotExist = AppError{
+		Message:  "The user does not exist",
+		Code:     "USER_SETTING_USECASE_FIND_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	UserSettingUsecaseGetUserSettings = AppError{
+		Message:  "Get the user settings has been failed",
+		Code:     "USER_SETTING_USECASE_GET_USER_SETTINGS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseGetUserSettingsCache = AppError{
+		Message:  "Get the cached user settings has been failed",
+		Code:     "USER_SETTING_USECASE_GET_USER_SETTINGS_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseSetUserSettingsCache = AppError{
+		Message:  "Cache the user settings has been failed",
+		Code:     "USER_SETTING_USECASE_SET_USER_SETTINGS_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseUpdateUserSettingsInvalid = AppError{
+		Message:  "The settings are not valid",
+		Code:     "USER_SETTING_USECASE_UPDATE_USER_SETTINGS_INVALID",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserSettingUsecaseUpdateUserSettingsSave = AppError{
+		Message:  "Save the user settings has been failed",
+		Code:     "USER_SETTING_USECASE_UPDATE_USER_SETTINGS_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseUpdateUserSettingsDropCache = AppError{
+		Message:  "Drop the cached user settings has been failed",
+		Code:     "USER_SETTING_USECASE_UPDATE_USER_SETTINGS_DROP_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserSettingUsecaseRecordAudit = AppError{
+		Message:  "Record the settings change has been failed",
+		Code:     "USER_SETTING_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportSettings = AppError{
+		Message:  "Get the settings of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_SETTINGS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "FOLLOW_USECASE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseFindUserNotExist = AppError{
+		Message:  "The user does not exist",
+		Code:     "FOLLOW_USECASE_FIND_USER_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	FollowUsecaseFindFollow = AppError{
+		Message:  "Find the follow has been failed",
+		Code:     "FOLLOW_USECASE_FIND_FOLLOW",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseFindFollows = AppError{
+		Message:  "List the follows has been failed",
+		Code:     "FOLLOW_USECASE_FIND_FOLLOWS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseFollowUserSelf = AppError{
+		Message:  "A user can't follow itself",
+		Code:     "FOLLOW_USECASE_FOLLOW_USER_SELF",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	FollowUsecaseFollowUserGetSettings = AppError{
+		Message:  "Get the settings of the followed user has been failed",
+		Code:     "FOLLOW_USECASE_FOLLOW_USER_GET_SETTINGS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseFollowUserSave = AppError{
+		Message:  "Save the follow has been failed",
+		Code:     "FOLLOW_USECASE_FOLLOW_USER_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseUnfollowUserDelete = AppError{
+		Message:  "Delete the follow has been failed",
+		Code:     "FOLLOW_USECASE_UNFOLLOW_USER_DELETE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseUnfollowUserNotFollowing = AppError{
+		Message:  "The user is not followed",
+		Code:     "FOLLOW_USECASE_UNFOLLOW_USER_NOT_FOLLOWING",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	FollowUsecaseGetFollowsForbidden = AppError{
+		Message:  "The follows of a private profile are only shown to its followers",
+		Code:     "FOLLOW_USECASE_GET_FOLLOWS_FORBIDDEN",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	FollowUsecaseFollowRequestNotFound = AppError{
+		Message:  "There is no such follow request",
+		Code:     "FOLLOW_USECASE_FOLLOW_REQUEST_NOT_FOUND",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	FollowUsecaseApproveFollowRequest = AppError{
+		Message:  "Approve the follow request has been failed",
+		Code:     "FOLLOW_USECASE_APPROVE_FOLLOW_REQUEST",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseRejectFollowRequest = AppError{
+		Message:  "Reject the follow request has been failed",
+		Code:     "FOLLOW_USECASE_REJECT_FOLLOW_REQUEST",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	FollowUsecaseGetFollowCounts = AppError{
+		Message:  "Count the follows has been failed",
+		Code:     "FOLLOW_USECASE_GET_FOLLOW_COUNTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	DataRequestUsecaseCollectDataExportFollowing = AppError{
+		Message:  "Get the follows of the user has been failed",
+		Code:     "DATA_REQUEST_USECASE_COLLECT_DATA_EXPORT_FOLLOWING",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseCreateInviteRoleNotExist = AppError{
+		Message:  "The role doesn't exist",
+		Code:     "INVITE_USECASE_CREATE_INVITE_ROLE_NOT_EXIST",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	InviteUsecaseCreateInviteAssignRole = AppError{
+		Message:  "Inviting with a role other than the default one needs the right to assign roles",
+		Code:     "INVITE_USECASE_CREATE_INVITE_ASSIGN_ROLE",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	InviteUsecaseEmailBusy = AppError{
+		Message:  "The email is already taken",
+		Code:     "INVITE_USECASE_EMAIL_BUSY",
+		HTTPCode: http.StatusConflict,
+	}
+
+	InviteUsecaseFindUserByEmail = AppError{
+		Message:  "Find the user by email has been failed",
+		Code:     "INVITE_USECASE_FIND_USER_BY_EMAIL",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseCreateInviteGenerateToken = AppError{
+		Message:  "Generate the invite token has been failed",
+		Code:     "INVITE_USECASE_CREATE_INVITE_GENERATE_TOKEN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseCreateInviteRevokePending = AppError{
+		Message:  "Revoke the pending invites has been failed",
+		Code:     "INVITE_USECASE_CREATE_INVITE_REVOKE_PENDING",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseCreateInviteSave = AppError{
+		Message:  "Save the invite has been failed",
+		Code:     "INVITE_USECASE_CREATE_INVITE_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseCreateInviteSend = AppError{
+		Message:  "Send the invite has been failed",
+		Code:     "INVITE_USECASE_CREATE_INVITE_SEND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteNotExist = AppError{
+		Message:  "The invite doesn't exist",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	InviteUsecaseAcceptInviteFind = AppError{
+		Message:  "Find the invite has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_FIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteNotPending = AppError{
+		Message:  "The invite has already been accepted, revoked or has expired",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_NOT_PENDING",
+		HTTPCode: http.StatusGone,
+	}
+
+	InviteUsecaseAcceptInviteFindInviter = AppError{
+		Message:  "Find the inviter has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_FIND_INVITER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteApplyRoleGrants = AppError{
+		Message:  "Apply the role grants of the inviter has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_APPLY_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteInviterRights = AppError{
+		Message:  "The inviter may no longer give the role of the invite",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_INVITER_RIGHTS",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	InviteUsecaseAcceptInvite = AppError{
+		Message:  "Accept the invite has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteChangeUserRole = AppError{
+		Message:  "Give the user the role of the invite has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_CHANGE_USER_ROLE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteSaveRoleRequest = AppError{
+		Message:  "Request the role of the invite has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_SAVE_ROLE_REQUEST",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseAcceptInviteDropUserCache = AppError{
+		Message:  "Drop the user cache has been failed",
+		Code:     "INVITE_USECASE_ACCEPT_INVITE_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseGetInvites = AppError{
+		Message:  "Get the invites has been failed",
+		Code:     "INVITE_USECASE_GET_INVITES",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseRevokeInviteNotExist = AppError{
+		Message:  "The invite doesn't exist",
+		Code:     "INVITE_USECASE_REVOKE_INVITE_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	InviteUsecaseRevokeInviteFind = AppError{
+		Message:  "Find the invite has been failed",
+		Code:     "INVITE_USECASE_REVOKE_INVITE_FIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseRevokeInviteNotInviter = AppError{
+		Message:  "Only the inviter or an admin can revoke the invite",
+		Code:     "INVITE_USECASE_REVOKE_INVITE_NOT_INVITER",
+		HTTPCode: http.StatusForbidden,
+	}
+
+	InviteUsecaseRevokeInviteNotPending = AppError{
+		Message:  "The invite has already been accepted or revoked",
+		Code:     "INVITE_USECASE_REVOKE_INVITE_NOT_PENDING",
+		HTTPCode: http.StatusConflict,
+	}
+
+	InviteUsecaseRevokeInvite = AppError{
+		Message:  "Revoke the invite has been failed",
+		Code:     "INVITE_USECASE_REVOKE_INVITE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseDisabled = AppError{
+		Message:  "Self registration is disabled",
+		Code:     "REGISTRATION_USECASE_DISABLED",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RegistrationUsecaseCheckIPLimit = AppError{
+		Message:  "Count the requests of the IP has been failed",
+		Code:     "REGISTRATION_USECASE_CHECK_IP_LIMIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseIPLimitExceeded = AppError{
+		Message:  "Too many requests from the IP, try again later",
+		Code:     "REGISTRATION_USECASE_IP_LIMIT_EXCEEDED",
+		HTTPCode: http.StatusTooManyRequests,
+	}
+
+	RegistrationUsecaseCreateChallengeGenerateToken = AppError{
+		Message:  "Generate the challenge has been failed",
+		Code:     "REGISTRATION_USECASE_CREATE_CHALLENGE_GENERATE_TOKEN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseCreateChallengeSave = AppError{
+		Message:  "Save the challenge has been failed",
+		Code:     "REGISTRATION_USECASE_CREATE_CHALLENGE_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseChallengeNotSolved = AppError{
+		Message:  "The nonce doesn't solve the challenge",
+		Code:     "REGISTRATION_USECASE_CHALLENGE_NOT_SOLVED",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RegistrationUsecaseChallengeNotExist = AppError{
+		Message:  "The challenge doesn't exist, has expired or has already been used",
+		Code:     "REGISTRATION_USECASE_CHALLENGE_NOT_EXIST",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	RegistrationUsecaseTakeChallenge = AppError{
+		Message:  "Take the challenge has been failed",
+		Code:     "REGISTRATION_USECASE_TAKE_CHALLENGE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseEmailDomainBlocked = AppError{
+		Message:  "Emails of this domain can't be used to register",
+		Code:     "REGISTRATION_USECASE_EMAIL_DOMAIN_BLOCKED",
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+
+	RegistrationUsecaseSetStatusPatchUser = AppError{
+		Message:  "Set the status of the user has been failed",
+		Code:     "REGISTRATION_USECASE_SET_STATUS_PATCH_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseSetStatusDropUserCache = AppError{
+		Message:  "Drop the user cache has been failed",
+		Code:     "REGISTRATION_USECASE_SET_STATUS_DROP_USER_CACHE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseSendVerificationGenerateToken = AppError{
+		Message:  "Generate the verification token has been failed",
+		Code:     "REGISTRATION_USECASE_SEND_VERIFICATION_GENERATE_TOKEN",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseSendVerificationSave = AppError{
+		Message:  "Save the verification token has been failed",
+		Code:     "REGISTRATION_USECASE_SEND_VERIFICATION_SAVE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseSendVerificationSend = AppError{
+		Message:  "Send the verification email has been failed",
+		Code:     "REGISTRATION_USECASE_SEND_VERIFICATION_SEND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseVerifyRegistrationNotExist = AppError{
+		Message:  "The verification token doesn't exist or has expired",
+		Code:     "REGISTRATION_USECASE_VERIFY_REGISTRATION_NOT_EXIST",
+		HTTPCode: http.StatusNotFound,
+	}
+
+	RegistrationUsecaseVerifyRegistrationFind = AppError{
+		Message:  "Find the verification token has been failed",
+		Code:     "REGISTRATION_USECASE_VERIFY_REGISTRATION_FIND",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseVerifyRegistrationNotUnverified = AppError{
+		Message:  "The user has already been verified",
+		Code:     "REGISTRATION_USECASE_VERIFY_REGISTRATION_NOT_UNVERIFIED",
+		HTTPCode: http.StatusConflict,
+	}
+
+	RegistrationUsecaseVerifyRegistrationDelete = AppError{
+		Message:  "Delete the verification token has been failed",
+		Code:     "REGISTRATION_USECASE_VERIFY_REGISTRATION_DELETE",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseFindUser = AppError{
+		Message:  "Find the user has been failed",
+		Code:     "REGISTRATION_USECASE_FIND_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RegistrationUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "REGISTRATION_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	ActiveUserUsecaseVerifyActiveUserGetUser = AppError{
+		Message:  "The active user check has been failed",
+		Code:     "ACTIVE_USER_USECASE_VERIFY_ACTIVE_USER_GET_USER",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	ActiveUserUsecaseVerifyActiveUserNotExist = AppError{
+		Message:  "The authenticated user does not exist",
+		Code:     "ACTIVE_USER_USECASE_VERIFY_ACTIVE_USER_NOT_EXIST",
+		HTTPCode: http.StatusUnauthorized,
+	}
+
+	ActiveUserUsecaseVerifyActiveUserApplyRoleGrants = AppError{
+		Message:  "The active user check has been failed, apply role grants error",
+		Code:     "ACTIVE_USER_USECASE_VERIFY_ACTIVE_USER_APPLY_ROLE_GRANTS",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	ActiveUserUsecaseVerifyActiveUserRoleChanged = AppError{
+		Message:  "The role of the token is no longer the role of the user, log in again",
+		Code:     "ACTIVE_USER_USECASE_VERIFY_ACTIVE_USER_ROLE_CHANGED",
+		HTTPCode: http.StatusUnauthorized,
+	}
+
+	UserImportUsecaseRowFindSchema = AppError{
+		Message:  "Find the attribute schema for the row has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_FIND_SCHEMA",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserImportUsecaseRowAttributes = AppError{
+		Message:  "The attributes of the row don't match the schema",
+		Code:     "USER_IMPORT_USECASE_ROW_ATTRIBUTES",
+		HTTPCode: http.StatusBadRequest,
+	}
+
+	UserImportUsecaseRowRecordAudit = AppError{
+		Message:  "Record the audit entry of the row has been failed",
+		Code:     "USER_IMPORT_USECASE_ROW_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	UserAvatarUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "USER_AVATAR_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "ROLE_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	RoleGrantUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "ROLE_GRANT_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
+
+	InviteUsecaseRecordAudit = AppError{
+		Message:  "Record the audit entry has been failed",
+		Code:     "INVITE_USECASE_RECORD_AUDIT",
+		HTTPCode: http.StatusInternalServerError,
+	}
 )
@@ -1,6 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
 	"usermanager/internal/apperrors"
 
 	"github.com/caarlos0/env/v8"
@@ -8,9 +13,25 @@ import (
 )
 
 const (
-	postgresPrefix = "POSTGRES_"
-	redisPrefix    = "REDIS_"
-	jwtPrefix      = "JWT_"
+	postgresPrefix     = "POSTGRES_"
+	redisPrefix        = "REDIS_"
+	jwtPrefix          = "JWT_"
+	rolePrefix         = "ROLE_"
+	profilePrefix      = "PROFILE_"
+	retentionPrefix    = "RETENTION_"
+	paginationPrefix   = "PAGINATION_"
+	importPrefix       = "IMPORT_"
+	blobPrefix         = "BLOB_"
+	avatarPrefix       = "AVATAR_"
+	nicknamePrefix     = "NICKNAME_"
+	mailPrefix         = "MAIL_"
+	emailPrefix        = "EMAIL_"
+	dataRequestPrefix  = "DATA_REQUEST_"
+	suspensionPrefix   = "SUSPENSION_"
+	hierarchyPrefix    = "HIERARCHY_"
+	settingsPrefix     = "SETTINGS_"
+	invitePrefix       = "INVITE_"
+	registrationPrefix = "REGISTRATION_"
 )
 
 type Config struct {
@@ -19,9 +40,28 @@ type Config struct {
 	Port           string `env:"PORT,required"`
 	PortGrpc       string `env:"PORT_GRPC,required"`
 	PortGrpcClient string `env:"PORT_GRPC_CLIENT,required"`
+	// TrustedProxies are the IPs and CIDRs whose X-Forwarded-For is believed. Without any the
+	// client IP is the address of the connection.
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
 	Postgres       *PostgresConfig
 	Redis          *RedisConfig
 	Jwt            *JwtConfig
+	Role           *RoleConfig
+	Profile        *ProfileConfig
+	Retention      *RetentionConfig
+	Pagination     *PaginationConfig
+	Import         *ImportConfig
+	Blob           *BlobConfig
+	Avatar         *AvatarConfig
+	Nickname       *NicknameConfig
+	Mail           *MailConfig
+	Email          *EmailConfig
+	DataRequest    *DataRequestConfig
+	Suspension     *SuspensionConfig
+	Hierarchy      *HierarchyConfig
+	Settings       *SettingsConfig
+	Invite         *InviteConfig
+	Registration   *RegistrationConfig
 }
 
 type PostgresConfig struct {
@@ -48,6 +88,117 @@ type JwtConfig struct {
 	Ttl    int    `env:"TTL,required"`
 }
 
+type RoleConfig struct {
+	AdminApprovalRequired       bool     `env:"ADMIN_APPROVAL_REQUIRED" envDefault:"false"`
+	GrantMaxDuration            int      `env:"GRANT_MAX_DURATION" envDefault:"480"`
+	GrantAutoApproveRoles       []string `env:"GRANT_AUTO_APPROVE_ROLES" envSeparator:","`
+	GrantAutoApproveMaxDuration int      `env:"GRANT_AUTO_APPROVE_MAX_DURATION" envDefault:"60"`
+	GrantSweepInterval          int      `env:"GRANT_SWEEP_INTERVAL" envDefault:"60"`
+}
+
+type ProfileConfig struct {
+	PrivateMode string `env:"PRIVATE_MODE" envDefault:"card"`
+}
+
+type RetentionConfig struct {
+	Days          int `env:"DAYS" envDefault:"30"`
+	PurgeInterval int `env:"PURGE_INTERVAL" envDefault:"3600"`
+}
+
+type PaginationConfig struct {
+	CursorSecret string `env:"CURSOR_SECRET,required"`
+}
+
+type ImportConfig struct {
+	MaxRows        int   `env:"MAX_ROWS" envDefault:"10000"`
+	MaxSize        int64 `env:"MAX_SIZE" envDefault:"10485760"`
+	PasswordLength int   `env:"PASSWORD_LENGTH" envDefault:"16"`
+	JobTtl         int   `env:"JOB_TTL" envDefault:"86400"`
+	PollInterval   int   `env:"POLL_INTERVAL" envDefault:"5"`
+}
+
+const (
+	BlobStorageLocal = "local"
+	BlobStorageS3    = "s3"
+)
+
+type BlobConfig struct {
+	Storage     string  `env:"STORAGE" envDefault:"local"`
+	LocalDir    string  `env:"LOCAL_DIR" envDefault:"./data/blobs"`
+	PublicURL   string  `env:"PUBLIC_URL"`
+	S3Endpoint  url.URL `env:"S3_ENDPOINT"`
+	S3Region    string  `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket    string  `env:"S3_BUCKET"`
+	S3AccessKey string  `env:"S3_ACCESS_KEY"`
+	S3SecretKey string  `env:"S3_SECRET_KEY"`
+	S3PathStyle bool    `env:"S3_PATH_STYLE" envDefault:"true"`
+}
+
+type AvatarConfig struct {
+	MaxSize   int64 `env:"MAX_SIZE" envDefault:"5242880"`
+	MaxPixels int   `env:"MAX_PIXELS" envDefault:"25000000"`
+	MaxSide   int   `env:"MAX_SIDE" envDefault:"1024"`
+	Sizes     []int `env:"SIZES" envSeparator:"," envDefault:"64,128,256"`
+}
+
+type NicknameConfig struct {
+	ChangeCooldown int `env:"CHANGE_COOLDOWN" envDefault:"604800"`
+}
+
+const (
+	MailTransportStdout = "stdout"
+	MailTransportSMTP   = "smtp"
+)
+
+type MailConfig struct {
+	Transport   string `env:"TRANSPORT" envDefault:"stdout"`
+	From        string `env:"FROM" envDefault:"usermanager <no-reply@localhost>"`
+	LinkBaseURL string `env:"LINK_BASE_URL" envDefault:"http://localhost:3000"`
+	SMTPHost    string `env:"SMTP_HOST"`
+	SMTPPort    int    `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUser    string `env:"SMTP_USER"`
+	SMTPPass    string `env:"SMTP_PASS"`
+}
+
+type EmailConfig struct {
+	ChangeTtl int `env:"CHANGE_TTL" envDefault:"86400"`
+}
+
+type DataRequestConfig struct {
+	PollInterval int `env:"POLL_INTERVAL" envDefault:"10"`
+	ArchiveTtl   int `env:"ARCHIVE_TTL" envDefault:"604800"`
+}
+
+type SuspensionConfig struct {
+	SweepInterval int `env:"SWEEP_INTERVAL" envDefault:"60"`
+}
+
+type HierarchyConfig struct {
+	MaxDepth      int    `env:"MAX_DEPTH" envDefault:"16"`
+	DeletePolicy  string `env:"DELETE_POLICY" envDefault:"block"`
+	SuspendPolicy string `env:"SUSPEND_POLICY" envDefault:"cascade"`
+}
+
+type SettingsConfig struct {
+	CacheTtl int `env:"CACHE_TTL" envDefault:"3600"`
+}
+
+type InviteConfig struct {
+	Ttl int `env:"TTL" envDefault:"604800"`
+}
+
+type RegistrationConfig struct {
+	Enabled             bool     `env:"ENABLED" envDefault:"false"`
+	ChallengeDifficulty int      `env:"CHALLENGE_DIFFICULTY" envDefault:"20"`
+	ChallengeTtl        int      `env:"CHALLENGE_TTL" envDefault:"300"`
+	ChallengeIPLimit    int      `env:"CHALLENGE_IP_LIMIT" envDefault:"30"`
+	IPLimit             int      `env:"IP_LIMIT" envDefault:"5"`
+	IPWindow            int      `env:"IP_WINDOW" envDefault:"3600"`
+	BlockedDomains      []string `env:"BLOCKED_DOMAINS" envSeparator:","`
+	VerifyEmail         bool     `env:"VERIFY_EMAIL" envDefault:"false"`
+	VerifyTtl           int      `env:"VERIFY_TTL" envDefault:"86400"`
+}
+
 func NewConfig(envStr string) (*Config, error) {
 	err := godotenv.Load(envStr)
 	if err != nil {
@@ -59,6 +210,9 @@ func NewConfig(envStr string) (*Config, error) {
 	if err != nil {
 		return cfg, apperrors.EnvConfigParseError.AppendMessage(err)
 	}
+	if _, err := cfg.TrustedProxyRanges(); err != nil {
+		return cfg, apperrors.EnvConfigParseError.AppendMessage(err)
+	}
 
 	postgresCfg := &PostgresConfig{}
 	opts := env.Options{
@@ -86,5 +240,255 @@ func NewConfig(envStr string) (*Config, error) {
 		return cfg, apperrors.EnvConfigJwtParseError.AppendMessage(err)
 	}
 	cfg.Jwt = jwtCfg
+
+	roleCfg := &RoleConfig{}
+	opts = env.Options{
+		Prefix: rolePrefix,
+	}
+	if err := env.ParseWithOptions(roleCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigRoleParseError.AppendMessage(err)
+	}
+	if err := roleCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigRoleParseError.AppendMessage(err)
+	}
+	cfg.Role = roleCfg
+
+	profileCfg := &ProfileConfig{}
+	opts = env.Options{
+		Prefix: profilePrefix,
+	}
+	if err := env.ParseWithOptions(profileCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigProfileParseError.AppendMessage(err)
+	}
+	cfg.Profile = profileCfg
+
+	retentionCfg := &RetentionConfig{}
+	opts = env.Options{
+		Prefix: retentionPrefix,
+	}
+	if err := env.ParseWithOptions(retentionCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigRetentionParseError.AppendMessage(err)
+	}
+	if err := retentionCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigRetentionParseError.AppendMessage(err)
+	}
+	cfg.Retention = retentionCfg
+
+	paginationCfg := &PaginationConfig{}
+	opts = env.Options{
+		Prefix: paginationPrefix,
+	}
+	if err := env.ParseWithOptions(paginationCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigPaginationParseError.AppendMessage(err)
+	}
+	cfg.Pagination = paginationCfg
+
+	importCfg := &ImportConfig{}
+	opts = env.Options{
+		Prefix: importPrefix,
+	}
+	if err := env.ParseWithOptions(importCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigImportParseError.AppendMessage(err)
+	}
+	if err := importCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigImportParseError.AppendMessage(err)
+	}
+	cfg.Import = importCfg
+
+	blobCfg := &BlobConfig{}
+	opts = env.Options{
+		Prefix: blobPrefix,
+	}
+	if err := env.ParseWithOptions(blobCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigBlobParseError.AppendMessage(err)
+	}
+	if err := blobCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigBlobParseError.AppendMessage(err)
+	}
+	cfg.Blob = blobCfg
+
+	avatarCfg := &AvatarConfig{}
+	opts = env.Options{
+		Prefix: avatarPrefix,
+	}
+	if err := env.ParseWithOptions(avatarCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigAvatarParseError.AppendMessage(err)
+	}
+	cfg.Avatar = avatarCfg
+
+	nicknameCfg := &NicknameConfig{}
+	opts = env.Options{
+		Prefix: nicknamePrefix,
+	}
+	if err := env.ParseWithOptions(nicknameCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigNicknameParseError.AppendMessage(err)
+	}
+	cfg.Nickname = nicknameCfg
+
+	mailCfg := &MailConfig{}
+	opts = env.Options{
+		Prefix: mailPrefix,
+	}
+	if err := env.ParseWithOptions(mailCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigMailParseError.AppendMessage(err)
+	}
+	if err := mailCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigMailParseError.AppendMessage(err)
+	}
+	cfg.Mail = mailCfg
+
+	emailCfg := &EmailConfig{}
+	opts = env.Options{
+		Prefix: emailPrefix,
+	}
+	if err := env.ParseWithOptions(emailCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigEmailParseError.AppendMessage(err)
+	}
+	cfg.Email = emailCfg
+
+	dataRequestCfg := &DataRequestConfig{}
+	opts = env.Options{
+		Prefix: dataRequestPrefix,
+	}
+	if err := env.ParseWithOptions(dataRequestCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigDataRequestParseError.AppendMessage(err)
+	}
+	if err := dataRequestCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigDataRequestParseError.AppendMessage(err)
+	}
+	cfg.DataRequest = dataRequestCfg
+
+	suspensionCfg := &SuspensionConfig{}
+	opts = env.Options{
+		Prefix: suspensionPrefix,
+	}
+	if err := env.ParseWithOptions(suspensionCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigSuspensionParseError.AppendMessage(err)
+	}
+	if err := suspensionCfg.validate(); err != nil {
+		return cfg, apperrors.EnvConfigSuspensionParseError.AppendMessage(err)
+	}
+	cfg.Suspension = suspensionCfg
+
+	hierarchyCfg := &HierarchyConfig{}
+	opts = env.Options{
+		Prefix: hierarchyPrefix,
+	}
+	if err := env.ParseWithOptions(hierarchyCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigHierarchyParseError.AppendMessage(err)
+	}
+	cfg.Hierarchy = hierarchyCfg
+
+	settingsCfg := &SettingsConfig{}
+	opts = env.Options{
+		Prefix: settingsPrefix,
+	}
+	if err := env.ParseWithOptions(settingsCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigSettingsParseError.AppendMessage(err)
+	}
+	cfg.Settings = settingsCfg
+
+	inviteCfg := &InviteConfig{}
+	opts = env.Options{
+		Prefix: invitePrefix,
+	}
+	if err := env.ParseWithOptions(inviteCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigInviteParseError.AppendMessage(err)
+	}
+	cfg.Invite = inviteCfg
+
+	registrationCfg := &RegistrationConfig{}
+	opts = env.Options{
+		Prefix: registrationPrefix,
+	}
+	if err := env.ParseWithOptions(registrationCfg, opts); err != nil {
+		return cfg, apperrors.EnvConfigRegistrationParseError.AppendMessage(err)
+	}
+	cfg.Registration = registrationCfg
 	return cfg, nil
 }
+
+// TrustedProxyRanges parses TrustedProxies, a plain IP stands for a range of its own.
+func (c *Config) TrustedProxyRanges() ([]*net.IPNet, error) {
+	ranges := make([]*net.IPNet, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		ranges = append(ranges, ipRange)
+	}
+	return ranges, nil
+}
+
+func (c *BlobConfig) validate() error {
+	switch c.Storage {
+	case BlobStorageLocal:
+		return nil
+	case BlobStorageS3:
+		if c.S3Endpoint.Scheme == "" || c.S3Endpoint.Host == "" || c.S3Bucket == "" {
+			return fmt.Errorf("%s storage needs an absolute S3_ENDPOINT and a S3_BUCKET", BlobStorageS3)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown blob storage %q", c.Storage)
+	}
+}
+
+func (c *MailConfig) validate() error {
+	switch c.Transport {
+	case MailTransportStdout:
+		return nil
+	case MailTransportSMTP:
+		if c.SMTPHost == "" {
+			return fmt.Errorf("%s transport needs a SMTP_HOST", MailTransportSMTP)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown mail transport %q", c.Transport)
+	}
+}
+
+func (c *RoleConfig) validate() error {
+	return validateInterval("GRANT_SWEEP_INTERVAL", c.GrantSweepInterval)
+}
+
+func (c *RetentionConfig) validate() error {
+	return validateInterval("PURGE_INTERVAL", c.PurgeInterval)
+}
+
+func (c *ImportConfig) validate() error {
+	return validateInterval("POLL_INTERVAL", c.PollInterval)
+}
+
+func (c *DataRequestConfig) validate() error {
+	return validateInterval("POLL_INTERVAL", c.PollInterval)
+}
+
+func (c *SuspensionConfig) validate() error {
+	return validateInterval("SWEEP_INTERVAL", c.SweepInterval)
+}
+
+// validateInterval rejects the worker intervals time.NewTicker panics on.
+func validateInterval(name string, seconds int) error {
+	if seconds <= 0 {
+		return fmt.Errorf("%s must be a positive number of seconds, got %d", name, seconds)
+	}
+	return nil
+}
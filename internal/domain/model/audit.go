@@ -0,0 +1,291 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionUserCreate      = "user.create"
+	AuditActionUserUpdate      = "user.update"
+	AuditActionUserPatch       = "user.patch"
+	AuditActionUserDelete      = "user.delete"
+	AuditActionUserHardDelete  = "user.hard_delete"
+	AuditActionUserRestore     = "user.restore"
+	AuditActionUserVote        = "user.vote"
+	AuditActionUserEmailChange = "user.email_change"
+	AuditActionUserErase       = "user.erase"
+	AuditActionUserSuspend     = "user.suspend"
+	AuditActionUserUnsuspend   = "user.unsuspend"
+	AuditActionUserTransfer    = "user.transfer"
+	AuditActionUserSettings    = "user.settings"
+	AuditActionUserVerify      = "user.verify"
+	AuditActionUserAvatar      = "user.avatar"
+	AuditActionUserRoleAssign  = "user.role_assign"
+	AuditActionUserRoleApprove = "user.role_approve"
+	AuditActionUserRoleGrant   = "user.role_grant"
+	AuditActionUserRoleRevoke  = "user.role_revoke"
+
+	// AuditRedacted stands in for secrets; the entry only tells that they changed.
+	AuditRedacted = "[redacted]"
+
+	AuditQueryDefaultLimit = 50
+	AuditQueryMaxLimit     = 500
+)
+
+var ErrAuditQuery = errors.New("invalid audit query")
+
+type auditActorCtxKey struct{}
+type auditRequestCtxKey struct{}
+
+// AuditEntry is one mutating operation. Entries are only ever appended. ActorID is nil when
+// nobody was authenticated, for instance when an email change is confirmed by its link.
+type AuditEntry struct {
+	EntryID       int64        `json:"entry_id" db:"entry_id"`
+	ActorID       *uuid.UUID   `json:"actor_id,omitempty" db:"actor_id"`
+	ActorNickname string       `json:"actor_nickname,omitempty" db:"actor_nickname"`
+	Action        string       `json:"action" db:"action"`
+	TargetID      uuid.UUID    `json:"target_id" db:"target_id"`
+	Changes       AuditChanges `json:"changes,omitempty" db:"changes"`
+	IP            string       `json:"ip,omitempty" db:"ip"`
+	RequestID     string       `json:"request_id,omitempty" db:"request_id"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+}
+
+type AuditEntries struct {
+	Entries    []*AuditEntry `json:"entries"`
+	NextBefore int64         `json:"next_before,omitempty"`
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditChanges maps a field name to its value before and after the operation.
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	changes, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(changes), nil
+}
+
+func (c *AuditChanges) Scan(src any) error {
+	var data []byte
+	switch typed := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = typed
+	case string:
+		data = []byte(typed)
+	default:
+		return fmt.Errorf("can't scan %T into audit changes", src)
+	}
+	return json.Unmarshal(data, c)
+}
+
+// AuditRequest is where a request came from, as seen by the transport.
+type AuditRequest struct {
+	IP        string `json:"ip,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func ContextWithAuditActor(ctx context.Context, actor *User) context.Context {
+	return context.WithValue(ctx, auditActorCtxKey{}, actor)
+}
+
+func ContextWithAuditRequest(ctx context.Context, request *AuditRequest) context.Context {
+	return context.WithValue(ctx, auditRequestCtxKey{}, request)
+}
+
+// AuditActorFromContext returns the actor set by ContextWithAuditActor, or nil.
+func AuditActorFromContext(ctx context.Context) *User {
+	actor, _ := ctx.Value(auditActorCtxKey{}).(*User)
+	return actor
+}
+
+// AuditRequestFromContext returns the request set by ContextWithAuditRequest, or nil.
+func AuditRequestFromContext(ctx context.Context) *AuditRequest {
+	request, _ := ctx.Value(auditRequestCtxKey{}).(*AuditRequest)
+	return request
+}
+
+// NewAuditEntry fills the actor and the request in from the context.
+func NewAuditEntry(ctx context.Context, action string, targetID uuid.UUID, changes AuditChanges, at time.Time) *AuditEntry {
+	entry := &AuditEntry{Action: action, TargetID: targetID, Changes: changes, CreatedAt: at}
+	if actor := AuditActorFromContext(ctx); actor != nil {
+		actorID := actor.UserID
+		entry.ActorID = &actorID
+		entry.ActorNickname = actor.Nickname
+	}
+	if request := AuditRequestFromContext(ctx); request != nil {
+		entry.IP = request.IP
+		entry.RequestID = request.RequestID
+	}
+	return entry
+}
+
+// DiffUsers lists the user fields that differ. A nil user stands for one that doesn't exist,
+// so creating a user lists every field it was given. The password is redacted.
+func DiffUsers(before *User, after *User) AuditChanges {
+	if before == nil {
+		before = &User{}
+	}
+	if after == nil {
+		after = &User{}
+	}
+
+	changes := AuditChanges{}
+	add := func(field string, beforeValue any, afterValue any) {
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			changes[field] = AuditChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	add("nickname", before.Nickname, after.Nickname)
+	add("first_name", before.FirstName, after.FirstName)
+	add("last_name", before.LastName, after.LastName)
+	add("email", before.Email, after.Email)
+	add("is_public", before.IsPublic, after.IsPublic)
+	add("user_role", before.Role, after.Role)
+	if len(before.Attributes) != 0 || len(after.Attributes) != 0 {
+		add("attributes", before.Attributes, after.Attributes)
+	}
+	add("avatar", auditAvatarURL(before.Avatar), auditAvatarURL(after.Avatar))
+	add("status", before.Status, after.Status)
+	add("status_reason", before.StatusReason, after.StatusReason)
+	add("status_until", before.StatusUntil, after.StatusUntil)
+	add("parent_id", before.ParentID, after.ParentID)
+	if before.Password != after.Password {
+		changes["password"] = AuditChange{Before: auditRedact(before.Password), After: auditRedact(after.Password)}
+	}
+
+	return changes
+}
+
+// DiffRoleGrant lists how the status of a grant changed, along with the grant and its role so
+// the entry tells which elevation it was about. An empty previousStatus stands for a new grant.
+func DiffRoleGrant(previousStatus string, roleGrant *RoleGrant) AuditChanges {
+	changes := AuditChanges{
+		"grant_id": {Before: roleGrant.GrantID, After: roleGrant.GrantID},
+		"role":     {Before: roleGrant.Role, After: roleGrant.Role},
+		"status":   {Before: previousStatus, After: roleGrant.Status},
+	}
+	if previousStatus == "" {
+		changes["grant_id"] = AuditChange{After: roleGrant.GrantID}
+		changes["role"] = AuditChange{After: roleGrant.Role}
+		changes["status"] = AuditChange{After: roleGrant.Status}
+	}
+
+	return changes
+}
+
+// RedactBefore keeps which fields held a value but not the value, for operations whose point
+// is to forget it.
+func (c AuditChanges) RedactBefore() AuditChanges {
+	for field, change := range c {
+		if change.Before != nil && !reflect.ValueOf(change.Before).IsZero() {
+			change.Before = AuditRedacted
+			c[field] = change
+		}
+	}
+	return c
+}
+
+func auditAvatarURL(avatar *UserAvatar) string {
+	if avatar == nil {
+		return ""
+	}
+	return avatar.URL
+}
+
+func auditRedact(secret string) any {
+	if secret == "" {
+		return nil
+	}
+	return AuditRedacted
+}
+
+// AuditQuery filters the audit log. Entries are listed newest first, and Before continues a
+// listing below the last entry_id seen.
+type AuditQuery struct {
+	TargetID *uuid.UUID
+	ActorID  *uuid.UUID
+	Action   string
+	From     *time.Time
+	To       *time.Time
+	Before   int64
+	Limit    int
+}
+
+// NewAuditQuery reads target_id, actor_id, action, from, to (RFC 3339), before and limit.
+func NewAuditQuery(values url.Values) (*AuditQuery, error) {
+	query := &AuditQuery{Action: values.Get("action"), Limit: AuditQueryDefaultLimit}
+	var err error
+	if query.TargetID, err = parseAuditUUID(values, "target_id"); err != nil {
+		return nil, err
+	}
+	if query.ActorID, err = parseAuditUUID(values, "actor_id"); err != nil {
+		return nil, err
+	}
+	if query.From, err = parseAuditTime(values, "from"); err != nil {
+		return nil, err
+	}
+	if query.To, err = parseAuditTime(values, "to"); err != nil {
+		return nil, err
+	}
+	if value := values.Get("before"); value != "" {
+		query.Before, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || query.Before <= 0 {
+			return nil, fmt.Errorf("%w: before %q", ErrAuditQuery, value)
+		}
+	}
+	if value := values.Get("limit"); value != "" {
+		query.Limit, err = strconv.Atoi(value)
+		if err != nil || query.Limit <= 0 || query.Limit > AuditQueryMaxLimit {
+			return nil, fmt.Errorf("%w: limit %q, expected 1 to %d", ErrAuditQuery, value, AuditQueryMaxLimit)
+		}
+	}
+
+	return query, nil
+}
+
+func parseAuditUUID(values url.Values, name string) (*uuid.UUID, error) {
+	value := values.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %q", ErrAuditQuery, name, value)
+	}
+	return &id, nil
+}
+
+func parseAuditTime(values url.Values, name string) (*time.Time, error) {
+	value := values.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %q", ErrAuditQuery, name, value)
+	}
+	return &at, nil
+}
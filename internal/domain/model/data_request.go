@@ -0,0 +1,180 @@
+package model
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DataRequestKindExport  = "export"
+	DataRequestKindErasure = "erasure"
+
+	DataRequestStatusQueued    = "queued"
+	DataRequestStatusRunning   = "running"
+	DataRequestStatusCompleted = "completed"
+	DataRequestStatusFailed    = "failed"
+
+	DataExportFormatZIP  = "zip"
+	DataExportFormatJSON = "json"
+
+	// ErasedNicknamePrefix starts the nickname an erased user is left with.
+	ErasedNicknamePrefix = "deleted-"
+
+	DataRequestQueryDefaultLimit = 50
+	DataRequestQueryMaxLimit     = 500
+)
+
+var (
+	ErrDataExportFormat = errors.New("unknown data export format")
+	ErrDataRequestQuery = errors.New("invalid data request query")
+)
+
+// DataRequest is a data subject request run in the background: an export of everything
+// stored about a user, or the erasure of the user. Requests are kept once finished, as a
+// record that they were answered.
+type DataRequest struct {
+	DataRequestID uuid.UUID  `json:"data_request_id" db:"data_request_id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	Kind          string     `json:"kind" db:"kind"`
+	Format        string     `json:"format,omitempty" db:"format"`
+	Status        string     `json:"status" db:"status"`
+	RequestedByID *uuid.UUID `json:"requested_by_id,omitempty" db:"requested_by_id"`
+	RequestedBy   string     `json:"requested_by,omitempty" db:"requested_by"`
+	Error         string     `json:"error,omitempty" db:"error"`
+	ArchiveKey    string     `json:"-" db:"archive_key"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+}
+
+// HasArchive tells whether the export can still be downloaded.
+func (r *DataRequest) HasArchive(now time.Time) bool {
+	return r.Kind == DataRequestKindExport && r.Status == DataRequestStatusCompleted && r.ArchiveKey != "" &&
+		r.ExpiresAt != nil && now.Before(*r.ExpiresAt)
+}
+
+type DataRequestQuery struct {
+	UserID *uuid.UUID
+	Kind   string
+	Status string
+	Limit  int
+}
+
+// NewDataRequestQuery reads user_id, kind, status and limit.
+func NewDataRequestQuery(values url.Values) (*DataRequestQuery, error) {
+	query := &DataRequestQuery{Kind: values.Get("kind"), Status: values.Get("status"), Limit: DataRequestQueryDefaultLimit}
+	if value := values.Get("user_id"); value != "" {
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: user_id %q", ErrDataRequestQuery, value)
+		}
+		query.UserID = &userID
+	}
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > DataRequestQueryMaxLimit {
+			return nil, fmt.Errorf("%w: limit %q, expected 1 to %d", ErrDataRequestQuery, value, DataRequestQueryMaxLimit)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+type DataRequestPolicy struct {
+	ArchiveTtl time.Duration
+}
+
+// DataExport is everything stored about a user. Votes received don't name the voters, who
+// are other data subjects.
+type DataExport struct {
+	ExportedAt      time.Time         `json:"exported_at"`
+	Profile         *User             `json:"profile"`
+	VotesCast       []*DataExportVote `json:"votes_cast"`
+	VotesReceived   []*DataExportVote `json:"votes_received"`
+	Sessions        []*LoginEvent     `json:"sessions"`
+	NicknameHistory []*NicknameChange `json:"nickname_history"`
+	EmailChanges    []*EmailChange    `json:"email_changes"`
+	Settings        []*UserSetting    `json:"settings"`
+	Following       []*Follow         `json:"following"`
+	AuditEntries    []*AuditEntry     `json:"audit_entries"`
+}
+
+type DataExportVote struct {
+	VoteID    int64      `json:"vote_id" db:"vote_id"`
+	Vote      int        `json:"vote" db:"vote"`
+	UserID    *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	CreatedAt *time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+// DataExportFormat defaults to a ZIP archive with one JSON file per section.
+func DataExportFormat(value string) (string, error) {
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case "", DataExportFormatZIP:
+		return DataExportFormatZIP, nil
+	case DataExportFormatJSON:
+		return DataExportFormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrDataExportFormat, value)
+	}
+}
+
+func DataExportContentType(format string) string {
+	if format == DataExportFormatJSON {
+		return "application/json"
+	}
+	return "application/zip"
+}
+
+// ErasedNickname is unique to the user, so the erased row keeps a valid nickname.
+func ErasedNickname(userID uuid.UUID) string {
+	return ErasedNicknamePrefix + strings.ReplaceAll(userID.String(), "-", "")
+}
+
+// WriteDataExport writes the export as one JSON document, or as a ZIP archive with a JSON file
+// per section.
+func WriteDataExport(w io.Writer, format string, export *DataExport) error {
+	if format == DataExportFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(export)
+	}
+
+	archive := zip.NewWriter(w)
+	sections := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", export.Profile},
+		{"votes_cast.json", export.VotesCast},
+		{"votes_received.json", export.VotesReceived},
+		{"sessions.json", export.Sessions},
+		{"nickname_history.json", export.NicknameHistory},
+		{"email_changes.json", export.EmailChanges},
+		{"settings.json", export.Settings},
+		{"following.json", export.Following},
+		{"audit_log.json", export.AuditEntries},
+	}
+	for _, section := range sections {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: section.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(section.value); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailChange moves a user to NewEmail once the new address confirms it. Until then the old
+// address can cancel it; only hashes of both tokens are stored.
+type EmailChange struct {
+	ChangeID         uuid.UUID  `json:"change_id" db:"change_id"`
+	UserID           uuid.UUID  `json:"user_id" db:"user_id"`
+	OldEmail         string     `json:"old_email,omitempty" db:"old_email"`
+	NewEmail         string     `json:"new_email" db:"new_email"`
+	ConfirmTokenHash string     `json:"-" db:"confirm_token_hash"`
+	CancelTokenHash  string     `json:"-" db:"cancel_token_hash"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+}
+
+func (c *EmailChange) IsPending(now time.Time) bool {
+	return c.ConfirmedAt == nil && c.CancelledAt == nil && now.Before(c.ExpiresAt)
+}
+
+type EmailPolicy struct {
+	ChangeTtl time.Duration
+	// LinkBaseURL is where the links in emails point to, the page that posts the token back.
+	LinkBaseURL string
+}
+
+type EmailChangeRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type EmailTokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
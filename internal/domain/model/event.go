@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventRoleGrantActivated = "role_grant.activated"
+	EventRoleGrantRevoked   = "role_grant.revoked"
+	EventRoleGrantExpired   = "role_grant.expired"
+	EventLoginNewClient     = "login.new_client"
+)
+
+type Event struct {
+	Type       string      `json:"type"`
+	UserID     uuid.UUID   `json:"user_id"`
+	Payload    interface{} `json:"payload,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
+
+func NewEvent(eventType string, userID uuid.UUID, payload interface{}) *Event {
+	return &Event{
+		Type:       eventType,
+		UserID:     userID,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}
+}
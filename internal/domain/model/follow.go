@@ -0,0 +1,117 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FollowStatusPending  = "pending"
+	FollowStatusAccepted = "accepted"
+
+	FollowDirectionFollowers = "followers"
+	FollowDirectionFollowing = "following"
+
+	FollowQueryDefaultLimit = 50
+	FollowQueryMaxLimit     = 500
+)
+
+var ErrFollowQuery = errors.New("invalid follow query")
+
+// Follow is FollowerID following FolloweeID. A follow of a private profile may wait in
+// pending until the followee approves it, only accepted follows are counted.
+type Follow struct {
+	FollowID   int64      `json:"follow_id" db:"follow_id"`
+	FollowerID uuid.UUID  `json:"follower_id" db:"follower_id"`
+	FolloweeID uuid.UUID  `json:"followee_id" db:"followee_id"`
+	Status     string     `json:"status" db:"status"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+}
+
+func (f *Follow) IsAccepted() bool {
+	return f != nil && f.Status == FollowStatusAccepted
+}
+
+// FollowResponse tells the follower whether the followee follows back.
+type FollowResponse struct {
+	*Follow
+	Mutual bool `json:"mutual"`
+}
+
+// FollowEntry is the other user of a follow in a followers or following list. Mutual is set
+// when that user is followed back.
+type FollowEntry struct {
+	FollowID  int64     `json:"follow_id" db:"follow_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Nickname  string    `json:"nickname" db:"nickname"`
+	IsPublic  bool      `json:"-" db:"is_public"`
+	Status    string    `json:"status" db:"status"`
+	Mutual    bool      `json:"mutual" db:"mutual"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type FollowEntries struct {
+	Entries    []*FollowEntry `json:"entries"`
+	NextBefore int64          `json:"next_before,omitempty"`
+}
+
+// VisibleTo drops the private users that have to look as if they don't exist.
+func (fe *FollowEntries) VisibleTo(viewer *User, privateProfileMode string) *FollowEntries {
+	if privateProfileMode != PrivateProfileModeNotFound {
+		return fe
+	}
+	visibleEntries := &FollowEntries{Entries: make([]*FollowEntry, 0, len(fe.Entries)), NextBefore: fe.NextBefore}
+	for _, entry := range fe.Entries {
+		user := &User{UserID: entry.UserID, IsPublic: entry.IsPublic}
+		if entry.IsPublic || user.IsOwnerOrAdmin(viewer) {
+			visibleEntries.Entries = append(visibleEntries.Entries, entry)
+		}
+	}
+	return visibleEntries
+}
+
+type FollowCounts struct {
+	Followers int64 `db:"followers"`
+	Following int64 `db:"following"`
+}
+
+type FollowQuery struct {
+	UserID    uuid.UUID
+	Direction string
+	Status    string
+	Before    int64
+	Limit     int
+}
+
+// NewFollowQuery reads before and limit. Before is the follow_id the page ends at, the
+// next_before of the previous page.
+func NewFollowQuery(userID uuid.UUID, direction string, status string, values url.Values) (*FollowQuery, error) {
+	query := &FollowQuery{UserID: userID, Direction: direction, Status: status, Limit: FollowQueryDefaultLimit}
+	if value := values.Get("before"); value != "" {
+		before, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, fmt.Errorf("%w: before %q", ErrFollowQuery, value)
+		}
+		query.Before = before
+	}
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > FollowQueryMaxLimit {
+			return nil, fmt.Errorf("%w: limit %q, expected 1 to %d", ErrFollowQuery, value, FollowQueryMaxLimit)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+func (r *GetUserResponse) SetFollowCounts(counts *FollowCounts) {
+	r.FollowersCount = &counts.Followers
+	r.FollowingCount = &counts.Following
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusRevoked  = "revoked"
+	InviteStatusExpired  = "expired"
+)
+
+// Invite lets whoever holds the token sign up once with Email, as a user with Role. Only the
+// hash of the token is stored, the token itself is only in the email sent to the invitee.
+type Invite struct {
+	InviteID       uuid.UUID  `json:"invite_id" db:"invite_id"`
+	Email          string     `json:"email" db:"email"`
+	EmailKey       string     `json:"-" db:"email_key"`
+	Role           string     `json:"user_role" db:"user_role"`
+	InvitedByID    uuid.UUID  `json:"invited_by_id" db:"invited_by_id"`
+	TokenHash      string     `json:"-" db:"token_hash"`
+	Status         string     `json:"status" db:"-"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	AcceptedUserID *uuid.UUID `json:"accepted_user_id,omitempty" db:"accepted_user_id"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+func (i *Invite) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// SetStatus fills Status, which isn't stored since an invite expires without being written to.
+func (i *Invite) SetStatus(now time.Time) *Invite {
+	switch {
+	case i.AcceptedAt != nil:
+		i.Status = InviteStatusAccepted
+	case i.RevokedAt != nil:
+		i.Status = InviteStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		i.Status = InviteStatusExpired
+	default:
+		i.Status = InviteStatusPending
+	}
+	return i
+}
+
+type InvitePolicy struct {
+	Ttl time.Duration
+	// LinkBaseURL is where the invite links point to, the page that posts the token back.
+	LinkBaseURL string
+	// AdminApprovalRequired leaves an admin invitee a plain user with a pending role request,
+	// as an admin assigning the role would.
+	AdminApprovalRequired bool
+}
+
+// CreateInviteRequest invites with the default role when Role is left out.
+type CreateInviteRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"user_role"`
+}
+
+type AcceptInviteRequest struct {
+	Token     string `json:"token" validate:"required"`
+	Nickname  string `json:"nickname" validate:"required"`
+	Password  string `json:"password" validate:"required,gte=6"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
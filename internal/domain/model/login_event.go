@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LoginMethodNickname = "nickname"
+	LoginMethodEmail    = "email"
+
+	LoginFailureUnknownUser   = "unknown_user"
+	LoginFailureWrongPassword = "wrong_password"
+	LoginFailureInactive      = "inactive"
+
+	LoginEventQueryDefaultLimit = 50
+	LoginEventQueryMaxLimit     = 500
+)
+
+var ErrLoginEventQuery = errors.New("invalid login event query")
+
+// LoginEvent is a login attempt. UserID is nil when nobody goes by the login that was tried.
+// NewClient marks a successful login from an IP address or a user agent the user never
+// logged in with before.
+type LoginEvent struct {
+	LoginEventID  int64      `json:"login_event_id" db:"login_event_id"`
+	UserID        *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Login         string     `json:"login" db:"login"`
+	Method        string     `json:"method" db:"method"`
+	Success       bool       `json:"success" db:"success"`
+	FailureReason string     `json:"failure_reason,omitempty" db:"failure_reason"`
+	IP            string     `json:"ip,omitempty" db:"ip"`
+	UserAgent     string     `json:"user_agent,omitempty" db:"user_agent"`
+	NewClient     bool       `json:"new_client,omitempty" db:"new_client"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// LoginClientHistory tells what the earlier successful logins of a user have in common with
+// a new one.
+type LoginClientHistory struct {
+	Logins         int  `db:"logins"`
+	KnownIP        bool `db:"known_ip"`
+	KnownUserAgent bool `db:"known_user_agent"`
+}
+
+// IsNewClient is false for the first login of a user, there is nothing to compare it with.
+func (h *LoginClientHistory) IsNewClient() bool {
+	return h.Logins > 0 && (!h.KnownIP || !h.KnownUserAgent)
+}
+
+type LoginNewClientPayload struct {
+	LoginEventID   int64  `json:"login_event_id"`
+	IP             string `json:"ip"`
+	UserAgent      string `json:"user_agent"`
+	KnownIP        bool   `json:"known_ip"`
+	KnownUserAgent bool   `json:"known_user_agent"`
+}
+
+type LoginEventQuery struct {
+	UserID  uuid.UUID
+	Success *bool
+	Before  int64
+	Limit   int
+}
+
+type LoginEvents struct {
+	Logins     []*LoginEvent `json:"logins"`
+	NextBefore int64         `json:"next_before,omitempty"`
+}
+
+// NewLoginEventQuery reads success, before and limit. Before is the login_event_id the page
+// ends at, the next_before of the previous page.
+func NewLoginEventQuery(userID uuid.UUID, values url.Values) (*LoginEventQuery, error) {
+	query := &LoginEventQuery{UserID: userID, Limit: LoginEventQueryDefaultLimit}
+	if value := values.Get("success"); value != "" {
+		success, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: success %q", ErrLoginEventQuery, value)
+		}
+		query.Success = &success
+	}
+	if value := values.Get("before"); value != "" {
+		before, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, fmt.Errorf("%w: before %q", ErrLoginEventQuery, value)
+		}
+		query.Before = before
+	}
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > LoginEventQueryMaxLimit {
+			return nil, fmt.Errorf("%w: limit %q, expected 1 to %d", ErrLoginEventQuery, value, LoginEventQueryMaxLimit)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservedNickname can't be taken by any user, in any spelling that shares its key.
+type ReservedNickname struct {
+	NicknameKey string    `json:"-" db:"nickname_key"`
+	Nickname    string    `json:"nickname" db:"nickname"`
+	Reason      string    `json:"reason,omitempty" db:"reason"`
+	CreatedBy   string    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// NicknameChange records the nickname a user gave up, so links to it keep resolving.
+type NicknameChange struct {
+	ID          int64     `json:"id" db:"id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	Nickname    string    `json:"nickname" db:"nickname"`
+	NicknameKey string    `json:"-" db:"nickname_key"`
+	NewNickname string    `json:"new_nickname" db:"new_nickname"`
+	ChangedAt   time.Time `json:"changed_at" db:"changed_at"`
+}
+
+type NicknamePolicy struct {
+	ChangeCooldown time.Duration
+}
+
+type ReserveNicknameRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=1000"`
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// disposableEmailDomains are blocked on top of the configured ones. The list only holds the
+// well known services, operators add the rest with REGISTRATION_BLOCKED_DOMAINS.
+var disposableEmailDomains = map[string]bool{
+	"10minutemail.com":      true,
+	"burnermail.io":         true,
+	"discard.email":         true,
+	"disposablemail.com":    true,
+	"dispostable.com":       true,
+	"dropmail.me":           true,
+	"emailfake.com":         true,
+	"emailondeck.com":       true,
+	"fakeinbox.com":         true,
+	"fakemailgenerator.com": true,
+	"getairmail.com":        true,
+	"getnada.com":           true,
+	"guerrillamail.com":     true,
+	"guerrillamail.net":     true,
+	"inboxkitten.com":       true,
+	"jetable.org":           true,
+	"mail.tm":               true,
+	"mailcatch.com":         true,
+	"maildrop.cc":           true,
+	"mailinator.com":        true,
+	"mailnesia.com":         true,
+	"mailpoof.com":          true,
+	"mintemail.com":         true,
+	"minuteinbox.com":       true,
+	"moakt.com":             true,
+	"mohmal.com":            true,
+	"mytemp.email":          true,
+	"sharklasers.com":       true,
+	"spambox.us":            true,
+	"spamgourmet.com":       true,
+	"temp-mail.org":         true,
+	"tempail.com":           true,
+	"tempmail.net":          true,
+	"tempmailo.com":         true,
+	"temporary-mail.net":    true,
+	"tempr.email":           true,
+	"throwawaymail.com":     true,
+	"trashmail.com":         true,
+	"yopmail.com":           true,
+	"yopmail.net":           true,
+}
+
+type RegistrationPolicy struct {
+	Enabled bool
+	// ChallengeDifficulty is the number of leading zero bits the proof of work has to reach.
+	ChallengeDifficulty int
+	ChallengeTtl        time.Duration
+	// ChallengeIPLimit and IPLimit are how many challenges and registrations an IP gets
+	// within IPWindow.
+	ChallengeIPLimit int
+	IPLimit          int
+	IPWindow         time.Duration
+	BlockedDomains   []string
+	VerifyEmail      bool
+	VerifyTtl        time.Duration
+	LinkBaseURL      string
+}
+
+// IsEmailDomainBlocked also blocks the subdomains of a blocked domain, disposable services
+// tend to hand those out as well.
+func (rp *RegistrationPolicy) IsEmailDomainBlocked(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(email[at+1:])), ".")
+	for domain != "" {
+		if disposableEmailDomains[domain] || rp.isBlocked(domain) {
+			return true
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
+
+func (rp *RegistrationPolicy) isBlocked(domain string) bool {
+	for _, blocked := range rp.BlockedDomains {
+		if strings.EqualFold(strings.TrimSpace(blocked), domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegistrationChallenge is solved by finding a nonce for which sha256(challenge + ":" + nonce)
+// starts with Difficulty zero bits. A challenge is good for one registration.
+type RegistrationChallenge struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type RegisterUserRequest struct {
+	Challenge string `json:"challenge" validate:"required"`
+	Nonce     string `json:"nonce" validate:"required,max=64"`
+	Nickname  string `json:"nickname" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required,gte=6"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// RegisterUserResponse tells the client whether the account waits for the email to be verified.
+type RegisterUserResponse struct {
+	*CreateUserResponse
+	Status string `json:"status"`
+}
+
+type VerifyRegistrationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ResendVerificationRequest costs a challenge as well, it sends an email to any address.
+type ResendVerificationRequest struct {
+	Challenge string `json:"challenge" validate:"required"`
+	Nonce     string `json:"nonce" validate:"required,max=64"`
+	Email     string `json:"email" validate:"required,email"`
+}
@@ -7,39 +7,59 @@ type LoginResponse struct {
 }
 
 type CreateUserResponse struct {
-	UserID    uuid.UUID `json:"user_id" db:"user_id" validate:"omitempty"`
-	Nickname  string    `json:"nickname" db:"nickname" validate:"required"`
-	FirstName string    `json:"first_name" db:"first_name" validate:"required"`
-	LastName  string    `json:"last_name" db:"last_name" validate:"required"`
-	Email     string    `json:"email,omitempty" db:"email" redis:"email" validate:"email"`
-	IsPublic  bool      `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
-	Role      string    `json:"user_role" db:"user_role" validate:"required"`
+	UserID     uuid.UUID      `json:"user_id" db:"user_id" validate:"omitempty"`
+	Nickname   string         `json:"nickname" db:"nickname" validate:"required"`
+	FirstName  string         `json:"first_name" db:"first_name" validate:"required"`
+	LastName   string         `json:"last_name" db:"last_name" validate:"required"`
+	Email      string         `json:"email,omitempty" db:"email" redis:"email" validate:"email"`
+	IsPublic   bool           `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
+	Role       string         `json:"user_role" db:"user_role" validate:"required"`
+	Attributes UserAttributes `json:"attributes,omitempty"`
 }
 
 type UpdateUserResponse struct {
-	UserID    uuid.UUID `json:"user_id" db:"user_id" validate:"omitempty"`
-	Nickname  string    `json:"nickname" db:"nickname" validate:"required"`
-	FirstName string    `json:"first_name" db:"first_name" validate:"required"`
-	LastName  string    `json:"last_name" db:"last_name" validate:"required"`
-	Email     string    `json:"email,omitempty" db:"email" redis:"email" validate:"email"`
-	IsPublic  bool      `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
-	Role      string    `json:"user_role" db:"user_role" validate:"required"`
+	UserID           uuid.UUID         `json:"user_id" db:"user_id" validate:"omitempty"`
+	Nickname         string            `json:"nickname" db:"nickname" validate:"required"`
+	FirstName        string            `json:"first_name" db:"first_name" validate:"required"`
+	LastName         string            `json:"last_name" db:"last_name" validate:"required"`
+	Email            string            `json:"email,omitempty" db:"email" redis:"email" validate:"email"`
+	IsPublic         bool              `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
+	Role             string            `json:"user_role" db:"user_role" validate:"required"`
+	Attributes       UserAttributes    `json:"attributes,omitempty"`
+	AvatarURL        string            `json:"avatar_url,omitempty"`
+	AvatarThumbnails map[string]string `json:"avatar_thumbnails,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	ParentID         *uuid.UUID        `json:"parent_id,omitempty"`
 }
 
 type GetUsersResponse struct {
-	Page    int                `json:"page"`
-	HasMore bool               `json:"has_more"`
-	Users   []*GetUserResponse `json:"users"`
+	Page           int                `json:"page"`
+	HasMore        bool               `json:"has_more"`
+	NextCursor     string             `json:"next_cursor,omitempty"`
+	PrevCursor     string             `json:"prev_cursor,omitempty"`
+	Size           int                `json:"size,omitempty"`
+	Total          *int               `json:"total,omitempty"`
+	TotalPages     *int               `json:"total_pages,omitempty"`
+	TotalEstimated bool               `json:"total_estimated,omitempty"`
+	Users          []*GetUserResponse `json:"users"`
 }
 
 type GetUserResponse struct {
-	UserID    uuid.UUID `json:"user_id" db:"user_id" validate:"omitempty"`
-	Nickname  string    `json:"nickname" db:"nickname" validate:"required"`
-	FirstName string    `json:"first_name" db:"first_name" validate:"required"`
-	LastName  string    `json:"last_name" db:"last_name" validate:"required"`
-	IsPublic  bool      `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
-	Role      string    `json:"user_role" db:"user_role" validate:"required"`
-	Rate      int       `json:"user_rate" db:"user_rate" validate:"required"`
+	UserID           uuid.UUID         `json:"user_id" db:"user_id" validate:"omitempty"`
+	Nickname         string            `json:"nickname" db:"nickname" validate:"required"`
+	FirstName        string            `json:"first_name" db:"first_name" validate:"required"`
+	LastName         string            `json:"last_name" db:"last_name" validate:"required"`
+	Email            string            `json:"email" db:"email" validate:"omitempty"`
+	IsPublic         bool              `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
+	Role             string            `json:"user_role" db:"user_role" validate:"required"`
+	Rate             int               `json:"user_rate" db:"user_rate" validate:"required"`
+	Attributes       UserAttributes    `json:"attributes,omitempty"`
+	AvatarURL        string            `json:"avatar_url,omitempty"`
+	AvatarThumbnails map[string]string `json:"avatar_thumbnails,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	ParentID         *uuid.UUID        `json:"parent_id,omitempty"`
+	FollowersCount   *int64            `json:"followers_count,omitempty"`
+	FollowingCount   *int64            `json:"following_count,omitempty"`
 }
 
 type VoteUserResponse struct {
@@ -7,16 +7,48 @@ import (
 )
 
 const (
-	RoleUser                          = "user"
-	RoleModerator                     = "moderator"
-	RoleAdmin                         = "admin"
-	PermissionUpdate                  = "update"
-	PermissionDelete                  = "delete"
-	hasNoPermissionsToUpdateUserError = "auth user can't change this user"
-	hasNoPermissionsToDeleteUserError = "auth user can't delete this user"
-	hasNoPermissionsError             = "auth user can't delete this user"
+	RoleUser                                  = "user"
+	RoleModerator                             = "moderator"
+	RoleAdmin                                 = "admin"
+	PermissionUpdate                          = "update"
+	PermissionDelete                          = "delete"
+	PermissionRoleAssign                      = "role.assign"
+	PermissionRestore                         = "restore"
+	PermissionImport                          = "import"
+	PermissionExport                          = "export"
+	PermissionAttributeSchema                 = "attributes.schema"
+	PermissionReservedNicknames               = "nicknames.reserved"
+	PermissionAuditLog                        = "audit.read"
+	PermissionDataRequests                    = "data_requests.manage"
+	PermissionSuspend                         = "suspend"
+	PermissionInvite                          = "users.invite"
+	hasNoPermissionsToUpdateUserError         = "auth user can't change this user"
+	hasNoPermissionsToDeleteUserError         = "auth user can't delete this user"
+	hasNoPermissionsToAssignRoleError         = "auth user can't assign roles"
+	hasNoPermissionsToRestoreUserError        = "auth user can't restore users"
+	hasNoPermissionsToImportUsersError        = "auth user can't import users"
+	hasNoPermissionsToExportUsersError        = "auth user can't export users"
+	hasNoPermissionsToManageSchemaError       = "auth user can't manage the attribute schema"
+	hasNoPermissionsToReserveNicknamesError   = "auth user can't manage reserved nicknames"
+	hasNoPermissionsToReadAuditLogError       = "auth user can't read the audit log"
+	hasNoPermissionsToManageDataRequestsError = "auth user can't manage data requests"
+	hasNoPermissionsToSuspendUsersError       = "auth user can't suspend users"
+	hasNoPermissionsToInviteUsersError        = "auth user can't invite users"
+	hasNoPermissionsError                     = "auth user can't delete this user"
 )
 
+var roleRights = map[string][]string{
+	RoleUser:      {},
+	RoleModerator: {PermissionSuspend, PermissionInvite},
+	RoleAdmin:     {PermissionUpdate, PermissionDelete, PermissionRoleAssign, PermissionRestore, PermissionImport, PermissionExport, PermissionAttributeSchema, PermissionReservedNicknames, PermissionAuditLog, PermissionDataRequests, PermissionSuspend, PermissionInvite},
+}
+
+var roleRanks = map[string]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
 func (u *User) GetDefaultRole() string {
 	return RoleUser
 }
@@ -29,12 +61,58 @@ func (u *User) IsAdmin() bool {
 	return (u.Role == RoleAdmin)
 }
 
+func (u *User) IsRoleExist(role string) bool {
+	_, ok := roleRights[role]
+	return ok
+}
+
+func (u *User) IsRoleHigher(role string) bool {
+	return roleRanks[role] > roleRanks[u.Role]
+}
+
+// ApplyRoleGrant elevates the user to the granted role for the lifetime of the grant.
+// A grant never lowers the role the user already has.
+func (u *User) ApplyRoleGrant(roleGrant *RoleGrant) {
+	if u.IsRoleHigher(roleGrant.Role) {
+		u.Role = roleGrant.Role
+	}
+}
+
+func (u *User) HasRight(right string) bool {
+	for _, roleRight := range roleRights[u.Role] {
+		if roleRight == right {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *User) Can(permission string) error {
 	switch permission {
 	case PermissionUpdate:
 		return u.HasPermissionsToUpdateUser()
 	case PermissionDelete:
 		return u.HasPermissionsToDeleteUser()
+	case PermissionRoleAssign:
+		return u.HasPermissionsToAssignRole()
+	case PermissionRestore:
+		return u.HasPermissionsToRestoreUser()
+	case PermissionImport:
+		return u.HasPermissionsToImportUsers()
+	case PermissionExport:
+		return u.HasPermissionsToExportUsers()
+	case PermissionAttributeSchema:
+		return u.HasPermissionsToManageAttributeSchema()
+	case PermissionReservedNicknames:
+		return u.HasPermissionsToManageReservedNicknames()
+	case PermissionAuditLog:
+		return u.HasPermissionsToReadAuditLog()
+	case PermissionDataRequests:
+		return u.HasPermissionsToManageDataRequests()
+	case PermissionSuspend:
+		return u.HasPermissionsToSuspendUsers()
+	case PermissionInvite:
+		return u.HasPermissionsToInviteUsers()
 	default:
 		return apperrors.RoleCanNoPermission.AppendMessage(fmt.Errorf(hasNoPermissionsError))
 	}
@@ -54,3 +132,83 @@ func (u *User) HasPermissionsToDeleteUser() error {
 
 	return apperrors.HasPermissionsDeleteUser.AppendMessage(fmt.Errorf(hasNoPermissionsToDeleteUserError))
 }
+
+func (u *User) HasPermissionsToAssignRole() error {
+	if u.HasRight(PermissionRoleAssign) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsAssignRole.AppendMessage(fmt.Errorf(hasNoPermissionsToAssignRoleError))
+}
+
+func (u *User) HasPermissionsToRestoreUser() error {
+	if u.HasRight(PermissionRestore) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsRestoreUser.AppendMessage(fmt.Errorf(hasNoPermissionsToRestoreUserError))
+}
+
+func (u *User) HasPermissionsToImportUsers() error {
+	if u.HasRight(PermissionImport) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsImportUsers.AppendMessage(fmt.Errorf(hasNoPermissionsToImportUsersError))
+}
+
+func (u *User) HasPermissionsToExportUsers() error {
+	if u.HasRight(PermissionExport) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsExportUsers.AppendMessage(fmt.Errorf(hasNoPermissionsToExportUsersError))
+}
+
+func (u *User) HasPermissionsToManageAttributeSchema() error {
+	if u.HasRight(PermissionAttributeSchema) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsManageAttributeSchema.AppendMessage(fmt.Errorf(hasNoPermissionsToManageSchemaError))
+}
+
+func (u *User) HasPermissionsToManageReservedNicknames() error {
+	if u.HasRight(PermissionReservedNicknames) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsManageReservedNicknames.AppendMessage(fmt.Errorf(hasNoPermissionsToReserveNicknamesError))
+}
+
+func (u *User) HasPermissionsToReadAuditLog() error {
+	if u.HasRight(PermissionAuditLog) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsReadAuditLog.AppendMessage(fmt.Errorf(hasNoPermissionsToReadAuditLogError))
+}
+
+func (u *User) HasPermissionsToManageDataRequests() error {
+	if u.HasRight(PermissionDataRequests) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsManageDataRequests.AppendMessage(fmt.Errorf(hasNoPermissionsToManageDataRequestsError))
+}
+
+func (u *User) HasPermissionsToSuspendUsers() error {
+	if u.HasRight(PermissionSuspend) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsSuspendUsers.AppendMessage(fmt.Errorf(hasNoPermissionsToSuspendUsersError))
+}
+
+func (u *User) HasPermissionsToInviteUsers() error {
+	if u.HasRight(PermissionInvite) {
+		return nil
+	}
+
+	return apperrors.HasPermissionsInviteUsers.AppendMessage(fmt.Errorf(hasNoPermissionsToInviteUsersError))
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleGrantStatusPending  = "pending"
+	RoleGrantStatusActive   = "active"
+	RoleGrantStatusRejected = "rejected"
+	RoleGrantStatusRevoked  = "revoked"
+	RoleGrantStatusExpired  = "expired"
+)
+
+type RoleGrant struct {
+	GrantID       uuid.UUID  `json:"grant_id" db:"grant_id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	Role          string     `json:"role" db:"role"`
+	Justification string     `json:"justification" db:"justification"`
+	Duration      int        `json:"duration" db:"duration"`
+	Status        string     `json:"status" db:"status"`
+	AutoApproved  bool       `json:"auto_approved" db:"auto_approved"`
+	ReviewedBy    *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	RevokedBy     *uuid.UUID `json:"revoked_by,omitempty" db:"revoked_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+type RequestRoleGrantRequest struct {
+	Role          string `json:"role" validate:"required"`
+	Justification string `json:"justification" validate:"required,min=10,max=1000"`
+	Duration      int    `json:"duration" validate:"required,min=1"`
+}
+
+func (rg *RoleGrant) IsPending() bool {
+	return rg.Status == RoleGrantStatusPending
+}
+
+func (rg *RoleGrant) IsActive(now time.Time) bool {
+	return rg.Status == RoleGrantStatusActive && rg.ExpiresAt != nil && rg.ExpiresAt.After(now)
+}
+
+// Activate starts the grant clock. Duration is in minutes and counts from the
+// approval, not from the request.
+func (rg *RoleGrant) Activate(reviewedBy *uuid.UUID, now time.Time) {
+	expiresAt := now.Add(time.Duration(rg.Duration) * time.Minute)
+	rg.Status = RoleGrantStatusActive
+	rg.ReviewedBy = reviewedBy
+	rg.ReviewedAt = &now
+	rg.ExpiresAt = &expiresAt
+}
+
+func IsRoleGrantStatusExist(status string) bool {
+	switch status {
+	case RoleGrantStatusPending, RoleGrantStatusActive, RoleGrantStatusRejected, RoleGrantStatusRevoked, RoleGrantStatusExpired:
+		return true
+	}
+	return false
+}
+
+func MapRequestRoleGrantRequestToRoleGrant(request *RequestRoleGrantRequest, userID uuid.UUID) *RoleGrant {
+	return &RoleGrant{
+		GrantID:       uuid.New(),
+		UserID:        userID,
+		Role:          request.Role,
+		Justification: request.Justification,
+		Duration:      request.Duration,
+		Status:        RoleGrantStatusPending,
+		CreatedAt:     time.Now(),
+	}
+}
+
+type RoleGrantPolicy struct {
+	MaxDuration            int
+	AutoApproveRoles       []string
+	AutoApproveMaxDuration int
+}
+
+func (p *RoleGrantPolicy) IsDurationAllowed(roleGrant *RoleGrant) bool {
+	return roleGrant.Duration <= p.MaxDuration
+}
+
+func (p *RoleGrantPolicy) CanAutoApprove(roleGrant *RoleGrant) bool {
+	if roleGrant.Duration > p.AutoApproveMaxDuration {
+		return false
+	}
+	for _, role := range p.AutoApproveRoles {
+		if role == roleGrant.Role {
+			return true
+		}
+	}
+	return false
+}
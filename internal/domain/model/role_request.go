@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleRequestStatusPending  = "pending"
+	RoleRequestStatusApproved = "approved"
+	RoleRequestStatusRejected = "rejected"
+)
+
+type RoleRequest struct {
+	RequestID    uuid.UUID  `json:"request_id" db:"request_id"`
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	Role         string     `json:"role" db:"role"`
+	PreviousRole string     `json:"previous_role" db:"previous_role"`
+	Reason       string     `json:"reason,omitempty" db:"reason"`
+	Status       string     `json:"status" db:"status"`
+	RequestedBy  uuid.UUID  `json:"requested_by" db:"requested_by"`
+	ReviewedBy   *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewReason string     `json:"review_reason,omitempty" db:"review_reason"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+}
+
+type RoleHistory struct {
+	ID           int64      `json:"id" db:"id"`
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	PreviousRole string     `json:"previous_role" db:"previous_role"`
+	Role         string     `json:"role" db:"role"`
+	ChangedBy    uuid.UUID  `json:"changed_by" db:"changed_by"`
+	RequestID    *uuid.UUID `json:"request_id,omitempty" db:"request_id"`
+	Reason       string     `json:"reason,omitempty" db:"reason"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+type AssignRoleRequest struct {
+	Role   string `json:"user_role" validate:"required"`
+	Reason string `json:"reason" validate:"omitempty,max=1000"`
+}
+
+type ReviewRoleRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=1000"`
+}
+
+type AssignRoleResponse struct {
+	User        *UpdateUserResponse `json:"user,omitempty"`
+	RoleRequest *RoleRequest        `json:"role_request,omitempty"`
+}
+
+func (rr *RoleRequest) IsPending() bool {
+	return rr.Status == RoleRequestStatusPending
+}
+
+func (rr *RoleRequest) MapRoleRequestToRoleHistory(changedBy uuid.UUID) *RoleHistory {
+	return &RoleHistory{
+		UserID:       rr.UserID,
+		PreviousRole: rr.PreviousRole,
+		Role:         rr.Role,
+		ChangedBy:    changedBy,
+		RequestID:    &rr.RequestID,
+		Reason:       rr.Reason,
+		CreatedAt:    time.Now(),
+	}
+}
+
+func IsRoleRequestStatusExist(status string) bool {
+	switch status {
+	case RoleRequestStatusPending, RoleRequestStatusApproved, RoleRequestStatusRejected:
+		return true
+	}
+	return false
+}
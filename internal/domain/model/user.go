@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"usermanager/internal/apperrors"
+	"usermanager/internal/utils"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -26,10 +27,24 @@ type User struct {
 	IsPublic  bool      `json:"is_public,omitempty" db:"is_public" validate:"omitempty"`
 	Role      string    `json:"user_role" db:"user_role" validate:"required"`
 	Created
-	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
-	LoginDate *time.Time `json:"login_date,omitempty" db:"login_date"`
-	Votes     []*Vote    `json:"votes,omitempty" db:"votes"`
+	UpdatedAt  *time.Time     `json:"updated_at,omitempty" db:"updated_at"`
+	DeletedAt  *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
+	LoginDate  *time.Time     `json:"login_date,omitempty" db:"login_date"`
+	Votes      []*Vote        `json:"votes,omitempty" db:"votes"`
+	Version    int64          `json:"version,omitempty" db:"version"`
+	Attributes UserAttributes `json:"attributes,omitempty" db:"attributes"`
+	Avatar     *UserAvatar    `json:"avatar,omitempty" db:"avatar"`
+	ParentID   *uuid.UUID     `json:"parent_id,omitempty" db:"parent_id"`
+	UserStatus
+	isCard bool
+}
+
+// UserStatus tells whether the user is suspended or banned, why, by whom and until when.
+type UserStatus struct {
+	Status       string     `json:"status,omitempty" db:"status"`
+	StatusReason string     `json:"status_reason,omitempty" db:"status_reason"`
+	StatusBy     *uuid.UUID `json:"status_by,omitempty" db:"status_by"`
+	StatusUntil  *time.Time `json:"status_until,omitempty" db:"status_until"`
 }
 
 type Created struct {
@@ -38,9 +53,15 @@ type Created struct {
 }
 
 type Users struct {
-	Page    int     `json:"page"`
-	HasMore bool    `json:"has_more"`
-	Users   []*User `json:"users"`
+	Page           int     `json:"page"`
+	HasMore        bool    `json:"has_more"`
+	NextCursor     string  `json:"next_cursor,omitempty"`
+	PrevCursor     string  `json:"prev_cursor,omitempty"`
+	Size           int     `json:"size,omitempty"`
+	Total          *int    `json:"total,omitempty"`
+	TotalPages     *int    `json:"total_pages,omitempty"`
+	TotalEstimated bool    `json:"total_estimated,omitempty"`
+	Users          []*User `json:"users"`
 }
 
 func (u *User) HashPassword() error {
@@ -69,6 +90,7 @@ func (u *User) MapCreateUserRequestToUserModel(req *CreateUserRequest) {
 	u.Password = req.Password
 	u.IsPublic = req.IsPublic
 	u.Role = req.Role
+	u.Attributes = req.Attributes
 }
 
 func (u *User) MapUserModelToCreateUserResponse() *CreateUserResponse {
@@ -80,6 +102,7 @@ func (u *User) MapUserModelToCreateUserResponse() *CreateUserResponse {
 	createUserResponse.Email = u.Email
 	createUserResponse.IsPublic = u.IsPublic
 	createUserResponse.Role = u.Role
+	createUserResponse.Attributes = u.Attributes
 
 	return createUserResponse
 }
@@ -92,6 +115,12 @@ func (u *User) MapUpdateUserRequestToUserModel(req *UpdateUserRequest) {
 	u.Password = req.Password
 	u.IsPublic = req.IsPublic
 	u.Role = req.Role
+	u.Attributes = req.Attributes
+}
+
+// ETag is the ETag of the full profile, the view writes are made against.
+func (u *User) ETag() string {
+	return utils.FormatVariantETag(u.Version, ProfileViewFull)
 }
 
 func (u *User) MapUserModelToUpdateUserResponse() *UpdateUserResponse {
@@ -103,6 +132,11 @@ func (u *User) MapUserModelToUpdateUserResponse() *UpdateUserResponse {
 	updateUserResponse.Email = u.Email
 	updateUserResponse.IsPublic = u.IsPublic
 	updateUserResponse.Role = u.Role
+	updateUserResponse.Attributes = u.Attributes
+	updateUserResponse.AvatarURL = u.AvatarURL()
+	updateUserResponse.AvatarThumbnails = u.AvatarThumbnails()
+	updateUserResponse.Status = u.inactiveStatus()
+	updateUserResponse.ParentID = u.ParentID
 
 	return updateUserResponse
 }
@@ -111,10 +145,19 @@ func (u *User) MapUserModelToGetUserResponse() *GetUserResponse {
 	GetUserResponse := &GetUserResponse{}
 	GetUserResponse.UserID = u.UserID
 	GetUserResponse.Nickname = u.Nickname
+	if u.IsCard() {
+		return GetUserResponse
+	}
 	GetUserResponse.FirstName = u.FirstName
 	GetUserResponse.LastName = u.LastName
+	GetUserResponse.Email = u.Email
 	GetUserResponse.IsPublic = u.IsPublic
 	GetUserResponse.Role = u.Role
+	GetUserResponse.Attributes = u.Attributes
+	GetUserResponse.AvatarURL = u.AvatarURL()
+	GetUserResponse.AvatarThumbnails = u.AvatarThumbnails()
+	GetUserResponse.Status = u.inactiveStatus()
+	GetUserResponse.ParentID = u.ParentID
 	if len(u.Votes) > 0 {
 		rate := 0
 		for _, vote := range u.Votes {
@@ -127,10 +170,27 @@ func (u *User) MapUserModelToGetUserResponse() *GetUserResponse {
 	return GetUserResponse
 }
 
+func (us *Users) SetTotal(total int, size int, estimated bool) {
+	totalPages := 0
+	if size > 0 {
+		totalPages = utils.GetTotalPages(total, size)
+	}
+	us.Size = size
+	us.Total = &total
+	us.TotalPages = &totalPages
+	us.TotalEstimated = estimated
+}
+
 func (us *Users) MapUserModelToGetUserResponse() *GetUsersResponse {
 	getUsersResponse := &GetUsersResponse{}
 	getUsersResponse.HasMore = us.HasMore
 	getUsersResponse.Page = us.Page
+	getUsersResponse.NextCursor = us.NextCursor
+	getUsersResponse.PrevCursor = us.PrevCursor
+	getUsersResponse.Size = us.Size
+	getUsersResponse.Total = us.Total
+	getUsersResponse.TotalPages = us.TotalPages
+	getUsersResponse.TotalEstimated = us.TotalEstimated
 	usersResponse := make([]*GetUserResponse, 0, len(us.Users))
 	for _, userValue := range us.Users {
 		usersResponse = append(usersResponse, userValue.MapUserModelToGetUserResponse())
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"usermanager/internal/utils"
+)
+
+// UserAttributes holds the custom profile fields stored in the attributes JSONB column.
+type UserAttributes map[string]any
+
+// Value stores nil as NULL, which the queries read as "keep the stored attributes".
+func (a UserAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	attributes, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(attributes), nil
+}
+
+func (a *UserAttributes) Scan(src any) error {
+	var data []byte
+	switch typed := src.(type) {
+	case nil:
+		*a = UserAttributes{}
+		return nil
+	case []byte:
+		data = typed
+	case string:
+		data = []byte(typed)
+	default:
+		return fmt.Errorf("can't scan %T into user attributes", src)
+	}
+	return json.Unmarshal(data, a)
+}
+
+// UserAttributeSchema is the JSON Schema the attributes of every user must match. Each change
+// adds a new row, the newest one is in force.
+type UserAttributeSchema struct {
+	SchemaID  int64           `json:"schema_id" db:"schema_id"`
+	Schema    json.RawMessage `json:"schema" db:"schema"`
+	CreatedBy string          `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+func (s *UserAttributeSchema) Compile() (*utils.JSONSchema, error) {
+	schema, err := utils.CompileJSONSchema(s.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if !schema.IsObject() {
+		return nil, fmt.Errorf("%w: attributes schema must have \"type\": \"object\"", utils.ErrJSONSchemaInvalid)
+	}
+
+	return schema, nil
+}
+
+func (s *UserAttributeSchema) ValidateAttributes(attributes UserAttributes) error {
+	schema, err := s.Compile()
+	if err != nil {
+		return err
+	}
+	if attributes == nil {
+		attributes = UserAttributes{}
+	}
+
+	return schema.Validate(map[string]any(attributes))
+}
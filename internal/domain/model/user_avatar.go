@@ -0,0 +1,57 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// UserAvatar is stored in the avatar JSONB column. Keys lists the blobs of the original and
+// every thumbnail, so all of them can be removed when the avatar changes.
+type UserAvatar struct {
+	URL        string            `json:"url"`
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+	Keys       []string          `json:"keys,omitempty"`
+}
+
+type UserAvatarPolicy struct {
+	MaxPixels int
+	MaxSide   int
+	Sizes     []int
+}
+
+// Value has a value receiver so that a nil *UserAvatar is stored as NULL.
+func (a UserAvatar) Value() (driver.Value, error) {
+	avatar, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(avatar), nil
+}
+
+func (a *UserAvatar) Scan(src any) error {
+	var data []byte
+	switch typed := src.(type) {
+	case []byte:
+		data = typed
+	case string:
+		data = []byte(typed)
+	default:
+		return fmt.Errorf("can't scan %T into user avatar", src)
+	}
+	return json.Unmarshal(data, a)
+}
+
+func (u *User) AvatarURL() string {
+	if u.Avatar == nil {
+		return ""
+	}
+	return u.Avatar.URL
+}
+
+func (u *User) AvatarThumbnails() map[string]string {
+	if u.Avatar == nil {
+		return nil
+	}
+	return u.Avatar.Thumbnails
+}
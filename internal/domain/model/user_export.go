@@ -0,0 +1,292 @@
+package model
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"usermanager/internal/utils"
+)
+
+const (
+	UserExportFormatCSV     = "csv"
+	UserExportFormatNDJSON  = "ndjson"
+	UserExportFormatParquet = "parquet"
+
+	UserExportFieldVotes = "votes"
+
+	userExportParquetRowGroupSize = 10000
+)
+
+var (
+	ErrUserExportFormat = errors.New("unknown export format")
+	ErrUserExportField  = errors.New("unknown export field")
+)
+
+type userExportField struct {
+	name        string
+	parquetType utils.ParquetType
+	value       func(user *User) any
+}
+
+// userExportFields lists what can be exported, in the default order. The password hash is
+// left out on purpose and must never be added here.
+var userExportFields = []userExportField{
+	{"user_id", utils.ParquetString, func(user *User) any { return user.UserID.String() }},
+	{"nickname", utils.ParquetString, func(user *User) any { return user.Nickname }},
+	{"first_name", utils.ParquetString, func(user *User) any { return user.FirstName }},
+	{"last_name", utils.ParquetString, func(user *User) any { return user.LastName }},
+	{"email", utils.ParquetString, func(user *User) any { return user.Email }},
+	{"is_public", utils.ParquetBool, func(user *User) any { return user.IsPublic }},
+	{"user_role", utils.ParquetString, func(user *User) any { return user.Role }},
+	{"created_by", utils.ParquetString, func(user *User) any { return user.Created.By }},
+	{"created_at", utils.ParquetTimestamp, func(user *User) any { return user.Created.At }},
+	{"updated_at", utils.ParquetTimestamp, func(user *User) any { return timeOrNil(user.UpdatedAt) }},
+	{"login_date", utils.ParquetTimestamp, func(user *User) any { return timeOrNil(user.LoginDate) }},
+	{"version", utils.ParquetInt64, func(user *User) any { return user.Version }},
+	{"attributes", utils.ParquetString, func(user *User) any { return user.Attributes }},
+	{UserExportFieldVotes, utils.ParquetString, func(user *User) any { return user.Votes }},
+}
+
+type UserExportQuery struct {
+	Format string
+	Fields []string
+	Filter *utils.UserFilter
+}
+
+// NewUserExportQuery checks the format and the comma separated fields. Without fields every
+// user field is exported; votes are only exported when asked for.
+func NewUserExportQuery(format string, fields string, filter *utils.UserFilter) (*UserExportQuery, error) {
+	format, err := UserExportFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &UserExportQuery{Format: format, Fields: []string{}, Filter: filter}
+	if strings.TrimSpace(fields) == "" {
+		for _, field := range userExportFields {
+			if field.name != UserExportFieldVotes {
+				query.Fields = append(query.Fields, field.name)
+			}
+		}
+		return query, nil
+	}
+
+	seen := map[string]bool{}
+	for _, name := range strings.Split(fields, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := findUserExportField(name); !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUserExportField, name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		query.Fields = append(query.Fields, name)
+	}
+
+	return query, nil
+}
+
+func (q *UserExportQuery) WithVotes() bool {
+	for _, field := range q.Fields {
+		if field == UserExportFieldVotes {
+			return true
+		}
+	}
+	return false
+}
+
+// UserExportFormat picks the export format from an explicit name or a file name.
+func UserExportFormat(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == UserExportFormatCSV, strings.HasSuffix(value, ".csv"):
+		return UserExportFormatCSV, nil
+	case value == UserExportFormatNDJSON, strings.HasSuffix(value, ".ndjson"), strings.HasSuffix(value, ".jsonl"):
+		return UserExportFormatNDJSON, nil
+	case value == UserExportFormatParquet, strings.HasSuffix(value, ".parquet"):
+		return UserExportFormatParquet, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUserExportFormat, value)
+	}
+}
+
+func UserExportContentType(format string) string {
+	switch format {
+	case UserExportFormatCSV:
+		return "text/csv"
+	case UserExportFormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+// UserExportWriter writes exported users one by one. Close must be called to flush
+// what is buffered and, for Parquet, to write the footer.
+type UserExportWriter interface {
+	Write(user *User) error
+	Close() error
+}
+
+func NewUserExportWriter(w io.Writer, query *UserExportQuery) UserExportWriter {
+	fields := make([]userExportField, 0, len(query.Fields))
+	for _, name := range query.Fields {
+		field, _ := findUserExportField(name)
+		fields = append(fields, field)
+	}
+
+	switch query.Format {
+	case UserExportFormatCSV:
+		return &csvUserExportWriter{writer: csv.NewWriter(w), fields: fields}
+	case UserExportFormatNDJSON:
+		return &ndjsonUserExportWriter{writer: bufio.NewWriter(w), fields: fields}
+	default:
+		columns := make([]utils.ParquetColumn, 0, len(fields))
+		for _, field := range fields {
+			columns = append(columns, utils.ParquetColumn{Name: field.name, Type: field.parquetType})
+		}
+		return &parquetUserExportWriter{writer: utils.NewParquetWriter(w, columns, userExportParquetRowGroupSize), fields: fields}
+	}
+}
+
+type csvUserExportWriter struct {
+	writer        *csv.Writer
+	fields        []userExportField
+	headerWritten bool
+}
+
+func (cw *csvUserExportWriter) Write(user *User) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, 0, len(cw.fields))
+	for _, field := range cw.fields {
+		value, err := userExportText(field.value(user))
+		if err != nil {
+			return err
+		}
+		record = append(record, value)
+	}
+
+	return cw.writer.Write(record)
+}
+
+func (cw *csvUserExportWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+func (cw *csvUserExportWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+
+	header := make([]string, 0, len(cw.fields))
+	for _, field := range cw.fields {
+		header = append(header, field.name)
+	}
+	return cw.writer.Write(header)
+}
+
+type ndjsonUserExportWriter struct {
+	writer *bufio.Writer
+	fields []userExportField
+}
+
+// Write keeps the fields in the requested order, which a map would lose.
+func (nw *ndjsonUserExportWriter) Write(user *User) error {
+	nw.writer.WriteByte('{')
+	for i, field := range nw.fields {
+		if i > 0 {
+			nw.writer.WriteByte(',')
+		}
+		value, err := json.Marshal(field.value(user))
+		if err != nil {
+			return err
+		}
+		nw.writer.WriteString(strconv.Quote(field.name))
+		nw.writer.WriteByte(':')
+		nw.writer.Write(value)
+	}
+	nw.writer.WriteString("}\n")
+
+	return nil
+}
+
+func (nw *ndjsonUserExportWriter) Close() error {
+	return nw.writer.Flush()
+}
+
+type parquetUserExportWriter struct {
+	writer *utils.ParquetWriter
+	fields []userExportField
+}
+
+func (pw *parquetUserExportWriter) Write(user *User) error {
+	values := make([]any, 0, len(pw.fields))
+	for _, field := range pw.fields {
+		value := field.value(user)
+		if field.parquetType == utils.ParquetString {
+			text, err := userExportText(value)
+			if err != nil {
+				return err
+			}
+			value = text
+		}
+		values = append(values, value)
+	}
+
+	return pw.writer.WriteRow(values)
+}
+
+func (pw *parquetUserExportWriter) Close() error {
+	return pw.writer.Close()
+}
+
+func findUserExportField(name string) (userExportField, bool) {
+	for _, field := range userExportFields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return userExportField{}, false
+}
+
+// userExportText formats a value for the text based columns; votes become a JSON array.
+func userExportText(value any) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano), nil
+	default:
+		text, err := json.Marshal(typed)
+		return string(text), err
+	}
+}
+
+func timeOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
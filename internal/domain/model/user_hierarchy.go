@@ -0,0 +1,61 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	HierarchyPolicyBlock   = "block"
+	HierarchyPolicyCascade = "cascade"
+)
+
+// UserHierarchyPolicy tells what happens to the child accounts when their parent is deleted or
+// suspended: the parent is refused while it owns children, or the children follow it.
+type UserHierarchyPolicy struct {
+	MaxDepth  int
+	OnDelete  string
+	OnSuspend string
+}
+
+// UserTreeNode is a user met while walking the hierarchy, depth levels away from where the
+// walk started.
+type UserTreeNode struct {
+	User
+	Depth int `json:"depth" db:"depth"`
+}
+
+// SetUserParentRequest transfers the user to another parent, or detaches it without parent_id.
+type SetUserParentRequest struct {
+	ParentID *uuid.UUID `json:"parent_id" validate:"omitempty"`
+}
+
+type UserTreeNodeResponse struct {
+	*GetUserResponse
+	Depth int `json:"depth"`
+}
+
+type UserTreeResponse struct {
+	UserID uuid.UUID               `json:"user_id"`
+	Nodes  []*UserTreeNodeResponse `json:"nodes"`
+}
+
+// VisibleTo returns the node with the part of the user the viewer is allowed to see, or nil
+// like User.VisibleTo.
+func (n *UserTreeNode) VisibleTo(viewer *User, privateProfileMode string) *UserTreeNode {
+	visibleUser := n.User.VisibleTo(viewer, privateProfileMode)
+	if visibleUser == nil {
+		return nil
+	}
+	return &UserTreeNode{User: *visibleUser, Depth: n.Depth}
+}
+
+func MapUserTreeToUserTreeResponse(userID uuid.UUID, nodes []*UserTreeNode) *UserTreeResponse {
+	userTreeResponse := &UserTreeResponse{UserID: userID, Nodes: make([]*UserTreeNodeResponse, 0, len(nodes))}
+	for _, node := range nodes {
+		userTreeResponse.Nodes = append(userTreeResponse.Nodes, &UserTreeNodeResponse{
+			GetUserResponse: node.MapUserModelToGetUserResponse(),
+			Depth:           node.Depth,
+		})
+	}
+	return userTreeResponse
+}
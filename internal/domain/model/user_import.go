@@ -0,0 +1,273 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserImportFormatCSV    = "csv"
+	UserImportFormatNDJSON = "ndjson"
+
+	UserImportStatusQueued    = "queued"
+	UserImportStatusRunning   = "running"
+	UserImportStatusCompleted = "completed"
+	UserImportStatusFailed    = "failed"
+
+	UserImportRowCreated = "created"
+	UserImportRowUpdated = "updated"
+	UserImportRowFailed  = "failed"
+)
+
+var (
+	ErrUserImportFormat  = errors.New("unknown import format")
+	ErrUserImportHeader  = errors.New("csv header must contain nickname, first_name and last_name")
+	ErrUserImportTooMany = errors.New("too many rows")
+)
+
+var userImportRequiredColumns = []string{"nickname", "first_name", "last_name"}
+
+type UserImportOptions struct {
+	DryRun            bool `json:"dry_run"`
+	Upsert            bool `json:"upsert"`
+	GeneratePasswords bool `json:"generate_passwords"`
+}
+
+type UserImportPolicy struct {
+	MaxRows        int
+	PasswordLength int
+}
+
+type UserImportJob struct {
+	JobID     uuid.UUID         `json:"job_id"`
+	Status    string            `json:"status"`
+	Format    string            `json:"format"`
+	Options   UserImportOptions `json:"options"`
+	CreatedBy string            `json:"created_by,omitempty"`
+	// CreatedByNickname and Request keep the audit context the job was queued in; the rows are
+	// imported later by the worker, on behalf of whoever queued them.
+	CreatedByNickname string                 `json:"created_by_nickname,omitempty"`
+	Request           *AuditRequest          `json:"request,omitempty"`
+	Total             int                    `json:"total"`
+	Processed         int                    `json:"processed"`
+	Succeeded         int                    `json:"succeeded"`
+	Failed            int                    `json:"failed"`
+	Error             string                 `json:"error,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+	StartedAt         *time.Time             `json:"started_at,omitempty"`
+	FinishedAt        *time.Time             `json:"finished_at,omitempty"`
+	Report            []*UserImportRowResult `json:"report,omitempty"`
+}
+
+type UserImportRow struct {
+	Line       int    `json:"-"`
+	Nickname   string `json:"nickname"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	IsPublic   bool   `json:"is_public"`
+	ParseError string `json:"-"`
+}
+
+type UserImportRowResult struct {
+	Line     int        `json:"line"`
+	Nickname string     `json:"nickname,omitempty"`
+	Status   string     `json:"status"`
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Password string     `json:"password,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+func NewUserImportJob(format string, options UserImportOptions, createdBy string) *UserImportJob {
+	return &UserImportJob{
+		JobID:     uuid.New(),
+		Status:    UserImportStatusQueued,
+		Format:    format,
+		Options:   options,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		Report:    []*UserImportRowResult{},
+	}
+}
+
+// KeepAuditContext copies the audit actor and request of ctx into the job.
+func (j *UserImportJob) KeepAuditContext(ctx context.Context) {
+	if actor := AuditActorFromContext(ctx); actor != nil {
+		j.CreatedByNickname = actor.Nickname
+	}
+	j.Request = AuditRequestFromContext(ctx)
+}
+
+// AuditContext puts the audit context the job was queued in back into ctx.
+func (j *UserImportJob) AuditContext(ctx context.Context) context.Context {
+	createdByID, err := uuid.Parse(j.CreatedBy)
+	if err == nil {
+		ctx = ContextWithAuditActor(ctx, &User{UserID: createdByID, Nickname: j.CreatedByNickname})
+	}
+	if j.Request != nil {
+		ctx = ContextWithAuditRequest(ctx, j.Request)
+	}
+	return ctx
+}
+
+func (j *UserImportJob) IsFinished() bool {
+	return j.Status == UserImportStatusCompleted || j.Status == UserImportStatusFailed
+}
+
+func (j *UserImportJob) AddResult(result *UserImportRowResult) {
+	j.Processed++
+	if result.Status == UserImportRowFailed {
+		j.Failed++
+	} else {
+		j.Succeeded++
+	}
+	j.Report = append(j.Report, result)
+}
+
+// UserImportFormat picks the import format from an explicit name, a content type or a file name.
+func UserImportFormat(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == UserImportFormatCSV, value == "text/csv", strings.HasSuffix(value, ".csv"):
+		return UserImportFormatCSV, nil
+	case value == UserImportFormatNDJSON, value == "application/x-ndjson", value == "application/ndjson", strings.HasSuffix(value, ".ndjson"), strings.HasSuffix(value, ".jsonl"):
+		return UserImportFormatNDJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUserImportFormat, value)
+	}
+}
+
+// ParseUserImportRows reads every row of the payload. A row that can't be decoded is kept with
+// ParseError set so it shows up in the report; only an unreadable file fails as a whole.
+func ParseUserImportRows(format string, payload []byte, maxRows int) ([]*UserImportRow, error) {
+	var rows []*UserImportRow
+	var err error
+	switch format {
+	case UserImportFormatCSV:
+		rows, err = parseUserImportCSV(payload)
+	case UserImportFormatNDJSON:
+		rows, err = parseUserImportNDJSON(payload)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUserImportFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if maxRows > 0 && len(rows) > maxRows {
+		return nil, fmt.Errorf("%w: %d rows, at most %d allowed", ErrUserImportTooMany, len(rows), maxRows)
+	}
+
+	return rows, nil
+}
+
+func parseUserImportCSV(payload []byte) ([]*UserImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(payload))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserImportHeader, err)
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	for _, column := range userImportRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, ErrUserImportHeader
+		}
+	}
+
+	rows := []*UserImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, &UserImportRow{Line: parseErr.StartLine, ParseError: parseErr.Err.Error()})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		row := &UserImportRow{Line: line}
+		rows = append(rows, row)
+		if len(record) != len(header) {
+			row.ParseError = fmt.Sprintf("expected %d fields, got %d", len(header), len(record))
+			continue
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row.Nickname = value("nickname")
+		row.FirstName = value("first_name")
+		row.LastName = value("last_name")
+		row.Email = value("email")
+		row.Password = value("password")
+		if isPublic := value("is_public"); isPublic != "" {
+			row.IsPublic, err = strconv.ParseBool(isPublic)
+			if err != nil {
+				row.ParseError = fmt.Sprintf("is_public: %v", err)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func parseUserImportNDJSON(payload []byte) ([]*UserImportRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(payload)+1)
+
+	rows := []*UserImportRow{}
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		row := &UserImportRow{Line: line}
+		rows = append(rows, row)
+
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(row); err != nil {
+			*row = UserImportRow{Line: line, ParseError: err.Error()}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *UserImportRow) MapUserImportRowToUserModel() *User {
+	return &User{
+		Nickname:  r.Nickname,
+		FirstName: r.FirstName,
+		LastName:  r.LastName,
+		Email:     r.Email,
+		Password:  r.Password,
+		IsPublic:  r.IsPublic,
+	}
+}
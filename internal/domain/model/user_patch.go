@@ -0,0 +1,109 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"usermanager/internal/utils"
+)
+
+// PatchUserDocument is the JSON document a PATCH request is applied to.
+// The password hash is never exposed, a patch can only set a new password. The role isn't
+// part of it either, roles only change through PUT /user/:id/role.
+type PatchUserDocument struct {
+	Nickname   string         `json:"nickname" validate:"required"`
+	FirstName  string         `json:"first_name" validate:"required"`
+	LastName   string         `json:"last_name" validate:"required"`
+	Email      string         `json:"email" validate:"email"`
+	Password   string         `json:"password,omitempty" validate:"omitempty,gte=6"`
+	IsPublic   bool           `json:"is_public"`
+	Attributes UserAttributes `json:"attributes"`
+}
+
+// PatchUserColumns are the columns the patch document maps to.
+var PatchUserColumns = map[string]bool{
+	"nickname":   true,
+	"first_name": true,
+	"last_name":  true,
+	"email":      true,
+	"password":   true,
+	"is_public":  true,
+	"attributes": true,
+}
+
+func (u *User) MapUserModelToPatchUserDocument() *PatchUserDocument {
+	return &PatchUserDocument{
+		Nickname:   u.Nickname,
+		FirstName:  u.FirstName,
+		LastName:   u.LastName,
+		Email:      u.Email,
+		IsPublic:   u.IsPublic,
+		Attributes: u.Attributes,
+	}
+}
+
+func IsPatchContentType(contentType string) bool {
+	return contentType == utils.ContentTypeMergePatch || contentType == utils.ContentTypeJSONPatch
+}
+
+func (d *PatchUserDocument) ApplyPatch(contentType string, patch []byte) (*PatchUserDocument, error) {
+	document, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch contentType {
+	case utils.ContentTypeMergePatch:
+		patched, err = utils.MergePatch(document, patch)
+	case utils.ContentTypeJSONPatch:
+		patched, err = utils.ApplyJSONPatch(document, patch)
+	default:
+		err = fmt.Errorf("unsupported content type %q", contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patchedDocument := &PatchUserDocument{}
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(patchedDocument); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrJSONPatchInvalid, err)
+	}
+
+	return patchedDocument, nil
+}
+
+// Changes returns the columns that differ from the original document.
+func (d *PatchUserDocument) Changes(original *PatchUserDocument) map[string]any {
+	changes := make(map[string]any)
+	if d.Nickname != original.Nickname {
+		changes["nickname"] = d.Nickname
+	}
+	if d.FirstName != original.FirstName {
+		changes["first_name"] = d.FirstName
+	}
+	if d.LastName != original.LastName {
+		changes["last_name"] = d.LastName
+	}
+	if d.Email != original.Email {
+		changes["email"] = d.Email
+	}
+	if d.Password != "" {
+		changes["password"] = d.Password
+	}
+	if d.IsPublic != original.IsPublic {
+		changes["is_public"] = d.IsPublic
+	}
+	if !reflect.DeepEqual(d.Attributes, original.Attributes) {
+		changes["attributes"] = d.Attributes
+		if d.Attributes == nil {
+			changes["attributes"] = UserAttributes{}
+		}
+	}
+
+	return changes
+}
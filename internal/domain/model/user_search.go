@@ -0,0 +1,35 @@
+package model
+
+import (
+	"strings"
+
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+const SearchQueryMinLength = 2
+
+type SearchUsersQuery struct {
+	Query           string
+	ViewerID        uuid.UUID
+	IncludePrivate  bool
+	PaginationQuery *utils.PaginationQuery
+}
+
+func NewSearchUsersQuery(viewer *User, query string, paginationQuery *utils.PaginationQuery) *SearchUsersQuery {
+	searchQuery := &SearchUsersQuery{
+		Query:           strings.TrimSpace(query),
+		PaginationQuery: paginationQuery,
+	}
+	if viewer != nil {
+		searchQuery.ViewerID = viewer.UserID
+		searchQuery.IncludePrivate = viewer.IsAdmin()
+	}
+
+	return searchQuery
+}
+
+func (sq *SearchUsersQuery) IsValid() bool {
+	return len([]rune(sq.Query)) >= SearchQueryMinLength
+}
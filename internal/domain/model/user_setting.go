@@ -0,0 +1,189 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+	// the release image has no zoneinfo, timezone settings are checked against the embedded one
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SettingTypeString = "string"
+	SettingTypeBool   = "bool"
+	SettingTypeInt    = "int"
+
+	SettingLanguage            = "language"
+	SettingTimezone            = "timezone"
+	SettingTheme               = "theme"
+	SettingItemsPerPage        = "items_per_page"
+	SettingNotificationsEmail  = "notifications.email"
+	SettingNotificationsPush   = "notifications.push"
+	SettingNotificationsDigest = "notifications.digest"
+	SettingFollowsApproval     = "follows.approval"
+)
+
+var ErrUserSettingInvalid = errors.New("invalid user setting")
+
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// SettingDefinition is a setting known to the server. Users only store the settings they
+// changed, every other one reads as its default.
+type SettingDefinition struct {
+	Key     string   `json:"key"`
+	Type    string   `json:"type"`
+	Default any      `json:"default"`
+	Options []string `json:"options,omitempty"`
+	Min     int      `json:"min,omitempty"`
+	Max     int      `json:"max,omitempty"`
+	check   func(value string) error
+}
+
+var UserSettingDefinitions = map[string]*SettingDefinition{
+	SettingLanguage:            {Key: SettingLanguage, Type: SettingTypeString, Default: "en", check: checkLanguageTag},
+	SettingTimezone:            {Key: SettingTimezone, Type: SettingTypeString, Default: "UTC", check: checkTimezone},
+	SettingTheme:               {Key: SettingTheme, Type: SettingTypeString, Default: "system", Options: []string{"system", "light", "dark"}},
+	SettingItemsPerPage:        {Key: SettingItemsPerPage, Type: SettingTypeInt, Default: 20, Min: 5, Max: 100},
+	SettingNotificationsEmail:  {Key: SettingNotificationsEmail, Type: SettingTypeBool, Default: true},
+	SettingNotificationsPush:   {Key: SettingNotificationsPush, Type: SettingTypeBool, Default: true},
+	SettingNotificationsDigest: {Key: SettingNotificationsDigest, Type: SettingTypeString, Default: "weekly", Options: []string{"never", "daily", "weekly"}},
+	// follows of a private profile wait for approval unless the user turns it off
+	SettingFollowsApproval: {Key: SettingFollowsApproval, Type: SettingTypeBool, Default: true},
+}
+
+func checkLanguageTag(value string) error {
+	if !languageTagPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a language tag", value)
+	}
+	return nil
+}
+
+func checkTimezone(value string) error {
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		return fmt.Errorf("%q is not a time zone", value)
+	}
+	return nil
+}
+
+// Parse checks a value decoded from JSON and returns it with the type of the setting, JSON
+// numbers become ints.
+func (d *SettingDefinition) Parse(value any) (any, error) {
+	switch d.Type {
+	case SettingTypeBool:
+		if typed, ok := value.(bool); ok {
+			return typed, nil
+		}
+	case SettingTypeInt:
+		var number float64
+		switch typed := value.(type) {
+		case int:
+			number = float64(typed)
+		case float64:
+			number = typed
+		default:
+			return nil, fmt.Errorf("%w: %s must be an integer", ErrUserSettingInvalid, d.Key)
+		}
+		if number != math.Trunc(number) || number < float64(d.Min) || number > float64(d.Max) {
+			return nil, fmt.Errorf("%w: %s must be an integer from %d to %d", ErrUserSettingInvalid, d.Key, d.Min, d.Max)
+		}
+		return int(number), nil
+	case SettingTypeString:
+		typed, ok := value.(string)
+		if !ok {
+			break
+		}
+		if len(d.Options) > 0 && !containsString(d.Options, typed) {
+			return nil, fmt.Errorf("%w: %s must be one of %v", ErrUserSettingInvalid, d.Key, d.Options)
+		}
+		if d.check != nil {
+			if err := d.check(typed); err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrUserSettingInvalid, d.Key, err)
+			}
+		}
+		return typed, nil
+	}
+	return nil, fmt.Errorf("%w: %s must be a %s", ErrUserSettingInvalid, d.Key, d.Type)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UserSetting is a setting the user changed from its default.
+type UserSetting struct {
+	UserID    uuid.UUID       `json:"user_id" db:"user_id"`
+	Key       string          `json:"key" db:"key"`
+	Value     json.RawMessage `json:"value" db:"value"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// UserSettings are the settings in force for a user. Overrides lists the keys the user
+// changed, the rest are defaults.
+type UserSettings struct {
+	UserID    uuid.UUID      `json:"user_id"`
+	Settings  map[string]any `json:"settings"`
+	Overrides []string       `json:"overrides"`
+}
+
+// NewUserSettings lays the stored settings over the defaults. A stored value that no longer
+// matches its definition, or whose key was dropped, reads as the default.
+func NewUserSettings(userID uuid.UUID, stored []*UserSetting) *UserSettings {
+	settings := &UserSettings{UserID: userID, Settings: map[string]any{}, Overrides: []string{}}
+	for key, definition := range UserSettingDefinitions {
+		settings.Settings[key] = definition.Default
+	}
+	for _, setting := range stored {
+		definition, ok := UserSettingDefinitions[setting.Key]
+		if !ok {
+			continue
+		}
+		var value any
+		if err := json.Unmarshal(setting.Value, &value); err != nil {
+			continue
+		}
+		value, err := definition.Parse(value)
+		if err != nil {
+			continue
+		}
+		settings.Settings[setting.Key] = value
+		settings.Overrides = append(settings.Overrides, setting.Key)
+	}
+	sort.Strings(settings.Overrides)
+	return settings
+}
+
+// ParseUserSettingChanges validates the settings sent by a client. A null value resets the
+// setting to its default and stays nil in the result.
+func ParseUserSettingChanges(changes map[string]any) (map[string]any, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: no settings given", ErrUserSettingInvalid)
+	}
+	parsed := make(map[string]any, len(changes))
+	for key, value := range changes {
+		definition, ok := UserSettingDefinitions[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown setting %q", ErrUserSettingInvalid, key)
+		}
+		if value == nil {
+			parsed[key] = nil
+			continue
+		}
+		value, err := definition.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
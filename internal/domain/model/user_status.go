@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"usermanager/internal/apperrors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusBanned    = "banned"
+	// UserStatusUnverified is a self registered user that hasn't verified its email yet.
+	UserStatusUnverified = "unverified"
+)
+
+// SuspendUserRequest suspends the user, or bans it with status banned. Without until the
+// suspension lasts until it's lifted.
+type SuspendUserRequest struct {
+	Status string     `json:"status" validate:"omitempty,oneof=suspended banned"`
+	Reason string     `json:"reason" validate:"required,max=500"`
+	Until  *time.Time `json:"until" validate:"omitempty"`
+}
+
+type UserStatusResponse struct {
+	UserID uuid.UUID  `json:"user_id"`
+	Status string     `json:"status"`
+	Reason string     `json:"reason,omitempty"`
+	By     *uuid.UUID `json:"by,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+// StatusAt is the status in force at the given time: a suspension is over once its until
+// has passed, even before the sweeper lifts it.
+func (u *User) StatusAt(now time.Time) string {
+	if u.Status == "" || u.Status == UserStatusActive {
+		return UserStatusActive
+	}
+	if u.StatusUntil != nil && !now.Before(*u.StatusUntil) {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
+// CheckActive rejects a suspended or banned user, telling why and until when, and a user
+// that hasn't verified its email.
+func (u *User) CheckActive(now time.Time) error {
+	status := u.StatusAt(now)
+	if status == UserStatusActive {
+		return nil
+	}
+	if status == UserStatusUnverified {
+		return apperrors.UserCheckActiveUnverified.AppendMessage(u.UserID)
+	}
+
+	message := fmt.Sprintf("user is %s: %s", status, u.StatusReason)
+	if u.StatusUntil != nil {
+		message += fmt.Sprintf(" (until %s)", u.StatusUntil.UTC().Format(time.RFC3339))
+	}
+	if status == UserStatusBanned {
+		return apperrors.UserCheckActiveBanned.AppendMessage(message)
+	}
+	return apperrors.UserCheckActiveSuspended.AppendMessage(message)
+}
+
+func (u *User) MapUserModelToUserStatusResponse(now time.Time) *UserStatusResponse {
+	userStatusResponse := &UserStatusResponse{UserID: u.UserID, Status: u.StatusAt(now)}
+	if userStatusResponse.Status == UserStatusActive {
+		return userStatusResponse
+	}
+	userStatusResponse.Reason = u.StatusReason
+	userStatusResponse.By = u.StatusBy
+	userStatusResponse.Until = u.StatusUntil
+	return userStatusResponse
+}
+
+// inactiveStatus is left out of responses for active users.
+func (u *User) inactiveStatus() string {
+	if status := u.StatusAt(time.Now()); status != UserStatusActive {
+		return status
+	}
+	return ""
+}
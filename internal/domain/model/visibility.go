@@ -0,0 +1,102 @@
+package model
+
+import (
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PrivateProfileModeCard     = "card"
+	PrivateProfileModeNotFound = "not_found"
+)
+
+const (
+	ProfileViewCard   = "card"
+	ProfileViewMasked = "masked"
+	ProfileViewFull   = "full"
+)
+
+func (u *User) IsOwnerOrAdmin(viewer *User) bool {
+	return viewer != nil && (viewer.UserID == u.UserID || viewer.IsAdmin())
+}
+
+// VisibleTo returns the part of the profile the viewer is allowed to see.
+// A nil viewer is an anonymous caller. It returns nil when a private profile
+// has to look as if it doesn't exist.
+func (u *User) VisibleTo(viewer *User, privateProfileMode string) *User {
+	if u.IsOwnerOrAdmin(viewer) {
+		return u
+	}
+	if !u.IsPublic {
+		if privateProfileMode == PrivateProfileModeNotFound {
+			return nil
+		}
+		return &User{UserID: u.UserID, Nickname: u.Nickname, isCard: true}
+	}
+
+	visibleUser := *u
+	visibleUser.Email = utils.MaskEmail(u.Email)
+	visibleUser.Password = ""
+	return &visibleUser
+}
+
+// ProfileView tells which representation of the profile VisibleTo gives the viewer.
+func (u *User) ProfileView(viewer *User) string {
+	if u.IsOwnerOrAdmin(viewer) {
+		return ProfileViewFull
+	}
+	if !u.IsPublic {
+		return ProfileViewCard
+	}
+	return ProfileViewMasked
+}
+
+// ViewETag is the ETag of the representation the viewer gets, so a validator
+// of one view never revalidates another.
+func (u *User) ViewETag(viewer *User) string {
+	return utils.FormatVariantETag(u.Version, u.ProfileView(viewer))
+}
+
+// HidePrivateProfiles makes a listing leave out the profiles VisibleTo would report as not
+// found to the viewer, so the pages, HasMore and the total only count what is shown.
+func HidePrivateProfiles(paginationQuery *utils.PaginationQuery, viewer *User, privateProfileMode string) {
+	if privateProfileMode != PrivateProfileModeNotFound {
+		return
+	}
+	viewerID := uuid.Nil
+	if viewer != nil {
+		if viewer.IsAdmin() {
+			return
+		}
+		viewerID = viewer.UserID
+	}
+	if paginationQuery.Filter == nil {
+		paginationQuery.Filter = &utils.UserFilter{}
+	}
+	paginationQuery.Filter.PublicOrUserID = &viewerID
+}
+
+func (us *Users) VisibleTo(viewer *User, privateProfileMode string) *Users {
+	visibleUsers := &Users{
+		Page:           us.Page,
+		HasMore:        us.HasMore,
+		NextCursor:     us.NextCursor,
+		PrevCursor:     us.PrevCursor,
+		Size:           us.Size,
+		Total:          us.Total,
+		TotalPages:     us.TotalPages,
+		TotalEstimated: us.TotalEstimated,
+		Users:          make([]*User, 0, len(us.Users)),
+	}
+	for _, user := range us.Users {
+		if visibleUser := user.VisibleTo(viewer, privateProfileMode); visibleUser != nil {
+			visibleUsers.Users = append(visibleUsers.Users, visibleUser)
+		}
+	}
+	return visibleUsers
+}
+
+func (u *User) IsCard() bool {
+	return u.isCard
+}
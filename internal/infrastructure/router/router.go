@@ -1,6 +1,8 @@
 package router
 
 import (
+	"net"
+
 	"usermanager/internal/interface/controller"
 
 	"github.com/go-playground/validator"
@@ -8,22 +10,122 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func NewRouter(e *echo.Echo, c controller.UserManagerController) *echo.Echo {
+func NewRouter(e *echo.Echo, c controller.UserManagerController, trustedProxies []*net.IPNet) *echo.Echo {
+	e.IPExtractor = ipExtractor(trustedProxies)
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(middleware.RequestID())
+	e.Use(c.UserController.AuditRequest())
 
 	e.Validator = &controller.CustomValidator{Validator: validator.New()}
 
 	e.POST("/user/login", func(context echo.Context) error { return c.UserController.Login(context) })
-	e.GET("/user/:id", func(context echo.Context) error { return c.UserController.GetUser(context) })
-	e.GET("/users", func(context echo.Context) error { return c.UserController.GetUsers(context) })
+	e.POST("/user/email/confirm", func(context echo.Context) error { return c.UserController.ConfirmEmailChange(context) })
+	e.POST("/user/email/cancel", func(context echo.Context) error { return c.UserController.CancelEmailChange(context) })
+	e.POST("/user/invites/accept", func(context echo.Context) error { return c.UserController.AcceptInvite(context) })
+	e.GET("/user/register/challenge", func(context echo.Context) error { return c.UserController.GetRegistrationChallenge(context) })
+	e.POST("/user/register", func(context echo.Context) error { return c.UserController.RegisterUser(context) })
+	e.POST("/user/register/verify", func(context echo.Context) error { return c.UserController.VerifyRegistration(context) })
+	e.POST("/user/register/resend", func(context echo.Context) error { return c.UserController.ResendVerification(context) })
+	e.GET("/user/:id", func(context echo.Context) error { return c.UserController.GetUser(context) }, c.UserController.SetUpOptionalJWTConfig())
+	e.GET("/user/:id/followers", func(context echo.Context) error { return c.UserController.GetFollowers(context) }, c.UserController.SetUpOptionalJWTConfig())
+	e.GET("/user/:id/following", func(context echo.Context) error { return c.UserController.GetFollowing(context) }, c.UserController.SetUpOptionalJWTConfig())
+	e.GET("/users", func(context echo.Context) error { return c.UserController.GetUsers(context) }, c.UserController.SetUpOptionalJWTConfig())
+	e.GET("/users/search", func(context echo.Context) error { return c.UserController.SearchUsers(context) }, c.UserController.SetUpOptionalJWTConfig())
+	e.GET("/users/export", func(context echo.Context) error { return c.UserController.ExportUsers(context) }, c.UserController.SetUpJWTConfig(), c.UserController.CanExportUsers())
+	e.GET("/users/attributes/schema", func(context echo.Context) error { return c.UserController.GetAttributeSchema(context) }, c.UserController.SetUpJWTConfig())
+	e.PUT("/users/attributes/schema", func(context echo.Context) error { return c.UserController.SetAttributeSchema(context) }, c.UserController.SetUpJWTConfig(), c.UserController.CanManageAttributeSchema())
+
+	e.GET("/avatars/*", func(context echo.Context) error { return c.UserController.GetAvatarFile(context) })
+	e.GET("/u/:nickname", func(context echo.Context) error { return c.UserController.GetUserByNickname(context) }, c.UserController.SetUpOptionalJWTConfig())
 
 	userGroup := e.Group("/user")
 	userGroup.Use(c.UserController.SetUpJWTConfig())
 	userGroup.POST("", func(context echo.Context) error { return c.UserController.CreateUser(context) })
 	userGroup.DELETE("/:id", func(context echo.Context) error { return c.UserController.DeleteUser(context) }, c.UserController.CanDeleteUser())
+	userGroup.POST("/:id/suspend", func(context echo.Context) error { return c.UserController.SuspendUser(context) }, c.UserController.CanSuspendUsers())
+	userGroup.POST("/:id/unsuspend", func(context echo.Context) error { return c.UserController.UnsuspendUser(context) }, c.UserController.CanSuspendUsers())
+	userGroup.POST("/:id/restore", func(context echo.Context) error { return c.UserController.RestoreUser(context) }, c.UserController.CanRestoreUser())
 	userGroup.PUT("/:id", func(context echo.Context) error { return c.UserController.UpdateUser(context) }, c.UserController.CanUpdateUser())
+	userGroup.PATCH("/:id", func(context echo.Context) error { return c.UserController.PatchUser(context) }, c.UserController.CanUpdateUser())
+	userGroup.PUT("/:id/avatar", func(context echo.Context) error { return c.UserController.SetAvatar(context) }, c.UserController.CanUpdateUser())
+	userGroup.DELETE("/:id/avatar", func(context echo.Context) error { return c.UserController.DeleteAvatar(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/:id/email", func(context echo.Context) error { return c.UserController.RequestEmailChange(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/history", func(context echo.Context) error { return c.UserController.GetUserHistory(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/:id/data-export", func(context echo.Context) error { return c.UserController.RequestDataExport(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/:id/erasure", func(context echo.Context) error { return c.UserController.RequestErasure(context) }, c.UserController.CanDeleteUser())
+	userGroup.GET("/:id/data-requests/:requestId", func(context echo.Context) error { return c.UserController.GetUserDataRequest(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/data-requests/:requestId/archive", func(context echo.Context) error { return c.UserController.GetDataExportArchive(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/logins", func(context echo.Context) error { return c.UserController.GetUserLogins(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/settings", func(context echo.Context) error { return c.UserController.GetUserSettings(context) }, c.UserController.CanUpdateUser())
+	userGroup.PUT("/:id/settings", func(context echo.Context) error { return c.UserController.UpdateUserSettings(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/:id/follow", func(context echo.Context) error { return c.UserController.FollowUser(context) })
+	userGroup.DELETE("/:id/follow", func(context echo.Context) error { return c.UserController.UnfollowUser(context) })
+	userGroup.GET("/:id/follow-requests", func(context echo.Context) error { return c.UserController.GetFollowRequests(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/:id/follow-requests/:followerId", func(context echo.Context) error { return c.UserController.ApproveFollowRequest(context) }, c.UserController.CanUpdateUser())
+	userGroup.DELETE("/:id/follow-requests/:followerId", func(context echo.Context) error { return c.UserController.RejectFollowRequest(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/invites", func(context echo.Context) error { return c.UserController.CreateInvite(context) }, c.UserController.CanInviteUsers())
+	userGroup.DELETE("/invites/:inviteId", func(context echo.Context) error { return c.UserController.RevokeInvite(context) })
+	userGroup.GET("/:id/invites", func(context echo.Context) error { return c.UserController.GetInvites(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/nicknames", func(context echo.Context) error { return c.UserController.GetNicknameHistory(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/children", func(context echo.Context) error { return c.UserController.GetUserChildren(context) }, c.UserController.CanUpdateUser())
+	userGroup.POST("/:id/children", func(context echo.Context) error { return c.UserController.CreateUserChild(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/descendants", func(context echo.Context) error { return c.UserController.GetUserDescendants(context) }, c.UserController.CanUpdateUser())
+	userGroup.GET("/:id/ancestors", func(context echo.Context) error { return c.UserController.GetUserAncestors(context) }, c.UserController.CanUpdateUser())
+	userGroup.PUT("/:id/parent", func(context echo.Context) error { return c.UserController.SetUserParent(context) })
 	userGroup.POST("/vote", func(context echo.Context) error { return c.UserController.VoteUser(context) })
+	userGroup.PUT("/:id/role", func(context echo.Context) error { return c.RoleController.AssignRole(context) }, c.RoleController.CanAssignRole())
+	userGroup.GET("/:id/role/history", func(context echo.Context) error { return c.RoleController.GetRoleHistory(context) }, c.RoleController.CanAssignRole())
+	userGroup.GET("/:id/role/grants", func(context echo.Context) error { return c.RoleController.GetUserRoleGrants(context) })
+
+	reservedNicknameGroup := e.Group("/nicknames/reserved")
+	reservedNicknameGroup.Use(c.UserController.SetUpJWTConfig(), c.UserController.CanManageReservedNicknames())
+	reservedNicknameGroup.GET("", func(context echo.Context) error { return c.UserController.GetReservedNicknames(context) })
+	reservedNicknameGroup.PUT("/:nickname", func(context echo.Context) error { return c.UserController.ReserveNickname(context) })
+	reservedNicknameGroup.DELETE("/:nickname", func(context echo.Context) error { return c.UserController.ReleaseNickname(context) })
+
+	auditGroup := e.Group("/audit")
+	auditGroup.Use(c.UserController.SetUpJWTConfig(), c.UserController.CanReadAuditLog())
+	auditGroup.GET("", func(context echo.Context) error { return c.UserController.SearchAuditLog(context) })
+	auditGroup.GET("/export", func(context echo.Context) error { return c.UserController.ExportAuditLog(context) })
+
+	dataRequestGroup := e.Group("/data-requests")
+	dataRequestGroup.Use(c.UserController.SetUpJWTConfig(), c.UserController.CanManageDataRequests())
+	dataRequestGroup.GET("", func(context echo.Context) error { return c.UserController.GetDataRequests(context) })
+	dataRequestGroup.GET("/:id", func(context echo.Context) error { return c.UserController.GetDataRequest(context) })
+
+	userImportGroup := e.Group("/users/import")
+	userImportGroup.Use(c.UserController.SetUpJWTConfig(), c.UserController.CanImportUsers())
+	userImportGroup.POST("", func(context echo.Context) error { return c.UserController.ImportUsers(context) })
+	userImportGroup.GET("/:id", func(context echo.Context) error { return c.UserController.GetImportJob(context) })
+
+	roleRequestGroup := e.Group("/role-requests")
+	roleRequestGroup.Use(c.UserController.SetUpJWTConfig(), c.RoleController.CanAssignRole())
+	roleRequestGroup.GET("", func(context echo.Context) error { return c.RoleController.GetRoleRequests(context) })
+	roleRequestGroup.POST("/:id/approve", func(context echo.Context) error { return c.RoleController.ApproveRoleRequest(context) })
+	roleRequestGroup.POST("/:id/reject", func(context echo.Context) error { return c.RoleController.RejectRoleRequest(context) })
+
+	roleGrantGroup := e.Group("/role-grants")
+	roleGrantGroup.Use(c.UserController.SetUpJWTConfig())
+	roleGrantGroup.POST("", func(context echo.Context) error { return c.RoleController.RequestRoleGrant(context) })
+	roleGrantGroup.GET("", func(context echo.Context) error { return c.RoleController.GetRoleGrants(context) }, c.RoleController.CanAssignRole())
+	roleGrantGroup.POST("/:id/approve", func(context echo.Context) error { return c.RoleController.ApproveRoleGrant(context) }, c.RoleController.CanAssignRole())
+	roleGrantGroup.POST("/:id/reject", func(context echo.Context) error { return c.RoleController.RejectRoleGrant(context) }, c.RoleController.CanAssignRole())
+	roleGrantGroup.DELETE("/:id", func(context echo.Context) error { return c.RoleController.RevokeRoleGrant(context) })
 
 	return e
 }
+
+// ipExtractor only takes the client IP from X-Forwarded-For when the request comes through one
+// of the trusted proxies, anyone else could send whatever IP it likes. The IP limits of self
+// registration and the IPs of the login history rely on it.
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipRange := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/infrastructure/logger"
+)
+
+type Job func(ctx context.Context) error
+
+type Worker struct {
+	name     string
+	interval time.Duration
+	job      Job
+	logger   logger.Logger
+}
+
+func NewWorker(name string, interval time.Duration, job Job, logger logger.Logger) *Worker {
+	return &Worker{
+		name:     name,
+		interval: interval,
+		job:      job,
+		logger:   logger,
+	}
+}
+
+// Run calls the job every interval until ctx is done. A failed run is logged
+// and retried on the next tick.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.job(ctx); err != nil {
+				w.logger.Printf("worker %s: %v", w.name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const auditExportContentType = "application/x-ndjson"
+
+func (uc *userController) GetUserHistory(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetUserHistoryUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	auditQuery, err := model.NewAuditQuery(ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerGetUserHistoryQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	auditEntries, err := uc.auditUsecase.GetUserHistory(ctx.Request().Context(), userUUID, auditQuery)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, auditEntries)
+}
+
+func (uc *userController) SearchAuditLog(ctx echo.Context) error {
+	auditQuery, err := model.NewAuditQuery(ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerSearchAuditLogQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	auditEntries, err := uc.auditUsecase.SearchAuditLog(ctx.Request().Context(), auditQuery)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, auditEntries)
+}
+
+// ExportAuditLog streams every entry matching the filters of SearchAuditLog; before and limit
+// are ignored.
+func (uc *userController) ExportAuditLog(ctx echo.Context) error {
+	auditQuery, err := model.NewAuditQuery(ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerExportAuditLogQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	auditQuery.Before = 0
+
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentType, auditExportContentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="audit-%s.ndjson"`, time.Now().UTC().Format("20060102T150405Z")))
+	_, err = uc.auditUsecase.ExportAuditLog(ctx.Request().Context(), auditQuery, ctx.Response())
+	if err != nil {
+		if ctx.Response().Committed {
+			ctx.Logger().Error(err)
+			return nil
+		}
+		header.Del(echo.HeaderContentDisposition)
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestDataExport queues an export of the user's data and answers with the request to poll.
+// The archive is a ZIP unless ?format=json is asked for.
+func (uc *userController) RequestDataExport(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerRequestDataExportUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := uc.FetchJWTUser(ctx)
+	dataRequest, err := uc.dataRequestUsecase.RequestDataExport(ctx.Request().Context(), userUUID, ctx.QueryParam("format"), authUser)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ctx.Response().Header().Set(echo.HeaderLocation, dataRequestLocation(dataRequest))
+	return ctx.JSON(http.StatusAccepted, dataRequest)
+}
+
+func (uc *userController) RequestErasure(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerRequestErasureUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := uc.FetchJWTUser(ctx)
+	dataRequest, err := uc.dataRequestUsecase.RequestErasure(ctx.Request().Context(), userUUID, authUser)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ctx.Response().Header().Set(echo.HeaderLocation, dataRequestLocation(dataRequest))
+	return ctx.JSON(http.StatusAccepted, dataRequest)
+}
+
+func (uc *userController) GetUserDataRequest(ctx echo.Context) error {
+	userUUID, dataRequestID, err := parseUserDataRequestIDs(ctx)
+	if err != nil {
+		appError := apperrors.UserControllerGetUserDataRequestUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	dataRequest, err := uc.dataRequestUsecase.GetUserDataRequest(ctx.Request().Context(), userUUID, dataRequestID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dataRequest)
+}
+
+func (uc *userController) GetDataExportArchive(ctx echo.Context) error {
+	userUUID, dataRequestID, err := parseUserDataRequestIDs(ctx)
+	if err != nil {
+		appError := apperrors.UserControllerGetDataExportArchiveUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	dataRequest, blob, err := uc.dataRequestUsecase.GetDataExportArchive(ctx.Request().Context(), userUUID, dataRequestID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	header := ctx.Response().Header()
+	header.Set("Cache-Control", "no-store")
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="data-%s.%s"`, dataRequest.UserID, dataRequest.Format))
+	return ctx.Blob(http.StatusOK, blob.ContentType, blob.Data)
+}
+
+func (uc *userController) GetDataRequests(ctx echo.Context) error {
+	dataRequestQuery, err := model.NewDataRequestQuery(ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerGetDataRequestsQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	dataRequests, err := uc.dataRequestUsecase.GetDataRequests(ctx.Request().Context(), dataRequestQuery)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dataRequests)
+}
+
+func (uc *userController) GetDataRequest(ctx echo.Context) error {
+	dataRequestID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetDataRequestUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	dataRequest, err := uc.dataRequestUsecase.GetDataRequest(ctx.Request().Context(), dataRequestID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, dataRequest)
+}
+
+func parseUserDataRequestIDs(ctx echo.Context) (uuid.UUID, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	dataRequestID, err := uuid.Parse(ctx.Param("requestId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return userUUID, dataRequestID, nil
+}
+
+func dataRequestLocation(dataRequest *model.DataRequest) string {
+	return fmt.Sprintf("/user/%s/data-requests/%s", dataRequest.UserID, dataRequest.DataRequestID)
+}
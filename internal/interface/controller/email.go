@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestEmailChange starts an email change; the email of the user stays as it is until the
+// link sent to the new address is followed.
+func (uc *userController) RequestEmailChange(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerRequestEmailChangeUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	emailChangeRequest := &model.EmailChangeRequest{}
+	if err = ctx.Bind(emailChangeRequest); err != nil {
+		appError := apperrors.UserControllerRequestEmailChangeBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	err = ctx.Validate(emailChangeRequest)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	emailChange, err := uc.emailUsecase.RequestEmailChange(ctx.Request().Context(), userUUID, emailChangeRequest.Email)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusAccepted, emailChange)
+}
+
+// ConfirmEmailChange and CancelEmailChange need no session, the token from the email is the proof.
+func (uc *userController) ConfirmEmailChange(ctx echo.Context) error {
+	tokenRequest, err := bindEmailTokenRequest(ctx)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	_, err = uc.emailUsecase.ConfirmEmailChange(ctx.Request().Context(), tokenRequest.Token)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (uc *userController) CancelEmailChange(ctx echo.Context) error {
+	tokenRequest, err := bindEmailTokenRequest(ctx)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	err = uc.emailUsecase.CancelEmailChange(ctx.Request().Context(), tokenRequest.Token)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func bindEmailTokenRequest(ctx echo.Context) (*model.EmailTokenRequest, error) {
+	tokenRequest := &model.EmailTokenRequest{}
+	if err := ctx.Bind(tokenRequest); err != nil {
+		return nil, apperrors.UserControllerEmailTokenBind.AppendMessage(err)
+	}
+	if err := ctx.Validate(tokenRequest); err != nil {
+		return nil, err
+	}
+
+	return tokenRequest, nil
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// FollowUser makes the logged in user follow the user. The follow comes back pending when
+// the user has to approve it.
+func (uc *userController) FollowUser(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerFollowUserUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	follow, err := uc.followUsecase.FollowUser(ctx.Request().Context(), uc.FetchJWTUser(ctx), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, follow)
+}
+
+func (uc *userController) UnfollowUser(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerUnfollowUserUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	err = uc.followUsecase.UnfollowUser(ctx.Request().Context(), uc.FetchJWTUser(ctx).UserID, userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (uc *userController) GetFollowers(ctx echo.Context) error {
+	return uc.getFollows(ctx, model.FollowDirectionFollowers)
+}
+
+func (uc *userController) GetFollowing(ctx echo.Context) error {
+	return uc.getFollows(ctx, model.FollowDirectionFollowing)
+}
+
+func (uc *userController) getFollows(ctx echo.Context, direction string) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetFollowsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	followQuery, err := model.NewFollowQuery(userUUID, direction, model.FollowStatusAccepted, ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerGetFollowsQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	viewer := fetchOptionalJWTUser(ctx)
+	follows, err := uc.followUsecase.GetFollows(ctx.Request().Context(), viewer, followQuery)
+	if err != nil {
+		// a private profile hidden as not found doesn't admit to having follows either
+		if apperrors.Is(err, &apperrors.FollowUsecaseGetFollowsForbidden) && uc.cfg.Profile.PrivateMode == model.PrivateProfileModeNotFound {
+			err = &apperrors.UserControllerGetUserUserNotExist
+		}
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, follows.VisibleTo(viewer, uc.cfg.Profile.PrivateMode))
+}
+
+func (uc *userController) GetFollowRequests(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetFollowRequestsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	followQuery, err := model.NewFollowQuery(userUUID, model.FollowDirectionFollowers, model.FollowStatusPending, ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerGetFollowRequestsQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	followRequests, err := uc.followUsecase.GetFollowRequests(ctx.Request().Context(), followQuery)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, followRequests)
+}
+
+func (uc *userController) ApproveFollowRequest(ctx echo.Context) error {
+	userUUID, followerUUID, err := parseFollowRequestIDs(ctx)
+	if err != nil {
+		appError := apperrors.UserControllerApproveFollowRequestUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	follow, err := uc.followUsecase.ApproveFollowRequest(ctx.Request().Context(), userUUID, followerUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, follow)
+}
+
+func (uc *userController) RejectFollowRequest(ctx echo.Context) error {
+	userUUID, followerUUID, err := parseFollowRequestIDs(ctx)
+	if err != nil {
+		appError := apperrors.UserControllerRejectFollowRequestUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	err = uc.followUsecase.RejectFollowRequest(ctx.Request().Context(), userUUID, followerUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func parseFollowRequestIDs(ctx echo.Context) (uuid.UUID, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	followerUUID, err := uuid.Parse(ctx.Param("followerId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return userUUID, followerUUID, nil
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func (uc *userController) CreateInvite(ctx echo.Context) error {
+	createInvite := &model.CreateInviteRequest{}
+	if err := ctx.Bind(createInvite); err != nil {
+		appError := apperrors.UserControllerCreateInviteBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if err := ctx.Validate(createInvite); err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	invite, err := uc.inviteUsecase.CreateInvite(ctx.Request().Context(), uc.FetchJWTUser(ctx), createInvite)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, invite)
+}
+
+// AcceptInvite is public, the token from the invite email is all the invitee has.
+func (uc *userController) AcceptInvite(ctx echo.Context) error {
+	acceptInvite := &model.AcceptInviteRequest{}
+	if err := ctx.Bind(acceptInvite); err != nil {
+		appError := apperrors.UserControllerAcceptInviteBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if err := ctx.Validate(acceptInvite); err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.inviteUsecase.AcceptInvite(ctx.Request().Context(), acceptInvite)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, user.MapUserModelToCreateUserResponse())
+}
+
+func (uc *userController) GetInvites(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetInvitesUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	invites, err := uc.inviteUsecase.GetInvites(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, invites)
+}
+
+func (uc *userController) RevokeInvite(ctx echo.Context) error {
+	inviteUUID, err := uuid.Parse(ctx.Param("inviteId"))
+	if err != nil {
+		appError := apperrors.UserControllerRevokeInviteUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	invite, err := uc.inviteUsecase.RevokeInvite(ctx.Request().Context(), uc.FetchJWTUser(ctx), inviteUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, invite)
+}
@@ -8,6 +8,7 @@ import (
 	"usermanager/internal/domain/model"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,29 +18,51 @@ func (uc *userController) Login(ctx echo.Context) error {
 		appErr := err.(*apperrors.AppError)
 		return ctx.JSON(appErr.HTTPCode, appErr.Error())
 	}
+	loginEvent := newLoginEvent(ctx, loginRequest)
 
-	user, err := uc.userUsecase.GetUserByNickname(ctx.Request().Context(), loginRequest.Nickname)
+	user, err := uc.findLoginUser(ctx, loginRequest)
 	if err != nil {
+		uc.recordFailedLogin(ctx, nil, loginEvent, model.LoginFailureUnknownUser)
 		appErr := err.(*apperrors.AppError)
 		return ctx.JSON(appErr.HTTPCode, appErr.Error())
 	}
-	if user == nil {
-		appError := apperrors.UserControllerLoginGetUserByNicknameEmpty.AppendMessage(echo.ErrUnauthorized)
-		return ctx.JSON(appError.HTTPCode, appError.Error())
-	}
 
 	err = user.ComparePasswords(loginRequest.Password)
 	if err != nil {
+		uc.recordFailedLogin(ctx, user, loginEvent, model.LoginFailureWrongPassword)
+		appErr := err.(*apperrors.AppError)
+		return ctx.JSON(appErr.HTTPCode, appErr.Error())
+	}
+	err = user.CheckActive(time.Now())
+	if err != nil {
+		uc.recordFailedLogin(ctx, user, loginEvent, model.LoginFailureInactive)
 		appErr := err.(*apperrors.AppError)
 		return ctx.JSON(appErr.HTTPCode, appErr.Error())
 	}
 
+	roleGrant, err := uc.roleGrantUsecase.ApplyRoleGrants(ctx.Request().Context(), user)
+	if err != nil {
+		appError := apperrors.UserControllerLoginApplyRoleGrants.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	loginEvent.Success = true
+	_, err = uc.loginEventUsecase.RecordLogin(ctx.Request().Context(), user, loginEvent)
+	if err != nil {
+		appError := apperrors.UserControllerLoginRecordLogin.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
 	claims := &model.JwtCustomClaims{
 		UserID:   user.UserID,
 		Nickname: user.Nickname,
 		Role:     user.Role,
 	}
-	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour * time.Duration(uc.cfg.Jwt.Ttl)))
+	expiresAt := time.Now().Add(time.Hour * time.Duration(uc.cfg.Jwt.Ttl))
+	if roleGrant != nil && roleGrant.ExpiresAt.Before(expiresAt) {
+		expiresAt = *roleGrant.ExpiresAt
+	}
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenSigned, err := token.SignedString([]byte(uc.cfg.Jwt.Secret))
@@ -50,3 +73,71 @@ func (uc *userController) Login(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, model.LoginResponse{Token: tokenSigned})
 }
+
+func (uc *userController) GetUserLogins(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetUserLoginsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	loginEventQuery, err := model.NewLoginEventQuery(userUUID, ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerGetUserLoginsQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	logins, err := uc.loginEventUsecase.GetUserLogins(ctx.Request().Context(), loginEventQuery)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, logins)
+}
+
+// findLoginUser looks the user up by email when one is given, by nickname otherwise.
+func (uc *userController) findLoginUser(ctx echo.Context, loginRequest *model.LoginRequest) (*model.User, error) {
+	if loginRequest.Email != "" {
+		user, err := uc.emailUsecase.GetUserByEmail(ctx.Request().Context(), loginRequest.Email)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, apperrors.UserControllerLoginGetUserByEmailEmpty.AppendMessage(echo.ErrUnauthorized)
+		}
+		return user, nil
+	}
+
+	user, err := uc.userUsecase.GetUserByNickname(ctx.Request().Context(), loginRequest.Nickname)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperrors.UserControllerLoginGetUserByNicknameEmpty.AppendMessage(echo.ErrUnauthorized)
+	}
+	return user, nil
+}
+
+func newLoginEvent(ctx echo.Context, loginRequest *model.LoginRequest) *model.LoginEvent {
+	loginEvent := &model.LoginEvent{
+		Login:     loginRequest.Nickname,
+		Method:    model.LoginMethodNickname,
+		IP:        ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+	if loginRequest.Email != "" {
+		loginEvent.Login = loginRequest.Email
+		loginEvent.Method = model.LoginMethodEmail
+	}
+	return loginEvent
+}
+
+// recordFailedLogin keeps the failed attempt. The login is refused anyway, so an attempt that
+// couldn't be stored doesn't change the response.
+func (uc *userController) recordFailedLogin(ctx echo.Context, user *model.User, loginEvent *model.LoginEvent, reason string) {
+	loginEvent.FailureReason = reason
+	_, err := uc.loginEventUsecase.RecordLogin(ctx.Request().Context(), user, loginEvent)
+	if err != nil {
+		ctx.Logger().Error(err)
+	}
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"time"
+
 	"usermanager/internal/apperrors"
 	"usermanager/internal/domain/model"
 
@@ -18,14 +20,80 @@ const (
 )
 
 func (uc *userController) SetUpJWTConfig() echo.MiddlewareFunc {
+	config := echojwt.Config{
+		NewClaimsFunc: func(c echo.Context) jwt.Claims {
+			return new(model.JwtCustomClaims)
+		},
+		SigningKey:     []byte(uc.cfg.Jwt.Secret),
+		SuccessHandler: setAuditActor,
+	}
+
+	return uc.withActiveUser(echojwt.WithConfig(config))
+}
+
+// SetUpOptionalJWTConfig authenticates the caller when a token is sent and lets
+// anonymous requests through. An invalid token is still rejected.
+func (uc *userController) SetUpOptionalJWTConfig() echo.MiddlewareFunc {
 	config := echojwt.Config{
 		NewClaimsFunc: func(c echo.Context) jwt.Claims {
 			return new(model.JwtCustomClaims)
 		},
 		SigningKey: []byte(uc.cfg.Jwt.Secret),
+		ErrorHandler: func(c echo.Context, err error) error {
+			if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+				return nil
+			}
+			return echo.ErrUnauthorized.SetInternal(err)
+		},
+		SuccessHandler:         setAuditActor,
+		ContinueOnIgnoredError: true,
 	}
 
-	return echojwt.WithConfig(config)
+	return uc.withActiveUser(echojwt.WithConfig(config))
+}
+
+// withActiveUser follows the JWT middleware: tokens stay valid until they expire, so a user
+// suspended since the token was issued is rejected here, and so is a token whose role is no
+// longer the role in force, like one issued under a grant that has been revoked or has expired.
+func (uc *userController) withActiveUser(jwtMiddleware echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return jwtMiddleware(func(ctx echo.Context) error {
+			if _, ok := ctx.Get("user").(*jwt.Token); !ok {
+				return next(ctx)
+			}
+
+			authUser := uc.FetchJWTUser(ctx)
+			_, err := uc.activeUserUsecase.VerifyActiveUser(ctx.Request().Context(), authUser.UserID, authUser.Role)
+			if err != nil {
+				appError := err.(*apperrors.AppError)
+				return ctx.JSON(appError.HTTPCode, appError.Error())
+			}
+
+			return next(ctx)
+		})
+	}
+}
+
+// AuditRequest keeps the client IP and the request ID in the request context, where audit
+// entries pick them up. It must run after the RequestID middleware.
+func (uc *userController) AuditRequest() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			ctx.SetRequest(request.WithContext(model.ContextWithAuditRequest(request.Context(), &model.AuditRequest{
+				IP:        ctx.RealIP(),
+				RequestID: ctx.Response().Header().Get(echo.HeaderXRequestID),
+			})))
+
+			return next(ctx)
+		}
+	}
+}
+
+// setAuditActor names the authenticated user as the actor of whatever the request changes.
+func setAuditActor(ctx echo.Context) {
+	request := ctx.Request()
+	ctx.SetRequest(request.WithContext(model.ContextWithAuditActor(request.Context(), fetchJWTUser(ctx))))
 }
 
 func (uc *userController) CanUpdateUser() echo.MiddlewareFunc {
@@ -36,6 +104,58 @@ func (uc *userController) CanDeleteUser() echo.MiddlewareFunc {
 	return uc.hasPermission(deletePermission)
 }
 
+func (uc *userController) CanRestoreUser() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionRestore)
+}
+
+func (uc *userController) CanImportUsers() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionImport)
+}
+
+func (uc *userController) CanExportUsers() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionExport)
+}
+
+func (uc *userController) CanManageAttributeSchema() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionAttributeSchema)
+}
+
+func (uc *userController) CanManageReservedNicknames() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionReservedNicknames)
+}
+
+func (uc *userController) CanReadAuditLog() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionAuditLog)
+}
+
+func (uc *userController) CanManageDataRequests() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionDataRequests)
+}
+
+func (uc *userController) CanSuspendUsers() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionSuspend)
+}
+
+func (uc *userController) CanInviteUsers() echo.MiddlewareFunc {
+	return uc.hasRight(model.PermissionInvite)
+}
+
+// hasRight checks a permission that isn't tied to the user in the path.
+func (uc *userController) hasRight(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			authUser := uc.FetchJWTUser(ctx)
+			err := authUser.Can(permission)
+			if err != nil {
+				appError := err.(*apperrors.AppError)
+				return ctx.JSON(appError.HTTPCode, appError.Error())
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
 func (uc *userController) hasPermission(permission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
@@ -52,6 +172,15 @@ func (uc *userController) hasPermission(permission string) echo.MiddlewareFunc {
 
 			err = authUser.Can(permission)
 			if err != nil {
+				// the owners of a child account manage it like their own
+				isAncestor, ancestorErr := uc.userHierarchyUsecase.IsUserAncestor(ctx.Request().Context(), authUser.UserID, userUUID)
+				if ancestorErr != nil {
+					appError := apperrors.HasPermissionIsUserAncestor.AppendMessage(ancestorErr)
+					return ctx.JSON(appError.HTTPCode, appError.Error())
+				}
+				if isAncestor {
+					return next(ctx)
+				}
 				appError := err.(*apperrors.AppError)
 				return ctx.JSON(appError.HTTPCode, appError.Error())
 			}
@@ -67,12 +196,14 @@ func (uc *userController) JWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
 		claims := user.Claims.(*model.JwtCustomClaims)
 
 		if !user.Valid {
-			apperrors.MiddlewareJWTAuthValid.AppendMessage(echo.ErrUnauthorized)
+			appError := apperrors.MiddlewareJWTAuthValid.AppendMessage(echo.ErrUnauthorized)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
 		}
 
 		_, err := uc.VerifyJwtUser(ctx, claims.Nickname, claims.Role)
 		if err != nil {
-			apperrors.MiddlewareJWTAuthVerifyJwtUser.AppendMessage(echo.ErrUnauthorized)
+			appError := apperrors.MiddlewareJWTAuthVerifyJwtUser.AppendMessage(err)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
 		}
 
 		return next(ctx)
@@ -83,6 +214,8 @@ func (uc *userController) BasicAuth() echo.MiddlewareFunc {
 	return middleware.BasicAuth(uc.VerifyAuthUser())
 }
 
+// VerifyJwtUser makes the same active user check as withActiveUser and keeps the user, with
+// the role in force, in the context.
 func (uc *userController) VerifyJwtUser(ctx echo.Context, nickname, role string) (bool, error) {
 	user, err := uc.userUsecase.GetUserByNickname(ctx.Request().Context(), nickname)
 	if err != nil {
@@ -91,8 +224,9 @@ func (uc *userController) VerifyJwtUser(ctx echo.Context, nickname, role string)
 	if user == nil {
 		return false, apperrors.MiddlewareVerifyJwtUserGetUserByNickname.AppendMessage(err)
 	}
-	if user.Role != role {
-		return false, apperrors.MiddlewareVerifyAuthUserGetUserByNickname.AppendMessage(err)
+	user, err = uc.activeUserUsecase.VerifyActiveUser(ctx.Request().Context(), user.UserID, role)
+	if err != nil {
+		return false, err
 	}
 	ctx.Set(UserAuthCtx, user)
 
@@ -113,6 +247,10 @@ func (uc *userController) VerifyAuthUser() func(username, password string, ctx e
 		if err != nil {
 			return false, apperrors.MiddlewareVerifyAuthUserComparePasswords.AppendMessage(err)
 		}
+		err = user.CheckActive(time.Now())
+		if err != nil {
+			return false, err
+		}
 
 		ctx.Set(UserAuthCtx, user)
 
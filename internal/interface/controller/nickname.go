@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetUserByNickname serves the profile at /u/:nickname. Another spelling of the current
+// nickname redirects permanently; a nickname the user gave up redirects temporarily, since
+// someone else may take it later.
+func (uc *userController) GetUserByNickname(ctx echo.Context) error {
+	nickname := ctx.Param("nickname")
+	user, err := uc.nicknameUsecase.ResolveNickname(ctx.Request().Context(), nickname)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if user.Nickname == nickname {
+		return uc.getUser(ctx, user.UserID)
+	}
+
+	if user.VisibleTo(fetchOptionalJWTUser(ctx), uc.cfg.Profile.PrivateMode) == nil {
+		appError := apperrors.UserControllerGetUserByNicknameUserNotExist
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	status := http.StatusFound
+	if utils.NicknameKey(user.Nickname) == utils.NicknameKey(nickname) {
+		status = http.StatusMovedPermanently
+	}
+	return ctx.Redirect(status, "/u/"+url.PathEscape(user.Nickname))
+}
+
+func (uc *userController) GetNicknameHistory(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetNicknameHistoryUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	nicknameChanges, err := uc.nicknameUsecase.GetNicknameHistory(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, nicknameChanges)
+}
+
+func (uc *userController) GetReservedNicknames(ctx echo.Context) error {
+	reservedNicknames, err := uc.nicknameUsecase.GetReservedNicknames(ctx.Request().Context())
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, reservedNicknames)
+}
+
+func (uc *userController) ReserveNickname(ctx echo.Context) error {
+	reserveNickname := &model.ReserveNicknameRequest{}
+	if err := ctx.Bind(reserveNickname); err != nil {
+		appError := apperrors.UserControllerReserveNicknameBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	err := ctx.Validate(reserveNickname)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := uc.FetchJWTUser(ctx)
+	reservedNickname := &model.ReservedNickname{
+		Nickname:  ctx.Param("nickname"),
+		Reason:    reserveNickname.Reason,
+		CreatedBy: authUser.UserID.String(),
+	}
+	reservedNickname, err = uc.nicknameUsecase.ReserveNickname(ctx.Request().Context(), reservedNickname)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, reservedNickname)
+}
+
+func (uc *userController) ReleaseNickname(ctx echo.Context) error {
+	err := uc.nicknameUsecase.ReleaseNickname(ctx.Request().Context(), ctx.Param("nickname"))
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetRegistrationChallenge hands out the proof of work a registration has to come with.
+func (uc *userController) GetRegistrationChallenge(ctx echo.Context) error {
+	challenge, err := uc.registrationUsecase.CreateChallenge(ctx.Request().Context(), ctx.RealIP())
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, challenge)
+}
+
+func (uc *userController) RegisterUser(ctx echo.Context) error {
+	registerUser := &model.RegisterUserRequest{}
+	if err := ctx.Bind(registerUser); err != nil {
+		appError := apperrors.UserControllerRegisterUserBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if err := ctx.Validate(registerUser); err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.registrationUsecase.RegisterUser(ctx.Request().Context(), ctx.RealIP(), registerUser)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, &model.RegisterUserResponse{
+		CreateUserResponse: user.MapUserModelToCreateUserResponse(),
+		Status:             user.StatusAt(time.Now()),
+	})
+}
+
+func (uc *userController) VerifyRegistration(ctx echo.Context) error {
+	verifyRegistration := &model.VerifyRegistrationRequest{}
+	if err := ctx.Bind(verifyRegistration); err != nil {
+		appError := apperrors.UserControllerVerifyRegistrationBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if err := ctx.Validate(verifyRegistration); err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.registrationUsecase.VerifyRegistration(ctx.Request().Context(), verifyRegistration)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, user.MapUserModelToCreateUserResponse())
+}
+
+// ResendVerification answers 202 whether or not an email went out.
+func (uc *userController) ResendVerification(ctx echo.Context) error {
+	resendVerification := &model.ResendVerificationRequest{}
+	if err := ctx.Bind(resendVerification); err != nil {
+		appError := apperrors.UserControllerResendVerificationBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if err := ctx.Validate(resendVerification); err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	err := uc.registrationUsecase.ResendVerification(ctx.Request().Context(), ctx.RealIP(), resendVerification)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
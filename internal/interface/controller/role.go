@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/config"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/usecase/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type roleController struct {
+	roleUsecase      usecase.IRoleUsecase
+	roleGrantUsecase usecase.IRoleGrantUsecase
+	cfg              *config.Config
+}
+
+type IRoleController interface {
+	AssignRole(ctx echo.Context) error
+	GetRoleHistory(ctx echo.Context) error
+	GetRoleRequests(ctx echo.Context) error
+	ApproveRoleRequest(ctx echo.Context) error
+	RejectRoleRequest(ctx echo.Context) error
+	RequestRoleGrant(ctx echo.Context) error
+	GetRoleGrants(ctx echo.Context) error
+	GetUserRoleGrants(ctx echo.Context) error
+	ApproveRoleGrant(ctx echo.Context) error
+	RejectRoleGrant(ctx echo.Context) error
+	RevokeRoleGrant(ctx echo.Context) error
+	CanAssignRole() echo.MiddlewareFunc
+}
+
+func NewRoleController(roleUsecase usecase.IRoleUsecase, roleGrantUsecase usecase.IRoleGrantUsecase, cfg *config.Config) IRoleController {
+	return &roleController{roleUsecase, roleGrantUsecase, cfg}
+}
+
+func (rc *roleController) AssignRole(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.RoleControllerAssignRoleUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	assignRole := &model.AssignRoleRequest{}
+	if err = ctx.Bind(assignRole); err != nil {
+		appError := apperrors.RoleControllerAssignRoleBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	err = ctx.Validate(assignRole)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	user, roleRequest, err := rc.roleUsecase.AssignRole(ctx.Request().Context(), authUser, userUUID, assignRole)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if roleRequest != nil {
+		return ctx.JSON(http.StatusAccepted, &model.AssignRoleResponse{RoleRequest: roleRequest})
+	}
+
+	return ctx.JSON(http.StatusOK, &model.AssignRoleResponse{User: user.MapUserModelToUpdateUserResponse()})
+}
+
+func (rc *roleController) GetRoleHistory(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.RoleControllerGetRoleHistoryUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	roleHistory, err := rc.roleUsecase.GetRoleHistory(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleHistory)
+}
+
+func (rc *roleController) GetRoleRequests(ctx echo.Context) error {
+	status := ctx.QueryParam("status")
+	if status != "" && !model.IsRoleRequestStatusExist(status) {
+		appError := apperrors.RoleControllerGetRoleRequestsStatus.AppendMessage(status)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	roleRequests, err := rc.roleUsecase.GetRoleRequests(ctx.Request().Context(), status)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleRequests)
+}
+
+func (rc *roleController) ApproveRoleRequest(ctx echo.Context) error {
+	requestUUID, reviewRole, appError := rc.bindReviewRoleRequest(ctx)
+	if appError != nil {
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	roleRequest, err := rc.roleUsecase.ApproveRoleRequest(ctx.Request().Context(), authUser, requestUUID, reviewRole.Reason)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleRequest)
+}
+
+func (rc *roleController) RejectRoleRequest(ctx echo.Context) error {
+	requestUUID, reviewRole, appError := rc.bindReviewRoleRequest(ctx)
+	if appError != nil {
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	roleRequest, err := rc.roleUsecase.RejectRoleRequest(ctx.Request().Context(), authUser, requestUUID, reviewRole.Reason)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleRequest)
+}
+
+func (rc *roleController) CanAssignRole() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			authUser := fetchJWTUser(ctx)
+			err := authUser.Can(model.PermissionRoleAssign)
+			if err != nil {
+				appError := err.(*apperrors.AppError)
+				return ctx.JSON(appError.HTTPCode, appError.Error())
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+func (rc *roleController) bindReviewRoleRequest(ctx echo.Context) (uuid.UUID, *model.ReviewRoleRequest, *apperrors.AppError) {
+	requestUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, nil, apperrors.RoleControllerReviewRoleRequestUuidParse.AppendMessage(err)
+	}
+
+	reviewRole := &model.ReviewRoleRequest{}
+	if err = ctx.Bind(reviewRole); err != nil {
+		return uuid.Nil, nil, apperrors.RoleControllerReviewRoleRequestBind.AppendMessage(err)
+	}
+	err = ctx.Validate(reviewRole)
+	if err != nil {
+		return uuid.Nil, nil, err.(*apperrors.AppError)
+	}
+
+	return requestUUID, reviewRole, nil
+}
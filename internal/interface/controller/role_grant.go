@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func (rc *roleController) RequestRoleGrant(ctx echo.Context) error {
+	requestRoleGrant := &model.RequestRoleGrantRequest{}
+	if err := ctx.Bind(requestRoleGrant); err != nil {
+		appError := apperrors.RoleControllerRequestRoleGrantBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	err := ctx.Validate(requestRoleGrant)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	roleGrant, err := rc.roleGrantUsecase.RequestRoleGrant(ctx.Request().Context(), authUser, requestRoleGrant)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if roleGrant.IsPending() {
+		return ctx.JSON(http.StatusAccepted, roleGrant)
+	}
+
+	return ctx.JSON(http.StatusCreated, roleGrant)
+}
+
+func (rc *roleController) GetRoleGrants(ctx echo.Context) error {
+	status := ctx.QueryParam("status")
+	if status != "" && !model.IsRoleGrantStatusExist(status) {
+		appError := apperrors.RoleControllerGetRoleGrantsStatus.AppendMessage(status)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	roleGrants, err := rc.roleGrantUsecase.GetRoleGrants(ctx.Request().Context(), status)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleGrants)
+}
+
+func (rc *roleController) GetUserRoleGrants(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.RoleControllerGetUserRoleGrantsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	if authUser.UserID != userUUID {
+		err = authUser.Can(model.PermissionRoleAssign)
+		if err != nil {
+			appError := err.(*apperrors.AppError)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+	}
+
+	roleGrants, err := rc.roleGrantUsecase.GetUserRoleGrants(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleGrants)
+}
+
+func (rc *roleController) ApproveRoleGrant(ctx echo.Context) error {
+	grantUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.RoleControllerRoleGrantUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	roleGrant, err := rc.roleGrantUsecase.ApproveRoleGrant(ctx.Request().Context(), authUser, grantUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleGrant)
+}
+
+func (rc *roleController) RejectRoleGrant(ctx echo.Context) error {
+	grantUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.RoleControllerRoleGrantUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	roleGrant, err := rc.roleGrantUsecase.RejectRoleGrant(ctx.Request().Context(), authUser, grantUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleGrant)
+}
+
+func (rc *roleController) RevokeRoleGrant(ctx echo.Context) error {
+	grantUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.RoleControllerRoleGrantUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := fetchJWTUser(ctx)
+	roleGrant, err := rc.roleGrantUsecase.RevokeRoleGrant(ctx.Request().Context(), authUser, grantUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, roleGrant)
+}
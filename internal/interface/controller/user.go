@@ -1,7 +1,13 @@
 package controller
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"usermanager/internal/apperrors"
 	"usermanager/internal/config"
@@ -14,29 +20,112 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const patchBodyLimit = 1 << 20
+
 type userController struct {
-	userUsecase usecase.IUserUsecase
-	cfg         *config.Config
+	userUsecase                usecase.IUserUsecase
+	roleGrantUsecase           usecase.IRoleGrantUsecase
+	activeUserUsecase          usecase.IActiveUserUsecase
+	userImportUsecase          usecase.IUserImportUsecase
+	userExportUsecase          usecase.IUserExportUsecase
+	userAttributeSchemaUsecase usecase.IUserAttributeSchemaUsecase
+	userAvatarUsecase          usecase.IUserAvatarUsecase
+	nicknameUsecase            usecase.INicknameUsecase
+	emailUsecase               usecase.IEmailUsecase
+	auditUsecase               usecase.IAuditUsecase
+	dataRequestUsecase         usecase.IDataRequestUsecase
+	userStatusUsecase          usecase.IUserStatusUsecase
+	userHierarchyUsecase       usecase.IUserHierarchyUsecase
+	loginEventUsecase          usecase.ILoginEventUsecase
+	userSettingUsecase         usecase.IUserSettingUsecase
+	followUsecase              usecase.IFollowUsecase
+	inviteUsecase              usecase.IInviteUsecase
+	registrationUsecase        usecase.IRegistrationUsecase
+	cfg                        *config.Config
 }
 
 type IUserController interface {
 	GetUser(ctx echo.Context) error
 	GetUsers(ctx echo.Context) error
+	SearchUsers(ctx echo.Context) error
 	CreateUser(ctx echo.Context) error
 	UpdateUser(ctx echo.Context) error
+	PatchUser(ctx echo.Context) error
 	DeleteUser(ctx echo.Context) error
+	RestoreUser(ctx echo.Context) error
+	ImportUsers(ctx echo.Context) error
+	GetImportJob(ctx echo.Context) error
+	ExportUsers(ctx echo.Context) error
+	GetAttributeSchema(ctx echo.Context) error
+	SetAttributeSchema(ctx echo.Context) error
+	SetAvatar(ctx echo.Context) error
+	DeleteAvatar(ctx echo.Context) error
+	GetAvatarFile(ctx echo.Context) error
+	GetUserByNickname(ctx echo.Context) error
+	GetNicknameHistory(ctx echo.Context) error
+	GetReservedNicknames(ctx echo.Context) error
+	ReserveNickname(ctx echo.Context) error
+	ReleaseNickname(ctx echo.Context) error
+	RequestEmailChange(ctx echo.Context) error
+	ConfirmEmailChange(ctx echo.Context) error
+	CancelEmailChange(ctx echo.Context) error
+	GetUserHistory(ctx echo.Context) error
+	SearchAuditLog(ctx echo.Context) error
+	ExportAuditLog(ctx echo.Context) error
+	RequestDataExport(ctx echo.Context) error
+	RequestErasure(ctx echo.Context) error
+	GetUserDataRequest(ctx echo.Context) error
+	GetDataExportArchive(ctx echo.Context) error
+	GetDataRequests(ctx echo.Context) error
+	GetDataRequest(ctx echo.Context) error
+	SuspendUser(ctx echo.Context) error
+	UnsuspendUser(ctx echo.Context) error
+	GetUserChildren(ctx echo.Context) error
+	GetUserDescendants(ctx echo.Context) error
+	GetUserAncestors(ctx echo.Context) error
+	CreateUserChild(ctx echo.Context) error
+	SetUserParent(ctx echo.Context) error
+	GetUserLogins(ctx echo.Context) error
+	GetUserSettings(ctx echo.Context) error
+	UpdateUserSettings(ctx echo.Context) error
+	FollowUser(ctx echo.Context) error
+	UnfollowUser(ctx echo.Context) error
+	GetFollowers(ctx echo.Context) error
+	GetFollowing(ctx echo.Context) error
+	GetFollowRequests(ctx echo.Context) error
+	ApproveFollowRequest(ctx echo.Context) error
+	RejectFollowRequest(ctx echo.Context) error
+	CreateInvite(ctx echo.Context) error
+	AcceptInvite(ctx echo.Context) error
+	GetInvites(ctx echo.Context) error
+	RevokeInvite(ctx echo.Context) error
+	GetRegistrationChallenge(ctx echo.Context) error
+	RegisterUser(ctx echo.Context) error
+	VerifyRegistration(ctx echo.Context) error
+	ResendVerification(ctx echo.Context) error
 	Login(ctx echo.Context) error
 	VoteUser(ctx echo.Context) error
 	SetUpJWTConfig() echo.MiddlewareFunc
+	SetUpOptionalJWTConfig() echo.MiddlewareFunc
+	AuditRequest() echo.MiddlewareFunc
 	BasicAuth() echo.MiddlewareFunc
 	JWTAuth(next echo.HandlerFunc) echo.HandlerFunc
 	FetchJWTUser(ctx echo.Context) *model.User
 	CanUpdateUser() echo.MiddlewareFunc
 	CanDeleteUser() echo.MiddlewareFunc
+	CanRestoreUser() echo.MiddlewareFunc
+	CanImportUsers() echo.MiddlewareFunc
+	CanExportUsers() echo.MiddlewareFunc
+	CanManageAttributeSchema() echo.MiddlewareFunc
+	CanManageReservedNicknames() echo.MiddlewareFunc
+	CanReadAuditLog() echo.MiddlewareFunc
+	CanManageDataRequests() echo.MiddlewareFunc
+	CanSuspendUsers() echo.MiddlewareFunc
+	CanInviteUsers() echo.MiddlewareFunc
 }
 
-func NewUserController(userUsecase usecase.IUserUsecase, cfg *config.Config) IUserController {
-	return &userController{userUsecase, cfg}
+func NewUserController(userUsecase usecase.IUserUsecase, roleGrantUsecase usecase.IRoleGrantUsecase, activeUserUsecase usecase.IActiveUserUsecase, userImportUsecase usecase.IUserImportUsecase, userExportUsecase usecase.IUserExportUsecase, userAttributeSchemaUsecase usecase.IUserAttributeSchemaUsecase, userAvatarUsecase usecase.IUserAvatarUsecase, nicknameUsecase usecase.INicknameUsecase, emailUsecase usecase.IEmailUsecase, auditUsecase usecase.IAuditUsecase, dataRequestUsecase usecase.IDataRequestUsecase, userStatusUsecase usecase.IUserStatusUsecase, userHierarchyUsecase usecase.IUserHierarchyUsecase, loginEventUsecase usecase.ILoginEventUsecase, userSettingUsecase usecase.IUserSettingUsecase, followUsecase usecase.IFollowUsecase, inviteUsecase usecase.IInviteUsecase, registrationUsecase usecase.IRegistrationUsecase, cfg *config.Config) IUserController {
+	return &userController{userUsecase, roleGrantUsecase, activeUserUsecase, userImportUsecase, userExportUsecase, userAttributeSchemaUsecase, userAvatarUsecase, nicknameUsecase, emailUsecase, auditUsecase, dataRequestUsecase, userStatusUsecase, userHierarchyUsecase, loginEventUsecase, userSettingUsecase, followUsecase, inviteUsecase, registrationUsecase, cfg}
 }
 
 func (uc *userController) CreateUser(ctx echo.Context) error {
@@ -77,10 +166,16 @@ func (uc *userController) UpdateUser(ctx echo.Context) error {
 		appError := apperrors.UserControllerUpdateUserUserNotExist
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
+	expectedVersion, err := ifMatchVersion(ctx, user)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
 	if err = ctx.Bind(updateUser); err != nil {
 		appError := apperrors.UserControllerUpdateUserBind.AppendMessage(err)
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
+	passwordHash := user.Password
 	user.MapUpdateUserRequestToUserModel(updateUser)
 
 	authUser := uc.FetchJWTUser(ctx)
@@ -89,11 +184,9 @@ func (uc *userController) UpdateUser(ctx echo.Context) error {
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
-	err = user.ComparePasswords(user.Password)
-	if err != nil {
-		err = user.HashPassword()
-	}
-	if err != nil {
+	if user.Password == "" {
+		user.Password = passwordHash
+	} else if err = user.HashPassword(); err != nil {
 		appError := err.(*apperrors.AppError)
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
@@ -110,15 +203,80 @@ func (uc *userController) UpdateUser(ctx echo.Context) error {
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
+	user.Version = expectedVersion
 	updatedUser, err := uc.userUsecase.UpdateUser(ctx.Request().Context(), user)
 	if err != nil {
 		appError := err.(*apperrors.AppError)
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
+	ctx.Response().Header().Set("ETag", updatedUser.ETag())
 	return ctx.JSON(http.StatusOK, updatedUser.MapUserModelToUpdateUserResponse())
 }
 
+func (uc *userController) PatchUser(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerPatchUserUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	contentType, _, err := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || !model.IsPatchContentType(contentType) {
+		appError := apperrors.UserControllerPatchUserContentType.AppendMessage(contentType)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	patch, err := io.ReadAll(io.LimitReader(ctx.Request().Body, patchBodyLimit))
+	if err != nil {
+		appError := apperrors.UserControllerPatchUserReadBody.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userUsecase.GetUser(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if user == nil {
+		appError := apperrors.UserControllerPatchUserUserNotExist
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	expectedVersion, err := ifMatchVersion(ctx, user)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	document := user.MapUserModelToPatchUserDocument()
+	patchedDocument, err := document.ApplyPatch(contentType, patch)
+	if err != nil {
+		appError := apperrors.UserControllerPatchUserApplyPatch.AppendMessage(err)
+		if errors.Is(err, utils.ErrJSONPatchTestFailed) {
+			appError = apperrors.UserControllerPatchUserTestFailed.AppendMessage(err)
+		}
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	err = ctx.Validate(patchedDocument)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	changes := patchedDocument.Changes(document)
+	if len(changes) == 0 {
+		ctx.Response().Header().Set("ETag", user.ETag())
+		return ctx.JSON(http.StatusOK, user.MapUserModelToUpdateUserResponse())
+	}
+	patchedUser, err := uc.userUsecase.PatchUser(ctx.Request().Context(), userUUID, expectedVersion, changes)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ctx.Response().Header().Set("ETag", patchedUser.ETag())
+	return ctx.JSON(http.StatusOK, patchedUser.MapUserModelToUpdateUserResponse())
+}
+
 func (uc *userController) DeleteUser(ctx echo.Context) error {
 	userID := ctx.Param("id")
 	userUUID, err := uuid.Parse(userID)
@@ -127,7 +285,41 @@ func (uc *userController) DeleteUser(ctx echo.Context) error {
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
-	err = uc.userUsecase.DeleteUser(ctx.Request().Context(), &userUUID)
+	hard := false
+	if hardParam := ctx.QueryParam("hard"); hardParam != "" {
+		hard, err = strconv.ParseBool(hardParam)
+		if err != nil {
+			appError := apperrors.UserControllerDeleteUserHardParse.AppendMessage(err)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+	}
+
+	var expectedVersion int64
+	if ctx.Request().Header.Get("If-Match") != "" {
+		user, err := uc.userUsecase.GetUser(ctx.Request().Context(), userUUID)
+		if err != nil {
+			appError := err.(*apperrors.AppError)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+		if user == nil {
+			appError := apperrors.UserControllerIfMatchPreconditionFailed.AppendMessage(userUUID)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+		expectedVersion, err = ifMatchVersion(ctx, user)
+		if err != nil {
+			appError := err.(*apperrors.AppError)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+	}
+
+	if hard {
+		authUser := uc.FetchJWTUser(ctx)
+		if !authUser.IsAdmin() {
+			appError := apperrors.UserControllerDeleteUserHardForbidden.AppendMessage(authUser.UserID)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+	}
+	err = uc.userHierarchyUsecase.DeleteUser(ctx.Request().Context(), &userUUID, expectedVersion, hard)
 	if err != nil {
 		appError := err.(*apperrors.AppError)
 		return ctx.JSON(appError.HTTPCode, appError.Error())
@@ -136,6 +328,22 @@ func (uc *userController) DeleteUser(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, userUUID)
 }
 
+func (uc *userController) RestoreUser(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerRestoreUserUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userUsecase.RestoreUser(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, user.MapUserModelToGetUserResponse())
+}
+
 func (uc *userController) GetUser(ctx echo.Context) error {
 	userUUID := ctx.Param("id")
 
@@ -145,6 +353,11 @@ func (uc *userController) GetUser(ctx echo.Context) error {
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
+	return uc.getUser(ctx, uid)
+}
+
+// getUser writes the profile as the viewer may see it, with the ETag of that view.
+func (uc *userController) getUser(ctx echo.Context, uid uuid.UUID) error {
 	user, err := uc.userUsecase.GetUserByID(ctx.Request().Context(), uid)
 	if err != nil {
 		appError := err.(*apperrors.AppError)
@@ -154,22 +367,96 @@ func (uc *userController) GetUser(ctx echo.Context) error {
 		appError := apperrors.UserControllerGetUserUserNotExist
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
-	return ctx.JSON(http.StatusOK, user.MapUserModelToGetUserResponse())
+
+	viewer := fetchOptionalJWTUser(ctx)
+	etag := user.ViewETag(viewer)
+	user = user.VisibleTo(viewer, uc.cfg.Profile.PrivateMode)
+	if user == nil {
+		appError := apperrors.UserControllerGetUserUserNotExist
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	getUserResponse := user.MapUserModelToGetUserResponse()
+	if !user.IsCard() {
+		counts, err := uc.followUsecase.GetFollowCounts(ctx.Request().Context(), user.UserID)
+		if err != nil {
+			appError := apperrors.UserControllerGetUserGetFollowCounts.AppendMessage(err)
+			return ctx.JSON(appError.HTTPCode, appError.Error())
+		}
+		getUserResponse.SetFollowCounts(counts)
+	}
+	body, err := json.Marshal(getUserResponse)
+	if err != nil {
+		appError := apperrors.UserControllerGetUserMarshal.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	// the rate and the follow counts move without a new version, the digest of the body
+	// keeps them from being revalidated as unchanged
+	etag = utils.WithBodyDigest(etag, body)
+
+	// the body depends on the viewer, so caches must key it on the token too
+	ctx.Response().Header().Set(echo.HeaderVary, echo.HeaderAuthorization)
+	ctx.Response().Header().Set("ETag", etag)
+	if ifNoneMatch := ctx.Request().Header.Get("If-None-Match"); ifNoneMatch != "" && utils.ETagMatches(ifNoneMatch, etag, true) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+	return ctx.JSONBlob(http.StatusOK, body)
 }
 
 func (uc *userController) GetUsers(ctx echo.Context) error {
-	paginationQuery, err := utils.GetPaginationFromCtx(ctx.QueryParam("page"), ctx.QueryParam("size"), ctx.QueryParam("orderBy"))
+	paginationQuery, err := utils.GetUsersQueryFromCtx(ctx.QueryParams())
 	if err != nil {
-		appError := apperrors.UserControllerGetUsersGetPaginationFromCtx.AppendMessage(err)
+		appError := apperrors.UserControllerGetUsersGetUsersQueryFromCtx.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	viewer := fetchOptionalJWTUser(ctx)
+	if paginationQuery.Filter != nil && paginationQuery.Filter.HasPrivateFields() && (viewer == nil || !viewer.IsAdmin()) {
+		appError := apperrors.UserControllerGetUsersPrivateFilter
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if paginationQuery.HasPrivateSort() && viewer == nil {
+		appError := apperrors.UserControllerGetUsersPrivateSortAnonymous
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if paginationQuery.HasPrivateSort() && !viewer.IsAdmin() {
+		appError := apperrors.UserControllerGetUsersPrivateSort
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
+	model.HidePrivateProfiles(paginationQuery, viewer, uc.cfg.Profile.PrivateMode)
 	users, err := uc.userUsecase.GetUsersByPaginationQuery(ctx.Request().Context(), paginationQuery)
 	if err != nil {
 		appError := err.(*apperrors.AppError)
 		return ctx.JSON(appError.HTTPCode, appError.Error())
 	}
 
+	users = users.VisibleTo(viewer, uc.cfg.Profile.PrivateMode)
+	ctx.Response().Header().Set("Link", utils.BuildLinkHeader(requestURL(ctx), &utils.PageInfo{
+		Page:       users.Page,
+		HasMore:    users.HasMore,
+		NextCursor: users.NextCursor,
+		PrevCursor: users.PrevCursor,
+		TotalPages: users.TotalPages,
+	}))
+	return ctx.JSON(http.StatusOK, users.MapUserModelToGetUserResponse())
+}
+
+func (uc *userController) SearchUsers(ctx echo.Context) error {
+	paginationQuery, err := utils.GetPaginationFromCtx(ctx.QueryParam("page"), ctx.QueryParam("size"), "")
+	if err != nil {
+		appError := apperrors.UserControllerSearchUsersGetPaginationFromCtx.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	viewer := fetchOptionalJWTUser(ctx)
+	users, err := uc.userUsecase.SearchUsers(ctx.Request().Context(), viewer, ctx.QueryParam("q"), paginationQuery)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	users = users.VisibleTo(viewer, uc.cfg.Profile.PrivateMode)
 	return ctx.JSON(http.StatusOK, users.MapUserModelToGetUserResponse())
 }
 
@@ -178,6 +465,10 @@ func (uc *userController) FetchAuthUser(ctx echo.Context, UserAuthCtx string) *m
 }
 
 func (uc *userController) FetchJWTUser(ctx echo.Context) *model.User {
+	return fetchJWTUser(ctx)
+}
+
+func fetchJWTUser(ctx echo.Context) *model.User {
 	userContext := ctx.Get("user").(*jwt.Token)
 	claims := userContext.Claims.(*model.JwtCustomClaims)
 
@@ -191,3 +482,33 @@ func (uc *userController) FetchJWTUser(ctx echo.Context) *model.User {
 		Role:     claims.Role,
 	}
 }
+
+func fetchOptionalJWTUser(ctx echo.Context) *model.User {
+	if _, ok := ctx.Get("user").(*jwt.Token); !ok {
+		return nil
+	}
+	return fetchJWTUser(ctx)
+}
+
+func requestURL(ctx echo.Context) *url.URL {
+	return &url.URL{
+		Scheme:   ctx.Scheme(),
+		Host:     ctx.Request().Host,
+		Path:     ctx.Request().URL.Path,
+		RawQuery: ctx.Request().URL.RawQuery,
+	}
+}
+
+// ifMatchVersion returns the version a write is conditional on: zero without If-Match,
+// otherwise the current version of the user, provided the header lists its ETag.
+func ifMatchVersion(ctx echo.Context, user *model.User) (int64, error) {
+	ifMatch := ctx.Request().Header.Get("If-Match")
+	if ifMatch == "" {
+		return 0, nil
+	}
+	if !utils.ETagMatchesVersion(ifMatch, user.ETag()) {
+		return 0, apperrors.UserControllerIfMatchPreconditionFailed.AppendMessage(ifMatch)
+	}
+
+	return user.Version, nil
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (uc *userController) GetAttributeSchema(ctx echo.Context) error {
+	schema, err := uc.userAttributeSchemaUsecase.GetSchema(ctx.Request().Context())
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, schema)
+}
+
+// SetAttributeSchema takes the JSON Schema itself as the body. Users saved before keep
+// their attributes; the new schema applies to the next write of each user.
+func (uc *userController) SetAttributeSchema(ctx echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request().Body, patchBodyLimit))
+	if err != nil {
+		appError := apperrors.UserControllerSetAttributeSchemaBody.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := uc.FetchJWTUser(ctx)
+	schema := &model.UserAttributeSchema{Schema: body, CreatedBy: authUser.UserID.String()}
+	schema, err = uc.userAttributeSchemaUsecase.SetSchema(ctx.Request().Context(), schema)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, schema)
+}
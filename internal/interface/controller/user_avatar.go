@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"usermanager/internal/apperrors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// avatarFormField is the file field read when the avatar is sent as multipart/form-data.
+const avatarFormField = "avatar"
+
+// SetAvatar takes the image as the raw body or as the avatar field of a multipart form.
+// The image type is sniffed from the content, the declared type isn't trusted.
+func (uc *userController) SetAvatar(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerSetAvatarUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	data, err := uc.readAvatar(ctx)
+	if err != nil {
+		appError := apperrors.UserControllerSetAvatarReadBody.AppendMessage(err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			appError = apperrors.UserControllerSetAvatarTooLarge.AppendMessage(err)
+		}
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userUsecase.GetUser(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if user == nil {
+		appError := apperrors.UserControllerSetAvatarUserNotExist
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	expectedVersion, err := ifMatchVersion(ctx, user)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	updatedUser, err := uc.userAvatarUsecase.SetAvatar(ctx.Request().Context(), userUUID, expectedVersion, data)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ctx.Response().Header().Set("ETag", updatedUser.ETag())
+	return ctx.JSON(http.StatusOK, updatedUser.MapUserModelToUpdateUserResponse())
+}
+
+func (uc *userController) DeleteAvatar(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerDeleteAvatarUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userUsecase.GetUser(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if user == nil {
+		appError := apperrors.UserControllerDeleteAvatarUserNotExist
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	expectedVersion, err := ifMatchVersion(ctx, user)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	updatedUser, err := uc.userAvatarUsecase.DeleteAvatar(ctx.Request().Context(), userUUID, expectedVersion)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ctx.Response().Header().Set("ETag", updatedUser.ETag())
+	return ctx.JSON(http.StatusOK, updatedUser.MapUserModelToUpdateUserResponse())
+}
+
+// GetAvatarFile serves stored avatars. Every upload is stored under new keys, so the files
+// never change and can be cached for good.
+func (uc *userController) GetAvatarFile(ctx echo.Context) error {
+	blob, err := uc.userAvatarUsecase.GetAvatarFile(ctx.Request().Context(), ctx.Param("*"))
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	header := ctx.Response().Header()
+	header.Set("Cache-Control", "public, max-age=31536000, immutable")
+	header.Set("X-Content-Type-Options", "nosniff")
+	return ctx.Blob(http.StatusOK, blob.ContentType, blob.Data)
+}
+
+func (uc *userController) readAvatar(ctx echo.Context) ([]byte, error) {
+	request := ctx.Request()
+	request.Body = http.MaxBytesReader(ctx.Response(), request.Body, uc.cfg.Avatar.MaxSize)
+
+	contentType, _, _ := mime.ParseMediaType(request.Header.Get(echo.HeaderContentType))
+	if contentType != echo.MIMEMultipartForm {
+		return io.ReadAll(request.Body)
+	}
+
+	file, _, err := request.FormFile(avatarFormField)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ExportUsers streams every listed user as CSV (default), NDJSON or Parquet. It takes the
+// filters of GET /users, ?format= and a comma separated ?fields=, where votes is opt-in.
+func (uc *userController) ExportUsers(ctx echo.Context) error {
+	filter, err := utils.GetUserFilterFromCtx(ctx.QueryParams())
+	if err != nil {
+		appError := apperrors.UserControllerExportUsersFilter.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	if filter.IsEmpty() {
+		filter = nil
+	}
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = model.UserExportFormatCSV
+	}
+	exportQuery, err := model.NewUserExportQuery(format, ctx.QueryParam("fields"), filter)
+	if err != nil {
+		appError := apperrors.UserControllerExportUsersQuery.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	// Headers are only sent with the first bytes, so an error before that is still
+	// answered with a status of its own.
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentType, model.UserExportContentType(exportQuery.Format))
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="users-%s.%s"`, time.Now().UTC().Format("20060102T150405Z"), exportQuery.Format))
+	_, err = uc.userExportUsecase.ExportUsers(ctx.Request().Context(), exportQuery, ctx.Response())
+	if err != nil {
+		if ctx.Response().Committed {
+			ctx.Logger().Error(err)
+			return nil
+		}
+		header.Del(echo.HeaderContentDisposition)
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"net/http"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func (uc *userController) GetUserChildren(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetUserChildrenUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	children, err := uc.userHierarchyUsecase.GetUserChildren(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, model.MapUserTreeToUserTreeResponse(userUUID, children))
+}
+
+func (uc *userController) GetUserDescendants(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetUserDescendantsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	descendants, err := uc.userHierarchyUsecase.GetUserDescendants(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, model.MapUserTreeToUserTreeResponse(userUUID, descendants))
+}
+
+// GetUserAncestors walks up from the user. Unlike descendants, the ancestors aren't owned by
+// the caller, so they are shown as any other profile.
+func (uc *userController) GetUserAncestors(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetUserAncestorsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ancestors, err := uc.userHierarchyUsecase.GetUserAncestors(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := uc.FetchJWTUser(ctx)
+	visibleAncestors := make([]*model.UserTreeNode, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		if visibleAncestor := ancestor.VisibleTo(authUser, uc.cfg.Profile.PrivateMode); visibleAncestor != nil {
+			visibleAncestors = append(visibleAncestors, visibleAncestor)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.MapUserTreeToUserTreeResponse(userUUID, visibleAncestors))
+}
+
+func (uc *userController) CreateUserChild(ctx echo.Context) error {
+	parentUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerCreateUserChildUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	createUser := &model.CreateUserRequest{}
+	if err = ctx.Bind(createUser); err != nil {
+		appError := apperrors.UserControllerCreateUserChildBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user := &model.User{}
+	authUser := uc.FetchJWTUser(ctx)
+	user.MapCreateUserRequestToUserModel(createUser)
+	user.Created.By = authUser.UserID.String()
+
+	createdUser, err := uc.userHierarchyUsecase.CreateUserChild(ctx.Request().Context(), parentUUID, user)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusCreated, createdUser.MapUserModelToCreateUserResponse())
+}
+
+// SetUserParent transfers the ownership of the user. Who may do it is decided by the usecase,
+// the user itself can't leave its parent.
+func (uc *userController) SetUserParent(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerSetUserParentUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	setUserParent := &model.SetUserParentRequest{}
+	if err = ctx.Bind(setUserParent); err != nil {
+		appError := apperrors.UserControllerSetUserParentBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userHierarchyUsecase.SetUserParent(ctx.Request().Context(), userUUID, setUserParent.ParentID, uc.FetchJWTUser(ctx))
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, user.MapUserModelToGetUserResponse())
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ImportUsers queues the CSV or NDJSON body for the import worker and answers with the job
+// to poll. The format comes from ?format= or, failing that, from the content type.
+func (uc *userController) ImportUsers(ctx echo.Context) error {
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format, _, _ = mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	}
+	format, err := model.UserImportFormat(format)
+	if err != nil {
+		appError := apperrors.UserControllerImportUsersFormat.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	options := model.UserImportOptions{}
+	for param, option := range map[string]*bool{
+		"dry_run":            &options.DryRun,
+		"upsert":             &options.Upsert,
+		"generate_passwords": &options.GeneratePasswords,
+	} {
+		if value := ctx.QueryParam(param); value != "" {
+			*option, err = strconv.ParseBool(value)
+			if err != nil {
+				appError := apperrors.UserControllerImportUsersOptionParse.AppendMessage(param, err)
+				return ctx.JSON(appError.HTTPCode, appError.Error())
+			}
+		}
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Response(), ctx.Request().Body, uc.cfg.Import.MaxSize))
+	if err != nil {
+		appError := apperrors.UserControllerImportUsersReadBody.AppendMessage(err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			appError = apperrors.UserControllerImportUsersTooLarge.AppendMessage(err)
+		}
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	authUser := uc.FetchJWTUser(ctx)
+	job := model.NewUserImportJob(format, options, authUser.UserID.String())
+	job, err = uc.userImportUsecase.CreateImportJob(ctx.Request().Context(), job, payload)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	ctx.Response().Header().Set(echo.HeaderLocation, "/users/import/"+job.JobID.String())
+	return ctx.JSON(http.StatusAccepted, job)
+}
+
+func (uc *userController) GetImportJob(ctx echo.Context) error {
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetImportJobUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	job, err := uc.userImportUsecase.GetImportJob(ctx.Request().Context(), jobID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"usermanager/internal/apperrors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func (uc *userController) GetUserSettings(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerGetUserSettingsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	settings, err := uc.userSettingUsecase.GetUserSettings(ctx.Request().Context(), userUUID)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateUserSettings takes a JSON object of the settings to change. The settings left out
+// keep their values, a null resets one to its default.
+func (uc *userController) UpdateUserSettings(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerUpdateUserSettingsUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	body, err := io.ReadAll(io.LimitReader(ctx.Request().Body, patchBodyLimit))
+	if err != nil {
+		appError := apperrors.UserControllerUpdateUserSettingsBody.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	changes := map[string]any{}
+	if err = json.Unmarshal(body, &changes); err != nil {
+		appError := apperrors.UserControllerUpdateUserSettingsBody.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	settings, err := uc.userSettingUsecase.UpdateUserSettings(ctx.Request().Context(), userUUID, changes)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func (uc *userController) SuspendUser(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerSuspendUserUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	suspendUser := &model.SuspendUserRequest{}
+	if err = ctx.Bind(suspendUser); err != nil {
+		appError := apperrors.UserControllerSuspendUserBind.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+	err = ctx.Validate(suspendUser)
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userStatusUsecase.SuspendUser(ctx.Request().Context(), userUUID, suspendUser, uc.FetchJWTUser(ctx))
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, user.MapUserModelToUserStatusResponse(time.Now()))
+}
+
+func (uc *userController) UnsuspendUser(ctx echo.Context) error {
+	userUUID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := apperrors.UserControllerUnsuspendUserUuidParse.AppendMessage(err)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	user, err := uc.userStatusUsecase.UnsuspendUser(ctx.Request().Context(), userUUID, uc.FetchJWTUser(ctx))
+	if err != nil {
+		appError := err.(*apperrors.AppError)
+		return ctx.JSON(appError.HTTPCode, appError.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, user.MapUserModelToUserStatusResponse(time.Now()))
+}
@@ -2,4 +2,5 @@ package controller
 
 type UserManagerController struct {
 	UserController IUserController
+	RoleController IRoleController
 }
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+)
+
+// AuditRepository only appends to the audit log; there is no way to change or remove an entry.
+type AuditRepository interface {
+	SaveAuditEntry(ctx context.Context, entry *model.AuditEntry) (*model.AuditEntry, error)
+	FindAuditEntries(ctx context.Context, auditQuery *model.AuditQuery) ([]*model.AuditEntry, error)
+	StreamAuditEntries(ctx context.Context, auditQuery *model.AuditQuery, fn func(entry *model.AuditEntry) error) error
+}
+
+type auditRepo struct {
+	db *datastore.DB
+}
+
+func NewAuditRepository(db *datastore.DB) AuditRepository {
+	return &auditRepo{db: db}
+}
+
+func (r *auditRepo) SaveAuditEntry(ctx context.Context, entry *model.AuditEntry) (*model.AuditEntry, error) {
+	err := r.db.SQL.QueryRowxContext(
+		ctx,
+		addAuditEntry,
+		entry.ActorID,
+		entry.ActorNickname,
+		entry.Action,
+		entry.TargetID,
+		entry.Changes,
+		entry.IP,
+		entry.RequestID,
+		entry.CreatedAt,
+	).Scan(&entry.EntryID)
+	if err != nil {
+		return nil, apperrors.AuditRepoSaveAuditEntryQueryRowxContext.AppendMessage(err)
+	}
+	return entry, nil
+}
+
+// FindAuditEntries lists at most auditQuery.Limit entries, newest first.
+func (r *auditRepo) FindAuditEntries(ctx context.Context, auditQuery *model.AuditQuery) ([]*model.AuditEntry, error) {
+	query, args := buildAuditEntriesQuery(auditQuery, true)
+	entries := []*model.AuditEntry{}
+	err := r.db.SQL.SelectContext(ctx, &entries, query, args...)
+	if err != nil {
+		return nil, apperrors.AuditRepoFindAuditEntriesSelectContext.AppendMessage(err)
+	}
+	return entries, nil
+}
+
+// StreamAuditEntries hands every matching entry to fn, oldest first, without a limit. An
+// error returned by fn stops the stream and is returned as is.
+func (r *auditRepo) StreamAuditEntries(ctx context.Context, auditQuery *model.AuditQuery, fn func(entry *model.AuditEntry) error) error {
+	query, args := buildAuditEntriesQuery(auditQuery, false)
+	rows, err := r.db.SQL.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return apperrors.AuditRepoStreamAuditEntriesQueryxContext.AppendMessage(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := &model.AuditEntry{}
+		if err = rows.StructScan(entry); err != nil {
+			return apperrors.AuditRepoStreamAuditEntriesStructScan.AppendMessage(err)
+		}
+		if err = fn(entry); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return apperrors.AuditRepoStreamAuditEntriesRows.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func buildAuditEntriesQuery(auditQuery *model.AuditQuery, page bool) (string, []any) {
+	var query strings.Builder
+	args := make([]any, 0)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		query.WriteString(" AND ")
+		query.WriteString(strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	query.WriteString(getAuditEntries)
+	if auditQuery.TargetID != nil {
+		addCondition("target_id = ?", *auditQuery.TargetID)
+	}
+	if auditQuery.ActorID != nil {
+		addCondition("actor_id = ?", *auditQuery.ActorID)
+	}
+	if auditQuery.Action != "" {
+		addCondition("action = ?", auditQuery.Action)
+	}
+	if auditQuery.From != nil {
+		addCondition("created_at >= ?", *auditQuery.From)
+	}
+	if auditQuery.To != nil {
+		addCondition("created_at < ?", *auditQuery.To)
+	}
+	if auditQuery.Before > 0 {
+		addCondition("entry_id < ?", auditQuery.Before)
+	}
+
+	if !page {
+		query.WriteString(" ORDER BY entry_id")
+		return query.String(), args
+	}
+	query.WriteString(" ORDER BY entry_id DESC LIMIT " + strconv.Itoa(auditQuery.Limit))
+	return query.String(), args
+}
@@ -0,0 +1,15 @@
+package repository
+
+const (
+	addAuditEntry = `INSERT INTO audit_log (actor_id, actor_nickname, action, target_id, changes, ip, request_id, created_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+					RETURNING entry_id`
+
+	// redactAuditChangesOfUser and redactAuditActorOfUser are the one exception to audit_log
+	// being append-only: erasing a user takes their data out of the entries, the entries stay.
+	redactAuditChangesOfUser = `UPDATE audit_log SET changes = NULL WHERE target_id = $1 AND changes IS NOT NULL`
+
+	redactAuditActorOfUser = `UPDATE audit_log SET actor_nickname = $2, ip = '' WHERE actor_id = $1`
+
+	getAuditEntries = `SELECT entry_id, actor_id, actor_nickname, action, target_id, changes, ip, request_id, created_at FROM audit_log WHERE TRUE`
+)
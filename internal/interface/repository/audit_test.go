@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAuditEntriesQuery(t *testing.T) {
+	targetID := uuid.New()
+	auditQuery := &model.AuditQuery{TargetID: &targetID, Action: model.AuditActionUserPatch, Before: 42, Limit: 10}
+
+	query, args := buildAuditEntriesQuery(auditQuery, true)
+	query = strings.Join(strings.Fields(query), " ")
+	assert.True(t, strings.HasSuffix(query, "WHERE TRUE AND target_id = $1 AND action = $2 AND entry_id < $3 ORDER BY entry_id DESC LIMIT 10"), query)
+	assert.Equal(t, []any{targetID, model.AuditActionUserPatch, int64(42)}, args)
+
+	query, _ = buildAuditEntriesQuery(&model.AuditQuery{Limit: 10}, false)
+	assert.True(t, strings.HasSuffix(query, "WHERE TRUE ORDER BY entry_id"), query)
+}
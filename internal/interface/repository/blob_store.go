@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"path"
+	"strings"
+)
+
+type Blob struct {
+	Data        []byte
+	ContentType string
+}
+
+// BlobStore keeps files such as avatars under slash separated keys. URL tells where a stored
+// blob can be downloaded from.
+type BlobStore interface {
+	Put(ctx context.Context, key string, blob *Blob) error
+	Get(ctx context.Context, key string) (*Blob, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// isValidBlobKey rejects keys that are empty, absolute or climb out with "..".
+func isValidBlobKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, "/") && path.Clean(key) == key && !strings.HasPrefix(key, "../") && key != ".."
+}
+
+func blobURL(publicURL string, key string) string {
+	return strings.TrimSuffix(publicURL, "/") + "/" + key
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"usermanager/internal/apperrors"
+)
+
+type localBlobStore struct {
+	dir       string
+	publicURL string
+}
+
+// NewLocalBlobStore keeps blobs as files under dir. The content type isn't stored, it's
+// read back from the key extension.
+func NewLocalBlobStore(dir string, publicURL string) BlobStore {
+	return &localBlobStore{dir: dir, publicURL: publicURL}
+}
+
+func (s *localBlobStore) Put(ctx context.Context, key string, blob *Blob) error {
+	if !isValidBlobKey(key) {
+		return apperrors.BlobStoreInvalidKey.AppendMessage(key)
+	}
+	filePath := filepath.Join(s.dir, filepath.FromSlash(key))
+	err := os.MkdirAll(filepath.Dir(filePath), 0o755)
+	if err != nil {
+		return apperrors.BlobStorePut.AppendMessage(err)
+	}
+
+	// Write next to the target and rename, so a reader never sees half a file.
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".blob-*")
+	if err != nil {
+		return apperrors.BlobStorePut.AppendMessage(err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(blob.Data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), filePath)
+	}
+	if err != nil {
+		return apperrors.BlobStorePut.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (s *localBlobStore) Get(ctx context.Context, key string) (*Blob, error) {
+	if !isValidBlobKey(key) {
+		return nil, apperrors.BlobStoreGetNotFound.AppendMessage(key)
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, apperrors.BlobStoreGetNotFound.AppendMessage(key)
+		}
+		return nil, apperrors.BlobStoreGet.AppendMessage(err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Blob{Data: data, ContentType: contentType}, nil
+}
+
+func (s *localBlobStore) Delete(ctx context.Context, key string) error {
+	if !isValidBlobKey(key) {
+		return apperrors.BlobStoreInvalidKey.AppendMessage(key)
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return apperrors.BlobStoreDelete.AppendMessage(err)
+	}
+	return nil
+}
+
+func (s *localBlobStore) URL(key string) string {
+	return blobURL(s.publicURL, key)
+}
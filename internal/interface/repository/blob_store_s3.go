@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+)
+
+const (
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3Service          = "s3"
+	// s3ErrorBodyLimit is how much of an error response is kept for the error message.
+	s3ErrorBodyLimit = 1 << 10
+	s3RequestTimeout = 30 * time.Second
+)
+
+type S3BlobStoreConfig struct {
+	Endpoint  url.URL
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle puts the bucket in the path instead of the host name, which most
+	// S3 compatible servers expect.
+	PathStyle bool
+	// PublicURL is where blobs are downloaded from, for instance a CDN. The object URL
+	// is used when it's empty.
+	PublicURL string
+}
+
+// s3BlobStore talks to the S3 REST API directly; only PUT, GET and DELETE of single
+// objects are needed, signed with AWS Signature Version 4.
+type s3BlobStore struct {
+	cfg    S3BlobStoreConfig
+	client *http.Client
+}
+
+func NewS3BlobStore(cfg S3BlobStoreConfig) BlobStore {
+	return &s3BlobStore{cfg: cfg, client: &http.Client{Timeout: s3RequestTimeout}}
+}
+
+func (s *s3BlobStore) Put(ctx context.Context, key string, blob *Blob) error {
+	if !isValidBlobKey(key) {
+		return apperrors.BlobStoreInvalidKey.AppendMessage(key)
+	}
+	response, err := s.do(ctx, http.MethodPut, key, blob)
+	if err != nil {
+		return apperrors.BlobStorePut.AppendMessage(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return apperrors.BlobStorePut.AppendMessage(s3ResponseError(response))
+	}
+
+	return nil
+}
+
+func (s *s3BlobStore) Get(ctx context.Context, key string) (*Blob, error) {
+	if !isValidBlobKey(key) {
+		return nil, apperrors.BlobStoreGetNotFound.AppendMessage(key)
+	}
+	response, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, apperrors.BlobStoreGet.AppendMessage(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, apperrors.BlobStoreGetNotFound.AppendMessage(key)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, apperrors.BlobStoreGet.AppendMessage(s3ResponseError(response))
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, apperrors.BlobStoreGet.AppendMessage(err)
+	}
+	return &Blob{Data: data, ContentType: response.Header.Get("Content-Type")}, nil
+}
+
+func (s *s3BlobStore) Delete(ctx context.Context, key string) error {
+	if !isValidBlobKey(key) {
+		return apperrors.BlobStoreInvalidKey.AppendMessage(key)
+	}
+	response, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return apperrors.BlobStoreDelete.AppendMessage(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return apperrors.BlobStoreDelete.AppendMessage(s3ResponseError(response))
+	}
+
+	return nil
+}
+
+func (s *s3BlobStore) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return blobURL(s.cfg.PublicURL, key)
+	}
+	return s.objectURL(key).String()
+}
+
+func (s *s3BlobStore) objectURL(key string) *url.URL {
+	objectURL := s.cfg.Endpoint
+	objectPath := strings.TrimSuffix(objectURL.Path, "/") + "/" + key
+	if s.cfg.PathStyle {
+		objectPath = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		objectURL.Host = s.cfg.Bucket + "." + objectURL.Host
+	}
+	objectURL.Path = objectPath
+	objectURL.RawPath = s3EscapePath(objectPath)
+
+	return &objectURL
+}
+
+func (s *s3BlobStore) do(ctx context.Context, method string, key string, blob *Blob) (*http.Response, error) {
+	var payload []byte
+	if blob != nil {
+		payload = blob.Data
+	}
+	request, err := http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if blob != nil {
+		request.Header.Set("Content-Type", blob.ContentType)
+	}
+	signS3Request(request, payload, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, time.Now())
+
+	return s.client.Do(request)
+}
+
+// signS3Request adds the AWS Signature Version 4 headers. Every header already set on the
+// request is signed, together with the host.
+func signS3Request(request *http.Request, payload []byte, accessKey string, secretKey string, region string, now time.Time) {
+	payloadHash := sha256Hex(payload)
+	amzDate := now.UTC().Format("20060102T150405Z")
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		s3EscapePath(request.URL.Path),
+		strings.ReplaceAll(request.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{s3SigningAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := []byte("AWS4" + secretKey)
+	for _, part := range []string{amzDate[:8], region, s3Service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, accessKey, scope, signedHeaders, signature))
+}
+
+// s3EscapePath percent-encodes everything but unreserved characters and slashes, as S3
+// expects in the canonical request.
+func s3EscapePath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			escaped.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&escaped, "%%%02X", c)
+	}
+	return escaped.String()
+}
+
+func s3ResponseError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, s3ErrorBodyLimit))
+	return fmt.Errorf("s3 responded %s: %s", response.Status, strings.TrimSpace(string(body)))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
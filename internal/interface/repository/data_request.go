@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type DataRequestRepository interface {
+	SaveDataRequest(ctx context.Context, dataRequest *model.DataRequest) (*model.DataRequest, error)
+	FindDataRequest(ctx context.Context, dataRequestID uuid.UUID) (*model.DataRequest, error)
+	GetDataRequests(ctx context.Context, dataRequestQuery *model.DataRequestQuery) ([]*model.DataRequest, error)
+	ClaimNextDataRequest(ctx context.Context, startedAt time.Time) (*model.DataRequest, error)
+	FinishDataRequest(ctx context.Context, dataRequest *model.DataRequest) error
+	GetExpiredDataExports(ctx context.Context, now time.Time) ([]*model.DataRequest, error)
+	ClearDataExportArchive(ctx context.Context, dataRequestID uuid.UUID) error
+}
+
+type dataRequestRepo struct {
+	db *datastore.DB
+}
+
+func NewDataRequestRepository(db *datastore.DB) DataRequestRepository {
+	return &dataRequestRepo{db: db}
+}
+
+func (r *dataRequestRepo) SaveDataRequest(ctx context.Context, dataRequest *model.DataRequest) (*model.DataRequest, error) {
+	_, err := r.db.SQL.ExecContext(
+		ctx,
+		addDataRequest,
+		dataRequest.DataRequestID,
+		dataRequest.UserID,
+		dataRequest.Kind,
+		dataRequest.Format,
+		dataRequest.Status,
+		dataRequest.RequestedByID,
+		dataRequest.RequestedBy,
+		dataRequest.Error,
+		dataRequest.ArchiveKey,
+		dataRequest.CreatedAt,
+		dataRequest.StartedAt,
+		dataRequest.FinishedAt,
+		dataRequest.ExpiresAt,
+	)
+	if err != nil {
+		return nil, apperrors.DataRequestRepoSaveDataRequestExecContext.AppendMessage(err)
+	}
+	return dataRequest, nil
+}
+
+func (r *dataRequestRepo) FindDataRequest(ctx context.Context, dataRequestID uuid.UUID) (*model.DataRequest, error) {
+	dataRequest := &model.DataRequest{}
+	err := r.db.SQL.GetContext(ctx, dataRequest, getDataRequest, dataRequestID)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.DataRequestRepoFindDataRequestGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.DataRequestRepoFindDataRequestGetContext.AppendMessage(err)
+	}
+	return dataRequest, nil
+}
+
+// GetDataRequests lists the newest requests first.
+func (r *dataRequestRepo) GetDataRequests(ctx context.Context, dataRequestQuery *model.DataRequestQuery) ([]*model.DataRequest, error) {
+	var query strings.Builder
+	args := make([]any, 0)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		query.WriteString(" AND " + condition + " = $" + strconv.Itoa(len(args)))
+	}
+	query.WriteString(getDataRequests)
+	if dataRequestQuery.UserID != nil {
+		addCondition("user_id", *dataRequestQuery.UserID)
+	}
+	if dataRequestQuery.Kind != "" {
+		addCondition("kind", dataRequestQuery.Kind)
+	}
+	if dataRequestQuery.Status != "" {
+		addCondition("status", dataRequestQuery.Status)
+	}
+	query.WriteString(" ORDER BY created_at DESC LIMIT " + strconv.Itoa(dataRequestQuery.Limit))
+
+	dataRequests := []*model.DataRequest{}
+	err := r.db.SQL.SelectContext(ctx, &dataRequests, query.String(), args...)
+	if err != nil {
+		return nil, apperrors.DataRequestRepoGetDataRequestsSelectContext.AppendMessage(err)
+	}
+	return dataRequests, nil
+}
+
+// ClaimNextDataRequest marks the oldest queued request as running and returns it. It
+// returns a nil request when nothing is queued.
+func (r *dataRequestRepo) ClaimNextDataRequest(ctx context.Context, startedAt time.Time) (*model.DataRequest, error) {
+	dataRequest := &model.DataRequest{}
+	err := r.db.SQL.GetContext(ctx, dataRequest, claimDataRequest, startedAt)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, nil
+		}
+		return nil, apperrors.DataRequestRepoClaimNextDataRequestGetContext.AppendMessage(err)
+	}
+	return dataRequest, nil
+}
+
+func (r *dataRequestRepo) FinishDataRequest(ctx context.Context, dataRequest *model.DataRequest) error {
+	_, err := r.db.SQL.ExecContext(
+		ctx,
+		finishDataRequest,
+		dataRequest.DataRequestID,
+		dataRequest.Status,
+		dataRequest.Error,
+		dataRequest.ArchiveKey,
+		dataRequest.FinishedAt,
+		dataRequest.ExpiresAt,
+	)
+	if err != nil {
+		return apperrors.DataRequestRepoFinishDataRequestExecContext.AppendMessage(err)
+	}
+	return nil
+}
+
+func (r *dataRequestRepo) GetExpiredDataExports(ctx context.Context, now time.Time) ([]*model.DataRequest, error) {
+	dataRequests := []*model.DataRequest{}
+	err := r.db.SQL.SelectContext(ctx, &dataRequests, getExpiredDataExports, now)
+	if err != nil {
+		return nil, apperrors.DataRequestRepoGetExpiredDataExportsSelectContext.AppendMessage(err)
+	}
+	return dataRequests, nil
+}
+
+func (r *dataRequestRepo) ClearDataExportArchive(ctx context.Context, dataRequestID uuid.UUID) error {
+	_, err := r.db.SQL.ExecContext(ctx, clearDataExportArchive, dataRequestID)
+	if err != nil {
+		return apperrors.DataRequestRepoClearDataExportArchiveExecContext.AppendMessage(err)
+	}
+	return nil
+}
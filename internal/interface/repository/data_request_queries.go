@@ -0,0 +1,31 @@
+package repository
+
+const (
+	dataRequestColumns = `data_request_id, user_id, kind, format, status, requested_by_id, requested_by, error, archive_key, created_at, started_at, finished_at, expires_at`
+
+	addDataRequest = `INSERT INTO data_requests (` + dataRequestColumns + `)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	getDataRequest = `SELECT ` + dataRequestColumns + ` FROM data_requests WHERE data_request_id = $1`
+
+	getDataRequests = `SELECT ` + dataRequestColumns + ` FROM data_requests WHERE TRUE`
+
+	// claimDataRequest skips rows another worker has locked, so each request runs once.
+	claimDataRequest = `UPDATE data_requests SET status = 'running', started_at = $1
+					WHERE data_request_id = (
+						SELECT data_request_id FROM data_requests
+						WHERE status = 'queued'
+						ORDER BY created_at
+						LIMIT 1
+						FOR UPDATE SKIP LOCKED
+					)
+					RETURNING ` + dataRequestColumns
+
+	finishDataRequest = `UPDATE data_requests SET status = $2, error = $3, archive_key = $4, finished_at = $5, expires_at = $6
+					WHERE data_request_id = $1`
+
+	getExpiredDataExports = `SELECT ` + dataRequestColumns + ` FROM data_requests
+					WHERE kind = 'export' AND archive_key <> '' AND expires_at < $1`
+
+	clearDataExportArchive = `UPDATE data_requests SET archive_key = '' WHERE data_request_id = $1`
+)
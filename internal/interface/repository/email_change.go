@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type EmailChangeRepository interface {
+	SaveEmailChange(ctx context.Context, emailChange *model.EmailChange) (*model.EmailChange, error)
+	FindEmailChangeByConfirmTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error)
+	FindEmailChangeByCancelTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error)
+	ConfirmEmailChange(ctx context.Context, emailChange *model.EmailChange, confirmedAt time.Time) (*model.User, error)
+	CancelEmailChange(ctx context.Context, changeID uuid.UUID, cancelledAt time.Time) error
+	CancelPendingEmailChanges(ctx context.Context, userID uuid.UUID, cancelledAt time.Time) error
+	GetEmailChangesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.EmailChange, error)
+}
+
+type emailChangeRepo struct {
+	db *datastore.DB
+}
+
+func NewEmailChangeRepository(db *datastore.DB) EmailChangeRepository {
+	return &emailChangeRepo{db: db}
+}
+
+func (r *emailChangeRepo) SaveEmailChange(ctx context.Context, emailChange *model.EmailChange) (*model.EmailChange, error) {
+	_, err := r.db.SQL.ExecContext(
+		ctx,
+		addEmailChange,
+		emailChange.ChangeID,
+		emailChange.UserID,
+		emailChange.OldEmail,
+		emailChange.NewEmail,
+		emailChange.ConfirmTokenHash,
+		emailChange.CancelTokenHash,
+		emailChange.CreatedAt,
+		emailChange.ExpiresAt,
+	)
+	if err != nil {
+		return nil, apperrors.EmailChangeRepoSaveEmailChangeExecContext.AppendMessage(err)
+	}
+	return emailChange, nil
+}
+
+func (r *emailChangeRepo) FindEmailChangeByConfirmTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error) {
+	return r.findEmailChange(ctx, getEmailChangeByConfirmTokenHash, tokenHash)
+}
+
+func (r *emailChangeRepo) FindEmailChangeByCancelTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error) {
+	return r.findEmailChange(ctx, getEmailChangeByCancelTokenHash, tokenHash)
+}
+
+func (r *emailChangeRepo) findEmailChange(ctx context.Context, query string, tokenHash string) (*model.EmailChange, error) {
+	emailChange := &model.EmailChange{}
+	err := r.db.SQL.GetContext(ctx, emailChange, query, tokenHash)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.EmailChangeRepoFindEmailChangeGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.EmailChangeRepoFindEmailChangeGetContext.AppendMessage(err)
+	}
+	return emailChange, nil
+}
+
+// ConfirmEmailChange only marks a change that is still pending, so a token confirms once.
+// The new email is written to the user in the same transaction, a confirmed change is never
+// left without it.
+func (r *emailChangeRepo) ConfirmEmailChange(ctx context.Context, emailChange *model.EmailChange, confirmedAt time.Time) (*model.User, error) {
+	tx, err := r.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangeBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, confirmEmailChange, emailChange.ChangeID, confirmedAt)
+	if err != nil {
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangeExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangeExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangeNotPending.AppendMessage(emailChange.ChangeID)
+	}
+
+	query, args, err := buildPatchUserQuery(emailChange.UserID, 0, map[string]any{"email": emailChange.NewEmail}, confirmedAt)
+	if err != nil {
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangePatchUser.AppendMessage(err)
+	}
+	user := &model.User{}
+	err = tx.QueryRowxContext(ctx, query, args...).StructScan(user)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.EmailChangeRepoConfirmEmailChangeUserNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangePatchUser.AppendMessage(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperrors.EmailChangeRepoConfirmEmailChangeCommit.AppendMessage(err)
+	}
+	return user, nil
+}
+
+func (r *emailChangeRepo) CancelEmailChange(ctx context.Context, changeID uuid.UUID, cancelledAt time.Time) error {
+	result, err := r.db.SQL.ExecContext(ctx, cancelEmailChange, changeID, cancelledAt)
+	if err != nil {
+		return apperrors.EmailChangeRepoCancelEmailChangeExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.EmailChangeRepoCancelEmailChangeExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.EmailChangeRepoCancelEmailChangeNotPending.AppendMessage(changeID)
+	}
+	return nil
+}
+
+// CancelPendingEmailChanges is called before a new change is requested, so only the latest
+// confirmation link works.
+func (r *emailChangeRepo) CancelPendingEmailChanges(ctx context.Context, userID uuid.UUID, cancelledAt time.Time) error {
+	_, err := r.db.SQL.ExecContext(ctx, cancelPendingEmailChanges, userID, cancelledAt)
+	if err != nil {
+		return apperrors.EmailChangeRepoCancelPendingEmailChangesExecContext.AppendMessage(err)
+	}
+	return nil
+}
+
+func (r *emailChangeRepo) GetEmailChangesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.EmailChange, error) {
+	emailChanges := []*model.EmailChange{}
+	err := r.db.SQL.SelectContext(ctx, &emailChanges, getEmailChangesByUserID, userID)
+	if err != nil {
+		return nil, apperrors.EmailChangeRepoGetEmailChangesByUserIDSelectContext.AppendMessage(err)
+	}
+	return emailChanges, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+const (
+	addEmailChange = `INSERT INTO email_changes (change_id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, created_at, expires_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	getEmailChangeByConfirmTokenHash = `SELECT change_id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, created_at, expires_at, confirmed_at, cancelled_at
+					FROM email_changes WHERE confirm_token_hash = $1`
+
+	getEmailChangesByUserID = `SELECT change_id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, created_at, expires_at, confirmed_at, cancelled_at
+					FROM email_changes WHERE user_id = $1 ORDER BY created_at`
+
+	getEmailChangeByCancelTokenHash = `SELECT change_id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, created_at, expires_at, confirmed_at, cancelled_at
+					FROM email_changes WHERE cancel_token_hash = $1`
+
+	confirmEmailChange = `UPDATE email_changes SET confirmed_at = $2
+					WHERE change_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL AND expires_at > $2`
+
+	cancelEmailChange = `UPDATE email_changes SET cancelled_at = $2
+					WHERE change_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL`
+
+	cancelPendingEmailChanges = `UPDATE email_changes SET cancelled_at = $2
+					WHERE user_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL`
+)
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailChangeRepo_ConfirmEmailChange(t *testing.T) {
+	emailChange := &model.EmailChange{ChangeID: uuid.New(), UserID: uuid.New(), NewEmail: "new@test.test"}
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"UPDATE users": {columns: []string{"user_id", "email"}, rows: [][]driver.Value{{emailChange.UserID.String(), "new@test.test"}}},
+	})
+
+	user, err := NewEmailChangeRepository(db).ConfirmEmailChange(context.Background(), emailChange, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "new@test.test", user.Email)
+	assert.Len(t, fake.ran("UPDATE email_changes SET confirmed_at"), 1)
+	assert.True(t, fake.committed)
+}
+
+func TestEmailChangeRepo_ConfirmEmailChange_UserNotFound(t *testing.T) {
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"UPDATE users": {columns: []string{"user_id"}},
+	})
+
+	_, err := NewEmailChangeRepository(db).ConfirmEmailChange(context.Background(), &model.EmailChange{ChangeID: uuid.New(), UserID: uuid.New(), NewEmail: "new@test.test"}, time.Now())
+	assert.True(t, apperrors.Is(err, &apperrors.EmailChangeRepoConfirmEmailChangeUserNotFound), err)
+	assert.Len(t, fake.ran("UPDATE email_changes SET confirmed_at"), 1)
+	assert.False(t, fake.committed)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+)
+
+const eventChannel = "usermanager:events"
+
+type EventRedisRepository interface {
+	Publish(ctx context.Context, event *model.Event) error
+}
+
+type eventRedisRepo struct {
+	redis *datastore.Redis
+}
+
+func NewEventRedisRepository(redis *datastore.Redis) EventRedisRepository {
+	return &eventRedisRepo{redis: redis}
+}
+
+func (er *eventRedisRepo) Publish(ctx context.Context, event *model.Event) error {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return apperrors.EventRedisRepoPublishMarshal.AppendMessage(err)
+	}
+
+	err = er.redis.RedisClient.Publish(ctx, eventChannel, eventBytes).Err()
+	if err != nil {
+		return apperrors.EventRedisRepoPublishPublish.AppendMessage(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const fakeDriverName = "fake"
+
+var (
+	fakeDBsMu sync.Mutex
+	fakeDBs   = map[string]*fakeDB{}
+)
+
+func init() {
+	sql.Register(fakeDriverName, fakeDriver{})
+}
+
+// fakeResult answers the statements containing its key: queries get the rows, writes affect
+// one row unless noRows is set.
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	noRows  bool
+}
+
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+}
+
+// fakeDB records the statements run against it, so repository tests can check what a method
+// writes without a database.
+type fakeDB struct {
+	mu         sync.Mutex
+	results    map[string]*fakeResult
+	statements []fakeStatement
+	committed  bool
+}
+
+func newFakeDB(t *testing.T, results map[string]*fakeResult) (*datastore.DB, *fakeDB) {
+	fake := &fakeDB{results: results}
+	fakeDBsMu.Lock()
+	fakeDBs[t.Name()] = fake
+	fakeDBsMu.Unlock()
+	t.Cleanup(func() {
+		fakeDBsMu.Lock()
+		delete(fakeDBs, t.Name())
+		fakeDBsMu.Unlock()
+	})
+
+	db, err := sqlx.Open(fakeDriverName, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &datastore.DB{SQL: db}, fake
+}
+
+// ran returns the statements that contain part, in the order they ran.
+func (f *fakeDB) ran(part string) []fakeStatement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statements := []fakeStatement{}
+	for _, statement := range f.statements {
+		if strings.Contains(statement.query, part) {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+func (f *fakeDB) run(query string, args []driver.Value) *fakeResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, fakeStatement{query: query, args: args})
+	for part, result := range f.results {
+		if strings.Contains(query, part) {
+			return result
+		}
+	}
+	return &fakeResult{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeDBsMu.Lock()
+	defer fakeDBsMu.Unlock()
+	return &fakeConn{db: fakeDBs[name]}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{db: c.db}, nil
+}
+
+// CheckNamedValue lets every argument through as it is, the fake doesn't need driver values.
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	tx.db.committed = true
+	tx.db.mu.Unlock()
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	result := s.db.run(s.query, args)
+	if result.noRows {
+		return driver.RowsAffected(0), nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	result := s.db.run(s.query, args)
+	return &fakeRows{columns: result.columns, rows: result.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
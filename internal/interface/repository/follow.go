@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type FollowRepository interface {
+	SaveFollow(ctx context.Context, follow *model.Follow) (*model.Follow, error)
+	FindFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (*model.Follow, error)
+	AcceptFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID, acceptedAt time.Time) (*model.Follow, error)
+	DeleteFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error)
+	FindFollows(ctx context.Context, followQuery *model.FollowQuery) ([]*model.FollowEntry, error)
+	GetFollowCounts(ctx context.Context, userID uuid.UUID) (*model.FollowCounts, error)
+	GetFollowsByFollowerID(ctx context.Context, followerID uuid.UUID) ([]*model.Follow, error)
+}
+
+type followRepo struct {
+	db *datastore.DB
+}
+
+func NewFollowRepository(db *datastore.DB) FollowRepository {
+	return &followRepo{db: db}
+}
+
+// SaveFollow returns the follow already stored for the pair, if any, instead of the new one.
+func (r *followRepo) SaveFollow(ctx context.Context, follow *model.Follow) (*model.Follow, error) {
+	savedFollow := &model.Follow{}
+	err := r.db.SQL.QueryRowxContext(ctx, addFollow, follow.FollowerID, follow.FolloweeID, follow.Status, follow.CreatedAt, follow.AcceptedAt).StructScan(savedFollow)
+	if err != nil {
+		return nil, apperrors.FollowRepoSaveFollowQueryRowxContext.AppendMessage(err)
+	}
+	return savedFollow, nil
+}
+
+func (r *followRepo) FindFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (*model.Follow, error) {
+	follow := &model.Follow{}
+	err := r.db.SQL.GetContext(ctx, follow, getFollow, followerID, followeeID)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.FollowRepoFindFollowGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.FollowRepoFindFollowGetContext.AppendMessage(err)
+	}
+	return follow, nil
+}
+
+// AcceptFollow approves a pending follow. A follow that isn't pending reads as not found.
+func (r *followRepo) AcceptFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID, acceptedAt time.Time) (*model.Follow, error) {
+	follow := &model.Follow{}
+	err := r.db.SQL.QueryRowxContext(ctx, acceptFollow, followerID, followeeID, acceptedAt).StructScan(follow)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.FollowRepoAcceptFollowDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.FollowRepoAcceptFollowQueryRowxContext.AppendMessage(err)
+	}
+	return follow, nil
+}
+
+// DeleteFollow tells whether there was a follow, pending or not, to delete.
+func (r *followRepo) DeleteFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error) {
+	result, err := r.db.SQL.ExecContext(ctx, deleteFollow, followerID, followeeID)
+	if err != nil {
+		return false, apperrors.FollowRepoDeleteFollowExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.FollowRepoDeleteFollowRowsAffected.AppendMessage(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// FindFollows lists at most followQuery.Limit follows, newest first. Deleted users are left out.
+func (r *followRepo) FindFollows(ctx context.Context, followQuery *model.FollowQuery) ([]*model.FollowEntry, error) {
+	query, args := buildFollowsQuery(followQuery)
+	entries := []*model.FollowEntry{}
+	err := r.db.SQL.SelectContext(ctx, &entries, query, args...)
+	if err != nil {
+		return nil, apperrors.FollowRepoFindFollowsSelectContext.AppendMessage(err)
+	}
+	return entries, nil
+}
+
+// GetFollowCounts counts the accepted follows from and to users that aren't deleted.
+func (r *followRepo) GetFollowCounts(ctx context.Context, userID uuid.UUID) (*model.FollowCounts, error) {
+	counts := &model.FollowCounts{}
+	err := r.db.SQL.GetContext(ctx, counts, getFollowCounts, userID)
+	if err != nil {
+		return nil, apperrors.FollowRepoGetFollowCountsGetContext.AppendMessage(err)
+	}
+	return counts, nil
+}
+
+func (r *followRepo) GetFollowsByFollowerID(ctx context.Context, followerID uuid.UUID) ([]*model.Follow, error) {
+	follows := []*model.Follow{}
+	err := r.db.SQL.SelectContext(ctx, &follows, getFollowsByFollowerID, followerID)
+	if err != nil {
+		return nil, apperrors.FollowRepoGetFollowsByFollowerIDSelectContext.AppendMessage(err)
+	}
+	return follows, nil
+}
+
+func buildFollowsQuery(followQuery *model.FollowQuery) (string, []any) {
+	var query strings.Builder
+	args := []any{followQuery.UserID}
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		query.WriteString(" AND ")
+		query.WriteString(strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if followQuery.Direction == model.FollowDirectionFollowing {
+		query.WriteString(getFollowing)
+	} else {
+		query.WriteString(getFollowers)
+	}
+	addCondition("f.status = ?", followQuery.Status)
+	if followQuery.Before > 0 {
+		addCondition("f.follow_id < ?", followQuery.Before)
+	}
+	query.WriteString(" ORDER BY f.follow_id DESC LIMIT " + strconv.Itoa(followQuery.Limit))
+	return query.String(), args
+}
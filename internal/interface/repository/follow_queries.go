@@ -0,0 +1,39 @@
+package repository
+
+const (
+	followColumns = `follow_id, follower_id, followee_id, status, created_at, accepted_at`
+
+	// addFollow returns the stored follow untouched when there is one already
+	addFollow = `INSERT INTO follows (follower_id, followee_id, status, created_at, accepted_at)
+					VALUES ($1, $2, $3, $4, $5)
+					ON CONFLICT (follower_id, followee_id) DO UPDATE SET status = follows.status
+					RETURNING ` + followColumns
+
+	getFollow = `SELECT ` + followColumns + ` FROM follows WHERE follower_id = $1 AND followee_id = $2`
+
+	acceptFollow = `UPDATE follows SET status = 'accepted', accepted_at = $3
+					WHERE follower_id = $1 AND followee_id = $2 AND status = 'pending'
+					RETURNING ` + followColumns
+
+	deleteFollow = `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`
+
+	getFollowsByFollowerID = `SELECT ` + followColumns + ` FROM follows WHERE follower_id = $1 ORDER BY follow_id`
+
+	getFollowCounts = `SELECT
+					(SELECT count(*) FROM follows f JOIN users u ON u.user_id = f.follower_id AND u.deleted_at IS NULL
+						WHERE f.followee_id = $1 AND f.status = 'accepted') AS followers,
+					(SELECT count(*) FROM follows f JOIN users u ON u.user_id = f.followee_id AND u.deleted_at IS NULL
+						WHERE f.follower_id = $1 AND f.status = 'accepted') AS following`
+
+	// getFollowers and getFollowing list the other user of each follow, mutual when that user
+	// is followed back
+	getFollowers = `SELECT f.follow_id, u.user_id, u.nickname, u.is_public, f.status, f.created_at,
+					EXISTS (SELECT 1 FROM follows b WHERE b.follower_id = $1 AND b.followee_id = f.follower_id AND b.status = 'accepted') AS mutual
+					FROM follows f JOIN users u ON u.user_id = f.follower_id AND u.deleted_at IS NULL
+					WHERE f.followee_id = $1`
+
+	getFollowing = `SELECT f.follow_id, u.user_id, u.nickname, u.is_public, f.status, f.created_at,
+					EXISTS (SELECT 1 FROM follows b WHERE b.follower_id = f.followee_id AND b.followee_id = $1 AND b.status = 'accepted') AS mutual
+					FROM follows f JOIN users u ON u.user_id = f.followee_id AND u.deleted_at IS NULL
+					WHERE f.follower_id = $1`
+)
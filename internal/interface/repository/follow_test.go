@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildFollowsQuery(t *testing.T) {
+	userID := uuid.New()
+	followQuery := &model.FollowQuery{UserID: userID, Direction: model.FollowDirectionFollowing, Status: model.FollowStatusAccepted, Before: 42, Limit: 10}
+
+	query, args := buildFollowsQuery(followQuery)
+	query = strings.Join(strings.Fields(query), " ")
+	assert.Contains(t, query, "JOIN users u ON u.user_id = f.followee_id")
+	assert.True(t, strings.HasSuffix(query, "WHERE f.follower_id = $1 AND f.status = $2 AND f.follow_id < $3 ORDER BY f.follow_id DESC LIMIT 10"), query)
+	assert.Equal(t, []any{userID, model.FollowStatusAccepted, int64(42)}, args)
+
+	query, _ = buildFollowsQuery(&model.FollowQuery{UserID: userID, Direction: model.FollowDirectionFollowers, Status: model.FollowStatusPending, Limit: 10})
+	query = strings.Join(strings.Fields(query), " ")
+	assert.True(t, strings.HasSuffix(query, "WHERE f.followee_id = $1 AND f.status = $2 ORDER BY f.follow_id DESC LIMIT 10"), query)
+}
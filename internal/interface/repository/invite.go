@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type InviteRepository interface {
+	SaveInvite(ctx context.Context, invite *model.Invite) (*model.Invite, error)
+	FindInviteByID(ctx context.Context, inviteID uuid.UUID) (*model.Invite, error)
+	FindInviteByTokenHash(ctx context.Context, tokenHash string) (*model.Invite, error)
+	GetInvitesByInviterID(ctx context.Context, inviterID uuid.UUID) ([]*model.Invite, error)
+	AcceptInvite(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID, acceptedAt time.Time) error
+	RevokeInvite(ctx context.Context, inviteID uuid.UUID, revokedAt time.Time) error
+	RevokePendingInvites(ctx context.Context, emailKey string, revokedAt time.Time) error
+}
+
+type inviteRepo struct {
+	db *datastore.DB
+}
+
+func NewInviteRepository(db *datastore.DB) InviteRepository {
+	return &inviteRepo{db: db}
+}
+
+func (r *inviteRepo) SaveInvite(ctx context.Context, invite *model.Invite) (*model.Invite, error) {
+	_, err := r.db.SQL.ExecContext(
+		ctx,
+		addInvite,
+		invite.InviteID,
+		invite.Email,
+		invite.EmailKey,
+		invite.Role,
+		invite.InvitedByID,
+		invite.TokenHash,
+		invite.CreatedAt,
+		invite.ExpiresAt,
+	)
+	if err != nil {
+		return nil, apperrors.InviteRepoSaveInviteExecContext.AppendMessage(err)
+	}
+	return invite, nil
+}
+
+func (r *inviteRepo) FindInviteByID(ctx context.Context, inviteID uuid.UUID) (*model.Invite, error) {
+	return r.findInvite(ctx, getInviteByID, inviteID)
+}
+
+func (r *inviteRepo) FindInviteByTokenHash(ctx context.Context, tokenHash string) (*model.Invite, error) {
+	return r.findInvite(ctx, getInviteByTokenHash, tokenHash)
+}
+
+func (r *inviteRepo) findInvite(ctx context.Context, query string, arg any) (*model.Invite, error) {
+	invite := &model.Invite{}
+	err := r.db.SQL.GetContext(ctx, invite, query, arg)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.InviteRepoFindInviteGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.InviteRepoFindInviteGetContext.AppendMessage(err)
+	}
+	return invite, nil
+}
+
+func (r *inviteRepo) GetInvitesByInviterID(ctx context.Context, inviterID uuid.UUID) ([]*model.Invite, error) {
+	invites := []*model.Invite{}
+	err := r.db.SQL.SelectContext(ctx, &invites, getInvitesByInviterID, inviterID)
+	if err != nil {
+		return nil, apperrors.InviteRepoGetInvitesByInviterIDSelectContext.AppendMessage(err)
+	}
+	return invites, nil
+}
+
+// AcceptInvite only marks an invite that is still pending, so a token signs up once.
+func (r *inviteRepo) AcceptInvite(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID, acceptedAt time.Time) error {
+	result, err := r.db.SQL.ExecContext(ctx, acceptInvite, inviteID, userID, acceptedAt)
+	if err != nil {
+		return apperrors.InviteRepoAcceptInviteExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.InviteRepoAcceptInviteExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.InviteRepoAcceptInviteNotPending.AppendMessage(inviteID)
+	}
+	return nil
+}
+
+func (r *inviteRepo) RevokeInvite(ctx context.Context, inviteID uuid.UUID, revokedAt time.Time) error {
+	result, err := r.db.SQL.ExecContext(ctx, revokeInvite, inviteID, revokedAt)
+	if err != nil {
+		return apperrors.InviteRepoRevokeInviteExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.InviteRepoRevokeInviteExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.InviteRepoRevokeInviteNotPending.AppendMessage(inviteID)
+	}
+	return nil
+}
+
+// RevokePendingInvites is called before an address is invited again, so only the latest
+// invite works.
+func (r *inviteRepo) RevokePendingInvites(ctx context.Context, emailKey string, revokedAt time.Time) error {
+	_, err := r.db.SQL.ExecContext(ctx, revokePendingInvites, emailKey, revokedAt)
+	if err != nil {
+		return apperrors.InviteRepoRevokePendingInvitesExecContext.AppendMessage(err)
+	}
+	return nil
+}
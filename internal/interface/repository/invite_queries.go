@@ -0,0 +1,24 @@
+package repository
+
+const (
+	addInvite = `INSERT INTO invites (invite_id, email, email_key, user_role, invited_by_id, token_hash, created_at, expires_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	getInviteByID = `SELECT invite_id, email, email_key, user_role, invited_by_id, token_hash, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+					FROM invites WHERE invite_id = $1`
+
+	getInviteByTokenHash = `SELECT invite_id, email, email_key, user_role, invited_by_id, token_hash, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+					FROM invites WHERE token_hash = $1`
+
+	getInvitesByInviterID = `SELECT invite_id, email, email_key, user_role, invited_by_id, token_hash, created_at, expires_at, accepted_at, accepted_user_id, revoked_at
+					FROM invites WHERE invited_by_id = $1 ORDER BY created_at DESC`
+
+	acceptInvite = `UPDATE invites SET accepted_at = $3, accepted_user_id = $2
+					WHERE invite_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $3`
+
+	revokeInvite = `UPDATE invites SET revoked_at = $2
+					WHERE invite_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`
+
+	revokePendingInvites = `UPDATE invites SET revoked_at = $2
+					WHERE email_key = $1 AND accepted_at IS NULL AND revoked_at IS NULL`
+)
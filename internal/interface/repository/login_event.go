@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type LoginEventRepository interface {
+	SaveLoginEvent(ctx context.Context, loginEvent *model.LoginEvent) (*model.LoginEvent, error)
+	FindLoginEvents(ctx context.Context, loginEventQuery *model.LoginEventQuery) ([]*model.LoginEvent, error)
+	GetLoginEventsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.LoginEvent, error)
+	GetLoginClientHistory(ctx context.Context, userID uuid.UUID, ip string, userAgent string) (*model.LoginClientHistory, error)
+}
+
+type loginEventRepo struct {
+	db *datastore.DB
+}
+
+func NewLoginEventRepository(db *datastore.DB) LoginEventRepository {
+	return &loginEventRepo{db: db}
+}
+
+func (r *loginEventRepo) SaveLoginEvent(ctx context.Context, loginEvent *model.LoginEvent) (*model.LoginEvent, error) {
+	err := r.db.SQL.QueryRowxContext(
+		ctx,
+		addLoginEvent,
+		loginEvent.UserID,
+		loginEvent.Login,
+		loginEvent.Method,
+		loginEvent.Success,
+		loginEvent.FailureReason,
+		loginEvent.IP,
+		loginEvent.UserAgent,
+		loginEvent.NewClient,
+		loginEvent.CreatedAt,
+	).Scan(&loginEvent.LoginEventID)
+	if err != nil {
+		return nil, apperrors.LoginEventRepoSaveLoginEventQueryRowxContext.AppendMessage(err)
+	}
+	return loginEvent, nil
+}
+
+// FindLoginEvents lists at most loginEventQuery.Limit attempts of the user, newest first.
+func (r *loginEventRepo) FindLoginEvents(ctx context.Context, loginEventQuery *model.LoginEventQuery) ([]*model.LoginEvent, error) {
+	query, args := buildLoginEventsQuery(loginEventQuery)
+	loginEvents := []*model.LoginEvent{}
+	err := r.db.SQL.SelectContext(ctx, &loginEvents, query, args...)
+	if err != nil {
+		return nil, apperrors.LoginEventRepoFindLoginEventsSelectContext.AppendMessage(err)
+	}
+	return loginEvents, nil
+}
+
+// GetLoginEventsByUserID lists every attempt of the user, oldest first.
+func (r *loginEventRepo) GetLoginEventsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.LoginEvent, error) {
+	loginEvents := []*model.LoginEvent{}
+	err := r.db.SQL.SelectContext(ctx, &loginEvents, getLoginEvents+" ORDER BY login_event_id", userID)
+	if err != nil {
+		return nil, apperrors.LoginEventRepoGetLoginEventsByUserIDSelectContext.AppendMessage(err)
+	}
+	return loginEvents, nil
+}
+
+// GetLoginClientHistory compares the client of a login with the earlier successful logins
+// of the user.
+func (r *loginEventRepo) GetLoginClientHistory(ctx context.Context, userID uuid.UUID, ip string, userAgent string) (*model.LoginClientHistory, error) {
+	history := &model.LoginClientHistory{}
+	err := r.db.SQL.GetContext(ctx, history, getLoginClientHistory, userID, ip, userAgent)
+	if err != nil {
+		return nil, apperrors.LoginEventRepoGetLoginClientHistoryGetContext.AppendMessage(err)
+	}
+	return history, nil
+}
+
+func buildLoginEventsQuery(loginEventQuery *model.LoginEventQuery) (string, []any) {
+	var query strings.Builder
+	args := []any{loginEventQuery.UserID}
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		query.WriteString(" AND ")
+		query.WriteString(strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	query.WriteString(getLoginEvents)
+	if loginEventQuery.Success != nil {
+		addCondition("success = ?", *loginEventQuery.Success)
+	}
+	if loginEventQuery.Before > 0 {
+		addCondition("login_event_id < ?", loginEventQuery.Before)
+	}
+	query.WriteString(" ORDER BY login_event_id DESC LIMIT " + strconv.Itoa(loginEventQuery.Limit))
+	return query.String(), args
+}
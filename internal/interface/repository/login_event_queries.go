@@ -0,0 +1,17 @@
+package repository
+
+const (
+	loginEventColumns = `login_event_id, user_id, login, method, success, failure_reason, ip, user_agent, new_client, created_at`
+
+	addLoginEvent = `INSERT INTO login_events (user_id, login, method, success, failure_reason, ip, user_agent, new_client, created_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+					RETURNING login_event_id`
+
+	getLoginEvents = `SELECT ` + loginEventColumns + ` FROM login_events WHERE user_id = $1`
+
+	getLoginClientHistory = `SELECT count(*) AS logins,
+						coalesce(bool_or(ip = $2), false) AS known_ip,
+						coalesce(bool_or(user_agent = $3), false) AS known_user_agent
+					FROM login_events
+					WHERE user_id = $1 AND success`
+)
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLoginEventsQuery(t *testing.T) {
+	userID := uuid.New()
+	success := false
+	loginEventQuery := &model.LoginEventQuery{UserID: userID, Success: &success, Before: 42, Limit: 10}
+
+	query, args := buildLoginEventsQuery(loginEventQuery)
+	query = strings.Join(strings.Fields(query), " ")
+	assert.True(t, strings.HasSuffix(query, "WHERE user_id = $1 AND success = $2 AND login_event_id < $3 ORDER BY login_event_id DESC LIMIT 10"), query)
+	assert.Equal(t, []any{userID, false, int64(42)}, args)
+
+	query, _ = buildLoginEventsQuery(&model.LoginEventQuery{UserID: userID, Limit: 10})
+	assert.True(t, strings.HasSuffix(query, "WHERE user_id = $1 ORDER BY login_event_id DESC LIMIT 10"), query)
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"usermanager/internal/apperrors"
+)
+
+type SMTPMailerConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	From     string
+}
+
+// smtpMailer hands every email to the relay. The connection is upgraded with STARTTLS when
+// the server offers it, and credentials are only sent over TLS or to localhost.
+type smtpMailer struct {
+	cfg SMTPMailerConfig
+}
+
+func NewSMTPMailer(cfg SMTPMailerConfig) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, message *Mail) error {
+	data, err := buildMail(m.cfg.From, message, time.Now())
+	if err != nil {
+		return apperrors.MailerSend.AppendMessage(err)
+	}
+	from, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return apperrors.MailerSend.AppendMessage(err)
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return apperrors.MailerSend.AppendMessage(err)
+	}
+
+	var auth smtp.Auth
+	if m.cfg.User != "" {
+		auth = smtp.PlainAuth("", m.cfg.User, m.cfg.Password, m.cfg.Host)
+	}
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	err = smtp.SendMail(addr, auth, from.Address, []string{to.Address}, data)
+	if err != nil {
+		return apperrors.MailerSend.AppendMessage(err)
+	}
+
+	return nil
+}
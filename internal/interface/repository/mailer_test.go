@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMail(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	data, err := buildMail("Usermanager <no-reply@example.com>", &Mail{
+		To:      "user@example.com",
+		Subject: "Confirm your new email – usermanager",
+		Body:    "Open the link:\nhttps://example.com/email/confirm?token=abc",
+	}, now)
+	assert.NoError(t, err)
+	message := string(data)
+	assert.Contains(t, message, "From: \"Usermanager\" <no-reply@example.com>\r\n")
+	assert.Contains(t, message, "To: <user@example.com>\r\n")
+	assert.Contains(t, message, "Subject: =?utf-8?q?Confirm_your_new_email_=E2=80=93_usermanager?=\r\n")
+	assert.Contains(t, message, "Date: Mon, 19 Oct 2026 09:00:00 +0000\r\n")
+	assert.True(t, strings.HasSuffix(message, "Open the link:\r\nhttps://example.com/email/confirm?token=3Dabc"))
+
+	for _, mail := range []*Mail{
+		{To: "user@example.com\r\nBcc: other@example.com", Subject: "hi"},
+		{To: "not an address", Subject: "hi"},
+		{To: "user@example.com", Subject: "hi\r\nBcc: other@example.com"},
+	} {
+		_, err = buildMail("no-reply@example.com", mail, now)
+		assert.Error(t, err, mail.To+mail.Subject)
+	}
+}
+
+func TestWriterMailer(t *testing.T) {
+	var out bytes.Buffer
+	mailer := NewWriterMailer(&out, "no-reply@example.com")
+	assert.NoError(t, mailer.Send(context.TODO(), &Mail{To: "user@example.com", Subject: "hi", Body: "hello"}))
+	assert.Contains(t, out.String(), "To: <user@example.com>")
+
+	err := mailer.Send(context.TODO(), &Mail{To: "", Subject: "hi"})
+	assert.True(t, apperrors.Is(err, &apperrors.MailerSend))
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"usermanager/internal/apperrors"
+)
+
+// writerMailer prints every email instead of sending it, for development where links are
+// copied from the output.
+type writerMailer struct {
+	mu   sync.Mutex
+	out  io.Writer
+	from string
+}
+
+func NewWriterMailer(out io.Writer, from string) Mailer {
+	return &writerMailer{out: out, from: from}
+}
+
+func (m *writerMailer) Send(ctx context.Context, message *Mail) error {
+	data, err := buildMail(m.from, message, time.Now())
+	if err != nil {
+		return apperrors.MailerSend.AppendMessage(err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err = m.out.Write(append(data, '\n')); err != nil {
+		return apperrors.MailerSend.AppendMessage(err)
+	}
+	return nil
+}
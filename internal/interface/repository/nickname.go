@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type NicknameRepository interface {
+	FindReservedNickname(ctx context.Context, nicknameKey string) (*model.ReservedNickname, error)
+	GetReservedNicknames(ctx context.Context) ([]*model.ReservedNickname, error)
+	SaveReservedNickname(ctx context.Context, reservedNickname *model.ReservedNickname) (*model.ReservedNickname, error)
+	DeleteReservedNickname(ctx context.Context, nicknameKey string) error
+	SaveNicknameChange(ctx context.Context, nicknameChange *model.NicknameChange) (*model.NicknameChange, error)
+	FindLastNicknameChangeByUserID(ctx context.Context, userID uuid.UUID) (*model.NicknameChange, error)
+	FindLastNicknameChangeByKey(ctx context.Context, nicknameKey string) (*model.NicknameChange, error)
+	GetNicknameChangesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.NicknameChange, error)
+}
+
+type nicknameRepo struct {
+	db *datastore.DB
+}
+
+func NewNicknameRepository(db *datastore.DB) NicknameRepository {
+	return &nicknameRepo{db: db}
+}
+
+func (r *nicknameRepo) FindReservedNickname(ctx context.Context, nicknameKey string) (*model.ReservedNickname, error) {
+	reservedNickname := &model.ReservedNickname{}
+	err := r.db.SQL.GetContext(ctx, reservedNickname, getReservedNickname, nicknameKey)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.NicknameRepoFindReservedNicknameGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.NicknameRepoFindReservedNicknameGetContext.AppendMessage(err)
+	}
+	return reservedNickname, nil
+}
+
+func (r *nicknameRepo) GetReservedNicknames(ctx context.Context) ([]*model.ReservedNickname, error) {
+	reservedNicknames := []*model.ReservedNickname{}
+	err := r.db.SQL.SelectContext(ctx, &reservedNicknames, getReservedNicknames)
+	if err != nil {
+		return nil, apperrors.NicknameRepoGetReservedNicknamesSelectContext.AppendMessage(err)
+	}
+	return reservedNicknames, nil
+}
+
+// SaveReservedNickname reserves the key of the nickname; reserving it again updates the reason.
+func (r *nicknameRepo) SaveReservedNickname(ctx context.Context, reservedNickname *model.ReservedNickname) (*model.ReservedNickname, error) {
+	err := r.db.SQL.QueryRowxContext(
+		ctx,
+		addReservedNickname,
+		reservedNickname.NicknameKey,
+		reservedNickname.Nickname,
+		reservedNickname.Reason,
+		reservedNickname.CreatedBy,
+		reservedNickname.CreatedAt,
+	).StructScan(reservedNickname)
+	if err != nil {
+		return nil, apperrors.NicknameRepoSaveReservedNicknameQueryRowxContext.AppendMessage(err)
+	}
+	return reservedNickname, nil
+}
+
+func (r *nicknameRepo) DeleteReservedNickname(ctx context.Context, nicknameKey string) error {
+	result, err := r.db.SQL.ExecContext(ctx, deleteReservedNickname, nicknameKey)
+	if err != nil {
+		return apperrors.NicknameRepoDeleteReservedNicknameExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NicknameRepoDeleteReservedNicknameExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.NicknameRepoDeleteReservedNicknameDataNotFound.AppendMessage(nicknameKey)
+	}
+	return nil
+}
+
+func (r *nicknameRepo) SaveNicknameChange(ctx context.Context, nicknameChange *model.NicknameChange) (*model.NicknameChange, error) {
+	err := r.db.SQL.QueryRowxContext(
+		ctx,
+		addNicknameChange,
+		nicknameChange.UserID,
+		nicknameChange.Nickname,
+		nicknameChange.NicknameKey,
+		nicknameChange.NewNickname,
+		nicknameChange.ChangedAt,
+	).Scan(&nicknameChange.ID)
+	if err != nil {
+		return nil, apperrors.NicknameRepoSaveNicknameChangeQueryRowxContext.AppendMessage(err)
+	}
+	return nicknameChange, nil
+}
+
+func (r *nicknameRepo) FindLastNicknameChangeByUserID(ctx context.Context, userID uuid.UUID) (*model.NicknameChange, error) {
+	return r.findLastNicknameChange(ctx, getLastNicknameChangeByUserID, userID)
+}
+
+// FindLastNicknameChangeByKey finds who gave up the nickname most recently.
+func (r *nicknameRepo) FindLastNicknameChangeByKey(ctx context.Context, nicknameKey string) (*model.NicknameChange, error) {
+	return r.findLastNicknameChange(ctx, getLastNicknameChangeByKey, nicknameKey)
+}
+
+func (r *nicknameRepo) findLastNicknameChange(ctx context.Context, query string, arg any) (*model.NicknameChange, error) {
+	nicknameChange := &model.NicknameChange{}
+	err := r.db.SQL.GetContext(ctx, nicknameChange, query, arg)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.NicknameRepoFindLastNicknameChangeGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.NicknameRepoFindLastNicknameChangeGetContext.AppendMessage(err)
+	}
+	return nicknameChange, nil
+}
+
+func (r *nicknameRepo) GetNicknameChangesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.NicknameChange, error) {
+	nicknameChanges := []*model.NicknameChange{}
+	err := r.db.SQL.SelectContext(ctx, &nicknameChanges, getNicknameChangesByUserID, userID)
+	if err != nil {
+		return nil, apperrors.NicknameRepoGetNicknameChangesByUserIDSelectContext.AppendMessage(err)
+	}
+	return nicknameChanges, nil
+}
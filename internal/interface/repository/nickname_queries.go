@@ -0,0 +1,27 @@
+package repository
+
+const (
+	getReservedNickname = `SELECT nickname_key, nickname, reason, created_by, created_at FROM reserved_nicknames WHERE nickname_key = $1`
+
+	getReservedNicknames = `SELECT nickname_key, nickname, reason, created_by, created_at FROM reserved_nicknames ORDER BY nickname_key`
+
+	addReservedNickname = `INSERT INTO reserved_nicknames (nickname_key, nickname, reason, created_by, created_at)
+					VALUES ($1, $2, $3, $4, $5)
+					ON CONFLICT (nickname_key) DO UPDATE SET nickname = EXCLUDED.nickname, reason = EXCLUDED.reason
+					RETURNING nickname_key, nickname, reason, created_by, created_at`
+
+	deleteReservedNickname = `DELETE FROM reserved_nicknames WHERE nickname_key = $1`
+
+	addNicknameChange = `INSERT INTO nickname_history (user_id, nickname, nickname_key, new_nickname, changed_at)
+					VALUES ($1, $2, $3, $4, $5)
+					RETURNING id`
+
+	getLastNicknameChangeByUserID = `SELECT id, user_id, nickname, nickname_key, new_nickname, changed_at FROM nickname_history
+					WHERE user_id = $1 ORDER BY changed_at DESC, id DESC LIMIT 1`
+
+	getLastNicknameChangeByKey = `SELECT id, user_id, nickname, nickname_key, new_nickname, changed_at FROM nickname_history
+					WHERE nickname_key = $1 ORDER BY changed_at DESC, id DESC LIMIT 1`
+
+	getNicknameChangesByUserID = `SELECT id, user_id, nickname, nickname_key, new_nickname, changed_at FROM nickname_history
+					WHERE user_id = $1 ORDER BY changed_at DESC, id DESC`
+)
@@ -1,26 +1,191 @@
 package repository
 
 const (
-	addUser = `INSERT INTO users (user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by)
-    			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	addUser = `INSERT INTO users (user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, attributes, nickname_key, email_key, parent_id, status, status_reason)
+    			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, coalesce($14::jsonb, '{}'), $15, nullif($16, ''), $17, coalesce(nullif($18, ''), 'active'), $19)
+				RETURNING version`
 
 	updateUser = `UPDATE users
-					SET nickname = $1, first_name = $2, last_name = $3, email = $4, password = $5, is_public = $6, updated_at = $7, login_date = $8
-					WHERE user_id = $9`
+					SET nickname = $1, first_name = $2, last_name = $3, email = $4, password = $5, is_public = $6, updated_at = $7, login_date = $8, attributes = coalesce($11::jsonb, attributes), nickname_key = $12, email_key = nullif($13, ''), version = version + 1
+					WHERE user_id = $9 AND deleted_at IS NULL AND ($10::bigint = 0 OR version = $10)
+					RETURNING user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id`
+
+	patchUserReturning = ` WHERE user_id = $1 AND deleted_at IS NULL AND ($3::bigint = 0 OR version = $3)
+					RETURNING user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id`
 
 	updateDeletedAt = `UPDATE users
-					SET deleted_at = $1
-					WHERE user_id = $2`
+					SET deleted_at = $1, version = version + 1
+					WHERE user_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR version = $3)
+					RETURNING user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id`
+
+	restoreDeletedAt = `UPDATE users
+					SET deleted_at = NULL, updated_at = $1, version = version + 1
+					WHERE user_id = $2 AND deleted_at IS NOT NULL
+					RETURNING user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id`
+
+	deleteVotesOfDeletedUsers = `WITH purged_users AS (
+						SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1
+					), deleted_user_votes AS (
+						DELETE FROM user_votes
+						WHERE user_id IN (SELECT user_id FROM purged_users)
+							OR vote_id IN (SELECT vote_id FROM vote WHERE created_user_id IN (SELECT user_id FROM purged_users))
+						RETURNING vote_id
+					)
+					DELETE FROM vote
+					WHERE vote_id IN (SELECT vote_id FROM deleted_user_votes)
+						OR created_user_id IN (SELECT user_id FROM purged_users)`
+
+	deleteVotesOfUser = `WITH deleted_user_votes AS (
+						DELETE FROM user_votes
+						WHERE user_id = $1
+							OR vote_id IN (SELECT vote_id FROM vote WHERE created_user_id = $1)
+						RETURNING vote_id
+					)
+					DELETE FROM vote
+					WHERE vote_id IN (SELECT vote_id FROM deleted_user_votes)
+						OR created_user_id = $1`
+
+	deleteEmailChangesOfDeletedUsers = `DELETE FROM email_changes
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteEmailChangesOfUser = `DELETE FROM email_changes WHERE user_id = $1`
+
+	deleteLoginEventsOfDeletedUsers = `DELETE FROM login_events
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteLoginEventsOfUser = `DELETE FROM login_events WHERE user_id = $1`
+
+	deleteSettingsOfDeletedUsers = `DELETE FROM user_settings
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteSettingsOfUser = `DELETE FROM user_settings WHERE user_id = $1`
+
+	deleteFollowsOfDeletedUsers = `DELETE FROM follows
+					WHERE follower_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)
+						OR followee_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteFollowsOfUser = `DELETE FROM follows WHERE follower_id = $1 OR followee_id = $1`
+
+	deleteInvitesOfDeletedUsers = `DELETE FROM invites
+					WHERE invited_by_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)
+						OR accepted_user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteInvitesOfUser = `DELETE FROM invites WHERE invited_by_id = $1 OR accepted_user_id = $1`
+
+	deleteRoleRequestsOfDeletedUsers = `DELETE FROM role_requests
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteRoleRequestsOfUser = `DELETE FROM role_requests WHERE user_id = $1`
+
+	deleteRoleHistoryOfDeletedUsers = `DELETE FROM role_history
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteRoleHistoryOfUser = `DELETE FROM role_history WHERE user_id = $1`
+
+	deleteRoleGrantsOfDeletedUsers = `DELETE FROM role_grants
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	deleteRoleGrantsOfUser = `DELETE FROM role_grants WHERE user_id = $1`
+
+	deleteNicknameHistoryOfDeletedUsers = `DELETE FROM nickname_history
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)`
+
+	// deleteDataRequestsOfDeletedUsers and deleteDataRequestsOfUser return the archive keys, the
+	// archives are removed from the blob store once the rows are gone.
+	deleteDataRequestsOfDeletedUsers = `DELETE FROM data_requests
+					WHERE user_id IN (SELECT user_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1)
+					RETURNING archive_key`
+
+	deleteDataRequestsOfUser = `DELETE FROM data_requests WHERE user_id = $1 RETURNING archive_key`
+
+	getAvatarKeysOfDeletedUsers = `SELECT jsonb_array_elements_text(avatar->'keys') FROM users
+					WHERE deleted_at IS NOT NULL AND deleted_at < $1 AND avatar IS NOT NULL`
+
+	getAvatarKeysOfUser = `SELECT jsonb_array_elements_text(avatar->'keys') FROM users WHERE user_id = $1 AND avatar IS NOT NULL`
+
+	// updateLoginDate leaves the version alone, logging in isn't a change of the user.
+	updateLoginDate = `UPDATE users SET login_date = $2 WHERE user_id = $1 AND deleted_at IS NULL`
+
+	// eraseUser keeps the row, so votes still point at a user. Names, email and password are
+	// emptied rather than set to NULL because every read scans them into strings.
+	eraseUser = `UPDATE users
+					SET nickname = $2, nickname_key = $3, first_name = '', last_name = '', email = '', email_key = NULL, password = '',
+						is_public = false, attributes = '{}', avatar = NULL, updated_at = $4, version = version + 1
+					WHERE user_id = $1
+					RETURNING user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id`
+
+	getUsersWithExpiredStatus = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id
+							FROM users
+							WHERE status <> 'active' AND status_until <= $1 AND deleted_at IS NULL`
+
+	// getUserAncestors walks up from the user, the parent first. The depth limit also stops the
+	// walk should a cycle ever make it into the table.
+	getUserAncestors = `WITH RECURSIVE ancestors (ancestor_id, depth) AS (
+						SELECT parent_id, 1 FROM users WHERE user_id = $1 AND deleted_at IS NULL
+						UNION ALL
+						SELECT users.parent_id, ancestors.depth + 1
+						FROM users JOIN ancestors ON users.user_id = ancestors.ancestor_id
+						WHERE users.deleted_at IS NULL AND ancestors.depth < $2
+					)
+					SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id, depth
+					FROM ancestors JOIN users ON users.user_id = ancestors.ancestor_id
+					WHERE users.deleted_at IS NULL
+					ORDER BY depth`
+
+	getUserDescendants = `WITH RECURSIVE descendants (descendant_id, depth) AS (
+						SELECT user_id, 1 FROM users WHERE parent_id = $1 AND deleted_at IS NULL
+						UNION ALL
+						SELECT users.user_id, descendants.depth + 1
+						FROM users JOIN descendants ON users.parent_id = descendants.descendant_id
+						WHERE users.deleted_at IS NULL AND descendants.depth < $2
+					)
+					SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id, depth
+					FROM descendants JOIN users ON users.user_id = descendants.descendant_id
+					ORDER BY depth, nickname, user_id`
+
+	deleteNicknameHistoryOfUser = `DELETE FROM nickname_history WHERE user_id = $1`
+
+	deleteDeletedUsers = `DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1`
 
 	deleteUserFromDb = `DELETE FROM users WHERE user_id = $1`
-	getUserByID      = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by
-							FROM users WHERE user_id=$1`
+	getUserByID      = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id
+							FROM users WHERE user_id=$1 AND deleted_at IS NULL`
+
+	getDeletedUserByID = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id
+							FROM users WHERE user_id=$1 AND deleted_at IS NOT NULL`
+
+	getUserByNickname = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id
+							FROM users
+							WHERE (nickname_key = $2 OR nickname = $1) AND deleted_at IS NULL
+							ORDER BY nickname = $1 DESC
+							LIMIT 1`
 
-	getUserByNickname = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by
+	getUserByEmail = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, deleted_at, login_date, created_by, version, attributes, avatar, status, status_reason, status_by, status_until, parent_id
 							FROM users
-							WHERE nickname = $1`
+							WHERE email_key = $1 AND deleted_at IS NULL`
 
-	getUsers = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, login_date
+	getUsers = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, login_date, attributes, avatar, status, status_reason, status_by, status_until, parent_id
   				FROM users
- 				ORDER BY created_at, updated_at OFFSET $1 LIMIT $2`
+ 				WHERE deleted_at IS NULL`
+
+	countUsers = `SELECT count(*) FROM users WHERE deleted_at IS NULL`
+
+	exportUsers = `SELECT user_id, nickname, first_name, last_name, email, is_public, user_role, coalesce(created_by, '') AS created_by, created_at, updated_at, login_date, version, attributes%s
+				FROM users
+				WHERE deleted_at IS NULL`
+
+	exportUserVotes = `, (SELECT coalesce(json_agg(json_build_object('vote_id', vote.vote_id, 'vote', vote.vote, 'created_user_id', vote.created_user_id,
+					'created_at', vote.created_at AT TIME ZONE 'UTC') ORDER BY vote.created_at, vote.vote_id), '[]')
+				FROM vote WHERE vote.created_user_id = users.user_id) AS votes_json`
+
+	explainUsers = `EXPLAIN (FORMAT JSON) SELECT 1 FROM users WHERE deleted_at IS NULL`
+
+	searchUsers = `SELECT user_id, nickname, first_name, last_name, email, password, is_public, user_role, created_at, updated_at, login_date, attributes, avatar, status, status_reason, status_by, status_until, parent_id,
+					ts_rank(search_vector, to_tsquery('simple', $2)) + word_similarity($1, search_text) AS score
+				FROM users
+				WHERE deleted_at IS NULL
+					AND ($3::boolean OR is_public IS TRUE OR user_id = $4)
+					AND (search_vector @@ to_tsquery('simple', $2) OR $1 <% search_text)
+				ORDER BY score DESC, nickname, user_id
+				OFFSET $5 LIMIT $6`
 )
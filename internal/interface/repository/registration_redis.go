@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const (
+	registrationChallengePrefix    = "registration_challenge:"
+	registrationVerificationPrefix = "registration_verification:"
+	registrationRatePrefix         = "registration_rate:"
+)
+
+// RegistrationRedisRepository keeps what self registration needs for a short while: the
+// challenges handed out, the hits per IP and the email verification tokens by their hash.
+type RegistrationRedisRepository interface {
+	SaveChallenge(ctx context.Context, challenge string, ttl time.Duration) error
+	TakeChallenge(ctx context.Context, challenge string) (bool, error)
+	IncrementIPHits(ctx context.Context, action string, ip string, window time.Duration) (int64, error)
+	SaveVerification(ctx context.Context, tokenHash string, userID uuid.UUID, ttl time.Duration) error
+	FindVerification(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	DeleteVerification(ctx context.Context, tokenHash string) error
+}
+
+type registrationRedisRepo struct {
+	redis *datastore.Redis
+}
+
+func NewRegistrationRedisRepository(redis *datastore.Redis) RegistrationRedisRepository {
+	return &registrationRedisRepo{redis: redis}
+}
+
+func (rr *registrationRedisRepo) SaveChallenge(ctx context.Context, challenge string, ttl time.Duration) error {
+	err := rr.redis.RedisClient.Set(ctx, registrationChallengePrefix+challenge, 1, ttl).Err()
+	if err != nil {
+		return apperrors.RegistrationRedisRepoSaveChallengeSet.AppendMessage(err)
+	}
+	return nil
+}
+
+// TakeChallenge deletes the challenge and tells whether it was there, so only the first of
+// two registrations with the same solution gets through.
+func (rr *registrationRedisRepo) TakeChallenge(ctx context.Context, challenge string) (bool, error) {
+	deleted, err := rr.redis.RedisClient.Del(ctx, registrationChallengePrefix+challenge).Result()
+	if err != nil {
+		return false, apperrors.RegistrationRedisRepoTakeChallengeDel.AppendMessage(err)
+	}
+	return deleted > 0, nil
+}
+
+// IncrementIPHits counts the hits of the IP on action within a fixed window that starts with
+// the first hit.
+func (rr *registrationRedisRepo) IncrementIPHits(ctx context.Context, action string, ip string, window time.Duration) (int64, error) {
+	key := registrationRatePrefix + action + ":" + ip
+	hits, err := rr.redis.RedisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, apperrors.RegistrationRedisRepoIncrementIPHitsIncr.AppendMessage(err)
+	}
+	if hits == 1 {
+		err = rr.redis.RedisClient.Expire(ctx, key, window).Err()
+		if err != nil {
+			return 0, apperrors.RegistrationRedisRepoIncrementIPHitsExpire.AppendMessage(err)
+		}
+	}
+	return hits, nil
+}
+
+func (rr *registrationRedisRepo) SaveVerification(ctx context.Context, tokenHash string, userID uuid.UUID, ttl time.Duration) error {
+	err := rr.redis.RedisClient.Set(ctx, registrationVerificationPrefix+tokenHash, userID.String(), ttl).Err()
+	if err != nil {
+		return apperrors.RegistrationRedisRepoSaveVerificationSet.AppendMessage(err)
+	}
+	return nil
+}
+
+func (rr *registrationRedisRepo) FindVerification(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	value, err := rr.redis.RedisClient.Get(ctx, registrationVerificationPrefix+tokenHash).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return uuid.Nil, apperrors.RegistrationRedisRepoFindVerificationGetDataNotFound.AppendMessage(err)
+		}
+		return uuid.Nil, apperrors.RegistrationRedisRepoFindVerificationGet.AppendMessage(err)
+	}
+
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, apperrors.RegistrationRedisRepoFindVerificationParse.AppendMessage(err)
+	}
+	return userID, nil
+}
+
+func (rr *registrationRedisRepo) DeleteVerification(ctx context.Context, tokenHash string) error {
+	err := rr.redis.RedisClient.Del(ctx, registrationVerificationPrefix+tokenHash).Err()
+	if err != nil {
+		return apperrors.RegistrationRedisRepoDeleteVerificationDel.AppendMessage(err)
+	}
+	return nil
+}
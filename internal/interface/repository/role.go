@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type RoleRepository interface {
+	ChangeUserRole(ctx context.Context, roleHistory *model.RoleHistory) (*model.RoleHistory, error)
+	FindRoleHistoryByUserID(ctx context.Context, userID uuid.UUID) ([]*model.RoleHistory, error)
+	SaveRoleRequest(ctx context.Context, roleRequest *model.RoleRequest) (*model.RoleRequest, error)
+	UpdateRoleRequest(ctx context.Context, roleRequest *model.RoleRequest) (*model.RoleRequest, error)
+	ApproveRoleRequest(ctx context.Context, roleRequest *model.RoleRequest, roleHistory *model.RoleHistory) (*model.RoleRequest, error)
+	FindRoleRequestByID(ctx context.Context, requestID uuid.UUID) (*model.RoleRequest, error)
+	FindRoleRequestsByStatus(ctx context.Context, status string) ([]*model.RoleRequest, error)
+}
+
+type roleRepo struct {
+	db *datastore.DB
+}
+
+func NewRoleRepository(db *datastore.DB) RoleRepository {
+	return &roleRepo{db: db}
+}
+
+// ChangeUserRole writes the new role and its history entry in one transaction,
+// so a role never changes without leaving a trace.
+func (r *roleRepo) ChangeUserRole(ctx context.Context, roleHistory *model.RoleHistory) (*model.RoleHistory, error) {
+	tx, err := r.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.RoleRepoChangeUserRoleBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	err = r.changeUserRole(ctx, tx, roleHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperrors.RoleRepoChangeUserRoleCommit.AppendMessage(err)
+	}
+	return roleHistory, nil
+}
+
+// ApproveRoleRequest closes a pending request and applies its role in the same
+// transaction. A request reviewed concurrently by someone else is not applied twice.
+func (r *roleRepo) ApproveRoleRequest(ctx context.Context, roleRequest *model.RoleRequest, roleHistory *model.RoleHistory) (*model.RoleRequest, error) {
+	tx, err := r.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.RoleRepoApproveRoleRequestBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	err = r.updateRoleRequest(ctx, tx, roleRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.changeUserRole(ctx, tx, roleHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperrors.RoleRepoApproveRoleRequestCommit.AppendMessage(err)
+	}
+	return roleRequest, nil
+}
+
+func (r *roleRepo) changeUserRole(ctx context.Context, tx *sqlx.Tx, roleHistory *model.RoleHistory) error {
+	result, err := tx.ExecContext(ctx, updateUserRole, roleHistory.Role, roleHistory.CreatedAt, roleHistory.UserID)
+	if err != nil {
+		return apperrors.RoleRepoChangeUserRoleExecContext.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.RoleRepoChangeUserRoleExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.RoleRepoChangeUserRoleEmptyRowsAffected.AppendMessage(sql.ErrNoRows)
+	}
+
+	err = tx.QueryRowxContext(
+		ctx,
+		addRoleHistory,
+		roleHistory.UserID,
+		roleHistory.PreviousRole,
+		roleHistory.Role,
+		roleHistory.ChangedBy,
+		roleHistory.RequestID,
+		roleHistory.Reason,
+		roleHistory.CreatedAt,
+	).Scan(&roleHistory.ID)
+	if err != nil {
+		return apperrors.RoleRepoChangeUserRoleQueryRowxContext.AppendMessage(err)
+	}
+	return nil
+}
+
+func (r *roleRepo) FindRoleHistoryByUserID(ctx context.Context, userID uuid.UUID) ([]*model.RoleHistory, error) {
+	roleHistory := make([]*model.RoleHistory, 0)
+	err := r.db.SQL.SelectContext(ctx, &roleHistory, getRoleHistoryByUserID, userID)
+	if err != nil {
+		return nil, apperrors.RoleRepoFindRoleHistoryByUserIDSelectContext.AppendMessage(err)
+	}
+	return roleHistory, nil
+}
+
+func (r *roleRepo) SaveRoleRequest(ctx context.Context, roleRequest *model.RoleRequest) (*model.RoleRequest, error) {
+	_, err := r.db.SQL.ExecContext(
+		ctx,
+		addRoleRequest,
+		roleRequest.RequestID,
+		roleRequest.UserID,
+		roleRequest.Role,
+		roleRequest.PreviousRole,
+		roleRequest.Reason,
+		roleRequest.Status,
+		roleRequest.RequestedBy,
+		roleRequest.ReviewReason,
+		roleRequest.CreatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.RoleRepoSaveRoleRequestExecContext.AppendMessage(err)
+	}
+	return roleRequest, nil
+}
+
+func (r *roleRepo) UpdateRoleRequest(ctx context.Context, roleRequest *model.RoleRequest) (*model.RoleRequest, error) {
+	err := r.updateRoleRequest(ctx, r.db.SQL, roleRequest)
+	if err != nil {
+		return nil, err
+	}
+	return roleRequest, nil
+}
+
+func (r *roleRepo) updateRoleRequest(ctx context.Context, execer sqlx.ExecerContext, roleRequest *model.RoleRequest) error {
+	result, err := execer.ExecContext(
+		ctx,
+		updateRoleRequest,
+		roleRequest.Status,
+		roleRequest.ReviewedBy,
+		roleRequest.ReviewReason,
+		roleRequest.ReviewedAt,
+		roleRequest.RequestID,
+		model.RoleRequestStatusPending,
+	)
+	if err != nil {
+		return apperrors.RoleRepoUpdateRoleRequestExecContext.AppendMessage(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.RoleRepoUpdateRoleRequestExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.RoleRepoUpdateRoleRequestEmptyRowsAffected.AppendMessage(sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *roleRepo) FindRoleRequestByID(ctx context.Context, requestID uuid.UUID) (*model.RoleRequest, error) {
+	roleRequest := &model.RoleRequest{}
+	err := r.db.SQL.GetContext(ctx, roleRequest, getRoleRequestByID, requestID)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.RoleRepoFindRoleRequestByIDGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.RoleRepoFindRoleRequestByIDGetContext.AppendMessage(err)
+	}
+	return roleRequest, nil
+}
+
+func (r *roleRepo) FindRoleRequestsByStatus(ctx context.Context, status string) ([]*model.RoleRequest, error) {
+	roleRequests := make([]*model.RoleRequest, 0)
+	err := r.db.SQL.SelectContext(ctx, &roleRequests, getRoleRequestsByStatus, status)
+	if err != nil {
+		return nil, apperrors.RoleRepoFindRoleRequestsByStatusSelectContext.AppendMessage(err)
+	}
+	return roleRequests, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type RoleGrantRepository interface {
+	SaveRoleGrant(ctx context.Context, roleGrant *model.RoleGrant) (*model.RoleGrant, error)
+	UpdateRoleGrant(ctx context.Context, roleGrant *model.RoleGrant, status string) (*model.RoleGrant, error)
+	FindRoleGrantByID(ctx context.Context, grantID uuid.UUID) (*model.RoleGrant, error)
+	FindRoleGrantsByStatus(ctx context.Context, status string) ([]*model.RoleGrant, error)
+	FindRoleGrantsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error)
+	FindActiveRoleGrantsByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*model.RoleGrant, error)
+	ExpireRoleGrants(ctx context.Context, now time.Time) ([]*model.RoleGrant, error)
+}
+
+type roleGrantRepo struct {
+	db *datastore.DB
+}
+
+func NewRoleGrantRepository(db *datastore.DB) RoleGrantRepository {
+	return &roleGrantRepo{db: db}
+}
+
+func (r *roleGrantRepo) SaveRoleGrant(ctx context.Context, roleGrant *model.RoleGrant) (*model.RoleGrant, error) {
+	_, err := r.db.SQL.ExecContext(
+		ctx,
+		addRoleGrant,
+		roleGrant.GrantID,
+		roleGrant.UserID,
+		roleGrant.Role,
+		roleGrant.Justification,
+		roleGrant.Duration,
+		roleGrant.Status,
+		roleGrant.AutoApproved,
+		roleGrant.ReviewedBy,
+		roleGrant.CreatedAt,
+		roleGrant.ReviewedAt,
+		roleGrant.ExpiresAt,
+	)
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoSaveRoleGrantExecContext.AppendMessage(err)
+	}
+	return roleGrant, nil
+}
+
+// UpdateRoleGrant writes the grant only if it is still in the given status,
+// so two reviewers can't move the same grant at once.
+func (r *roleGrantRepo) UpdateRoleGrant(ctx context.Context, roleGrant *model.RoleGrant, status string) (*model.RoleGrant, error) {
+	result, err := r.db.SQL.ExecContext(
+		ctx,
+		updateRoleGrant,
+		roleGrant.Status,
+		roleGrant.ReviewedBy,
+		roleGrant.RevokedBy,
+		roleGrant.ReviewedAt,
+		roleGrant.ExpiresAt,
+		roleGrant.RevokedAt,
+		roleGrant.GrantID,
+		status,
+	)
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoUpdateRoleGrantExecContext.AppendMessage(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoUpdateRoleGrantExecContext.AppendMessage(err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.RoleGrantRepoUpdateRoleGrantEmptyRowsAffected.AppendMessage(sql.ErrNoRows)
+	}
+	return roleGrant, nil
+}
+
+func (r *roleGrantRepo) FindRoleGrantByID(ctx context.Context, grantID uuid.UUID) (*model.RoleGrant, error) {
+	roleGrant := &model.RoleGrant{}
+	err := r.db.SQL.GetContext(ctx, roleGrant, getRoleGrantByID, grantID)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.RoleGrantRepoFindRoleGrantByIDGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.RoleGrantRepoFindRoleGrantByIDGetContext.AppendMessage(err)
+	}
+	return roleGrant, nil
+}
+
+func (r *roleGrantRepo) FindRoleGrantsByStatus(ctx context.Context, status string) ([]*model.RoleGrant, error) {
+	roleGrants := make([]*model.RoleGrant, 0)
+	err := r.db.SQL.SelectContext(ctx, &roleGrants, getRoleGrantsByStatus, status)
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoFindRoleGrantsByStatusSelectContext.AppendMessage(err)
+	}
+	return roleGrants, nil
+}
+
+func (r *roleGrantRepo) FindRoleGrantsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error) {
+	roleGrants := make([]*model.RoleGrant, 0)
+	err := r.db.SQL.SelectContext(ctx, &roleGrants, getRoleGrantsByUserID, userID)
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoFindRoleGrantsByUserIDSelectContext.AppendMessage(err)
+	}
+	return roleGrants, nil
+}
+
+func (r *roleGrantRepo) FindActiveRoleGrantsByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*model.RoleGrant, error) {
+	roleGrants := make([]*model.RoleGrant, 0)
+	err := r.db.SQL.SelectContext(ctx, &roleGrants, getActiveRoleGrantsByUserID, userID, model.RoleGrantStatusActive, now)
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoFindActiveRoleGrantsByUserIDSelectContext.AppendMessage(err)
+	}
+	return roleGrants, nil
+}
+
+func (r *roleGrantRepo) ExpireRoleGrants(ctx context.Context, now time.Time) ([]*model.RoleGrant, error) {
+	roleGrants := make([]*model.RoleGrant, 0)
+	err := r.db.SQL.SelectContext(ctx, &roleGrants, expireRoleGrants, model.RoleGrantStatusExpired, model.RoleGrantStatusActive, now)
+	if err != nil {
+		return nil, apperrors.RoleGrantRepoExpireRoleGrantsSelectContext.AppendMessage(err)
+	}
+	return roleGrants, nil
+}
@@ -0,0 +1,26 @@
+package repository
+
+const (
+	roleGrantColumns = `grant_id, user_id, role, justification, duration, status, auto_approved, reviewed_by, revoked_by,
+							created_at, reviewed_at, expires_at, revoked_at`
+
+	addRoleGrant = `INSERT INTO role_grants (grant_id, user_id, role, justification, duration, status, auto_approved, reviewed_by, created_at, reviewed_at, expires_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	updateRoleGrant = `UPDATE role_grants
+					SET status = $1, reviewed_by = $2, revoked_by = $3, reviewed_at = $4, expires_at = $5, revoked_at = $6
+					WHERE grant_id = $7 AND status = $8`
+
+	getRoleGrantByID = `SELECT ` + roleGrantColumns + ` FROM role_grants WHERE grant_id = $1`
+
+	getRoleGrantsByStatus = `SELECT ` + roleGrantColumns + ` FROM role_grants WHERE status = $1 ORDER BY created_at DESC`
+
+	getRoleGrantsByUserID = `SELECT ` + roleGrantColumns + ` FROM role_grants WHERE user_id = $1 ORDER BY created_at DESC`
+
+	getActiveRoleGrantsByUserID = `SELECT ` + roleGrantColumns + ` FROM role_grants
+							WHERE user_id = $1 AND status = $2 AND expires_at > $3 ORDER BY expires_at DESC`
+
+	expireRoleGrants = `UPDATE role_grants SET status = $1
+					WHERE status = $2 AND expires_at <= $3
+					RETURNING ` + roleGrantColumns
+)
@@ -0,0 +1,26 @@
+package repository
+
+const (
+	updateUserRole = `UPDATE users SET user_role = $1, updated_at = $2, version = version + 1 WHERE user_id = $3 AND deleted_at IS NULL`
+
+	addRoleRequest = `INSERT INTO role_requests (request_id, user_id, role, previous_role, reason, status, requested_by, review_reason, created_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	updateRoleRequest = `UPDATE role_requests
+					SET status = $1, reviewed_by = $2, review_reason = $3, reviewed_at = $4
+					WHERE request_id = $5 AND status = $6`
+
+	getRoleRequestByID = `SELECT request_id, user_id, role, COALESCE(previous_role, '') AS previous_role, COALESCE(reason, '') AS reason, status,
+							requested_by, reviewed_by, COALESCE(review_reason, '') AS review_reason, created_at, reviewed_at
+							FROM role_requests WHERE request_id = $1`
+
+	getRoleRequestsByStatus = `SELECT request_id, user_id, role, COALESCE(previous_role, '') AS previous_role, COALESCE(reason, '') AS reason, status,
+							requested_by, reviewed_by, COALESCE(review_reason, '') AS review_reason, created_at, reviewed_at
+							FROM role_requests WHERE status = $1 ORDER BY created_at DESC`
+
+	addRoleHistory = `INSERT INTO role_history (user_id, previous_role, role, changed_by, request_id, reason, created_at)
+					VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	getRoleHistoryByUserID = `SELECT id, user_id, COALESCE(previous_role, '') AS previous_role, role, changed_by, request_id, COALESCE(reason, '') AS reason, created_at
+							FROM role_history WHERE user_id = $1 ORDER BY created_at DESC`
+)
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleRepo_ChangeUserRole_DeletedUser(t *testing.T) {
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"UPDATE users": {noRows: true},
+	})
+
+	_, err := NewRoleRepository(db).ChangeUserRole(context.Background(), &model.RoleHistory{UserID: uuid.New(), Role: model.RoleModerator, CreatedAt: time.Now()})
+	assert.True(t, apperrors.Is(err, &apperrors.RoleRepoChangeUserRoleEmptyRowsAffected), err)
+	assert.Contains(t, fake.ran("UPDATE users")[0].query, "AND deleted_at IS NULL")
+	assert.Empty(t, fake.ran("INSERT INTO role_history"))
+	assert.False(t, fake.committed)
+}
@@ -3,7 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"usermanager/internal/apperrors"
 	"usermanager/internal/domain/model"
@@ -16,19 +21,32 @@ import (
 type UserRepository interface {
 	FindUserByUUID(ctx context.Context, userID uuid.UUID) (*model.User, error)
 	FindUserByNickname(ctx context.Context, nickname string) (*model.User, error)
+	FindUserByEmail(ctx context.Context, email string) (*model.User, error)
 	GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error)
+	SearchUsers(ctx context.Context, searchQuery *model.SearchUsersQuery) (*model.Users, error)
+	StreamUsers(ctx context.Context, exportQuery *model.UserExportQuery, fn func(user *model.User) error) error
 	SaveUser(ctx context.Context, user *model.User) (*model.User, error)
 	UpdateUser(ctx context.Context, user *model.User) (*model.User, error)
-	SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID) (*model.User, error)
-	DeleteUserByUserID(ctx context.Context, userID *uuid.UUID) error
+	PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error)
+	SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID, expectedVersion int64) (*model.User, error)
+	FindDeletedUserByUUID(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	RestoreUserByUserID(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, []string, error)
+	DeleteUserByUserID(ctx context.Context, userID *uuid.UUID) ([]string, error)
+	EraseUser(ctx context.Context, userID uuid.UUID, nickname string) (*model.User, error)
+	GetUsersWithExpiredStatus(ctx context.Context, now time.Time) ([]*model.User, error)
+	GetUserAncestors(ctx context.Context, userID uuid.UUID, maxDepth int) ([]*model.UserTreeNode, error)
+	GetUserDescendants(ctx context.Context, userID uuid.UUID, maxDepth int) ([]*model.UserTreeNode, error)
+	UpdateLoginDate(ctx context.Context, userID uuid.UUID, loginDate time.Time) error
 }
 
 type userRepo struct {
-	db *datastore.DB
+	db          *datastore.DB
+	cursorCodec *utils.CursorCodec
 }
 
-func NewUserRepository(db *datastore.DB) UserRepository {
-	return &userRepo{db: db}
+func NewUserRepository(db *datastore.DB, cursorCodec *utils.CursorCodec) UserRepository {
+	return &userRepo{db: db, cursorCodec: cursorCodec}
 }
 
 func (u *userRepo) FindUserByUUID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
@@ -44,9 +62,11 @@ func (u *userRepo) FindUserByUUID(ctx context.Context, userID uuid.UUID) (*model
 	return user, nil
 }
 
+// FindUserByNickname compares nicknames by their utils.NicknameKey. The exact spelling wins,
+// which only matters for users that shared a key before keys were enforced.
 func (u *userRepo) FindUserByNickname(ctx context.Context, nickname string) (*model.User, error) {
 	existingUser := &model.User{}
-	if err := u.db.SQL.GetContext(ctx, existingUser, getUserByNickname, nickname); err != nil {
+	if err := u.db.SQL.GetContext(ctx, existingUser, getUserByNickname, nickname, utils.NicknameKey(nickname)); err != nil {
 		if sql.ErrNoRows == err {
 			return nil, apperrors.UserRepoFindUserByNicknameGetDataNotFound.AppendMessage(err)
 		}
@@ -56,6 +76,20 @@ func (u *userRepo) FindUserByNickname(ctx context.Context, nickname string) (*mo
 	return existingUser, nil
 }
 
+// FindUserByEmail compares emails by their utils.EmailKey.
+func (u *userRepo) FindUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	existingUser := &model.User{}
+	if err := u.db.SQL.GetContext(ctx, existingUser, getUserByEmail, utils.EmailKey(email)); err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.UserRepoFindUserByEmailGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserRepoFindUserByEmailGetContext.AppendMessage(err)
+	}
+
+	return existingUser, nil
+}
+
+// SaveUser inserts the user with its status, an empty one is stored as active.
 func (u *userRepo) SaveUser(ctx context.Context, user *model.User) (*model.User, error) {
 	err := u.db.SQL.QueryRowxContext(
 		ctx,
@@ -73,6 +107,12 @@ func (u *userRepo) SaveUser(ctx context.Context, user *model.User) (*model.User,
 		&user.DeletedAt,
 		&user.LoginDate,
 		&user.Created.By,
+		user.Attributes,
+		utils.NicknameKey(user.Nickname),
+		utils.EmailKey(user.Email),
+		user.ParentID,
+		user.Status,
+		user.StatusReason,
 	).StructScan(user)
 	if err != nil && sql.ErrNoRows != err {
 		return nil, apperrors.UserRepoSaveUserQueryRowxContext.AppendMessage(err)
@@ -80,6 +120,7 @@ func (u *userRepo) SaveUser(ctx context.Context, user *model.User) (*model.User,
 	return user, nil
 }
 
+// UpdateUser only writes the row while it is still at user.Version; zero skips the check.
 func (u *userRepo) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
 	err := u.db.SQL.QueryRowxContext(
 		ctx,
@@ -93,14 +134,45 @@ func (u *userRepo) UpdateUser(ctx context.Context, user *model.User) (*model.Use
 		&user.UpdatedAt,
 		&user.LoginDate,
 		&user.UserID,
+		&user.Version,
+		user.Attributes,
+		utils.NicknameKey(user.Nickname),
+		utils.EmailKey(user.Email),
 	).StructScan(user)
-	if err != nil && sql.ErrNoRows != err {
+	if err != nil {
+		if sql.ErrNoRows == err {
+			if user.Version != 0 {
+				return nil, apperrors.UserRepoUpdateUserVersionMismatch.AppendMessage(err)
+			}
+			return nil, apperrors.UserRepoUpdateUserDataNotFound.AppendMessage(err)
+		}
 		return nil, apperrors.UserRepoUpdateUserQueryRowxContext.AppendMessage(err)
 	}
 	return user, nil
 }
 
-func (u *userRepo) SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+// PatchUser writes only the changed columns and bumps updated_at.
+func (u *userRepo) PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error) {
+	query, args, err := buildPatchUserQuery(userID, expectedVersion, changes, time.Now())
+	if err != nil {
+		return nil, apperrors.UserRepoPatchUserBuildQuery.AppendMessage(err)
+	}
+
+	patchedUser := &model.User{}
+	err = u.db.SQL.QueryRowxContext(ctx, query, args...).StructScan(patchedUser)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			if expectedVersion != 0 {
+				return nil, apperrors.UserRepoPatchUserVersionMismatch.AppendMessage(err)
+			}
+			return nil, apperrors.UserRepoPatchUserDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserRepoPatchUserQueryRowxContext.AppendMessage(err)
+	}
+	return patchedUser, nil
+}
+
+func (u *userRepo) SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID, expectedVersion int64) (*model.User, error) {
 	existingUser := &model.User{}
 	deletedAt := time.Now()
 	err := u.db.SQL.QueryRowxContext(
@@ -108,27 +180,341 @@ func (u *userRepo) SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID)
 		updateDeletedAt,
 		deletedAt,
 		userID,
-	).StructScan(&existingUser)
+		expectedVersion,
+	).StructScan(existingUser)
 	if err != nil {
+		if sql.ErrNoRows == err {
+			if expectedVersion != 0 {
+				return nil, apperrors.UserRepoSoftDeleteUserByUserIDVersionMismatch.AppendMessage(err)
+			}
+			return nil, apperrors.UserRepoSoftDeleteUserByUserIDDataNotFound.AppendMessage(err)
+		}
 		return nil, apperrors.UserRepoSoftDeleteUserByUserIDQueryRowxContext.AppendMessage(err)
 	}
 	return existingUser, nil
 }
 
-func (u *userRepo) DeleteUserByUserID(ctx context.Context, userID *uuid.UUID) error {
-	result, err := u.db.SQL.ExecContext(ctx, deleteUserFromDb, userID)
+func (u *userRepo) FindDeletedUserByUUID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user := &model.User{}
+	err := u.db.SQL.GetContext(ctx, user, getDeletedUserByID, userID)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.UserRepoFindDeletedUserByUUIDGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserRepoFindDeletedUserByUUIDGetContext.AppendMessage(err)
+	}
+	return user, nil
+}
+
+// GetUsersWithExpiredStatus lists the suspended and banned users whose until has passed.
+func (u *userRepo) GetUsersWithExpiredStatus(ctx context.Context, now time.Time) ([]*model.User, error) {
+	users := []*model.User{}
+	err := u.db.SQL.SelectContext(ctx, &users, getUsersWithExpiredStatus, now)
+	if err != nil {
+		return nil, apperrors.UserRepoGetUsersWithExpiredStatusSelectContext.AppendMessage(err)
+	}
+	return users, nil
+}
+
+func (u *userRepo) GetUserAncestors(ctx context.Context, userID uuid.UUID, maxDepth int) ([]*model.UserTreeNode, error) {
+	nodes := []*model.UserTreeNode{}
+	err := u.db.SQL.SelectContext(ctx, &nodes, getUserAncestors, userID, maxDepth)
+	if err != nil {
+		return nil, apperrors.UserRepoGetUserAncestorsSelectContext.AppendMessage(err)
+	}
+	return nodes, nil
+}
+
+// GetUserDescendants walks down at most maxDepth levels, the children first. A maxDepth of 1
+// lists the children.
+func (u *userRepo) GetUserDescendants(ctx context.Context, userID uuid.UUID, maxDepth int) ([]*model.UserTreeNode, error) {
+	nodes := []*model.UserTreeNode{}
+	err := u.db.SQL.SelectContext(ctx, &nodes, getUserDescendants, userID, maxDepth)
+	if err != nil {
+		return nil, apperrors.UserRepoGetUserDescendantsSelectContext.AppendMessage(err)
+	}
+	return nodes, nil
+}
+
+func (u *userRepo) UpdateLoginDate(ctx context.Context, userID uuid.UUID, loginDate time.Time) error {
+	_, err := u.db.SQL.ExecContext(ctx, updateLoginDate, userID, loginDate)
+	if err != nil {
+		return apperrors.UserRepoUpdateLoginDateExecContext.AppendMessage(err)
+	}
+	return nil
+}
+
+func (u *userRepo) RestoreUserByUserID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	restoredUser := &model.User{}
+	err := u.db.SQL.QueryRowxContext(
+		ctx,
+		restoreDeletedAt,
+		time.Now(),
+		userID,
+	).StructScan(restoredUser)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.UserRepoRestoreUserByUserIDDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserRepoRestoreUserByUserIDQueryRowxContext.AppendMessage(err)
+	}
+	return restoredUser, nil
+}
+
+// PurgeDeletedUsers permanently removes users soft-deleted before deletedBefore
+// together with the votes they cast and received, their email changes, login events, settings,
+// follows, invites, role requests, role history, role grants, nickname history and data requests.
+// It returns the keys of the avatar files and data export archives left in the blob store.
+func (u *userRepo) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, []string, error) {
+	tx, err := u.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, deleteVotesOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteVotes.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteEmailChangesOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteEmailChanges.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteLoginEventsOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteLoginEvents.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteSettingsOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteSettings.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteFollowsOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteFollows.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteInvitesOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteInvites.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRoleRequestsOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteRoleRequests.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRoleHistoryOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteRoleHistory.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRoleGrantsOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteRoleGrants.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteNicknameHistoryOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteNicknameHistory.AppendMessage(err)
+	}
+
+	archiveKeys := []string{}
+	err = tx.SelectContext(ctx, &archiveKeys, deleteDataRequestsOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteDataRequests.AppendMessage(err)
+	}
+
+	avatarKeys := []string{}
+	err = tx.SelectContext(ctx, &avatarKeys, getAvatarKeysOfDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersGetAvatarKeys.AppendMessage(err)
+	}
+
+	result, err := tx.ExecContext(ctx, deleteDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteUsers.AppendMessage(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersDeleteUsers.AppendMessage(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, nil, apperrors.UserRepoPurgeDeletedUsersCommit.AppendMessage(err)
+	}
+	return rowsAffected, blobKeys(avatarKeys, archiveKeys), nil
+}
+
+// DeleteUserByUserID removes the user and everything that belongs to them, like
+// PurgeDeletedUsers does, and returns the keys of the blobs left in the blob store.
+func (u *userRepo) DeleteUserByUserID(ctx context.Context, userID *uuid.UUID) ([]string, error) {
+	tx, err := u.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, deleteVotesOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteVotes.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteEmailChangesOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteEmailChanges.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteLoginEventsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteLoginEvents.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteSettingsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteSettings.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteFollowsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteFollows.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteInvitesOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteInvites.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRoleRequestsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteRoleRequests.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRoleHistoryOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteRoleHistory.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRoleGrantsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteRoleGrants.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteNicknameHistoryOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteNicknameHistory.AppendMessage(err)
+	}
+
+	archiveKeys := []string{}
+	err = tx.SelectContext(ctx, &archiveKeys, deleteDataRequestsOfUser, userID)
 	if err != nil {
-		return apperrors.UserRepoDeleteUserByUserIDExecContext.AppendMessage(err)
+		return nil, apperrors.UserRepoDeleteUserByUserIDDeleteDataRequests.AppendMessage(err)
+	}
+
+	avatarKeys := []string{}
+	err = tx.SelectContext(ctx, &avatarKeys, getAvatarKeysOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDGetAvatarKeys.AppendMessage(err)
+	}
+
+	result, err := tx.ExecContext(ctx, deleteUserFromDb, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDExecContext.AppendMessage(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return apperrors.UserRepoDeleteUserByUserIDRowsAffected.AppendMessage(err)
+		return nil, apperrors.UserRepoDeleteUserByUserIDRowsAffected.AppendMessage(err)
 	}
 	if rowsAffected == 0 {
-		return apperrors.UserRepoDeleteUserByUserIDEmptyRowsAffected.AppendMessage(err)
+		return nil, apperrors.UserRepoDeleteUserByUserIDEmptyRowsAffected.AppendMessage(err)
 	}
-	return nil
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperrors.UserRepoDeleteUserByUserIDCommit.AppendMessage(err)
+	}
+	return blobKeys(avatarKeys, archiveKeys), nil
+}
+
+// blobKeys joins the avatar keys and the archive keys, data requests without an archive have
+// an empty key.
+func blobKeys(avatarKeys []string, archiveKeys []string) []string {
+	keys := avatarKeys
+	for _, key := range archiveKeys {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// EraseUser anonymizes the user, deleted or not, under the given nickname and removes the
+// email changes, nickname history, login events, settings, follows and invites that still
+// belong to the user. The audit entries keep their actions, without the changes made to the
+// user and with the new nickname as the actor.
+func (u *userRepo) EraseUser(ctx context.Context, userID uuid.UUID, nickname string) (*model.User, error) {
+	tx, err := u.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	erasedUser := &model.User{}
+	err = tx.QueryRowxContext(ctx, eraseUser, userID, nickname, utils.NicknameKey(nickname), time.Now()).StructScan(erasedUser)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.UserRepoEraseUserDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserRepoEraseUserQueryRowxContext.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteEmailChangesOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserDeleteEmailChanges.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteNicknameHistoryOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserDeleteNicknameHistory.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteLoginEventsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserDeleteLoginEvents.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteSettingsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserDeleteSettings.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteFollowsOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserDeleteFollows.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteInvitesOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserDeleteInvites.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, redactAuditChangesOfUser, userID)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserRedactAuditChanges.AppendMessage(err)
+	}
+
+	_, err = tx.ExecContext(ctx, redactAuditActorOfUser, userID, nickname)
+	if err != nil {
+		return nil, apperrors.UserRepoEraseUserRedactAuditActor.AppendMessage(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperrors.UserRepoEraseUserCommit.AppendMessage(err)
+	}
+	return erasedUser, nil
 }
 
 func (u *userRepo) GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
@@ -138,17 +524,30 @@ func (u *userRepo) GetUsers(ctx context.Context, paginationQuery *utils.Paginati
 		Users:   make([]*model.User, 0),
 	}
 
-	users := make([]*model.User, 0, paginationQuery.GetSize())
+	var cursor *utils.Cursor
+	if paginationQuery.IsKeyset() {
+		var err error
+		cursor, err = u.cursorCodec.Decode(paginationQuery.Cursor)
+		if err != nil {
+			return nil, apperrors.UserRepoGetUsersDecodeCursor.AppendMessage(err)
+		}
+		if cursor.OrderBy != paginationQuery.OrderBy {
+			return nil, apperrors.UserRepoGetUsersDecodeCursor.AppendMessage(utils.ErrInvalidCursor)
+		}
+	}
 
 	usersLimit := paginationQuery.GetLimit()
-	queryLimit := paginationQuery.GetLimit() + 1
-	rows, err := u.db.SQL.QueryxContext(ctx, getUsers, paginationQuery.GetOffset(), queryLimit)
+	query, args, err := buildGetUsersQuery(paginationQuery, cursor, usersLimit+1)
+	if err != nil {
+		return nil, apperrors.UserRepoGetUsersBuildQuery.AppendMessage(err)
+	}
+	rows, err := u.db.SQL.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, apperrors.UserRepoGetUsersQueryxContext.AppendMessage(err)
 	}
 	defer rows.Close()
 
-	userCounter := 0
+	users := make([]*model.User, 0, paginationQuery.GetSize())
 	for rows.Next() {
 		user := &model.User{}
 
@@ -157,17 +556,151 @@ func (u *userRepo) GetUsers(ctx context.Context, paginationQuery *utils.Paginati
 		}
 
 		users = append(users, user)
-		userCounter++
-		if userCounter == usersLimit {
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.UserRepoGetUsersRows.AppendMessage(err)
+	}
+
+	hasExtra := len(users) > usersLimit
+	if hasExtra {
+		users = users[:usersLimit]
+	}
+
+	hasNext, hasPrev := hasExtra, cursor != nil || paginationQuery.GetOffset() > 0
+	if cursor != nil && cursor.Backward {
+		for i, j := 0, len(users)-1; i < j; i, j = i+1, j-1 {
+			users[i], users[j] = users[j], users[i]
+		}
+		hasNext, hasPrev = true, hasExtra
+	}
+
+	usersList.Users = users
+	usersList.HasMore = hasNext
+	if paginationQuery.Count != "" {
+		total, estimated, err := u.countUsers(ctx, paginationQuery)
+		if err != nil {
+			return nil, apperrors.UserRepoGetUsersCountUsers.AppendMessage(err)
+		}
+		usersList.SetTotal(total, paginationQuery.GetSize(), estimated)
+	}
+	if len(users) == 0 {
+		return usersList, nil
+	}
+	if hasNext {
+		usersList.NextCursor, err = u.encodeUsersCursor(paginationQuery, users[len(users)-1], false)
+		if err != nil {
+			return nil, apperrors.UserRepoGetUsersEncodeCursor.AppendMessage(err)
+		}
+	}
+	if hasPrev {
+		usersList.PrevCursor, err = u.encodeUsersCursor(paginationQuery, users[0], true)
+		if err != nil {
+			return nil, apperrors.UserRepoGetUsersEncodeCursor.AppendMessage(err)
+		}
+	}
+
+	return usersList, nil
+}
+
+func (u *userRepo) SearchUsers(ctx context.Context, searchQuery *model.SearchUsersQuery) (*model.Users, error) {
+	paginationQuery := searchQuery.PaginationQuery
+	usersList := &model.Users{
+		Page:    paginationQuery.GetPage(),
+		HasMore: false,
+		Users:   make([]*model.User, 0),
+	}
+
+	query := strings.ToLower(searchQuery.Query)
+	rows, err := u.db.SQL.QueryxContext(ctx, searchUsers,
+		query,
+		buildPrefixTsQuery(query),
+		searchQuery.IncludePrivate,
+		searchQuery.ViewerID,
+		paginationQuery.GetOffset(),
+		paginationQuery.GetLimit()+1,
+	)
+	if err != nil {
+		return nil, apperrors.UserRepoSearchUsersQueryxContext.AppendMessage(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		scoredUser := &struct {
+			model.User
+			Score float64 `db:"score"`
+		}{}
+		if err = rows.StructScan(scoredUser); err != nil {
+			return nil, apperrors.UserRepoSearchUsersStructScan.AppendMessage(err)
+		}
+		if len(usersList.Users) == paginationQuery.GetLimit() {
 			usersList.HasMore = true
 			break
 		}
+		user := scoredUser.User
+		usersList.Users = append(usersList.Users, &user)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, apperrors.UserRepoGetUsersRows.AppendMessage(err)
+		return nil, apperrors.UserRepoSearchUsersRows.AppendMessage(err)
 	}
 
-	usersList.Users = users
 	return usersList, nil
 }
+
+// buildPrefixTsQuery turns free text into a tsquery matching every word as a prefix.
+func buildPrefixTsQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+var patchableUserColumns = map[string]bool{
+	"nickname":   true,
+	"first_name": true,
+	"last_name":  true,
+	"email":      true,
+	"password":   true,
+	"is_public":  true,
+	"attributes": true,
+	"avatar":     true,
+	// status columns are only patched by suspensions, the patch document doesn't have them
+	"status":        true,
+	"status_reason": true,
+	"status_by":     true,
+	"status_until":  true,
+	// parent_id is only patched by ownership transfers
+	"parent_id": true,
+}
+
+func buildPatchUserQuery(userID uuid.UUID, expectedVersion int64, changes map[string]any, updatedAt time.Time) (string, []any, error) {
+	columns := make([]string, 0, len(changes))
+	for column := range changes {
+		if !patchableUserColumns[column] {
+			return "", nil, fmt.Errorf("column %q can't be patched", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	args := []any{userID, updatedAt, expectedVersion}
+	assignments := []string{"updated_at = $2", "version = version + 1"}
+	for _, column := range columns {
+		args = append(args, changes[column])
+		assignments = append(assignments, column+" = $"+strconv.Itoa(len(args)))
+	}
+	if nickname, ok := changes["nickname"].(string); ok {
+		args = append(args, utils.NicknameKey(nickname))
+		assignments = append(assignments, "nickname_key = $"+strconv.Itoa(len(args)))
+	}
+	if email, ok := changes["email"].(string); ok {
+		args = append(args, utils.EmailKey(email))
+		assignments = append(assignments, "email_key = nullif($"+strconv.Itoa(len(args))+", '')")
+	}
+
+	return "UPDATE users SET " + strings.Join(assignments, ", ") + patchUserReturning, args, nil
+}
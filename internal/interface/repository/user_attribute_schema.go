@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+)
+
+type UserAttributeSchemaRepository interface {
+	FindSchema(ctx context.Context) (*model.UserAttributeSchema, error)
+	SaveSchema(ctx context.Context, schema *model.UserAttributeSchema) (*model.UserAttributeSchema, error)
+}
+
+type userAttributeSchemaRepo struct {
+	db *datastore.DB
+}
+
+func NewUserAttributeSchemaRepository(db *datastore.DB) UserAttributeSchemaRepository {
+	return &userAttributeSchemaRepo{db: db}
+}
+
+// FindSchema returns the schema in force, which is the latest saved one.
+func (r *userAttributeSchemaRepo) FindSchema(ctx context.Context) (*model.UserAttributeSchema, error) {
+	schema := &model.UserAttributeSchema{}
+	err := r.db.SQL.GetContext(ctx, schema, getLatestUserAttributeSchema)
+	if err != nil {
+		if sql.ErrNoRows == err {
+			return nil, apperrors.UserAttributeSchemaRepoFindSchemaGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserAttributeSchemaRepoFindSchemaGetContext.AppendMessage(err)
+	}
+	return schema, nil
+}
+
+func (r *userAttributeSchemaRepo) SaveSchema(ctx context.Context, schema *model.UserAttributeSchema) (*model.UserAttributeSchema, error) {
+	err := r.db.SQL.QueryRowxContext(ctx, addUserAttributeSchema, string(schema.Schema), schema.CreatedBy, schema.CreatedAt).Scan(&schema.SchemaID)
+	if err != nil {
+		return nil, apperrors.UserAttributeSchemaRepoSaveSchemaQueryRowxContext.AppendMessage(err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,9 @@
+package repository
+
+const (
+	addUserAttributeSchema = `INSERT INTO user_attribute_schemas (schema, created_by, created_at)
+					VALUES ($1, $2, $3)
+					RETURNING schema_id`
+
+	getLatestUserAttributeSchema = `SELECT schema_id, schema, created_by, created_at FROM user_attribute_schemas ORDER BY schema_id DESC LIMIT 1`
+)
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+)
+
+type exportUserRow struct {
+	model.User
+	VotesJSON []byte `db:"votes_json"`
+}
+
+// StreamUsers hands the listed users to fn one row at a time, so an export of the whole
+// table never holds more than a row in memory. An error returned by fn stops the stream
+// and is returned as is.
+func (u *userRepo) StreamUsers(ctx context.Context, exportQuery *model.UserExportQuery, fn func(user *model.User) error) error {
+	query, args := buildExportUsersQuery(exportQuery)
+	rows, err := u.db.SQL.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return apperrors.UserRepoStreamUsersQueryxContext.AppendMessage(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &exportUserRow{}
+		if err = rows.StructScan(row); err != nil {
+			return apperrors.UserRepoStreamUsersStructScan.AppendMessage(err)
+		}
+		if exportQuery.WithVotes() {
+			row.User.Votes = []*model.Vote{}
+			if err = json.Unmarshal(row.VotesJSON, &row.User.Votes); err != nil {
+				return apperrors.UserRepoStreamUsersUnmarshalVotes.AppendMessage(err)
+			}
+		}
+
+		if err = fn(&row.User); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return apperrors.UserRepoStreamUsersRows.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func buildExportUsersQuery(exportQuery *model.UserExportQuery) (string, []any) {
+	var query strings.Builder
+	args := make([]any, 0)
+	addArg := func(arg any) string {
+		args = append(args, arg)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	votes := ""
+	if exportQuery.WithVotes() {
+		votes = exportUserVotes
+	}
+	query.WriteString(fmt.Sprintf(exportUsers, votes))
+	for _, condition := range userFilterConditions(exportQuery.Filter, addArg) {
+		query.WriteString(" AND ")
+		query.WriteString(condition)
+	}
+	query.WriteString(" ORDER BY created_at, user_id")
+
+	return query.String(), args
+}
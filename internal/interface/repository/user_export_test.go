@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildExportUsersQuery(t *testing.T) {
+	isPublic := true
+	tests := []struct {
+		name      string
+		query     *model.UserExportQuery
+		wantVotes bool
+		wantWhere string
+		wantArgs  []any
+	}{
+		{
+			"no filter",
+			&model.UserExportQuery{Fields: []string{"nickname"}},
+			false,
+			"WHERE deleted_at IS NULL ORDER BY created_at, user_id",
+			[]any{},
+		},
+		{
+			"filter and votes",
+			&model.UserExportQuery{Fields: []string{"nickname", "votes"}, Filter: &utils.UserFilter{Role: "admin", IsPublic: &isPublic}},
+			true,
+			"WHERE deleted_at IS NULL AND user_role = $1 AND is_public = $2 ORDER BY created_at, user_id",
+			[]any{"admin", true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := buildExportUsersQuery(tt.query)
+			query = strings.Join(strings.Fields(query), " ")
+			assert.NotContains(t, query, "password")
+			assert.Equal(t, tt.wantVotes, strings.Contains(query, "AS votes_json"))
+			assert.True(t, strings.HasSuffix(query, tt.wantWhere), query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
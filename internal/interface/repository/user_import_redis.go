@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const (
+	userImportPrefix        = "user_import:"
+	userImportPayloadSuffix = ":payload"
+	userImportQueue         = "user_import:queue"
+)
+
+type UserImportRedisRepository interface {
+	SaveJob(ctx context.Context, job *model.UserImportJob) error
+	FindJob(ctx context.Context, jobID uuid.UUID) (*model.UserImportJob, error)
+	EnqueueJob(ctx context.Context, job *model.UserImportJob, payload []byte) error
+	DequeueJob(ctx context.Context) (*model.UserImportJob, []byte, error)
+}
+
+// userImportRedisRepo keeps jobs, their uploaded files and reports only for ttl:
+// reports may carry generated passwords, so they must not outlive the hand-over.
+type userImportRedisRepo struct {
+	redis *datastore.Redis
+	ttl   time.Duration
+}
+
+func NewUserImportRedisRepository(redis *datastore.Redis, ttl time.Duration) UserImportRedisRepository {
+	return &userImportRedisRepo{redis: redis, ttl: ttl}
+}
+
+func (ir *userImportRedisRepo) SaveJob(ctx context.Context, job *model.UserImportJob) error {
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return apperrors.UserImportRedisRepoSaveJobMarshal.AppendMessage(err)
+	}
+
+	err = ir.redis.RedisClient.Set(ctx, ir.makeKey(job.JobID), jobBytes, ir.ttl).Err()
+	if err != nil {
+		return apperrors.UserImportRedisRepoSaveJobSet.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (ir *userImportRedisRepo) FindJob(ctx context.Context, jobID uuid.UUID) (*model.UserImportJob, error) {
+	jobBytes, err := ir.redis.RedisClient.Get(ctx, ir.makeKey(jobID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, apperrors.UserImportRedisRepoFindJobGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserImportRedisRepoFindJobGet.AppendMessage(err)
+	}
+
+	job := &model.UserImportJob{}
+	err = json.Unmarshal(jobBytes, job)
+	if err != nil {
+		return nil, apperrors.UserImportRedisRepoFindJobUnmarshal.AppendMessage(err)
+	}
+
+	return job, nil
+}
+
+func (ir *userImportRedisRepo) EnqueueJob(ctx context.Context, job *model.UserImportJob, payload []byte) error {
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return apperrors.UserImportRedisRepoEnqueueJobMarshal.AppendMessage(err)
+	}
+
+	_, err = ir.redis.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, ir.makeKey(job.JobID), jobBytes, ir.ttl)
+		pipe.Set(ctx, ir.makeKey(job.JobID)+userImportPayloadSuffix, payload, ir.ttl)
+		pipe.LPush(ctx, userImportQueue, job.JobID.String())
+		return nil
+	})
+	if err != nil {
+		return apperrors.UserImportRedisRepoEnqueueJobTxPipelined.AppendMessage(err)
+	}
+
+	return nil
+}
+
+// DequeueJob pops the oldest queued job together with its file. It returns a nil job
+// when the queue is empty.
+func (ir *userImportRedisRepo) DequeueJob(ctx context.Context) (*model.UserImportJob, []byte, error) {
+	jobIDStr, err := ir.redis.RedisClient.RPop(ctx, userImportQueue).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil, nil
+		}
+		return nil, nil, apperrors.UserImportRedisRepoDequeueJobRPop.AppendMessage(err)
+	}
+	jobID, err := uuid.Parse(jobIDStr)
+	if err != nil {
+		return nil, nil, apperrors.UserImportRedisRepoDequeueJobUuidParse.AppendMessage(err)
+	}
+
+	job, err := ir.FindJob(ctx, jobID)
+	if err != nil {
+		return nil, nil, apperrors.UserImportRedisRepoDequeueJobFindJob.AppendMessage(err)
+	}
+	payloadKey := ir.makeKey(jobID) + userImportPayloadSuffix
+	var payloadCmd *redis.StringCmd
+	_, err = ir.redis.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		payloadCmd = pipe.Get(ctx, payloadKey)
+		pipe.Del(ctx, payloadKey)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, apperrors.UserImportRedisRepoDequeueJobGetPayload.AppendMessage(err)
+	}
+	payload, err := payloadCmd.Bytes()
+	if err != nil {
+		return nil, nil, apperrors.UserImportRedisRepoDequeueJobGetPayload.AppendMessage(err)
+	}
+
+	return job, payload, nil
+}
+
+func (ir *userImportRedisRepo) makeKey(jobID uuid.UUID) string {
+	return userImportPrefix + jobID.String()
+}
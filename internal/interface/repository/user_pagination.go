@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/utils"
+)
+
+const (
+	cursorTimeLayout = "2006-01-02 15:04:05.999999"
+	// countEstimateThreshold is the planner estimate below which an exact count is still cheap.
+	countEstimateThreshold = 10000
+)
+
+type userSortColumn struct {
+	expr  string
+	cast  string
+	value func(user *model.User) string
+}
+
+var userSortColumns = map[string]userSortColumn{
+	"nickname":   {"nickname", "text", func(user *model.User) string { return user.Nickname }},
+	"first_name": {"first_name", "text", func(user *model.User) string { return user.FirstName }},
+	"last_name":  {"last_name", "text", func(user *model.User) string { return user.LastName }},
+	"user_role":  {"user_role", "text", func(user *model.User) string { return user.Role }},
+	"created_at": {"created_at", "timestamp", func(user *model.User) string { return formatCursorTime(&user.Created.At) }},
+	"updated_at": {"coalesce(updated_at, '-infinity')", "timestamp", func(user *model.User) string { return formatCursorTime(user.UpdatedAt) }},
+	"login_date": {"coalesce(login_date, '-infinity')", "timestamp", func(user *model.User) string { return formatCursorTime(user.LoginDate) }},
+}
+
+var defaultUserSort = []utils.SortField{
+	{Field: "created_at", Direction: utils.SortAsc},
+	{Field: "updated_at", Direction: utils.SortAsc},
+}
+
+func buildGetUsersQuery(paginationQuery *utils.PaginationQuery, cursor *utils.Cursor, limit int) (string, []any, error) {
+	sortFields, err := paginationQuery.GetSort(utils.UserSortFields)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(sortFields) == 0 {
+		sortFields = defaultUserSort
+	}
+
+	var query strings.Builder
+	args := make([]any, 0)
+	addArg := func(arg any) string {
+		args = append(args, arg)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	query.WriteString(getUsers)
+	for _, condition := range userFilterConditions(paginationQuery.Filter, addArg) {
+		query.WriteString(" AND ")
+		query.WriteString(condition)
+	}
+
+	backward := cursor != nil && cursor.Backward
+	if cursor != nil {
+		if len(cursor.Values) != len(sortFields) {
+			return "", nil, utils.ErrInvalidCursor
+		}
+
+		// (a, b, id) after (va, vb, vid) expands to
+		// a > va OR (a = va AND b > vb) OR (a = va AND b = vb AND id > vid)
+		// with the comparison flipped for descending fields and backward cursors.
+		branches := make([]string, 0, len(sortFields)+1)
+		equalities := make([]string, 0, len(sortFields))
+		for i, sortField := range sortFields {
+			column := userSortColumns[sortField.Field]
+			placeholder := addArg(cursor.Values[i]) + "::" + column.cast
+			operator := keysetOperator(sortField.Direction == utils.SortDesc, backward)
+			branches = append(branches, "("+strings.Join(append(equalities, column.expr+" "+operator+" "+placeholder), " AND ")+")")
+			equalities = append(equalities, column.expr+" = "+placeholder)
+		}
+		idCondition := "user_id " + keysetOperator(false, backward) + " " + addArg(cursor.ID) + "::uuid"
+		branches = append(branches, "("+strings.Join(append(equalities, idCondition), " AND ")+")")
+
+		query.WriteString(" AND (")
+		query.WriteString(strings.Join(branches, " OR "))
+		query.WriteString(")")
+	}
+
+	orderBy := make([]string, 0, len(sortFields)+1)
+	for _, sortField := range sortFields {
+		orderBy = append(orderBy, userSortColumns[sortField.Field].expr+" "+orderDirection(sortField.Direction == utils.SortDesc, backward))
+	}
+	orderBy = append(orderBy, "user_id "+orderDirection(false, backward))
+	query.WriteString(" ORDER BY ")
+	query.WriteString(strings.Join(orderBy, ", "))
+
+	if cursor == nil {
+		query.WriteString(" OFFSET " + addArg(paginationQuery.GetOffset()))
+	}
+	query.WriteString(" LIMIT " + addArg(limit))
+
+	return query.String(), args, nil
+}
+
+// countUsers returns the number of listed users and whether it is a planner estimate.
+func (u *userRepo) countUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (int, bool, error) {
+	if paginationQuery.Count == utils.CountEstimated {
+		query, args := buildCountUsersQuery(explainUsers, paginationQuery.Filter)
+		var plan string
+		if err := u.db.SQL.GetContext(ctx, &plan, query, args...); err != nil {
+			return 0, false, err
+		}
+		estimate, err := parsePlanRows(plan)
+		if err != nil {
+			return 0, false, err
+		}
+		if estimate > countEstimateThreshold {
+			return estimate, true, nil
+		}
+	}
+
+	query, args := buildCountUsersQuery(countUsers, paginationQuery.Filter)
+	var total int
+	if err := u.db.SQL.GetContext(ctx, &total, query, args...); err != nil {
+		return 0, false, err
+	}
+
+	return total, false, nil
+}
+
+func parsePlanRows(plan string) (int, error) {
+	plans := []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}{}
+	if err := json.Unmarshal([]byte(plan), &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, fmt.Errorf("empty query plan")
+	}
+
+	return int(plans[0].Plan.PlanRows), nil
+}
+
+func buildCountUsersQuery(countQuery string, filter *utils.UserFilter) (string, []any) {
+	var query strings.Builder
+	args := make([]any, 0)
+	addArg := func(arg any) string {
+		args = append(args, arg)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	query.WriteString(countQuery)
+	for _, condition := range userFilterConditions(filter, addArg) {
+		query.WriteString(" AND ")
+		query.WriteString(condition)
+	}
+
+	return query.String(), args
+}
+
+func userFilterConditions(filter *utils.UserFilter, addArg func(arg any) string) []string {
+	conditions := make([]string, 0)
+	if filter == nil {
+		return conditions
+	}
+	addCondition := func(condition string, arg any) {
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", addArg(arg)))
+	}
+
+	if filter.NicknamePrefix != "" {
+		addCondition("nickname LIKE ? || '%'", escapeLike(filter.NicknamePrefix))
+	}
+	if filter.Role != "" {
+		addCondition("user_role = ?", filter.Role)
+	}
+	if filter.IsPublic != nil {
+		addCondition("is_public = ?", *filter.IsPublic)
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		addCondition("updated_at >= ?", *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		addCondition("updated_at < ?", *filter.UpdatedTo)
+	}
+	if filter.LoginFrom != nil {
+		addCondition("login_date >= ?", *filter.LoginFrom)
+	}
+	if filter.LoginTo != nil {
+		addCondition("login_date < ?", *filter.LoginTo)
+	}
+	if filter.EmailDomain != "" {
+		addCondition("lower(split_part(email, '@', 2)) = ?", filter.EmailDomain)
+	}
+	if len(filter.Attributes) > 0 {
+		addCondition("attributes @> ?::jsonb", attributesContainment(filter.Attributes))
+	}
+	if filter.PublicOrUserID != nil {
+		addCondition("(is_public IS TRUE OR user_id = ?)", *filter.PublicOrUserID)
+	}
+
+	return conditions
+}
+
+// attributesContainment builds the JSON object for a containment check, which the GIN index
+// on attributes can serve. A value that reads as JSON keeps its type, so 42 matches the
+// number and true the boolean; anything else is compared as a string.
+func attributesContainment(attributes map[string]string) string {
+	containment := make(map[string]any, len(attributes))
+	for key, value := range attributes {
+		var typed any
+		if err := json.Unmarshal([]byte(value), &typed); err != nil {
+			typed = value
+		}
+		containment[key] = typed
+	}
+	data, _ := json.Marshal(containment)
+
+	return string(data)
+}
+
+func (u *userRepo) encodeUsersCursor(paginationQuery *utils.PaginationQuery, user *model.User, backward bool) (string, error) {
+	sortFields, err := paginationQuery.GetSort(utils.UserSortFields)
+	if err != nil {
+		return "", err
+	}
+	if len(sortFields) == 0 {
+		sortFields = defaultUserSort
+	}
+
+	cursor := &utils.Cursor{
+		OrderBy:  paginationQuery.OrderBy,
+		Values:   make([]string, 0, len(sortFields)),
+		ID:       user.UserID.String(),
+		Backward: backward,
+	}
+	for _, sortField := range sortFields {
+		cursor.Values = append(cursor.Values, userSortColumns[sortField.Field].value(user))
+	}
+
+	return u.cursorCodec.Encode(cursor)
+}
+
+func keysetOperator(desc bool, backward bool) string {
+	if desc != backward {
+		return "<"
+	}
+	return ">"
+}
+
+func orderDirection(desc bool, backward bool) string {
+	if desc != backward {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+func formatCursorTime(t *time.Time) string {
+	if t == nil {
+		return "-infinity"
+	}
+	return t.Format(cursorTimeLayout)
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
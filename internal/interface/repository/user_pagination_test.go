@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGetUsersQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     *utils.PaginationQuery
+		cursor    *utils.Cursor
+		wantWhere string
+		wantOrder string
+		wantArgs  []any
+		wantErr   bool
+	}{
+		{
+			"offset mode",
+			&utils.PaginationQuery{Size: 2, Page: 2},
+			nil,
+			"WHERE deleted_at IS NULL ORDER BY",
+			"ORDER BY created_at ASC, coalesce(updated_at, '-infinity') ASC, user_id ASC OFFSET $1 LIMIT $2",
+			[]any{2, 3},
+			false,
+		},
+		{
+			"forward cursor",
+			&utils.PaginationQuery{Size: 2, OrderBy: "nickname:desc"},
+			&utils.Cursor{OrderBy: "nickname:desc", Values: []string{"john"}, ID: "id"},
+			"AND ((nickname < $1::text) OR (nickname = $1::text AND user_id > $2::uuid))",
+			"ORDER BY nickname DESC, user_id ASC LIMIT $3",
+			[]any{"john", "id", 3},
+			false,
+		},
+		{
+			"backward cursor",
+			&utils.PaginationQuery{Size: 2, OrderBy: "nickname:desc"},
+			&utils.Cursor{OrderBy: "nickname:desc", Values: []string{"john"}, ID: "id", Backward: true},
+			"AND ((nickname > $1::text) OR (nickname = $1::text AND user_id < $2::uuid))",
+			"ORDER BY nickname ASC, user_id DESC LIMIT $3",
+			[]any{"john", "id", 3},
+			false,
+		},
+		{
+			"cursor does not match sort",
+			&utils.PaginationQuery{Size: 2, OrderBy: "nickname,created_at"},
+			&utils.Cursor{Values: []string{"john"}, ID: "id"},
+			"",
+			"",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := buildGetUsersQuery(tt.query, tt.cursor, tt.query.GetLimit()+1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				return
+			}
+			query = strings.Join(strings.Fields(query), " ")
+			assert.Contains(t, query, tt.wantWhere)
+			assert.True(t, strings.HasSuffix(query, tt.wantOrder), query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUserFilterConditions_Attributes(t *testing.T) {
+	args := []any{}
+	addArg := func(arg any) string {
+		args = append(args, arg)
+		return "$" + strconv.Itoa(len(args))
+	}
+	filter := &utils.UserFilter{Attributes: map[string]string{"team": "core", "level": "3", "admin": "true", "note": "{oops"}}
+
+	conditions := userFilterConditions(filter, addArg)
+	assert.Equal(t, []string{"attributes @> $1::jsonb"}, conditions)
+	assert.Equal(t, []any{`{"admin":true,"level":3,"note":"{oops","team":"core"}`}, args)
+}
+
+func TestBuildCountUsersQuery_PublicOrUserID(t *testing.T) {
+	viewerID := uuid.New()
+	filter := &utils.UserFilter{Role: model.RoleUser, PublicOrUserID: &viewerID}
+
+	query, args := buildCountUsersQuery(countUsers, filter)
+	assert.True(t, strings.HasSuffix(query, " AND user_role = $1 AND (is_public IS TRUE OR user_id = $2)"), query)
+	assert.Equal(t, []any{model.RoleUser, viewerID}, args)
+}
@@ -24,6 +24,8 @@ type UserRedisRepository interface {
 	SetFindUserByUUID(ctx context.Context, userID uuid.UUID, user *model.User) error
 	FindUserByNickname(ctx context.Context, nickname string) (*model.User, error)
 	SetFindUserByNickname(ctx context.Context, nickname string, user *model.User) error
+	DeleteFindUserByUUID(ctx context.Context, userID uuid.UUID) error
+	DeleteFindUserByNickname(ctx context.Context, nickname string) error
 	GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error)
 	SetGetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery, users *model.Users) error
 }
@@ -104,6 +106,26 @@ func (ur *userRedisRepo) SetFindUserByNickname(ctx context.Context, nickname str
 	return nil
 }
 
+func (ur *userRedisRepo) DeleteFindUserByUUID(ctx context.Context, userID uuid.UUID) error {
+	key := ur.makeKey(userID.String())
+	err := ur.redis.RedisClient.Del(ctx, key).Err()
+	if err != nil {
+		return apperrors.UserRedisRepoDeleteFindUserByUUIDDel.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (ur *userRedisRepo) DeleteFindUserByNickname(ctx context.Context, nickname string) error {
+	key := ur.makeKey(nickname)
+	err := ur.redis.RedisClient.Del(ctx, key).Err()
+	if err != nil {
+		return apperrors.UserRedisRepoDeleteFindUserByNicknameDel.AppendMessage(err)
+	}
+
+	return nil
+}
+
 func (ur *userRedisRepo) GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	preparedKey, err := ur.prepareKey(paginationQuery)
 	if err != nil {
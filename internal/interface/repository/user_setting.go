@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/google/uuid"
+)
+
+type UserSettingRepository interface {
+	GetUserSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error)
+	SaveUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any, updatedAt time.Time) error
+}
+
+type userSettingRepo struct {
+	db *datastore.DB
+}
+
+func NewUserSettingRepository(db *datastore.DB) UserSettingRepository {
+	return &userSettingRepo{db: db}
+}
+
+// GetUserSettings lists the settings the user changed from their defaults.
+func (r *userSettingRepo) GetUserSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error) {
+	settings := []*model.UserSetting{}
+	err := r.db.SQL.SelectContext(ctx, &settings, getUserSettings, userID)
+	if err != nil {
+		return nil, apperrors.UserSettingRepoGetUserSettingsSelectContext.AppendMessage(err)
+	}
+	return settings, nil
+}
+
+// SaveUserSettings stores the changes in one transaction. A nil value drops the stored
+// setting, so that it reads as its default again.
+func (r *userSettingRepo) SaveUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any, updatedAt time.Time) error {
+	tx, err := r.db.SQL.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.UserSettingRepoSaveUserSettingsBeginTxx.AppendMessage(err)
+	}
+	defer tx.Rollback()
+
+	for key, value := range changes {
+		if value == nil {
+			_, err = tx.ExecContext(ctx, deleteUserSetting, userID, key)
+			if err != nil {
+				return apperrors.UserSettingRepoSaveUserSettingsDelete.AppendMessage(err)
+			}
+			continue
+		}
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return apperrors.UserSettingRepoSaveUserSettingsMarshal.AppendMessage(err)
+		}
+		_, err = tx.ExecContext(ctx, upsertUserSetting, userID, key, string(valueBytes), updatedAt)
+		if err != nil {
+			return apperrors.UserSettingRepoSaveUserSettingsUpsert.AppendMessage(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return apperrors.UserSettingRepoSaveUserSettingsCommit.AppendMessage(err)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+const (
+	getUserSettings = `SELECT user_id, key, value, updated_at FROM user_settings WHERE user_id = $1 ORDER BY key`
+
+	upsertUserSetting = `INSERT INTO user_settings (user_id, key, value, updated_at)
+					VALUES ($1, $2, $3, $4)
+					ON CONFLICT (user_id, key) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at`
+
+	deleteUserSetting = `DELETE FROM user_settings WHERE user_id = $1 AND key = $2`
+)
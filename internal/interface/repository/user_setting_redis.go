@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/datastore"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const userSettingsPrefix = "user_settings:"
+
+// UserSettingRedisRepository caches the stored settings of a user rather than the settings in
+// force, so that a changed default applies without waiting for the cache to expire.
+type UserSettingRedisRepository interface {
+	GetUserSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error)
+	SetUserSettings(ctx context.Context, userID uuid.UUID, settings []*model.UserSetting) error
+	DeleteUserSettings(ctx context.Context, userID uuid.UUID) error
+}
+
+type userSettingRedisRepo struct {
+	redis *datastore.Redis
+	ttl   time.Duration
+}
+
+func NewUserSettingRedisRepository(redis *datastore.Redis, ttl time.Duration) UserSettingRedisRepository {
+	return &userSettingRedisRepo{redis: redis, ttl: ttl}
+}
+
+func (sr *userSettingRedisRepo) GetUserSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error) {
+	settingsBytes, err := sr.redis.RedisClient.Get(ctx, sr.makeKey(userID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, apperrors.UserSettingRedisRepoGetUserSettingsGetDataNotFound.AppendMessage(err)
+		}
+		return nil, apperrors.UserSettingRedisRepoGetUserSettingsGet.AppendMessage(err)
+	}
+
+	settings := []*model.UserSetting{}
+	err = json.Unmarshal(settingsBytes, &settings)
+	if err != nil {
+		return nil, apperrors.UserSettingRedisRepoGetUserSettingsUnmarshal.AppendMessage(err)
+	}
+
+	return settings, nil
+}
+
+func (sr *userSettingRedisRepo) SetUserSettings(ctx context.Context, userID uuid.UUID, settings []*model.UserSetting) error {
+	settingsBytes, err := json.Marshal(settings)
+	if err != nil {
+		return apperrors.UserSettingRedisRepoSetUserSettingsMarshal.AppendMessage(err)
+	}
+
+	err = sr.redis.RedisClient.Set(ctx, sr.makeKey(userID), settingsBytes, sr.ttl).Err()
+	if err != nil {
+		return apperrors.UserSettingRedisRepoSetUserSettingsSet.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (sr *userSettingRedisRepo) DeleteUserSettings(ctx context.Context, userID uuid.UUID) error {
+	err := sr.redis.RedisClient.Del(ctx, sr.makeKey(userID)).Err()
+	if err != nil {
+		return apperrors.UserSettingRedisRepoDeleteUserSettingsDel.AppendMessage(err)
+	}
+	return nil
+}
+
+func (sr *userSettingRedisRepo) makeKey(userID uuid.UUID) string {
+	return userSettingsPrefix + userID.String()
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepo_EraseUser(t *testing.T) {
+	userID := uuid.New()
+	nickname := model.ErasedNickname(userID)
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"UPDATE users": {columns: []string{"user_id", "nickname"}, rows: [][]driver.Value{{userID.String(), nickname}}},
+	})
+
+	erasedUser, err := NewUserRepository(db, nil).EraseUser(context.Background(), userID, nickname)
+	assert.NoError(t, err)
+	assert.Equal(t, nickname, erasedUser.Nickname)
+	assert.True(t, fake.committed)
+
+	redactChanges := fake.ran("UPDATE audit_log SET changes = NULL")
+	assert.Len(t, redactChanges, 1)
+	assert.Equal(t, []driver.Value{userID}, redactChanges[0].args)
+	redactActor := fake.ran("UPDATE audit_log SET actor_nickname")
+	assert.Len(t, redactActor, 1)
+	assert.Equal(t, []driver.Value{userID, nickname}, redactActor[0].args)
+}
+
+func TestUserRepo_SaveUser(t *testing.T) {
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"INSERT INTO users": {columns: []string{"version"}, rows: [][]driver.Value{{int64(1)}}},
+	})
+	user := &model.User{UserID: uuid.New(), Nickname: "newcomer", Email: "new@test.test"}
+	user.UserStatus = model.UserStatus{Status: model.UserStatusUnverified, StatusReason: "email not verified"}
+
+	savedUser, err := NewUserRepository(db, nil).SaveUser(context.Background(), user)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), savedUser.Version)
+
+	inserts := fake.ran("INSERT INTO users")
+	assert.Len(t, inserts, 1)
+	assert.Equal(t, []driver.Value{model.UserStatusUnverified, "email not verified"}, inserts[0].args[17:])
+	assert.Empty(t, fake.ran("UPDATE users"))
+}
+
+func TestUserRepo_PurgeDeletedUsers(t *testing.T) {
+	deletedBefore := time.Now()
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"DELETE FROM data_requests": {columns: []string{"archive_key"}, rows: [][]driver.Value{{"exports/archive.zip"}, {""}}},
+		"jsonb_array_elements_text": {columns: []string{"key"}, rows: [][]driver.Value{{"avatars/original"}}},
+	})
+
+	purged, blobKeys, err := NewUserRepository(db, nil).PurgeDeletedUsers(context.Background(), deletedBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.Equal(t, []string{"avatars/original", "exports/archive.zip"}, blobKeys)
+	assert.True(t, fake.committed)
+
+	for _, table := range []string{"role_requests", "role_history", "role_grants", "nickname_history", "data_requests"} {
+		deletes := fake.ran("DELETE FROM " + table)
+		assert.Len(t, deletes, 1, table)
+		assert.Equal(t, []driver.Value{deletedBefore}, deletes[0].args, table)
+	}
+}
+
+func TestUserRepo_DeleteUserByUserID(t *testing.T) {
+	userID := uuid.New()
+	db, fake := newFakeDB(t, map[string]*fakeResult{
+		"DELETE FROM data_requests": {columns: []string{"archive_key"}, rows: [][]driver.Value{{"exports/archive.zip"}}},
+		"jsonb_array_elements_text": {columns: []string{"key"}, rows: [][]driver.Value{{"avatars/original"}, {"avatars/thumbnail"}}},
+	})
+
+	blobKeys, err := NewUserRepository(db, nil).DeleteUserByUserID(context.Background(), &userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"avatars/original", "avatars/thumbnail", "exports/archive.zip"}, blobKeys)
+	assert.True(t, fake.committed)
+
+	for _, table := range []string{"role_requests", "role_history", "role_grants", "nickname_history", "data_requests"} {
+		deletes := fake.ran("DELETE FROM " + table)
+		assert.Len(t, deletes, 1, table)
+		assert.Equal(t, []driver.Value{&userID}, deletes[0].args, table)
+	}
+}
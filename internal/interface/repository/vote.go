@@ -23,6 +23,8 @@ type VoteRepository interface {
 	SaveUserVote(ctx context.Context, userVote *model.UserVote) (*model.UserVote, error)
 	DeleteUserVote(ctx context.Context, userVote *model.UserVote) error
 	DeleteVote(ctx context.Context, userVote *model.Vote) error
+	GetVotesCastByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExportVote, error)
+	GetVotesReceivedByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExportVote, error)
 }
 type voteRepo struct {
 	db *datastore.DB
@@ -190,3 +192,23 @@ func (v *voteRepo) DeleteVote(ctx context.Context, vote *model.Vote) error {
 	}
 	return nil
 }
+
+// GetVotesCastByUserID lists the votes the user gave, with the user each one went to.
+func (v *voteRepo) GetVotesCastByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExportVote, error) {
+	votes := []*model.DataExportVote{}
+	err := v.db.SQL.SelectContext(ctx, &votes, getVotesCastByUserID, userID)
+	if err != nil {
+		return nil, apperrors.VoteRepoGetVotesCastByUserIDSelectContext.AppendMessage(err)
+	}
+	return votes, nil
+}
+
+// GetVotesReceivedByUserID lists the votes given to the user, without the voters.
+func (v *voteRepo) GetVotesReceivedByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExportVote, error) {
+	votes := []*model.DataExportVote{}
+	err := v.db.SQL.SelectContext(ctx, &votes, getVotesReceivedByUserID, userID)
+	if err != nil {
+		return nil, apperrors.VoteRepoGetVotesReceivedByUserIDSelectContext.AppendMessage(err)
+	}
+	return votes, nil
+}
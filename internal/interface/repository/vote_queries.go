@@ -20,4 +20,12 @@ const (
 	getUserVotesByUserID = `SELECT id, user_id, vote_id FROM user_votes WHERE user_id = $1`
 
 	deleteUserVote = `DELETE FROM user_votes WHERE id = $1`
+
+	getVotesCastByUserID = `SELECT vote.vote_id, vote.vote, user_votes.user_id, vote.created_at FROM vote
+					JOIN user_votes ON user_votes.vote_id = vote.vote_id
+					WHERE vote.created_user_id = $1 ORDER BY vote.created_at`
+
+	getVotesReceivedByUserID = `SELECT vote.vote_id, vote.vote, vote.created_at FROM vote
+					JOIN user_votes ON user_votes.vote_id = vote.vote_id
+					WHERE user_votes.user_id = $1 ORDER BY vote.created_at`
 )
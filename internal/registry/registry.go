@@ -1,9 +1,16 @@
 package registry
 
 import (
+	"os"
+
 	"usermanager/internal/config"
 	"usermanager/internal/infrastructure/datastore"
+	"usermanager/internal/infrastructure/logger"
+	"usermanager/internal/infrastructure/worker"
 	"usermanager/internal/interface/controller"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/usecase/usecase"
+	"usermanager/internal/utils"
 )
 
 type registry struct {
@@ -14,6 +21,10 @@ type registry struct {
 
 type Registry interface {
 	NewAppController() controller.UserManagerController
+	NewWorkers(logger logger.Logger) []*worker.Worker
+	NewUserImportUsecase() usecase.IUserImportUsecase
+	NewUserExportUsecase() usecase.IUserExportUsecase
+	NewUserAttributeSchemaUsecase() usecase.IUserAttributeSchemaUsecase
 }
 
 func NewRegistry(db *datastore.DB, redis *datastore.Redis, cfg *config.Config) Registry {
@@ -27,5 +38,48 @@ func NewRegistry(db *datastore.DB, redis *datastore.Redis, cfg *config.Config) R
 func (r *registry) NewAppController() controller.UserManagerController {
 	return controller.UserManagerController{
 		UserController: r.NewUserController(),
+		RoleController: r.NewRoleController(),
+	}
+}
+
+func (r *registry) NewWorkers(logger logger.Logger) []*worker.Worker {
+	return []*worker.Worker{
+		r.NewRoleGrantSweeper(logger),
+		r.NewUserPurgeWorker(logger),
+		r.NewUserImportWorker(logger),
+		r.NewDataRequestWorker(logger),
+		r.NewSuspensionSweeper(logger),
+	}
+}
+
+func (r *registry) NewCursorCodec() *utils.CursorCodec {
+	return utils.NewCursorCodec(r.cfg.Pagination.CursorSecret)
+}
+
+func (r *registry) NewBlobStore() repository.BlobStore {
+	if r.cfg.Blob.Storage == config.BlobStorageS3 {
+		return repository.NewS3BlobStore(repository.S3BlobStoreConfig{
+			Endpoint:  r.cfg.Blob.S3Endpoint,
+			Region:    r.cfg.Blob.S3Region,
+			Bucket:    r.cfg.Blob.S3Bucket,
+			AccessKey: r.cfg.Blob.S3AccessKey,
+			SecretKey: r.cfg.Blob.S3SecretKey,
+			PathStyle: r.cfg.Blob.S3PathStyle,
+			PublicURL: r.cfg.Blob.PublicURL,
+		})
+	}
+	return repository.NewLocalBlobStore(r.cfg.Blob.LocalDir, r.cfg.Blob.PublicURL)
+}
+
+func (r *registry) NewMailer() repository.Mailer {
+	if r.cfg.Mail.Transport == config.MailTransportSMTP {
+		return repository.NewSMTPMailer(repository.SMTPMailerConfig{
+			Host:     r.cfg.Mail.SMTPHost,
+			Port:     r.cfg.Mail.SMTPPort,
+			User:     r.cfg.Mail.SMTPUser,
+			Password: r.cfg.Mail.SMTPPass,
+			From:     r.cfg.Mail.From,
+		})
 	}
+	return repository.NewWriterMailer(os.Stdout, r.cfg.Mail.From)
 }
@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/logger"
+	"usermanager/internal/infrastructure/worker"
+	"usermanager/internal/interface/controller"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/usecase/usecase"
+)
+
+const roleGrantSweeperName = "role_grant_sweeper"
+
+func (r *registry) NewRoleController() controller.IRoleController {
+	roleUsecase := usecase.NewRoleUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewRoleRepository(r.db),
+		repository.NewAuditRepository(r.db),
+		r.cfg.Role.AdminApprovalRequired,
+	)
+
+	return controller.NewRoleController(roleUsecase, r.NewRoleGrantUsecase(), r.cfg)
+}
+
+func (r *registry) NewRoleGrantUsecase() usecase.IRoleGrantUsecase {
+	return usecase.NewRoleGrantUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewRoleGrantRepository(r.db),
+		repository.NewEventRedisRepository(r.redis),
+		repository.NewAuditRepository(r.db),
+		&model.RoleGrantPolicy{
+			MaxDuration:            r.cfg.Role.GrantMaxDuration,
+			AutoApproveRoles:       r.cfg.Role.GrantAutoApproveRoles,
+			AutoApproveMaxDuration: r.cfg.Role.GrantAutoApproveMaxDuration,
+		},
+	)
+}
+
+func (r *registry) NewRoleGrantSweeper(logger logger.Logger) *worker.Worker {
+	roleGrantUsecase := r.NewRoleGrantUsecase()
+	job := func(ctx context.Context) error {
+		roleGrants, err := roleGrantUsecase.RevokeExpiredRoleGrants(ctx)
+		if len(roleGrants) > 0 {
+			logger.Printf("%s: %d role grants expired", roleGrantSweeperName, len(roleGrants))
+		}
+		return err
+	}
+
+	return worker.NewWorker(roleGrantSweeperName, time.Duration(r.cfg.Role.GrantSweepInterval)*time.Second, job, logger)
+}
@@ -1,18 +1,293 @@
 package registry
 
 import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/infrastructure/logger"
+	"usermanager/internal/infrastructure/worker"
 	"usermanager/internal/interface/controller"
 	"usermanager/internal/interface/repository"
 	"usermanager/internal/usecase/usecase"
+
+	"github.com/go-playground/validator"
+)
+
+const (
+	userPurgeWorkerName   = "user_purge"
+	userImportWorkerName  = "user_import"
+	dataRequestWorkerName = "data_request"
+	suspensionSweeperName = "suspension_sweeper"
 )
 
 func (r *registry) NewUserController() controller.IUserController {
 	userUsecase := usecase.NewUserUsecase(
-		repository.NewUserRepository(r.db),
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
 		repository.NewVoteRepository(r.db),
 		repository.NewUserRedisRepository(r.redis),
 		repository.NewVoteRedisRepository(r.redis),
+		repository.NewUserAttributeSchemaRepository(r.db),
+		repository.NewNicknameRepository(r.db),
+		repository.NewAuditRepository(r.db),
+		r.NewNicknamePolicy(),
+		r.NewBlobStore(),
+	)
+
+	roleGrantUsecase := r.NewRoleGrantUsecase()
+
+	return controller.NewUserController(userUsecase, roleGrantUsecase, usecase.NewActiveUserUsecase(userUsecase, roleGrantUsecase), r.NewUserImportUsecase(), r.NewUserExportUsecase(), r.NewUserAttributeSchemaUsecase(), r.NewUserAvatarUsecase(), r.NewNicknameUsecase(), r.NewEmailUsecase(), r.NewAuditUsecase(), r.NewDataRequestUsecase(), r.NewUserStatusUsecase(), r.NewUserHierarchyUsecase(userUsecase), r.NewLoginEventUsecase(), r.NewUserSettingUsecase(), r.NewFollowUsecase(), r.NewInviteUsecase(userUsecase), r.NewRegistrationUsecase(userUsecase), r.cfg)
+}
+
+func (r *registry) NewUserImportUsecase() usecase.IUserImportUsecase {
+	return usecase.NewUserImportUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewUserImportRedisRepository(r.redis, time.Duration(r.cfg.Import.JobTtl)*time.Second),
+		repository.NewNicknameRepository(r.db),
+		repository.NewUserAttributeSchemaRepository(r.db),
+		repository.NewAuditRepository(r.db),
+		&controller.CustomValidator{Validator: validator.New()},
+		&model.UserImportPolicy{
+			MaxRows:        r.cfg.Import.MaxRows,
+			PasswordLength: r.cfg.Import.PasswordLength,
+		},
 	)
+}
+
+func (r *registry) NewUserExportUsecase() usecase.IUserExportUsecase {
+	return usecase.NewUserExportUsecase(repository.NewUserRepository(r.db, r.NewCursorCodec()))
+}
+
+func (r *registry) NewUserAttributeSchemaUsecase() usecase.IUserAttributeSchemaUsecase {
+	return usecase.NewUserAttributeSchemaUsecase(repository.NewUserAttributeSchemaRepository(r.db))
+}
+
+func (r *registry) NewUserAvatarUsecase() usecase.IUserAvatarUsecase {
+	return usecase.NewUserAvatarUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewAuditRepository(r.db),
+		r.NewBlobStore(),
+		&model.UserAvatarPolicy{
+			MaxPixels: r.cfg.Avatar.MaxPixels,
+			MaxSide:   r.cfg.Avatar.MaxSide,
+			Sizes:     r.cfg.Avatar.Sizes,
+		},
+	)
+}
+
+func (r *registry) NewNicknameUsecase() usecase.INicknameUsecase {
+	return usecase.NewNicknameUsecase(repository.NewUserRepository(r.db, r.NewCursorCodec()), repository.NewNicknameRepository(r.db))
+}
+
+func (r *registry) NewEmailUsecase() usecase.IEmailUsecase {
+	return usecase.NewEmailUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewEmailChangeRepository(r.db),
+		r.NewMailer(),
+		repository.NewAuditRepository(r.db),
+		&model.EmailPolicy{
+			ChangeTtl:   time.Duration(r.cfg.Email.ChangeTtl) * time.Second,
+			LinkBaseURL: r.cfg.Mail.LinkBaseURL,
+		},
+	)
+}
+
+func (r *registry) NewAuditUsecase() usecase.IAuditUsecase {
+	return usecase.NewAuditUsecase(repository.NewAuditRepository(r.db))
+}
+
+func (r *registry) NewDataRequestUsecase() usecase.IDataRequestUsecase {
+	return usecase.NewDataRequestUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewVoteRepository(r.db),
+		repository.NewNicknameRepository(r.db),
+		repository.NewEmailChangeRepository(r.db),
+		repository.NewLoginEventRepository(r.db),
+		repository.NewUserSettingRepository(r.db),
+		repository.NewFollowRepository(r.db),
+		repository.NewAuditRepository(r.db),
+		repository.NewDataRequestRepository(r.db),
+		r.NewBlobStore(),
+		&model.DataRequestPolicy{ArchiveTtl: time.Duration(r.cfg.DataRequest.ArchiveTtl) * time.Second},
+	)
+}
+
+func (r *registry) NewUserStatusUsecase() usecase.IUserStatusUsecase {
+	return usecase.NewUserStatusUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewAuditRepository(r.db),
+		r.NewUserHierarchyPolicy(),
+	)
+}
+
+func (r *registry) NewUserHierarchyUsecase(userUsecase usecase.IUserUsecase) usecase.IUserHierarchyUsecase {
+	return usecase.NewUserHierarchyUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewAuditRepository(r.db),
+		userUsecase,
+		r.NewUserHierarchyPolicy(),
+	)
+}
+
+func (r *registry) NewLoginEventUsecase() usecase.ILoginEventUsecase {
+	return usecase.NewLoginEventUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewLoginEventRepository(r.db),
+		repository.NewEventRedisRepository(r.redis),
+	)
+}
+
+func (r *registry) NewUserSettingUsecase() usecase.IUserSettingUsecase {
+	return usecase.NewUserSettingUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserSettingRepository(r.db),
+		repository.NewUserSettingRedisRepository(r.redis, time.Duration(r.cfg.Settings.CacheTtl)*time.Second),
+		repository.NewAuditRepository(r.db),
+	)
+}
+
+func (r *registry) NewFollowUsecase() usecase.IFollowUsecase {
+	return usecase.NewFollowUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewFollowRepository(r.db),
+		r.NewUserSettingUsecase(),
+	)
+}
+
+func (r *registry) NewInviteUsecase(userUsecase usecase.IUserUsecase) usecase.IInviteUsecase {
+	return usecase.NewInviteUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewRoleRepository(r.db),
+		repository.NewInviteRepository(r.db),
+		repository.NewAuditRepository(r.db),
+		r.NewMailer(),
+		userUsecase,
+		r.NewRoleGrantUsecase(),
+		&model.InvitePolicy{
+			Ttl:                   time.Duration(r.cfg.Invite.Ttl) * time.Second,
+			LinkBaseURL:           r.cfg.Mail.LinkBaseURL,
+			AdminApprovalRequired: r.cfg.Role.AdminApprovalRequired,
+		},
+	)
+}
+
+func (r *registry) NewRegistrationUsecase(userUsecase usecase.IUserUsecase) usecase.IRegistrationUsecase {
+	return usecase.NewRegistrationUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		repository.NewUserRedisRepository(r.redis),
+		repository.NewRegistrationRedisRepository(r.redis),
+		repository.NewAuditRepository(r.db),
+		r.NewMailer(),
+		userUsecase,
+		&model.RegistrationPolicy{
+			Enabled:             r.cfg.Registration.Enabled,
+			ChallengeDifficulty: r.cfg.Registration.ChallengeDifficulty,
+			ChallengeTtl:        time.Duration(r.cfg.Registration.ChallengeTtl) * time.Second,
+			ChallengeIPLimit:    r.cfg.Registration.ChallengeIPLimit,
+			IPLimit:             r.cfg.Registration.IPLimit,
+			IPWindow:            time.Duration(r.cfg.Registration.IPWindow) * time.Second,
+			BlockedDomains:      r.cfg.Registration.BlockedDomains,
+			VerifyEmail:         r.cfg.Registration.VerifyEmail,
+			VerifyTtl:           time.Duration(r.cfg.Registration.VerifyTtl) * time.Second,
+			LinkBaseURL:         r.cfg.Mail.LinkBaseURL,
+		},
+	)
+}
+
+func (r *registry) NewUserHierarchyPolicy() *model.UserHierarchyPolicy {
+	return &model.UserHierarchyPolicy{
+		MaxDepth:  r.cfg.Hierarchy.MaxDepth,
+		OnDelete:  r.cfg.Hierarchy.DeletePolicy,
+		OnSuspend: r.cfg.Hierarchy.SuspendPolicy,
+	}
+}
+
+func (r *registry) NewNicknamePolicy() *model.NicknamePolicy {
+	return &model.NicknamePolicy{ChangeCooldown: time.Duration(r.cfg.Nickname.ChangeCooldown) * time.Second}
+}
+
+func (r *registry) NewUserPurgeWorker(logger logger.Logger) *worker.Worker {
+	userRetentionUsecase := usecase.NewUserRetentionUsecase(
+		repository.NewUserRepository(r.db, r.NewCursorCodec()),
+		r.NewBlobStore(),
+		time.Duration(r.cfg.Retention.Days)*24*time.Hour,
+	)
+	job := func(ctx context.Context) error {
+		purged, err := userRetentionUsecase.PurgeDeletedUsers(ctx)
+		if purged > 0 {
+			logger.Printf("%s: %d deleted users purged", userPurgeWorkerName, purged)
+		}
+		return err
+	}
+
+	return worker.NewWorker(userPurgeWorkerName, time.Duration(r.cfg.Retention.PurgeInterval)*time.Second, job, logger)
+}
+
+// NewUserImportWorker drains the import queue on every tick, one job after another.
+func (r *registry) NewUserImportWorker(logger logger.Logger) *worker.Worker {
+	userImportUsecase := r.NewUserImportUsecase()
+	job := func(ctx context.Context) error {
+		for {
+			importJob, err := userImportUsecase.RunNextImportJob(ctx)
+			if err != nil {
+				return err
+			}
+			if importJob == nil {
+				return nil
+			}
+			logger.Printf("%s: job %s imported %d of %d rows, %d failed", userImportWorkerName, importJob.JobID, importJob.Succeeded, importJob.Total, importJob.Failed)
+		}
+	}
+
+	return worker.NewWorker(userImportWorkerName, time.Duration(r.cfg.Import.PollInterval)*time.Second, job, logger)
+}
+
+// NewDataRequestWorker removes the expired export archives, then drains the data request queue
+// on every tick.
+func (r *registry) NewDataRequestWorker(logger logger.Logger) *worker.Worker {
+	dataRequestUsecase := r.NewDataRequestUsecase()
+	job := func(ctx context.Context) error {
+		deleted, err := dataRequestUsecase.DeleteExpiredDataExports(ctx)
+		if deleted > 0 {
+			logger.Printf("%s: %d expired data exports deleted", dataRequestWorkerName, deleted)
+		}
+		if err != nil {
+			return err
+		}
+		for {
+			dataRequest, err := dataRequestUsecase.RunNextDataRequest(ctx)
+			if dataRequest != nil {
+				logger.Printf("%s: %s request %s for user %s %s", dataRequestWorkerName, dataRequest.Kind, dataRequest.DataRequestID, dataRequest.UserID, dataRequest.Status)
+			}
+			if err != nil {
+				return err
+			}
+			if dataRequest == nil {
+				return nil
+			}
+		}
+	}
+
+	return worker.NewWorker(dataRequestWorkerName, time.Duration(r.cfg.DataRequest.PollInterval)*time.Second, job, logger)
+}
+
+func (r *registry) NewSuspensionSweeper(logger logger.Logger) *worker.Worker {
+	userStatusUsecase := r.NewUserStatusUsecase()
+	job := func(ctx context.Context) error {
+		lifted, err := userStatusUsecase.LiftExpiredSuspensions(ctx)
+		if lifted > 0 {
+			logger.Printf("%s: %d suspensions lifted", suspensionSweeperName, lifted)
+		}
+		return err
+	}
 
-	return controller.NewUserController(userUsecase, r.cfg)
+	return worker.NewWorker(suspensionSweeperName, time.Duration(r.cfg.Suspension.SweepInterval)*time.Second, job, logger)
 }
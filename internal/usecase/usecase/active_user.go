@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type IActiveUserUsecase interface {
+	VerifyActiveUser(ctx context.Context, userID uuid.UUID, role string) (*model.User, error)
+}
+
+type ActiveUserUsecase struct {
+	UserUsecase      IUserUsecase
+	RoleGrantUsecase IRoleGrantUsecase
+}
+
+func NewActiveUserUsecase(userUsecase IUserUsecase, roleGrantUsecase IRoleGrantUsecase) IActiveUserUsecase {
+	return &ActiveUserUsecase{
+		UserUsecase:      userUsecase,
+		RoleGrantUsecase: roleGrantUsecase,
+	}
+}
+
+// VerifyActiveUser checks a token against the user as it is now: tokens stay valid until they
+// expire, so the user must still exist and be active, and the role of the token must still be
+// the role in force once the active grants are applied. It returns the user with that role.
+func (aus *ActiveUserUsecase) VerifyActiveUser(ctx context.Context, userID uuid.UUID, role string) (*model.User, error) {
+	user, err := aus.UserUsecase.GetUser(ctx, userID)
+	if err != nil {
+		return nil, apperrors.ActiveUserUsecaseVerifyActiveUserGetUser.AppendMessage(err)
+	}
+	if user == nil {
+		return nil, apperrors.ActiveUserUsecaseVerifyActiveUserNotExist.AppendMessage(userID)
+	}
+	err = user.CheckActive(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveUser := *user
+	_, err = aus.RoleGrantUsecase.ApplyRoleGrants(ctx, &effectiveUser)
+	if err != nil {
+		return nil, apperrors.ActiveUserUsecaseVerifyActiveUserApplyRoleGrants.AppendMessage(err)
+	}
+	if effectiveUser.Role != role {
+		return nil, apperrors.ActiveUserUsecaseVerifyActiveUserRoleChanged.AppendMessage(role)
+	}
+
+	return &effectiveUser, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestActiveUserUsecase_VerifyActiveUser(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		grants  []*model.RoleGrant
+		role    string
+		wantErr *apperrors.AppError
+	}{
+		{"own role", []*model.RoleGrant{}, model.RoleUser, nil},
+		{"granted role", []*model.RoleGrant{{Role: model.RoleAdmin, Status: model.RoleGrantStatusActive, ExpiresAt: &expiresAt}}, model.RoleAdmin, nil},
+		{"grant ended", []*model.RoleGrant{}, model.RoleAdmin, &apperrors.ActiveUserUsecaseVerifyActiveUserRoleChanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{UserID: uuid.New(), Nickname: "nickname", Role: model.RoleUser}
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			roleGrantRepoMock := &RoleGrantRepositoryMock{}
+			roleGrantRepoMock.On("FindActiveRoleGrantsByUserID", mock.Anything, user.UserID, mock.Anything).Return(tt.grants, nil)
+
+			userusecase := NewUserUsecase(&UserRepositoryMock{}, nil, userRedisRepoMock, nil, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+			activeuserusecase := NewActiveUserUsecase(userusecase, NewRoleGrantUsecase(nil, roleGrantRepoMock, nil, nil, &model.RoleGrantPolicy{}))
+			got, err := activeuserusecase.VerifyActiveUser(context.TODO(), user.UserID, tt.role)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got.Role, tt.role)
+			assert.Equal(t, user.Role, model.RoleUser)
+		})
+	}
+}
+
+func TestActiveUserUsecase_VerifyActiveUser_Suspended(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Role: model.RoleUser, UserStatus: model.UserStatus{Status: model.UserStatusSuspended, StatusReason: "spam"}}
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+
+	userusecase := NewUserUsecase(&UserRepositoryMock{}, nil, userRedisRepoMock, nil, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+	_, err := NewActiveUserUsecase(userusecase, NewRoleGrantUsecase(nil, &RoleGrantRepositoryMock{}, nil, nil, &model.RoleGrantPolicy{})).VerifyActiveUser(context.TODO(), user.UserID, model.RoleUser)
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserCheckActiveSuspended))
+}
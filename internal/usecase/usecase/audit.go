@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IAuditUsecase interface {
+	GetUserHistory(ctx context.Context, userID uuid.UUID, auditQuery *model.AuditQuery) (*model.AuditEntries, error)
+	SearchAuditLog(ctx context.Context, auditQuery *model.AuditQuery) (*model.AuditEntries, error)
+	ExportAuditLog(ctx context.Context, auditQuery *model.AuditQuery, w io.Writer) (int, error)
+}
+
+type AuditUsecase struct {
+	AuditRepo repository.AuditRepository
+}
+
+func NewAuditUsecase(auditRepo repository.AuditRepository) IAuditUsecase {
+	return &AuditUsecase{AuditRepo: auditRepo}
+}
+
+// GetUserHistory lists what was done to the user, whatever target the query names.
+func (as *AuditUsecase) GetUserHistory(ctx context.Context, userID uuid.UUID, auditQuery *model.AuditQuery) (*model.AuditEntries, error) {
+	auditQuery.TargetID = &userID
+	entries, err := as.AuditRepo.FindAuditEntries(ctx, auditQuery)
+	if err != nil {
+		return nil, apperrors.AuditUsecaseGetUserHistoryFindAuditEntries.AppendMessage(err)
+	}
+
+	return newAuditEntries(entries, auditQuery), nil
+}
+
+func (as *AuditUsecase) SearchAuditLog(ctx context.Context, auditQuery *model.AuditQuery) (*model.AuditEntries, error) {
+	entries, err := as.AuditRepo.FindAuditEntries(ctx, auditQuery)
+	if err != nil {
+		return nil, apperrors.AuditUsecaseSearchAuditLogFindAuditEntries.AppendMessage(err)
+	}
+
+	return newAuditEntries(entries, auditQuery), nil
+}
+
+// ExportAuditLog writes every matching entry to w as NDJSON, oldest first, and returns how
+// many were written.
+func (as *AuditUsecase) ExportAuditLog(ctx context.Context, auditQuery *model.AuditQuery, w io.Writer) (int, error) {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	exported := 0
+	err := as.AuditRepo.StreamAuditEntries(ctx, auditQuery, func(entry *model.AuditEntry) error {
+		if err := encoder.Encode(entry); err != nil {
+			return apperrors.AuditUsecaseExportAuditLogWrite.AppendMessage(err)
+		}
+		exported++
+		return nil
+	})
+	if err != nil {
+		if apperrors.Is(err, &apperrors.AuditUsecaseExportAuditLogWrite) {
+			return exported, err
+		}
+		return exported, apperrors.AuditUsecaseExportAuditLogStreamAuditEntries.AppendMessage(err)
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return exported, apperrors.AuditUsecaseExportAuditLogFlush.AppendMessage(err)
+	}
+
+	return exported, nil
+}
+
+// newAuditEntries points to the next page when this one is full.
+func newAuditEntries(entries []*model.AuditEntry, auditQuery *model.AuditQuery) *model.AuditEntries {
+	auditEntries := &model.AuditEntries{Entries: entries}
+	if len(entries) > 0 && len(entries) == auditQuery.Limit {
+		auditEntries.NextBefore = entries[len(entries)-1].EntryID
+	}
+	return auditEntries
+}
+
+// recordAudit appends an entry for an operation that already succeeded. The actor, IP and
+// request ID come from the context.
+func recordAudit(ctx context.Context, auditRepo repository.AuditRepository, action string, targetID uuid.UUID, changes model.AuditChanges) error {
+	_, err := auditRepo.SaveAuditEntry(ctx, model.NewAuditEntry(ctx, action, targetID, changes, time.Now()))
+	return err
+}
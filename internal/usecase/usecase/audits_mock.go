@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditRepositoryMock struct {
+	mock.Mock
+}
+
+func (arm *AuditRepositoryMock) SaveAuditEntry(ctx context.Context, entry *model.AuditEntry) (*model.AuditEntry, error) {
+	args := arm.Called(ctx, entry)
+	return args.Get(0).(*model.AuditEntry), args.Error(1)
+}
+
+func (arm *AuditRepositoryMock) FindAuditEntries(ctx context.Context, auditQuery *model.AuditQuery) ([]*model.AuditEntry, error) {
+	args := arm.Called(ctx, auditQuery)
+	return args.Get(0).([]*model.AuditEntry), args.Error(1)
+}
+
+func (arm *AuditRepositoryMock) StreamAuditEntries(ctx context.Context, auditQuery *model.AuditQuery, fn func(entry *model.AuditEntry) error) error {
+	args := arm.Called(ctx, auditQuery, fn)
+	return args.Error(0)
+}
+
+func newAuditRepoMock() *AuditRepositoryMock {
+	auditRepoMock := &AuditRepositoryMock{}
+	auditRepoMock.On("SaveAuditEntry", mock.Anything, mock.Anything).Return(&model.AuditEntry{}, nil)
+	return auditRepoMock
+}
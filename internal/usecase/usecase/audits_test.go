@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestUserUsecase_PatchUser_Audit(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", FirstName: "fname", Password: "hash"}
+	patchedUser := &model.User{UserID: user.UserID, Nickname: "nickname", FirstName: "renamed", Password: "new hash"}
+	changes := map[string]any{"first_name": "renamed", "password": "secret"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userRepoMock.On("PatchUser", mock.Anything, user.UserID, int64(0), changes).Return(patchedUser, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	actor := &model.User{UserID: uuid.New(), Nickname: "admin"}
+	ctx := model.ContextWithAuditActor(context.TODO(), actor)
+	ctx = model.ContextWithAuditRequest(ctx, &model.AuditRequest{IP: "192.0.2.1", RequestID: "request"})
+	userusecase := NewUserUsecase(userRepoMock, nil, userRedisRepoMock, nil, newAttributeSchemaRepoMock(), newNicknameRepoMock(), auditRepoMock, nicknameTestPolicy, nil)
+	_, err := userusecase.PatchUser(ctx, user.UserID, 0, changes)
+	assert.NilError(t, err)
+
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserPatch)
+	assert.Equal(t, entry.TargetID, user.UserID)
+	assert.Equal(t, *entry.ActorID, actor.UserID)
+	assert.Equal(t, entry.ActorNickname, "admin")
+	assert.Equal(t, entry.IP, "192.0.2.1")
+	assert.Equal(t, entry.RequestID, "request")
+	assert.DeepEqual(t, entry.Changes, model.AuditChanges{
+		"first_name": {Before: "fname", After: "renamed"},
+		"password":   {Before: model.AuditRedacted, After: model.AuditRedacted},
+	})
+	data, err := json.Marshal(entry)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(data), "secret") && !strings.Contains(string(data), "hash"))
+}
+
+func TestAuditUsecase_SearchAuditLog(t *testing.T) {
+	auditRepoMock := &AuditRepositoryMock{}
+	auditRepoMock.On("FindAuditEntries", mock.Anything, mock.Anything).Return([]*model.AuditEntry{{EntryID: 9}, {EntryID: 7}}, nil)
+	auditusecase := NewAuditUsecase(auditRepoMock)
+
+	auditEntries, err := auditusecase.SearchAuditLog(context.TODO(), &model.AuditQuery{Limit: 2})
+	assert.NilError(t, err)
+	assert.Equal(t, auditEntries.NextBefore, int64(7))
+
+	auditEntries, err = auditusecase.SearchAuditLog(context.TODO(), &model.AuditQuery{Limit: 3})
+	assert.NilError(t, err)
+	assert.Equal(t, auditEntries.NextBefore, int64(0))
+
+	userID := uuid.New()
+	auditQuery := &model.AuditQuery{Limit: 3}
+	_, err = auditusecase.GetUserHistory(context.TODO(), userID, auditQuery)
+	assert.NilError(t, err)
+	assert.Equal(t, *auditQuery.TargetID, userID)
+}
+
+func TestAuditUsecase_ExportAuditLog(t *testing.T) {
+	entries := []*model.AuditEntry{{EntryID: 1, Action: model.AuditActionUserCreate}, {EntryID: 2, Action: model.AuditActionUserDelete}}
+	auditRepoMock := &AuditRepositoryMock{}
+	auditRepoMock.On("StreamAuditEntries", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(entry *model.AuditEntry) error)
+		for _, entry := range entries {
+			_ = fn(entry)
+		}
+	}).Return(nil)
+
+	var out bytes.Buffer
+	exported, err := NewAuditUsecase(auditRepoMock).ExportAuditLog(context.TODO(), &model.AuditQuery{}, &out)
+	assert.NilError(t, err)
+	assert.Equal(t, exported, 2)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), 2)
+	entry := &model.AuditEntry{}
+	assert.NilError(t, json.Unmarshal([]byte(lines[1]), entry))
+	assert.Equal(t, entry.Action, model.AuditActionUserDelete)
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+const dataExportKeyPrefix = "data-exports/"
+
+type IDataRequestUsecase interface {
+	RequestDataExport(ctx context.Context, userID uuid.UUID, format string, requestedBy *model.User) (*model.DataRequest, error)
+	RequestErasure(ctx context.Context, userID uuid.UUID, requestedBy *model.User) (*model.DataRequest, error)
+	GetUserDataRequest(ctx context.Context, userID uuid.UUID, dataRequestID uuid.UUID) (*model.DataRequest, error)
+	GetDataExportArchive(ctx context.Context, userID uuid.UUID, dataRequestID uuid.UUID) (*model.DataRequest, *repository.Blob, error)
+	GetDataRequest(ctx context.Context, dataRequestID uuid.UUID) (*model.DataRequest, error)
+	GetDataRequests(ctx context.Context, dataRequestQuery *model.DataRequestQuery) ([]*model.DataRequest, error)
+	RunNextDataRequest(ctx context.Context) (*model.DataRequest, error)
+	DeleteExpiredDataExports(ctx context.Context) (int, error)
+}
+
+type DataRequestUsecase struct {
+	UserRepo        repository.UserRepository
+	UserRedisRepo   repository.UserRedisRepository
+	VoteRepo        repository.VoteRepository
+	NicknameRepo    repository.NicknameRepository
+	EmailChangeRepo repository.EmailChangeRepository
+	LoginEventRepo  repository.LoginEventRepository
+	UserSettingRepo repository.UserSettingRepository
+	FollowRepo      repository.FollowRepository
+	AuditRepo       repository.AuditRepository
+	DataRequestRepo repository.DataRequestRepository
+	BlobStore       repository.BlobStore
+	Policy          *model.DataRequestPolicy
+}
+
+func NewDataRequestUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, voteRepo repository.VoteRepository, nicknameRepo repository.NicknameRepository, emailChangeRepo repository.EmailChangeRepository, loginEventRepo repository.LoginEventRepository, userSettingRepo repository.UserSettingRepository, followRepo repository.FollowRepository, auditRepo repository.AuditRepository, dataRequestRepo repository.DataRequestRepository, blobStore repository.BlobStore, policy *model.DataRequestPolicy) IDataRequestUsecase {
+	return &DataRequestUsecase{
+		UserRepo:        userRepo,
+		UserRedisRepo:   userRedisRepo,
+		VoteRepo:        voteRepo,
+		NicknameRepo:    nicknameRepo,
+		EmailChangeRepo: emailChangeRepo,
+		LoginEventRepo:  loginEventRepo,
+		UserSettingRepo: userSettingRepo,
+		FollowRepo:      followRepo,
+		AuditRepo:       auditRepo,
+		DataRequestRepo: dataRequestRepo,
+		BlobStore:       blobStore,
+		Policy:          policy,
+	}
+}
+
+// RequestDataExport queues an export of everything stored about the user. The archive can be
+// downloaded once the request is completed, until it expires.
+func (ds *DataRequestUsecase) RequestDataExport(ctx context.Context, userID uuid.UUID, format string, requestedBy *model.User) (*model.DataRequest, error) {
+	format, err := model.DataExportFormat(format)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseRequestDataExportFormat.AppendMessage(err)
+	}
+	_, err = ds.findUser(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindDeletedUserByUUIDGetDataNotFound) {
+			return nil, apperrors.DataRequestUsecaseRequestDataExportNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.DataRequestUsecaseRequestDataExportFindUser.AppendMessage(err)
+	}
+
+	dataRequest, err := ds.DataRequestRepo.SaveDataRequest(ctx, newDataRequest(userID, model.DataRequestKindExport, format, requestedBy))
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseRequestDataExportSave.AppendMessage(err)
+	}
+
+	return dataRequest, nil
+}
+
+// RequestErasure queues the anonymization of the user, deleted or not.
+func (ds *DataRequestUsecase) RequestErasure(ctx context.Context, userID uuid.UUID, requestedBy *model.User) (*model.DataRequest, error) {
+	_, err := ds.findUser(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindDeletedUserByUUIDGetDataNotFound) {
+			return nil, apperrors.DataRequestUsecaseRequestErasureNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.DataRequestUsecaseRequestErasureFindUser.AppendMessage(err)
+	}
+
+	dataRequest, err := ds.DataRequestRepo.SaveDataRequest(ctx, newDataRequest(userID, model.DataRequestKindErasure, "", requestedBy))
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseRequestErasureSave.AppendMessage(err)
+	}
+
+	return dataRequest, nil
+}
+
+// GetUserDataRequest only finds requests about the given user.
+func (ds *DataRequestUsecase) GetUserDataRequest(ctx context.Context, userID uuid.UUID, dataRequestID uuid.UUID) (*model.DataRequest, error) {
+	dataRequest, err := ds.GetDataRequest(ctx, dataRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if dataRequest.UserID != userID {
+		return nil, apperrors.DataRequestUsecaseGetDataRequestNotExist.AppendMessage(dataRequestID)
+	}
+
+	return dataRequest, nil
+}
+
+func (ds *DataRequestUsecase) GetDataExportArchive(ctx context.Context, userID uuid.UUID, dataRequestID uuid.UUID) (*model.DataRequest, *repository.Blob, error) {
+	dataRequest, err := ds.GetUserDataRequest(ctx, userID, dataRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !dataRequest.HasArchive(time.Now()) {
+		return nil, nil, apperrors.DataRequestUsecaseGetDataExportArchiveUnavailable.AppendMessage(dataRequest.Status)
+	}
+
+	blob, err := ds.BlobStore.Get(ctx, dataRequest.ArchiveKey)
+	if err != nil {
+		return nil, nil, apperrors.DataRequestUsecaseGetDataExportArchiveGet.AppendMessage(err)
+	}
+
+	return dataRequest, blob, nil
+}
+
+func (ds *DataRequestUsecase) GetDataRequest(ctx context.Context, dataRequestID uuid.UUID) (*model.DataRequest, error) {
+	dataRequest, err := ds.DataRequestRepo.FindDataRequest(ctx, dataRequestID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.DataRequestRepoFindDataRequestGetDataNotFound) {
+			return nil, apperrors.DataRequestUsecaseGetDataRequestNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.DataRequestUsecaseGetDataRequestFind.AppendMessage(err)
+	}
+
+	return dataRequest, nil
+}
+
+func (ds *DataRequestUsecase) GetDataRequests(ctx context.Context, dataRequestQuery *model.DataRequestQuery) ([]*model.DataRequest, error) {
+	dataRequests, err := ds.DataRequestRepo.GetDataRequests(ctx, dataRequestQuery)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseGetDataRequests.AppendMessage(err)
+	}
+
+	return dataRequests, nil
+}
+
+// RunNextDataRequest runs the oldest queued request. It returns a nil request when nothing is
+// queued, and the failed request together with its error when it fails.
+func (ds *DataRequestUsecase) RunNextDataRequest(ctx context.Context) (*model.DataRequest, error) {
+	dataRequest, err := ds.DataRequestRepo.ClaimNextDataRequest(ctx, time.Now())
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseRunNextDataRequestClaim.AppendMessage(err)
+	}
+	if dataRequest == nil {
+		return nil, nil
+	}
+
+	// Whatever the request changes is done on behalf of whoever asked for it.
+	if dataRequest.RequestedByID != nil {
+		ctx = model.ContextWithAuditActor(ctx, &model.User{UserID: *dataRequest.RequestedByID, Nickname: dataRequest.RequestedBy})
+	}
+	switch dataRequest.Kind {
+	case model.DataRequestKindExport:
+		err = ds.runDataExport(ctx, dataRequest)
+	case model.DataRequestKindErasure:
+		err = ds.runErasure(ctx, dataRequest)
+	default:
+		err = apperrors.DataRequestUsecaseRunNextDataRequestKind.AppendMessage(dataRequest.Kind)
+	}
+
+	finishedAt := time.Now()
+	dataRequest.FinishedAt = &finishedAt
+	dataRequest.Status = model.DataRequestStatusCompleted
+	if err != nil {
+		dataRequest.Status = model.DataRequestStatusFailed
+		dataRequest.Error = err.Error()
+	}
+	finishErr := ds.DataRequestRepo.FinishDataRequest(ctx, dataRequest)
+	if finishErr != nil {
+		return nil, apperrors.DataRequestUsecaseRunNextDataRequestFinish.AppendMessage(finishErr)
+	}
+
+	return dataRequest, err
+}
+
+// DeleteExpiredDataExports removes the archives that can no longer be downloaded and returns
+// how many were removed.
+func (ds *DataRequestUsecase) DeleteExpiredDataExports(ctx context.Context) (int, error) {
+	dataRequests, err := ds.DataRequestRepo.GetExpiredDataExports(ctx, time.Now())
+	if err != nil {
+		return 0, apperrors.DataRequestUsecaseDeleteExpiredDataExportsGet.AppendMessage(err)
+	}
+
+	deleted := 0
+	for _, dataRequest := range dataRequests {
+		err = ds.deleteArchive(ctx, dataRequest)
+		if err != nil {
+			return deleted, apperrors.DataRequestUsecaseDeleteExpiredDataExportsDelete.AppendMessage(err)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func (ds *DataRequestUsecase) runDataExport(ctx context.Context, dataRequest *model.DataRequest) error {
+	export, err := ds.collectDataExport(ctx, dataRequest.UserID)
+	if err != nil {
+		return err
+	}
+
+	var archive bytes.Buffer
+	err = model.WriteDataExport(&archive, dataRequest.Format, export)
+	if err != nil {
+		return apperrors.DataRequestUsecaseRunDataExportWrite.AppendMessage(err)
+	}
+	key := fmt.Sprintf("%s%s/%s.%s", dataExportKeyPrefix, dataRequest.UserID, dataRequest.DataRequestID, dataRequest.Format)
+	err = ds.BlobStore.Put(ctx, key, &repository.Blob{Data: archive.Bytes(), ContentType: model.DataExportContentType(dataRequest.Format)})
+	if err != nil {
+		return apperrors.DataRequestUsecaseRunDataExportPut.AppendMessage(err)
+	}
+
+	expiresAt := time.Now().Add(ds.Policy.ArchiveTtl)
+	dataRequest.ArchiveKey = key
+	dataRequest.ExpiresAt = &expiresAt
+	return nil
+}
+
+func (ds *DataRequestUsecase) collectDataExport(ctx context.Context, userID uuid.UUID) (*model.DataExport, error) {
+	user, err := ds.findUser(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportFindUser.AppendMessage(err)
+	}
+	user.Password = ""
+	export := &model.DataExport{ExportedAt: time.Now().UTC(), Profile: user}
+
+	export.VotesCast, err = ds.VoteRepo.GetVotesCastByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportVotes.AppendMessage(err)
+	}
+	export.VotesReceived, err = ds.VoteRepo.GetVotesReceivedByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportVotes.AppendMessage(err)
+	}
+	export.NicknameHistory, err = ds.NicknameRepo.GetNicknameChangesByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportNicknameHistory.AppendMessage(err)
+	}
+	export.EmailChanges, err = ds.EmailChangeRepo.GetEmailChangesByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportEmailChanges.AppendMessage(err)
+	}
+	export.Sessions, err = ds.LoginEventRepo.GetLoginEventsByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportSessions.AppendMessage(err)
+	}
+	export.Settings, err = ds.UserSettingRepo.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportSettings.AppendMessage(err)
+	}
+	export.Following, err = ds.FollowRepo.GetFollowsByFollowerID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportFollowing.AppendMessage(err)
+	}
+	export.AuditEntries = []*model.AuditEntry{}
+	err = ds.AuditRepo.StreamAuditEntries(ctx, &model.AuditQuery{TargetID: &userID}, func(entry *model.AuditEntry) error {
+		export.AuditEntries = append(export.AuditEntries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.DataRequestUsecaseCollectDataExportAuditEntries.AppendMessage(err)
+	}
+
+	return export, nil
+}
+
+// runErasure anonymizes the user in place, so the votes given and received keep pointing at a
+// user. Avatar files and the archives of earlier exports are removed as well. The audit log is
+// kept as the record of what was done; the erasure entry itself doesn't repeat the erased values.
+func (ds *DataRequestUsecase) runErasure(ctx context.Context, dataRequest *model.DataRequest) error {
+	user, err := ds.findUser(ctx, dataRequest.UserID)
+	if err != nil {
+		return apperrors.DataRequestUsecaseRunErasureFindUser.AppendMessage(err)
+	}
+
+	erasedUser, err := ds.UserRepo.EraseUser(ctx, user.UserID, model.ErasedNickname(user.UserID))
+	if err != nil {
+		return apperrors.DataRequestUsecaseRunErasureEraseUser.AppendMessage(err)
+	}
+	if user.Avatar != nil {
+		for _, key := range user.Avatar.Keys {
+			_ = ds.BlobStore.Delete(ctx, key)
+		}
+	}
+
+	exports, err := ds.DataRequestRepo.GetDataRequests(ctx, &model.DataRequestQuery{UserID: &user.UserID, Kind: model.DataRequestKindExport, Limit: model.DataRequestQueryMaxLimit})
+	if err != nil {
+		return apperrors.DataRequestUsecaseRunErasureGetDataExports.AppendMessage(err)
+	}
+	for _, export := range exports {
+		if export.ArchiveKey == "" {
+			continue
+		}
+		err = ds.deleteArchive(ctx, export)
+		if err != nil {
+			return apperrors.DataRequestUsecaseRunErasureDeleteDataExport.AppendMessage(err)
+		}
+	}
+
+	err = ds.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err == nil {
+		err = ds.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return apperrors.DataRequestUsecaseRunErasureDropUserCache.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, ds.AuditRepo, model.AuditActionUserErase, user.UserID, model.DiffUsers(user, erasedUser).RedactBefore())
+	if err != nil {
+		return apperrors.DataRequestUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (ds *DataRequestUsecase) deleteArchive(ctx context.Context, dataRequest *model.DataRequest) error {
+	err := ds.BlobStore.Delete(ctx, dataRequest.ArchiveKey)
+	if err != nil {
+		return err
+	}
+	return ds.DataRequestRepo.ClearDataExportArchive(ctx, dataRequest.DataRequestID)
+}
+
+// findUser finds the user whether it's deleted or not.
+func (ds *DataRequestUsecase) findUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := ds.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil && apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+		return ds.UserRepo.FindDeletedUserByUUID(ctx, userID)
+	}
+	return user, err
+}
+
+func newDataRequest(userID uuid.UUID, kind string, format string, requestedBy *model.User) *model.DataRequest {
+	dataRequest := &model.DataRequest{
+		DataRequestID: uuid.New(),
+		UserID:        userID,
+		Kind:          kind,
+		Format:        format,
+		Status:        model.DataRequestStatusQueued,
+		CreatedAt:     time.Now(),
+	}
+	if requestedBy != nil {
+		requestedByID := requestedBy.UserID
+		dataRequest.RequestedByID = &requestedByID
+		dataRequest.RequestedBy = requestedBy.Nickname
+	}
+	return dataRequest
+}
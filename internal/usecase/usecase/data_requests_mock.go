@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type DataRequestRepositoryMock struct {
+	mock.Mock
+}
+
+func (drrm *DataRequestRepositoryMock) SaveDataRequest(ctx context.Context, dataRequest *model.DataRequest) (*model.DataRequest, error) {
+	args := drrm.Called(ctx, dataRequest)
+	return args.Get(0).(*model.DataRequest), args.Error(1)
+}
+
+func (drrm *DataRequestRepositoryMock) FindDataRequest(ctx context.Context, dataRequestID uuid.UUID) (*model.DataRequest, error) {
+	args := drrm.Called(ctx, dataRequestID)
+	return args.Get(0).(*model.DataRequest), args.Error(1)
+}
+
+func (drrm *DataRequestRepositoryMock) GetDataRequests(ctx context.Context, dataRequestQuery *model.DataRequestQuery) ([]*model.DataRequest, error) {
+	args := drrm.Called(ctx, dataRequestQuery)
+	return args.Get(0).([]*model.DataRequest), args.Error(1)
+}
+
+func (drrm *DataRequestRepositoryMock) ClaimNextDataRequest(ctx context.Context, startedAt time.Time) (*model.DataRequest, error) {
+	args := drrm.Called(ctx, startedAt)
+	return args.Get(0).(*model.DataRequest), args.Error(1)
+}
+
+func (drrm *DataRequestRepositoryMock) FinishDataRequest(ctx context.Context, dataRequest *model.DataRequest) error {
+	args := drrm.Called(ctx, dataRequest)
+	return args.Error(0)
+}
+
+func (drrm *DataRequestRepositoryMock) GetExpiredDataExports(ctx context.Context, now time.Time) ([]*model.DataRequest, error) {
+	args := drrm.Called(ctx, now)
+	return args.Get(0).([]*model.DataRequest), args.Error(1)
+}
+
+func (drrm *DataRequestRepositoryMock) ClearDataExportArchive(ctx context.Context, dataRequestID uuid.UUID) error {
+	args := drrm.Called(ctx, dataRequestID)
+	return args.Error(0)
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var dataRequestTestPolicy = &model.DataRequestPolicy{ArchiveTtl: time.Hour}
+
+func TestDataRequestUsecase_RunNextDataRequest_Export(t *testing.T) {
+	loginDate := time.Now().Add(-time.Hour)
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Email: "user@example.com", Password: "hash", LoginDate: &loginDate}
+	dataRequest := &model.DataRequest{DataRequestID: uuid.New(), UserID: user.UserID, Kind: model.DataRequestKindExport, Format: model.DataExportFormatZIP, Status: model.DataRequestStatusRunning}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	voteRepoMock := &VoteRepositoryMock{}
+	voteRepoMock.On("GetVotesCastByUserID", mock.Anything, user.UserID).Return([]*model.DataExportVote{{VoteID: 1, Vote: 1}}, nil)
+	voteRepoMock.On("GetVotesReceivedByUserID", mock.Anything, user.UserID).Return([]*model.DataExportVote{}, nil)
+	nicknameRepoMock := newNicknameRepoMock()
+	nicknameRepoMock.On("GetNicknameChangesByUserID", mock.Anything, user.UserID).Return([]*model.NicknameChange{}, nil)
+	emailChangeRepoMock := &EmailChangeRepositoryMock{}
+	emailChangeRepoMock.On("GetEmailChangesByUserID", mock.Anything, user.UserID).Return([]*model.EmailChange{}, nil)
+	loginEventRepoMock := &LoginEventRepositoryMock{}
+	loginEventRepoMock.On("GetLoginEventsByUserID", mock.Anything, user.UserID).Return([]*model.LoginEvent{{LoginEventID: 1, UserID: &user.UserID, Success: true, CreatedAt: loginDate}}, nil)
+	userSettingRepoMock := &UserSettingRepositoryMock{}
+	userSettingRepoMock.On("GetUserSettings", mock.Anything, user.UserID).Return([]*model.UserSetting{{UserID: user.UserID, Key: model.SettingTheme, Value: []byte(`"dark"`)}}, nil)
+	followRepoMock := &FollowRepositoryMock{}
+	followRepoMock.On("GetFollowsByFollowerID", mock.Anything, user.UserID).Return([]*model.Follow{{FollowID: 4, FollowerID: user.UserID, FolloweeID: uuid.New(), Status: model.FollowStatusAccepted}}, nil)
+	auditRepoMock := newAuditRepoMock()
+	auditRepoMock.On("StreamAuditEntries", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		assert.Equal(t, *args.Get(1).(*model.AuditQuery).TargetID, user.UserID)
+		assert.NilError(t, args.Get(2).(func(entry *model.AuditEntry) error)(&model.AuditEntry{EntryID: 3, Action: model.AuditActionUserCreate}))
+	})
+	dataRequestRepoMock := &DataRequestRepositoryMock{}
+	dataRequestRepoMock.On("ClaimNextDataRequest", mock.Anything, mock.Anything).Return(dataRequest, nil)
+	dataRequestRepoMock.On("FinishDataRequest", mock.Anything, dataRequest).Return(nil)
+	var archive *repository.Blob
+	blobStoreMock := &BlobStoreMock{}
+	blobStoreMock.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) { archive = args.Get(2).(*repository.Blob) })
+
+	datarequestusecase := NewDataRequestUsecase(userRepoMock, nil, voteRepoMock, nicknameRepoMock, emailChangeRepoMock, loginEventRepoMock, userSettingRepoMock, followRepoMock, auditRepoMock, dataRequestRepoMock, blobStoreMock, dataRequestTestPolicy)
+	finished, err := datarequestusecase.RunNextDataRequest(context.TODO())
+	assert.NilError(t, err)
+
+	assert.Equal(t, finished.Status, model.DataRequestStatusCompleted)
+	assert.Equal(t, finished.ArchiveKey, "data-exports/"+user.UserID.String()+"/"+dataRequest.DataRequestID.String()+".zip")
+	assert.Assert(t, finished.HasArchive(time.Now()))
+	blobStoreMock.AssertCalled(t, "Put", mock.Anything, finished.ArchiveKey, mock.Anything)
+	assert.Equal(t, archive.ContentType, "application/zip")
+
+	files := map[string]string{}
+	reader, err := zip.NewReader(bytes.NewReader(archive.Data), int64(len(archive.Data)))
+	assert.NilError(t, err)
+	for _, file := range reader.File {
+		content, err := file.Open()
+		assert.NilError(t, err)
+		data, err := io.ReadAll(content)
+		assert.NilError(t, err)
+		files[file.Name] = string(data)
+	}
+	assert.Equal(t, len(files), 9)
+	var profile model.User
+	assert.NilError(t, json.Unmarshal([]byte(files["profile.json"]), &profile))
+	assert.Equal(t, profile.Email, "user@example.com")
+	assert.Assert(t, !strings.Contains(files["profile.json"], "hash"))
+	var votesCast []*model.DataExportVote
+	assert.NilError(t, json.Unmarshal([]byte(files["votes_cast.json"]), &votesCast))
+	assert.Equal(t, len(votesCast), 1)
+	var sessions []*model.LoginEvent
+	assert.NilError(t, json.Unmarshal([]byte(files["sessions.json"]), &sessions))
+	assert.Equal(t, len(sessions), 1)
+	assert.Assert(t, strings.Contains(files["settings.json"], `"dark"`))
+	var following []*model.Follow
+	assert.NilError(t, json.Unmarshal([]byte(files["following.json"]), &following))
+	assert.Equal(t, len(following), 1)
+	assert.Assert(t, strings.Contains(files["audit_log.json"], model.AuditActionUserCreate))
+}
+
+func TestDataRequestUsecase_RunNextDataRequest_Erasure(t *testing.T) {
+	requestedByID := uuid.New()
+	avatar := &model.UserAvatar{URL: "/avatar", Keys: []string{"avatars/a/original.png"}}
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", FirstName: "fname", Email: "user@example.com", Password: "hash", IsPublic: true, Avatar: avatar}
+	erasedUser := &model.User{UserID: user.UserID, Nickname: model.ErasedNickname(user.UserID)}
+	dataRequest := &model.DataRequest{DataRequestID: uuid.New(), UserID: user.UserID, Kind: model.DataRequestKindErasure, Status: model.DataRequestStatusRunning, RequestedByID: &requestedByID, RequestedBy: "admin"}
+	export := &model.DataRequest{DataRequestID: uuid.New(), UserID: user.UserID, Kind: model.DataRequestKindExport, ArchiveKey: "data-exports/archive.zip"}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userRepoMock.On("EraseUser", mock.Anything, user.UserID, erasedUser.Nickname).Return(erasedUser, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "nickname").Return(nil)
+	auditRepoMock := newAuditRepoMock()
+	dataRequestRepoMock := &DataRequestRepositoryMock{}
+	dataRequestRepoMock.On("ClaimNextDataRequest", mock.Anything, mock.Anything).Return(dataRequest, nil)
+	dataRequestRepoMock.On("GetDataRequests", mock.Anything, mock.Anything).Return([]*model.DataRequest{export}, nil)
+	dataRequestRepoMock.On("ClearDataExportArchive", mock.Anything, export.DataRequestID).Return(nil)
+	dataRequestRepoMock.On("FinishDataRequest", mock.Anything, dataRequest).Return(nil)
+	blobStoreMock := &BlobStoreMock{}
+	blobStoreMock.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	datarequestusecase := NewDataRequestUsecase(userRepoMock, userRedisRepoMock, nil, nil, nil, nil, nil, nil, auditRepoMock, dataRequestRepoMock, blobStoreMock, dataRequestTestPolicy)
+	finished, err := datarequestusecase.RunNextDataRequest(context.TODO())
+	assert.NilError(t, err)
+
+	assert.Equal(t, finished.Status, model.DataRequestStatusCompleted)
+	userRepoMock.AssertCalled(t, "EraseUser", mock.Anything, user.UserID, erasedUser.Nickname)
+	blobStoreMock.AssertCalled(t, "Delete", mock.Anything, "avatars/a/original.png")
+	blobStoreMock.AssertCalled(t, "Delete", mock.Anything, "data-exports/archive.zip")
+	userRedisRepoMock.AssertExpectations(t)
+
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserErase)
+	assert.Equal(t, *entry.ActorID, requestedByID)
+	assert.DeepEqual(t, entry.Changes["nickname"], model.AuditChange{Before: model.AuditRedacted, After: erasedUser.Nickname})
+	data, err := json.Marshal(entry)
+	assert.NilError(t, err)
+	for _, erased := range []string{"fname", "user@example.com", "hash", "/avatar"} {
+		assert.Assert(t, !strings.Contains(string(data), erased), erased)
+	}
+}
+
+func TestDataRequestUsecase_RunNextDataRequest_Failed(t *testing.T) {
+	dataRequest := &model.DataRequest{DataRequestID: uuid.New(), UserID: uuid.New(), Kind: model.DataRequestKindErasure, Status: model.DataRequestStatusRunning}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, dataRequest.UserID).Return((*model.User)(nil), &apperrors.UserRepoFindUserByUUIDGetContext)
+	dataRequestRepoMock := &DataRequestRepositoryMock{}
+	dataRequestRepoMock.On("ClaimNextDataRequest", mock.Anything, mock.Anything).Return(dataRequest, nil)
+	dataRequestRepoMock.On("FinishDataRequest", mock.Anything, dataRequest).Return(nil)
+
+	datarequestusecase := NewDataRequestUsecase(userRepoMock, nil, nil, nil, nil, nil, nil, nil, nil, dataRequestRepoMock, nil, dataRequestTestPolicy)
+	finished, err := datarequestusecase.RunNextDataRequest(context.TODO())
+	assert.Assert(t, apperrors.Is(err, &apperrors.DataRequestUsecaseRunErasureFindUser))
+	assert.Equal(t, finished.Status, model.DataRequestStatusFailed)
+	assert.Assert(t, finished.Error != "")
+	dataRequestRepoMock.AssertCalled(t, "FinishDataRequest", mock.Anything, dataRequest)
+}
+
+func TestDataRequestUsecase_GetUserDataRequest_OtherUser(t *testing.T) {
+	dataRequest := &model.DataRequest{DataRequestID: uuid.New(), UserID: uuid.New()}
+	dataRequestRepoMock := &DataRequestRepositoryMock{}
+	dataRequestRepoMock.On("FindDataRequest", mock.Anything, dataRequest.DataRequestID).Return(dataRequest, nil)
+
+	datarequestusecase := NewDataRequestUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, dataRequestRepoMock, nil, dataRequestTestPolicy)
+	_, err := datarequestusecase.GetUserDataRequest(context.TODO(), uuid.New(), dataRequest.DataRequestID)
+	assert.Assert(t, apperrors.Is(err, &apperrors.DataRequestUsecaseGetDataRequestNotExist))
+}
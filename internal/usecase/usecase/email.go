@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	emailConfirmPath = "/email/confirm"
+	emailCancelPath  = "/email/cancel"
+
+	emailConfirmSubject = "Confirm your new email address"
+	emailConfirmBody    = `Hello %s,
+
+someone asked to use this address for the account %q. Open the link below to confirm it:
+
+%s
+
+The link expires at %s. If you didn't ask for this, ignore this email and nothing changes.
+`
+	emailChangeSubject = "Your email address is being changed"
+	emailChangeBody    = `Hello %s,
+
+someone asked to change the email address of the account %q to %s. The change takes effect
+once the new address confirms it. If it wasn't you, cancel it with the link below:
+
+%s
+`
+)
+
+type IEmailUsecase interface {
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, email string) (*model.EmailChange, error)
+	ConfirmEmailChange(ctx context.Context, token string) (*model.User, error)
+	CancelEmailChange(ctx context.Context, token string) error
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+}
+
+type EmailUsecase struct {
+	UserRepo        repository.UserRepository
+	UserRedisRepo   repository.UserRedisRepository
+	EmailChangeRepo repository.EmailChangeRepository
+	Mailer          repository.Mailer
+	AuditRepo       repository.AuditRepository
+	Policy          *model.EmailPolicy
+}
+
+func NewEmailUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, emailChangeRepo repository.EmailChangeRepository, mailer repository.Mailer, auditRepo repository.AuditRepository, policy *model.EmailPolicy) IEmailUsecase {
+	return &EmailUsecase{
+		UserRepo:        userRepo,
+		UserRedisRepo:   userRedisRepo,
+		EmailChangeRepo: emailChangeRepo,
+		Mailer:          mailer,
+		AuditRepo:       auditRepo,
+		Policy:          policy,
+	}
+}
+
+// RequestEmailChange sends a confirmation link to the new address and a cancel link to the
+// current one. The user keeps the current address until the new one is confirmed, and a new
+// request replaces any pending one.
+func (es *EmailUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, email string) (*model.EmailChange, error) {
+	user, err := es.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.EmailUsecaseRequestEmailChangeNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.EmailUsecaseRequestEmailChangeFindUser.AppendMessage(err)
+	}
+
+	email = strings.TrimSpace(email)
+	if utils.EmailKey(email) == utils.EmailKey(user.Email) {
+		return nil, apperrors.EmailUsecaseRequestEmailChangeSameEmail.AppendMessage(email)
+	}
+	err = es.checkEmailFree(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, apperrors.EmailUsecaseRequestEmailChangeGenerateToken.AppendMessage(err)
+	}
+	cancelToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, apperrors.EmailUsecaseRequestEmailChangeGenerateToken.AppendMessage(err)
+	}
+
+	now := time.Now()
+	err = es.EmailChangeRepo.CancelPendingEmailChanges(ctx, userID, now)
+	if err != nil {
+		return nil, apperrors.EmailUsecaseRequestEmailChangeCancelPending.AppendMessage(err)
+	}
+	emailChange, err := es.EmailChangeRepo.SaveEmailChange(ctx, &model.EmailChange{
+		ChangeID:         uuid.New(),
+		UserID:           userID,
+		OldEmail:         user.Email,
+		NewEmail:         email,
+		ConfirmTokenHash: utils.HashToken(confirmToken),
+		CancelTokenHash:  utils.HashToken(cancelToken),
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(es.Policy.ChangeTtl),
+	})
+	if err != nil {
+		return nil, apperrors.EmailUsecaseRequestEmailChangeSave.AppendMessage(err)
+	}
+
+	err = es.Mailer.Send(ctx, &repository.Mail{
+		To:      email,
+		Subject: emailConfirmSubject,
+		Body:    fmt.Sprintf(emailConfirmBody, user.FirstName, user.Nickname, es.link(emailConfirmPath, confirmToken), emailChange.ExpiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return nil, apperrors.EmailUsecaseRequestEmailChangeSend.AppendMessage(err)
+	}
+	if user.Email != "" {
+		err = es.Mailer.Send(ctx, &repository.Mail{
+			To:      user.Email,
+			Subject: emailChangeSubject,
+			Body:    fmt.Sprintf(emailChangeBody, user.FirstName, user.Nickname, email, es.link(emailCancelPath, cancelToken)),
+		})
+		if err != nil {
+			return nil, apperrors.EmailUsecaseRequestEmailChangeSend.AppendMessage(err)
+		}
+	}
+
+	return emailChange, nil
+}
+
+// ConfirmEmailChange moves the user to the new address, unless another account took it in the meantime.
+func (es *EmailUsecase) ConfirmEmailChange(ctx context.Context, token string) (*model.User, error) {
+	emailChange, err := es.EmailChangeRepo.FindEmailChangeByConfirmTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if apperrors.Is(err, &apperrors.EmailChangeRepoFindEmailChangeGetDataNotFound) {
+			return nil, apperrors.EmailUsecaseConfirmEmailChangeNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.EmailUsecaseConfirmEmailChangeFind.AppendMessage(err)
+	}
+	now := time.Now()
+	if !emailChange.IsPending(now) {
+		return nil, apperrors.EmailUsecaseConfirmEmailChangeNotPending.AppendMessage(emailChange.ChangeID)
+	}
+	err = es.checkEmailFree(ctx, emailChange.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := es.EmailChangeRepo.ConfirmEmailChange(ctx, emailChange, now)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.EmailChangeRepoConfirmEmailChangeNotPending) {
+			return nil, apperrors.EmailUsecaseConfirmEmailChangeNotPending.AppendMessage(err)
+		}
+		if apperrors.Is(err, &apperrors.EmailChangeRepoConfirmEmailChangeUserNotFound) {
+			return nil, apperrors.EmailUsecaseConfirmEmailChangeNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.EmailUsecaseConfirmEmailChange.AppendMessage(err)
+	}
+
+	changes := model.AuditChanges{"email": {Before: emailChange.OldEmail, After: user.Email}}
+	err = recordAudit(ctx, es.AuditRepo, model.AuditActionUserEmailChange, user.UserID, changes)
+	if err != nil {
+		return nil, apperrors.EmailUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	err = es.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err == nil {
+		err = es.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return nil, apperrors.EmailUsecaseConfirmEmailChangeDropUserCache.AppendMessage(err)
+	}
+
+	return user, nil
+}
+
+func (es *EmailUsecase) CancelEmailChange(ctx context.Context, token string) error {
+	emailChange, err := es.EmailChangeRepo.FindEmailChangeByCancelTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if apperrors.Is(err, &apperrors.EmailChangeRepoFindEmailChangeGetDataNotFound) {
+			return apperrors.EmailUsecaseCancelEmailChangeNotExist.AppendMessage(err)
+		}
+		return apperrors.EmailUsecaseCancelEmailChangeFind.AppendMessage(err)
+	}
+
+	err = es.EmailChangeRepo.CancelEmailChange(ctx, emailChange.ChangeID, time.Now())
+	if err != nil {
+		if apperrors.Is(err, &apperrors.EmailChangeRepoCancelEmailChangeNotPending) {
+			return apperrors.EmailUsecaseCancelEmailChangeNotPending.AppendMessage(err)
+		}
+		return apperrors.EmailUsecaseCancelEmailChange.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (es *EmailUsecase) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	user, err := es.UserRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByEmailGetDataNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.EmailUsecaseGetUserByEmail.AppendMessage(err)
+	}
+
+	return user, nil
+}
+
+func (es *EmailUsecase) checkEmailFree(ctx context.Context, email string) error {
+	_, err := es.UserRepo.FindUserByEmail(ctx, email)
+	if err == nil {
+		return apperrors.EmailUsecaseEmailBusy.AppendMessage(email)
+	}
+	if !apperrors.Is(err, &apperrors.UserRepoFindUserByEmailGetDataNotFound) {
+		return apperrors.EmailUsecaseFindUserByEmail.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (es *EmailUsecase) link(path string, token string) string {
+	return strings.TrimSuffix(es.Policy.LinkBaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var emailTestPolicy = &model.EmailPolicy{ChangeTtl: time.Hour, LinkBaseURL: "https://app.test/"}
+
+type EmailChangeRepositoryMock struct {
+	mock.Mock
+}
+
+func (ecrm *EmailChangeRepositoryMock) SaveEmailChange(ctx context.Context, emailChange *model.EmailChange) (*model.EmailChange, error) {
+	args := ecrm.Called(ctx, emailChange)
+	return args.Get(0).(*model.EmailChange), args.Error(1)
+}
+
+func (ecrm *EmailChangeRepositoryMock) FindEmailChangeByConfirmTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error) {
+	args := ecrm.Called(ctx, tokenHash)
+	return args.Get(0).(*model.EmailChange), args.Error(1)
+}
+
+func (ecrm *EmailChangeRepositoryMock) FindEmailChangeByCancelTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error) {
+	args := ecrm.Called(ctx, tokenHash)
+	return args.Get(0).(*model.EmailChange), args.Error(1)
+}
+
+func (ecrm *EmailChangeRepositoryMock) ConfirmEmailChange(ctx context.Context, emailChange *model.EmailChange, confirmedAt time.Time) (*model.User, error) {
+	args := ecrm.Called(ctx, emailChange, confirmedAt)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (ecrm *EmailChangeRepositoryMock) CancelEmailChange(ctx context.Context, changeID uuid.UUID, cancelledAt time.Time) error {
+	args := ecrm.Called(ctx, changeID, cancelledAt)
+	return args.Error(0)
+}
+
+func (ecrm *EmailChangeRepositoryMock) CancelPendingEmailChanges(ctx context.Context, userID uuid.UUID, cancelledAt time.Time) error {
+	args := ecrm.Called(ctx, userID, cancelledAt)
+	return args.Error(0)
+}
+
+func (ecrm *EmailChangeRepositoryMock) GetEmailChangesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.EmailChange, error) {
+	args := ecrm.Called(ctx, userID)
+	return args.Get(0).([]*model.EmailChange), args.Error(1)
+}
+
+type MailerMock struct {
+	mock.Mock
+}
+
+func (mm *MailerMock) Send(ctx context.Context, mail *repository.Mail) error {
+	args := mm.Called(ctx, mail)
+	return args.Error(0)
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var emailTestLink = regexp.MustCompile(`https://app\.test(/email/\w+)\?token=(\S+)`)
+
+// emailTestToken pulls the path and token out of the link in a sent email.
+func emailTestToken(t *testing.T, mail *repository.Mail) (string, string) {
+	match := emailTestLink.FindStringSubmatch(mail.Body)
+	assert.Assert(t, match != nil, mail.Body)
+	token, err := url.QueryUnescape(match[2])
+	assert.NilError(t, err)
+	return match[1], token
+}
+
+func TestEmailUsecase_RequestEmailChange(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", FirstName: "fname", Email: "old@test.test"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userRepoMock.On("FindUserByEmail", mock.Anything, "new@test.test").Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	emailChangeRepoMock := &EmailChangeRepositoryMock{}
+	emailChangeRepoMock.On("CancelPendingEmailChanges", mock.Anything, user.UserID, mock.Anything).Return(nil)
+	emailChangeRepoMock.On("SaveEmailChange", mock.Anything, mock.Anything).Return(&model.EmailChange{}, nil)
+	mailerMock := &MailerMock{}
+	mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	emailusecase := NewEmailUsecase(userRepoMock, &UserRedisRepositoryMock{}, emailChangeRepoMock, mailerMock, newAuditRepoMock(), emailTestPolicy)
+	_, err := emailusecase.RequestEmailChange(context.TODO(), user.UserID, " new@test.test ")
+	assert.NilError(t, err)
+	emailChange := emailChangeRepoMock.Calls[1].Arguments.Get(1).(*model.EmailChange)
+	assert.Equal(t, emailChange.OldEmail, "old@test.test")
+	assert.Equal(t, emailChange.NewEmail, "new@test.test")
+	assert.Equal(t, emailChange.ExpiresAt, emailChange.CreatedAt.Add(time.Hour))
+
+	mailerMock.AssertNumberOfCalls(t, "Send", 2)
+	confirmMail := mailerMock.Calls[0].Arguments.Get(1).(*repository.Mail)
+	assert.Equal(t, confirmMail.To, "new@test.test")
+	path, token := emailTestToken(t, confirmMail)
+	assert.Equal(t, path, "/email/confirm")
+	assert.Equal(t, utils.HashToken(token), emailChange.ConfirmTokenHash)
+
+	noticeMail := mailerMock.Calls[1].Arguments.Get(1).(*repository.Mail)
+	assert.Equal(t, noticeMail.To, "old@test.test")
+	path, token = emailTestToken(t, noticeMail)
+	assert.Equal(t, path, "/email/cancel")
+	assert.Equal(t, utils.HashToken(token), emailChange.CancelTokenHash)
+}
+
+func TestEmailUsecase_RequestEmailChange_Error(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Email: "old@test.test"}
+	tests := []struct {
+		name    string
+		email   string
+		taken   *model.User
+		wantErr *apperrors.AppError
+	}{
+		{"same email", "OLD@test.test", nil, &apperrors.EmailUsecaseRequestEmailChangeSameEmail},
+		{"email busy", "taken@test.test", &model.User{UserID: uuid.New()}, &apperrors.EmailUsecaseEmailBusy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			userRepoMock.On("FindUserByEmail", mock.Anything, tt.email).Return(tt.taken, nil)
+			emailChangeRepoMock := &EmailChangeRepositoryMock{}
+			mailerMock := &MailerMock{}
+
+			_, err := NewEmailUsecase(userRepoMock, &UserRedisRepositoryMock{}, emailChangeRepoMock, mailerMock, newAuditRepoMock(), emailTestPolicy).RequestEmailChange(context.TODO(), user.UserID, tt.email)
+			assert.Assert(t, apperrors.Is(err, tt.wantErr))
+			assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 409)
+			emailChangeRepoMock.AssertNotCalled(t, "SaveEmailChange", mock.Anything, mock.Anything)
+			mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEmailUsecase_ConfirmEmailChange(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Email: "new@test.test"}
+	confirmedAt := time.Now()
+	tests := []struct {
+		name        string
+		emailChange *model.EmailChange
+		wantErr     *apperrors.AppError
+	}{
+		{"pending", &model.EmailChange{ExpiresAt: time.Now().Add(time.Hour)}, nil},
+		{"expired", &model.EmailChange{ExpiresAt: time.Now().Add(-time.Minute)}, &apperrors.EmailUsecaseConfirmEmailChangeNotPending},
+		{"cancelled", &model.EmailChange{ExpiresAt: time.Now().Add(time.Hour), CancelledAt: &confirmedAt}, &apperrors.EmailUsecaseConfirmEmailChangeNotPending},
+		{"confirmed", &model.EmailChange{ExpiresAt: time.Now().Add(time.Hour), ConfirmedAt: &confirmedAt}, &apperrors.EmailUsecaseConfirmEmailChangeNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.emailChange.ChangeID = uuid.New()
+			tt.emailChange.UserID = user.UserID
+			tt.emailChange.NewEmail = user.Email
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByEmail", mock.Anything, user.Email).Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+			emailChangeRepoMock := &EmailChangeRepositoryMock{}
+			emailChangeRepoMock.On("FindEmailChangeByConfirmTokenHash", mock.Anything, utils.HashToken("token")).Return(tt.emailChange, nil)
+			emailChangeRepoMock.On("ConfirmEmailChange", mock.Anything, tt.emailChange, mock.Anything).Return(user, nil)
+
+			emailusecase := NewEmailUsecase(userRepoMock, userRedisRepoMock, emailChangeRepoMock, &MailerMock{}, newAuditRepoMock(), emailTestPolicy)
+			got, err := emailusecase.ConfirmEmailChange(context.TODO(), "token")
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 410)
+				emailChangeRepoMock.AssertNotCalled(t, "ConfirmEmailChange", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got.Email, "new@test.test")
+			emailChangeRepoMock.AssertCalled(t, "ConfirmEmailChange", mock.Anything, tt.emailChange, mock.Anything)
+			userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			userRedisRepoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailUsecase_CancelEmailChange(t *testing.T) {
+	changeID := uuid.New()
+	emailChangeRepoMock := &EmailChangeRepositoryMock{}
+	emailChangeRepoMock.On("FindEmailChangeByCancelTokenHash", mock.Anything, utils.HashToken("token")).Return(&model.EmailChange{ChangeID: changeID}, nil)
+	emailChangeRepoMock.On("FindEmailChangeByCancelTokenHash", mock.Anything, mock.Anything).Return((*model.EmailChange)(nil), &apperrors.EmailChangeRepoFindEmailChangeGetDataNotFound)
+	emailChangeRepoMock.On("CancelEmailChange", mock.Anything, changeID, mock.Anything).Return(nil).Once()
+	emailChangeRepoMock.On("CancelEmailChange", mock.Anything, changeID, mock.Anything).Return(&apperrors.EmailChangeRepoCancelEmailChangeNotPending)
+	emailusecase := NewEmailUsecase(&UserRepositoryMock{}, &UserRedisRepositoryMock{}, emailChangeRepoMock, &MailerMock{}, newAuditRepoMock(), emailTestPolicy)
+
+	assert.NilError(t, emailusecase.CancelEmailChange(context.TODO(), "token"))
+	err := emailusecase.CancelEmailChange(context.TODO(), "token")
+	assert.Assert(t, apperrors.Is(err, &apperrors.EmailUsecaseCancelEmailChangeNotPending))
+	err = emailusecase.CancelEmailChange(context.TODO(), "other")
+	assert.Assert(t, apperrors.Is(err, &apperrors.EmailUsecaseCancelEmailChangeNotExist))
+}
+
+func TestUserUsecase_CreateUser_EmailBusy(t *testing.T) {
+	user := &model.User{Nickname: "nickname", FirstName: "fname", LastName: "lname", Email: "Taken@test.test"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByEmail", mock.Anything, user.Email).Return(&model.User{UserID: uuid.New()}, nil)
+
+	_, err := NewUserUsecase(userRepoMock, nil, nil, nil, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil).CreateUser(context.TODO(), user)
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserUsecaseEmailBusy))
+	userRepoMock.AssertNotCalled(t, "SaveUser", mock.Anything, user)
+}
+
+func TestUserUsecase_EmailChangeNotConfirmed(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Email: "old@test.test"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, &UserRedisRepositoryMock{}, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+
+	_, err := userusecase.UpdateUser(context.TODO(), &model.User{UserID: user.UserID, Nickname: "nickname", Email: "new@test.test"})
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserUsecaseEmailChangeNotConfirmed))
+	_, err = userusecase.PatchUser(context.TODO(), user.UserID, 0, map[string]any{"email": "new@test.test"})
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserUsecaseEmailChangeNotConfirmed))
+	userRepoMock.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
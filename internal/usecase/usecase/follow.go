@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IFollowUsecase interface {
+	FollowUser(ctx context.Context, follower *model.User, followeeID uuid.UUID) (*model.FollowResponse, error)
+	UnfollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	GetFollows(ctx context.Context, viewer *model.User, followQuery *model.FollowQuery) (*model.FollowEntries, error)
+	GetFollowRequests(ctx context.Context, followQuery *model.FollowQuery) (*model.FollowEntries, error)
+	ApproveFollowRequest(ctx context.Context, followeeID uuid.UUID, followerID uuid.UUID) (*model.Follow, error)
+	RejectFollowRequest(ctx context.Context, followeeID uuid.UUID, followerID uuid.UUID) error
+	GetFollowCounts(ctx context.Context, userID uuid.UUID) (*model.FollowCounts, error)
+}
+
+type FollowUsecase struct {
+	UserRepo           repository.UserRepository
+	FollowRepo         repository.FollowRepository
+	UserSettingUsecase IUserSettingUsecase
+}
+
+func NewFollowUsecase(userRepo repository.UserRepository, followRepo repository.FollowRepository, userSettingUsecase IUserSettingUsecase) IFollowUsecase {
+	return &FollowUsecase{
+		UserRepo:           userRepo,
+		FollowRepo:         followRepo,
+		UserSettingUsecase: userSettingUsecase,
+	}
+}
+
+// FollowUser follows the user right away when the profile is public. A private profile has
+// to approve the follow first, unless its follows.approval setting is off. Following a user
+// twice returns the follow already there.
+func (fs *FollowUsecase) FollowUser(ctx context.Context, follower *model.User, followeeID uuid.UUID) (*model.FollowResponse, error) {
+	if follower.UserID == followeeID {
+		return nil, apperrors.FollowUsecaseFollowUserSelf.AppendMessage(followeeID)
+	}
+	followee, err := fs.findUser(ctx, followeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	follow := &model.Follow{FollowerID: follower.UserID, FolloweeID: followeeID, Status: model.FollowStatusAccepted, CreatedAt: now, AcceptedAt: &now}
+	if !followee.IsPublic {
+		settings, err := fs.UserSettingUsecase.GetUserSettings(ctx, followeeID)
+		if err != nil {
+			return nil, apperrors.FollowUsecaseFollowUserGetSettings.AppendMessage(err)
+		}
+		if settings.Settings[model.SettingFollowsApproval] == true {
+			follow.Status = model.FollowStatusPending
+			follow.AcceptedAt = nil
+		}
+	}
+
+	follow, err = fs.FollowRepo.SaveFollow(ctx, follow)
+	if err != nil {
+		return nil, apperrors.FollowUsecaseFollowUserSave.AppendMessage(err)
+	}
+	mutual, err := fs.isMutual(ctx, follow)
+	if err != nil {
+		return nil, err
+	}
+	return &model.FollowResponse{Follow: follow, Mutual: mutual}, nil
+}
+
+// UnfollowUser also withdraws a follow still waiting for approval.
+func (fs *FollowUsecase) UnfollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	deleted, err := fs.FollowRepo.DeleteFollow(ctx, followerID, followeeID)
+	if err != nil {
+		return apperrors.FollowUsecaseUnfollowUserDelete.AppendMessage(err)
+	}
+	if !deleted {
+		return apperrors.FollowUsecaseUnfollowUserNotFollowing.AppendMessage(followeeID)
+	}
+	return nil
+}
+
+// GetFollows lists the accepted followers or followings of the user. Those of a private
+// profile are only shown to the user, admins and its followers.
+func (fs *FollowUsecase) GetFollows(ctx context.Context, viewer *model.User, followQuery *model.FollowQuery) (*model.FollowEntries, error) {
+	user, err := fs.findUser(ctx, followQuery.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsPublic && !user.IsOwnerOrAdmin(viewer) {
+		if viewer == nil {
+			return nil, apperrors.FollowUsecaseGetFollowsForbidden.AppendMessage(user.UserID)
+		}
+		follow, err := fs.findFollow(ctx, viewer.UserID, user.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !follow.IsAccepted() {
+			return nil, apperrors.FollowUsecaseGetFollowsForbidden.AppendMessage(user.UserID)
+		}
+	}
+
+	followQuery.Status = model.FollowStatusAccepted
+	return fs.findFollows(ctx, followQuery)
+}
+
+// GetFollowRequests lists the follows waiting for the approval of the user.
+func (fs *FollowUsecase) GetFollowRequests(ctx context.Context, followQuery *model.FollowQuery) (*model.FollowEntries, error) {
+	_, err := fs.findUser(ctx, followQuery.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	followQuery.Direction = model.FollowDirectionFollowers
+	followQuery.Status = model.FollowStatusPending
+	return fs.findFollows(ctx, followQuery)
+}
+
+func (fs *FollowUsecase) ApproveFollowRequest(ctx context.Context, followeeID uuid.UUID, followerID uuid.UUID) (*model.Follow, error) {
+	follow, err := fs.FollowRepo.AcceptFollow(ctx, followerID, followeeID, time.Now())
+	if err != nil {
+		if apperrors.Is(err, &apperrors.FollowRepoAcceptFollowDataNotFound) {
+			return nil, apperrors.FollowUsecaseFollowRequestNotFound.AppendMessage(followerID)
+		}
+		return nil, apperrors.FollowUsecaseApproveFollowRequest.AppendMessage(err)
+	}
+	return follow, nil
+}
+
+// RejectFollowRequest deletes a pending follow. An accepted one is left alone, the follower
+// has to be removed some other way.
+func (fs *FollowUsecase) RejectFollowRequest(ctx context.Context, followeeID uuid.UUID, followerID uuid.UUID) error {
+	follow, err := fs.findFollow(ctx, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+	if follow == nil || follow.Status != model.FollowStatusPending {
+		return apperrors.FollowUsecaseFollowRequestNotFound.AppendMessage(followerID)
+	}
+
+	_, err = fs.FollowRepo.DeleteFollow(ctx, followerID, followeeID)
+	if err != nil {
+		return apperrors.FollowUsecaseRejectFollowRequest.AppendMessage(err)
+	}
+	return nil
+}
+
+func (fs *FollowUsecase) GetFollowCounts(ctx context.Context, userID uuid.UUID) (*model.FollowCounts, error) {
+	counts, err := fs.FollowRepo.GetFollowCounts(ctx, userID)
+	if err != nil {
+		return nil, apperrors.FollowUsecaseGetFollowCounts.AppendMessage(err)
+	}
+	return counts, nil
+}
+
+func (fs *FollowUsecase) findFollows(ctx context.Context, followQuery *model.FollowQuery) (*model.FollowEntries, error) {
+	entries, err := fs.FollowRepo.FindFollows(ctx, followQuery)
+	if err != nil {
+		return nil, apperrors.FollowUsecaseFindFollows.AppendMessage(err)
+	}
+
+	followEntries := &model.FollowEntries{Entries: entries}
+	if len(entries) > 0 && len(entries) == followQuery.Limit {
+		followEntries.NextBefore = entries[len(entries)-1].FollowID
+	}
+	return followEntries, nil
+}
+
+// isMutual tells whether an accepted follow is returned by the followee.
+func (fs *FollowUsecase) isMutual(ctx context.Context, follow *model.Follow) (bool, error) {
+	if !follow.IsAccepted() {
+		return false, nil
+	}
+	followBack, err := fs.findFollow(ctx, follow.FolloweeID, follow.FollowerID)
+	if err != nil {
+		return false, err
+	}
+	return followBack.IsAccepted(), nil
+}
+
+// findFollow returns nil when followerID doesn't follow followeeID.
+func (fs *FollowUsecase) findFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (*model.Follow, error) {
+	follow, err := fs.FollowRepo.FindFollow(ctx, followerID, followeeID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.FollowRepoFindFollowGetDataNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.FollowUsecaseFindFollow.AppendMessage(err)
+	}
+	return follow, nil
+}
+
+func (fs *FollowUsecase) findUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := fs.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.FollowUsecaseFindUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.FollowUsecaseFindUser.AppendMessage(err)
+	}
+	return user, nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type FollowRepositoryMock struct {
+	mock.Mock
+}
+
+func (frm *FollowRepositoryMock) SaveFollow(ctx context.Context, follow *model.Follow) (*model.Follow, error) {
+	args := frm.Called(ctx, follow)
+	return args.Get(0).(*model.Follow), args.Error(1)
+}
+
+func (frm *FollowRepositoryMock) FindFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (*model.Follow, error) {
+	args := frm.Called(ctx, followerID, followeeID)
+	return args.Get(0).(*model.Follow), args.Error(1)
+}
+
+func (frm *FollowRepositoryMock) AcceptFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID, acceptedAt time.Time) (*model.Follow, error) {
+	args := frm.Called(ctx, followerID, followeeID, acceptedAt)
+	return args.Get(0).(*model.Follow), args.Error(1)
+}
+
+func (frm *FollowRepositoryMock) DeleteFollow(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error) {
+	args := frm.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (frm *FollowRepositoryMock) FindFollows(ctx context.Context, followQuery *model.FollowQuery) ([]*model.FollowEntry, error) {
+	args := frm.Called(ctx, followQuery)
+	return args.Get(0).([]*model.FollowEntry), args.Error(1)
+}
+
+func (frm *FollowRepositoryMock) GetFollowCounts(ctx context.Context, userID uuid.UUID) (*model.FollowCounts, error) {
+	args := frm.Called(ctx, userID)
+	return args.Get(0).(*model.FollowCounts), args.Error(1)
+}
+
+func (frm *FollowRepositoryMock) GetFollowsByFollowerID(ctx context.Context, followerID uuid.UUID) ([]*model.Follow, error) {
+	args := frm.Called(ctx, followerID)
+	return args.Get(0).([]*model.Follow), args.Error(1)
+}
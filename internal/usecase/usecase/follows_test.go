@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func newFollowSettingUsecase(userRepo *UserRepositoryMock, userID uuid.UUID, stored []*model.UserSetting) IUserSettingUsecase {
+	userSettingRedisRepoMock := &UserSettingRedisRepositoryMock{}
+	userSettingRedisRepoMock.On("GetUserSettings", mock.Anything, userID).Return(stored, nil)
+	return NewUserSettingUsecase(userRepo, nil, userSettingRedisRepoMock, nil)
+}
+
+func TestFollowUsecase_FollowUser(t *testing.T) {
+	follower := &model.User{UserID: uuid.New(), IsPublic: true}
+	tests := []struct {
+		name       string
+		isPublic   bool
+		stored     []*model.UserSetting
+		followBack *model.Follow
+		wantStatus string
+		wantMutual bool
+	}{
+		{"public profile", true, nil, nil, model.FollowStatusAccepted, false},
+		{"public profile following back", true, nil, &model.Follow{Status: model.FollowStatusAccepted}, model.FollowStatusAccepted, true},
+		{"private profile", false, []*model.UserSetting{}, nil, model.FollowStatusPending, false},
+		{"private profile without approval", false, []*model.UserSetting{{Key: model.SettingFollowsApproval, Value: []byte(`false`)}}, nil, model.FollowStatusAccepted, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			followee := &model.User{UserID: uuid.New(), IsPublic: tt.isPublic}
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, followee.UserID).Return(followee, nil)
+			var saved *model.Follow
+			followRepoMock := &FollowRepositoryMock{}
+			followRepoMock.On("SaveFollow", mock.Anything, mock.Anything).Return(&model.Follow{FollowerID: follower.UserID, FolloweeID: followee.UserID, Status: tt.wantStatus}, nil).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*model.Follow)
+			})
+			if tt.followBack != nil {
+				followRepoMock.On("FindFollow", mock.Anything, followee.UserID, follower.UserID).Return(tt.followBack, nil)
+			} else {
+				followRepoMock.On("FindFollow", mock.Anything, followee.UserID, follower.UserID).Return((*model.Follow)(nil), &apperrors.FollowRepoFindFollowGetDataNotFound)
+			}
+
+			followusecase := NewFollowUsecase(userRepoMock, followRepoMock, newFollowSettingUsecase(userRepoMock, followee.UserID, tt.stored))
+			follow, err := followusecase.FollowUser(context.TODO(), follower, followee.UserID)
+			assert.NilError(t, err)
+			assert.Equal(t, saved.Status, tt.wantStatus)
+			assert.Equal(t, saved.AcceptedAt != nil, tt.wantStatus == model.FollowStatusAccepted)
+			assert.Equal(t, follow.Mutual, tt.wantMutual)
+		})
+	}
+}
+
+func TestFollowUsecase_FollowUser_Self(t *testing.T) {
+	user := &model.User{UserID: uuid.New()}
+	followRepoMock := &FollowRepositoryMock{}
+
+	_, err := NewFollowUsecase(nil, followRepoMock, nil).FollowUser(context.TODO(), user, user.UserID)
+	assert.Assert(t, apperrors.Is(err, &apperrors.FollowUsecaseFollowUserSelf), err)
+	followRepoMock.AssertNotCalled(t, "SaveFollow", mock.Anything, mock.Anything)
+}
+
+func TestFollowUsecase_GetFollows_PrivateProfile(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	follower := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	requester := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	entries := []*model.FollowEntry{{FollowID: 9, UserID: follower.UserID}, {FollowID: 7, UserID: requester.UserID}}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	followRepoMock := &FollowRepositoryMock{}
+	followRepoMock.On("FindFollow", mock.Anything, follower.UserID, user.UserID).Return(&model.Follow{Status: model.FollowStatusAccepted}, nil)
+	followRepoMock.On("FindFollow", mock.Anything, requester.UserID, user.UserID).Return(&model.Follow{Status: model.FollowStatusPending}, nil)
+	followRepoMock.On("FindFollows", mock.Anything, mock.Anything).Return(entries, nil)
+	followusecase := NewFollowUsecase(userRepoMock, followRepoMock, nil)
+
+	for _, viewer := range []*model.User{nil, requester} {
+		_, err := followusecase.GetFollows(context.TODO(), viewer, &model.FollowQuery{UserID: user.UserID, Limit: 2})
+		assert.Assert(t, apperrors.Is(err, &apperrors.FollowUsecaseGetFollowsForbidden), err)
+	}
+	for _, viewer := range []*model.User{user, follower} {
+		follows, err := followusecase.GetFollows(context.TODO(), viewer, &model.FollowQuery{UserID: user.UserID, Limit: 2})
+		assert.NilError(t, err)
+		assert.Equal(t, len(follows.Entries), 2)
+		assert.Equal(t, follows.NextBefore, int64(7))
+	}
+}
+
+func TestFollowUsecase_RejectFollowRequest(t *testing.T) {
+	user := &model.User{UserID: uuid.New()}
+	requester := &model.User{UserID: uuid.New()}
+	follower := &model.User{UserID: uuid.New()}
+
+	followRepoMock := &FollowRepositoryMock{}
+	followRepoMock.On("FindFollow", mock.Anything, requester.UserID, user.UserID).Return(&model.Follow{Status: model.FollowStatusPending}, nil)
+	followRepoMock.On("FindFollow", mock.Anything, follower.UserID, user.UserID).Return(&model.Follow{Status: model.FollowStatusAccepted}, nil)
+	followRepoMock.On("DeleteFollow", mock.Anything, requester.UserID, user.UserID).Return(true, nil)
+	followusecase := NewFollowUsecase(nil, followRepoMock, nil)
+
+	assert.NilError(t, followusecase.RejectFollowRequest(context.TODO(), user.UserID, requester.UserID))
+	err := followusecase.RejectFollowRequest(context.TODO(), user.UserID, follower.UserID)
+	assert.Assert(t, apperrors.Is(err, &apperrors.FollowUsecaseFollowRequestNotFound), err)
+	followRepoMock.AssertNotCalled(t, "DeleteFollow", mock.Anything, follower.UserID, user.UserID)
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	inviteAcceptPath = "/invite/accept"
+	inviteReason     = "invite"
+
+	inviteSubject = "You have been invited"
+	inviteBody    = `Hello,
+
+%s invited you to create an account. Open the link below to choose your nickname and password:
+
+%s
+
+The link expires at %s. If you weren't expecting this, ignore this email.
+`
+)
+
+type IInviteUsecase interface {
+	CreateInvite(ctx context.Context, inviter *model.User, createInvite *model.CreateInviteRequest) (*model.Invite, error)
+	AcceptInvite(ctx context.Context, acceptInvite *model.AcceptInviteRequest) (*model.User, error)
+	GetInvites(ctx context.Context, inviterID uuid.UUID) ([]*model.Invite, error)
+	RevokeInvite(ctx context.Context, actor *model.User, inviteID uuid.UUID) (*model.Invite, error)
+}
+
+type InviteUsecase struct {
+	UserRepo         repository.UserRepository
+	UserRedisRepo    repository.UserRedisRepository
+	RoleRepo         repository.RoleRepository
+	InviteRepo       repository.InviteRepository
+	AuditRepo        repository.AuditRepository
+	Mailer           repository.Mailer
+	UserUsecase      IUserUsecase
+	RoleGrantUsecase IRoleGrantUsecase
+	Policy           *model.InvitePolicy
+}
+
+func NewInviteUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, roleRepo repository.RoleRepository, inviteRepo repository.InviteRepository, auditRepo repository.AuditRepository, mailer repository.Mailer, userUsecase IUserUsecase, roleGrantUsecase IRoleGrantUsecase, policy *model.InvitePolicy) IInviteUsecase {
+	return &InviteUsecase{
+		UserRepo:         userRepo,
+		UserRedisRepo:    userRedisRepo,
+		RoleRepo:         roleRepo,
+		InviteRepo:       inviteRepo,
+		AuditRepo:        auditRepo,
+		Mailer:           mailer,
+		UserUsecase:      userUsecase,
+		RoleGrantUsecase: roleGrantUsecase,
+		Policy:           policy,
+	}
+}
+
+// CreateInvite mails an invite link to the address. Any role but the default one takes the
+// right to assign roles, and inviting an address again revokes the invite sent before.
+func (is *InviteUsecase) CreateInvite(ctx context.Context, inviter *model.User, createInvite *model.CreateInviteRequest) (*model.Invite, error) {
+	role := createInvite.Role
+	if role == "" {
+		role = inviter.GetDefaultRole()
+	}
+	if !inviter.IsRoleExist(role) {
+		return nil, apperrors.InviteUsecaseCreateInviteRoleNotExist.AppendMessage(role)
+	}
+	if role != inviter.GetDefaultRole() {
+		if err := inviter.HasPermissionsToAssignRole(); err != nil {
+			return nil, apperrors.InviteUsecaseCreateInviteAssignRole.AppendMessage(err)
+		}
+		if inviter.IsRoleHigher(role) {
+			return nil, apperrors.InviteUsecaseCreateInviteAssignRole.AppendMessage(role)
+		}
+	}
+
+	email := strings.TrimSpace(createInvite.Email)
+	_, err := is.UserRepo.FindUserByEmail(ctx, email)
+	if err == nil {
+		return nil, apperrors.InviteUsecaseEmailBusy.AppendMessage(email)
+	}
+	if !apperrors.Is(err, &apperrors.UserRepoFindUserByEmailGetDataNotFound) {
+		return nil, apperrors.InviteUsecaseFindUserByEmail.AppendMessage(err)
+	}
+
+	token, err := utils.GenerateToken()
+	if err != nil {
+		return nil, apperrors.InviteUsecaseCreateInviteGenerateToken.AppendMessage(err)
+	}
+
+	now := time.Now()
+	err = is.InviteRepo.RevokePendingInvites(ctx, utils.EmailKey(email), now)
+	if err != nil {
+		return nil, apperrors.InviteUsecaseCreateInviteRevokePending.AppendMessage(err)
+	}
+	invite, err := is.InviteRepo.SaveInvite(ctx, &model.Invite{
+		InviteID:    uuid.New(),
+		Email:       email,
+		EmailKey:    utils.EmailKey(email),
+		Role:        role,
+		InvitedByID: inviter.UserID,
+		TokenHash:   utils.HashToken(token),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(is.Policy.Ttl),
+	})
+	if err != nil {
+		return nil, apperrors.InviteUsecaseCreateInviteSave.AppendMessage(err)
+	}
+
+	err = is.Mailer.Send(ctx, &repository.Mail{
+		To:      email,
+		Subject: inviteSubject,
+		Body:    fmt.Sprintf(inviteBody, inviter.Nickname, is.link(token), invite.ExpiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return nil, apperrors.InviteUsecaseCreateInviteSend.AppendMessage(err)
+	}
+
+	return invite.SetStatus(now), nil
+}
+
+// AcceptInvite creates the invited user with the address the invite was sent to and gives it
+// the role of the invite, provided the inviter may still give it. The admin role goes through a
+// role request instead when admins need a second admin's approval. The user is created before
+// the invite is marked, a second attempt with the same token then fails on the address being
+// taken.
+func (is *InviteUsecase) AcceptInvite(ctx context.Context, acceptInvite *model.AcceptInviteRequest) (*model.User, error) {
+	invite, err := is.InviteRepo.FindInviteByTokenHash(ctx, utils.HashToken(acceptInvite.Token))
+	if err != nil {
+		if apperrors.Is(err, &apperrors.InviteRepoFindInviteGetDataNotFound) {
+			return nil, apperrors.InviteUsecaseAcceptInviteNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.InviteUsecaseAcceptInviteFind.AppendMessage(err)
+	}
+	if !invite.IsPending(time.Now()) {
+		return nil, apperrors.InviteUsecaseAcceptInviteNotPending.AppendMessage(invite.InviteID)
+	}
+
+	user := &model.User{
+		Nickname:  acceptInvite.Nickname,
+		FirstName: acceptInvite.FirstName,
+		LastName:  acceptInvite.LastName,
+		Email:     invite.Email,
+		Password:  acceptInvite.Password,
+	}
+	user.Created.By = invite.InvitedByID.String()
+	if invite.Role != user.GetDefaultRole() {
+		err = is.checkInviter(ctx, invite)
+		if err != nil {
+			return nil, err
+		}
+	}
+	user, err = is.UserUsecase.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = is.InviteRepo.AcceptInvite(ctx, invite.InviteID, user.UserID, now)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.InviteRepoAcceptInviteNotPending) {
+			return nil, apperrors.InviteUsecaseAcceptInviteNotPending.AppendMessage(err)
+		}
+		return nil, apperrors.InviteUsecaseAcceptInvite.AppendMessage(err)
+	}
+
+	if invite.Role == model.RoleAdmin && is.Policy.AdminApprovalRequired {
+		_, err = is.RoleRepo.SaveRoleRequest(ctx, &model.RoleRequest{
+			RequestID:    uuid.New(),
+			UserID:       user.UserID,
+			Role:         invite.Role,
+			PreviousRole: user.Role,
+			Reason:       inviteReason,
+			Status:       model.RoleRequestStatusPending,
+			RequestedBy:  invite.InvitedByID,
+			CreatedAt:    now,
+		})
+		if err != nil {
+			return nil, apperrors.InviteUsecaseAcceptInviteSaveRoleRequest.AppendMessage(err)
+		}
+		return user, nil
+	}
+
+	if invite.Role != user.Role {
+		_, err = is.RoleRepo.ChangeUserRole(ctx, &model.RoleHistory{
+			UserID:       user.UserID,
+			PreviousRole: user.Role,
+			Role:         invite.Role,
+			ChangedBy:    invite.InvitedByID,
+			Reason:       inviteReason,
+			CreatedAt:    now,
+		})
+		if err != nil {
+			return nil, apperrors.InviteUsecaseAcceptInviteChangeUserRole.AppendMessage(err)
+		}
+		previousUser := *user
+		user.Role = invite.Role
+
+		err = is.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+		if err == nil {
+			err = is.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+		}
+		if err != nil {
+			return nil, apperrors.InviteUsecaseAcceptInviteDropUserCache.AppendMessage(err)
+		}
+
+		err = recordAudit(ctx, is.AuditRepo, model.AuditActionUserRoleAssign, user.UserID, model.DiffUsers(&previousUser, user))
+		if err != nil {
+			return nil, apperrors.InviteUsecaseRecordAudit.AppendMessage(err)
+		}
+	}
+
+	return user, nil
+}
+
+// checkInviter makes sure the inviter may still give the role of the invite. An invite outlives
+// a role grant, so the rights that count are the inviter's at accept time, grants included.
+func (is *InviteUsecase) checkInviter(ctx context.Context, invite *model.Invite) error {
+	inviter, err := is.UserRepo.FindUserByUUID(ctx, invite.InvitedByID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return apperrors.InviteUsecaseAcceptInviteInviterRights.AppendMessage(err)
+		}
+		return apperrors.InviteUsecaseAcceptInviteFindInviter.AppendMessage(err)
+	}
+	err = inviter.CheckActive(time.Now())
+	if err != nil {
+		return apperrors.InviteUsecaseAcceptInviteInviterRights.AppendMessage(err)
+	}
+	_, err = is.RoleGrantUsecase.ApplyRoleGrants(ctx, inviter)
+	if err != nil {
+		return apperrors.InviteUsecaseAcceptInviteApplyRoleGrants.AppendMessage(err)
+	}
+	err = inviter.HasPermissionsToAssignRole()
+	if err != nil {
+		return apperrors.InviteUsecaseAcceptInviteInviterRights.AppendMessage(err)
+	}
+	if inviter.IsRoleHigher(invite.Role) {
+		return apperrors.InviteUsecaseAcceptInviteInviterRights.AppendMessage(invite.Role)
+	}
+	return nil
+}
+
+func (is *InviteUsecase) GetInvites(ctx context.Context, inviterID uuid.UUID) ([]*model.Invite, error) {
+	invites, err := is.InviteRepo.GetInvitesByInviterID(ctx, inviterID)
+	if err != nil {
+		return nil, apperrors.InviteUsecaseGetInvites.AppendMessage(err)
+	}
+
+	now := time.Now()
+	for _, invite := range invites {
+		invite.SetStatus(now)
+	}
+	return invites, nil
+}
+
+// RevokeInvite is left to the inviter and admins.
+func (is *InviteUsecase) RevokeInvite(ctx context.Context, actor *model.User, inviteID uuid.UUID) (*model.Invite, error) {
+	invite, err := is.InviteRepo.FindInviteByID(ctx, inviteID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.InviteRepoFindInviteGetDataNotFound) {
+			return nil, apperrors.InviteUsecaseRevokeInviteNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.InviteUsecaseRevokeInviteFind.AppendMessage(err)
+	}
+	if invite.InvitedByID != actor.UserID && !actor.IsAdmin() {
+		return nil, apperrors.InviteUsecaseRevokeInviteNotInviter.AppendMessage(inviteID)
+	}
+
+	now := time.Now()
+	err = is.InviteRepo.RevokeInvite(ctx, inviteID, now)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.InviteRepoRevokeInviteNotPending) {
+			return nil, apperrors.InviteUsecaseRevokeInviteNotPending.AppendMessage(err)
+		}
+		return nil, apperrors.InviteUsecaseRevokeInvite.AppendMessage(err)
+	}
+
+	invite.RevokedAt = &now
+	return invite.SetStatus(now), nil
+}
+
+func (is *InviteUsecase) link(token string) string {
+	return strings.TrimSuffix(is.Policy.LinkBaseURL, "/") + inviteAcceptPath + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var inviteTestPolicy = &model.InvitePolicy{Ttl: 48 * time.Hour, LinkBaseURL: "https://app.test"}
+
+type InviteRepositoryMock struct {
+	mock.Mock
+}
+
+func (irm *InviteRepositoryMock) SaveInvite(ctx context.Context, invite *model.Invite) (*model.Invite, error) {
+	args := irm.Called(ctx, invite)
+	return args.Get(0).(*model.Invite), args.Error(1)
+}
+
+func (irm *InviteRepositoryMock) FindInviteByID(ctx context.Context, inviteID uuid.UUID) (*model.Invite, error) {
+	args := irm.Called(ctx, inviteID)
+	return args.Get(0).(*model.Invite), args.Error(1)
+}
+
+func (irm *InviteRepositoryMock) FindInviteByTokenHash(ctx context.Context, tokenHash string) (*model.Invite, error) {
+	args := irm.Called(ctx, tokenHash)
+	return args.Get(0).(*model.Invite), args.Error(1)
+}
+
+func (irm *InviteRepositoryMock) GetInvitesByInviterID(ctx context.Context, inviterID uuid.UUID) ([]*model.Invite, error) {
+	args := irm.Called(ctx, inviterID)
+	return args.Get(0).([]*model.Invite), args.Error(1)
+}
+
+func (irm *InviteRepositoryMock) AcceptInvite(ctx context.Context, inviteID uuid.UUID, userID uuid.UUID, acceptedAt time.Time) error {
+	args := irm.Called(ctx, inviteID, userID, acceptedAt)
+	return args.Error(0)
+}
+
+func (irm *InviteRepositoryMock) RevokeInvite(ctx context.Context, inviteID uuid.UUID, revokedAt time.Time) error {
+	args := irm.Called(ctx, inviteID, revokedAt)
+	return args.Error(0)
+}
+
+func (irm *InviteRepositoryMock) RevokePendingInvites(ctx context.Context, emailKey string, revokedAt time.Time) error {
+	args := irm.Called(ctx, emailKey, revokedAt)
+	return args.Error(0)
+}
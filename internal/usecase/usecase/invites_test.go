@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var inviteTestLink = regexp.MustCompile(`https://app\.test/invite/accept\?token=(\S+)`)
+
+func TestInviteUsecase_CreateInvite(t *testing.T) {
+	inviter := &model.User{UserID: uuid.New(), Nickname: "inviter", Role: model.RoleModerator}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByEmail", mock.Anything, "New@Test.test").Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	inviteRepoMock := &InviteRepositoryMock{}
+	inviteRepoMock.On("RevokePendingInvites", mock.Anything, "new@test.test", mock.Anything).Return(nil)
+	inviteRepoMock.On("SaveInvite", mock.Anything, mock.Anything).Return(&model.Invite{}, nil)
+	mailerMock := &MailerMock{}
+	mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	inviteusecase := NewInviteUsecase(userRepoMock, nil, nil, inviteRepoMock, newAuditRepoMock(), mailerMock, nil, nil, inviteTestPolicy)
+	_, err := inviteusecase.CreateInvite(context.TODO(), inviter, &model.CreateInviteRequest{Email: " New@Test.test "})
+	assert.NilError(t, err)
+	invite := inviteRepoMock.Calls[1].Arguments.Get(1).(*model.Invite)
+	assert.Equal(t, invite.Email, "New@Test.test")
+	assert.Equal(t, invite.Role, model.RoleUser)
+	assert.Equal(t, invite.InvitedByID, inviter.UserID)
+	assert.Equal(t, invite.ExpiresAt, invite.CreatedAt.Add(48*time.Hour))
+
+	mail := mailerMock.Calls[0].Arguments.Get(1).(*repository.Mail)
+	assert.Equal(t, mail.To, "New@Test.test")
+	match := inviteTestLink.FindStringSubmatch(mail.Body)
+	assert.Assert(t, match != nil, mail.Body)
+	token, err := url.QueryUnescape(match[1])
+	assert.NilError(t, err)
+	assert.Equal(t, utils.HashToken(token), invite.TokenHash)
+}
+
+func TestInviteUsecase_CreateInvite_Errors(t *testing.T) {
+	moderator := &model.User{UserID: uuid.New(), Role: model.RoleModerator}
+	tests := []struct {
+		name    string
+		role    string
+		email   string
+		wantErr *apperrors.AppError
+	}{
+		{"unknown role", "owner", "new@test.test", &apperrors.InviteUsecaseCreateInviteRoleNotExist},
+		{"role above the default one", model.RoleModerator, "new@test.test", &apperrors.InviteUsecaseCreateInviteAssignRole},
+		{"taken email", "", "taken@test.test", &apperrors.InviteUsecaseEmailBusy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByEmail", mock.Anything, "taken@test.test").Return(&model.User{UserID: uuid.New()}, nil)
+			inviteRepoMock := &InviteRepositoryMock{}
+
+			_, err := NewInviteUsecase(userRepoMock, nil, nil, inviteRepoMock, newAuditRepoMock(), nil, nil, nil, inviteTestPolicy).CreateInvite(context.TODO(), moderator, &model.CreateInviteRequest{Email: tt.email, Role: tt.role})
+			assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+			inviteRepoMock.AssertNotCalled(t, "SaveInvite", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInviteUsecase_AcceptInvite(t *testing.T) {
+	inviterID := uuid.New()
+	invite := &model.Invite{InviteID: uuid.New(), Email: "new@test.test", Role: model.RoleModerator, InvitedByID: inviterID, ExpiresAt: time.Now().Add(time.Hour)}
+	savedUser := &model.User{UserID: uuid.New(), Nickname: "newcomer", Email: "new@test.test", Role: model.RoleUser}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, inviterID).Return(&model.User{UserID: inviterID, Role: model.RoleAdmin}, nil)
+	userRepoMock.On("FindUserByEmail", mock.Anything, "new@test.test").Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	userRepoMock.On("FindUserByNickname", mock.Anything, "newcomer").Return((*model.User)(nil), &apperrors.UserRepoFindUserByNicknameGetDataNotFound)
+	userRepoMock.On("SaveUser", mock.Anything, mock.Anything).Return(savedUser, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, mock.Anything).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "newcomer").Return(nil)
+	roleRepoMock := &RoleRepositoryMock{}
+	roleRepoMock.On("ChangeUserRole", mock.Anything, mock.Anything).Return(&model.RoleHistory{}, nil)
+	inviteRepoMock := &InviteRepositoryMock{}
+	inviteRepoMock.On("FindInviteByTokenHash", mock.Anything, utils.HashToken("token")).Return(invite, nil)
+	inviteRepoMock.On("AcceptInvite", mock.Anything, invite.InviteID, mock.Anything, mock.Anything).Return(nil)
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+
+	auditRepoMock := newAuditRepoMock()
+
+	inviteusecase := NewInviteUsecase(userRepoMock, userRedisRepoMock, roleRepoMock, inviteRepoMock, auditRepoMock, nil, userusecase, newInviteRoleGrantUsecase(), inviteTestPolicy)
+	user, err := inviteusecase.AcceptInvite(context.TODO(), &model.AcceptInviteRequest{Token: "token", Nickname: "newcomer", Password: "secret"})
+	assert.NilError(t, err)
+	assert.Equal(t, user.Role, model.RoleModerator)
+	createdUser := userRepoMock.Calls[3].Arguments.Get(1).(*model.User)
+	assert.Equal(t, createdUser.Email, "new@test.test")
+	assert.Equal(t, createdUser.Created.By, inviterID.String())
+	inviteRepoMock.AssertCalled(t, "AcceptInvite", mock.Anything, invite.InviteID, user.UserID, mock.Anything)
+
+	roleHistory := roleRepoMock.Calls[0].Arguments.Get(1).(*model.RoleHistory)
+	assert.Equal(t, roleHistory.PreviousRole, model.RoleUser)
+	assert.Equal(t, roleHistory.ChangedBy, inviterID)
+	auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditActionUserRoleAssign && entry.TargetID == user.UserID && entry.Changes["user_role"] == model.AuditChange{Before: model.RoleUser, After: model.RoleModerator}
+	}))
+}
+
+func TestInviteUsecase_AcceptInvite_AdminApproval(t *testing.T) {
+	inviterID := uuid.New()
+	invite := &model.Invite{InviteID: uuid.New(), Email: "new@test.test", Role: model.RoleAdmin, InvitedByID: inviterID, ExpiresAt: time.Now().Add(time.Hour)}
+	savedUser := &model.User{UserID: uuid.New(), Nickname: "newcomer", Email: "new@test.test", Role: model.RoleUser}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, inviterID).Return(&model.User{UserID: inviterID, Role: model.RoleAdmin}, nil)
+	userRepoMock.On("FindUserByEmail", mock.Anything, "new@test.test").Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	userRepoMock.On("FindUserByNickname", mock.Anything, "newcomer").Return((*model.User)(nil), &apperrors.UserRepoFindUserByNicknameGetDataNotFound)
+	userRepoMock.On("SaveUser", mock.Anything, mock.Anything).Return(savedUser, nil)
+	roleRepoMock := &RoleRepositoryMock{}
+	roleRepoMock.On("SaveRoleRequest", mock.Anything, mock.Anything).Return(&model.RoleRequest{}, nil)
+	inviteRepoMock := &InviteRepositoryMock{}
+	inviteRepoMock.On("FindInviteByTokenHash", mock.Anything, utils.HashToken("token")).Return(invite, nil)
+	inviteRepoMock.On("AcceptInvite", mock.Anything, invite.InviteID, mock.Anything, mock.Anything).Return(nil)
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, &UserRedisRepositoryMock{}, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+	policy := *inviteTestPolicy
+	policy.AdminApprovalRequired = true
+
+	inviteusecase := NewInviteUsecase(userRepoMock, nil, roleRepoMock, inviteRepoMock, newAuditRepoMock(), nil, userusecase, newInviteRoleGrantUsecase(), &policy)
+	user, err := inviteusecase.AcceptInvite(context.TODO(), &model.AcceptInviteRequest{Token: "token", Nickname: "newcomer", Password: "secret"})
+	assert.NilError(t, err)
+	assert.Equal(t, user.Role, model.RoleUser)
+	roleRepoMock.AssertNotCalled(t, "ChangeUserRole", mock.Anything, mock.Anything)
+	roleRequest := roleRepoMock.Calls[0].Arguments.Get(1).(*model.RoleRequest)
+	assert.Equal(t, roleRequest.Role, model.RoleAdmin)
+	assert.Equal(t, roleRequest.Status, model.RoleRequestStatusPending)
+	assert.Equal(t, roleRequest.RequestedBy, inviterID)
+}
+
+func TestInviteUsecase_AcceptInvite_InviterRights(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		inviter *model.User
+		grants  []*model.RoleGrant
+		wantErr *apperrors.AppError
+	}{
+		{"admin", &model.User{Role: model.RoleAdmin}, []*model.RoleGrant{}, nil},
+		{"active grant", &model.User{Role: model.RoleUser}, []*model.RoleGrant{{Role: model.RoleAdmin, Status: model.RoleGrantStatusActive, ExpiresAt: &expiresAt}}, nil},
+		{"grant ended", &model.User{Role: model.RoleUser}, []*model.RoleGrant{}, &apperrors.InviteUsecaseAcceptInviteInviterRights},
+		{"suspended", &model.User{Role: model.RoleAdmin, UserStatus: model.UserStatus{Status: model.UserStatusSuspended}}, []*model.RoleGrant{}, &apperrors.InviteUsecaseAcceptInviteInviterRights},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.inviter.UserID = uuid.New()
+			invite := &model.Invite{InviteID: uuid.New(), Email: "new@test.test", Role: model.RoleModerator, InvitedByID: tt.inviter.UserID, ExpiresAt: time.Now().Add(time.Hour)}
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, tt.inviter.UserID).Return(tt.inviter, nil)
+			inviteRepoMock := &InviteRepositoryMock{}
+			inviteRepoMock.On("FindInviteByTokenHash", mock.Anything, utils.HashToken("token")).Return(invite, nil)
+			roleGrantRepoMock := &RoleGrantRepositoryMock{}
+			roleGrantRepoMock.On("FindActiveRoleGrantsByUserID", mock.Anything, tt.inviter.UserID, mock.Anything).Return(tt.grants, nil)
+
+			inviteusecase := NewInviteUsecase(userRepoMock, nil, nil, inviteRepoMock, newAuditRepoMock(), nil, nil, NewRoleGrantUsecase(nil, roleGrantRepoMock, nil, nil, &model.RoleGrantPolicy{}), inviteTestPolicy)
+			err := inviteusecase.(*InviteUsecase).checkInviter(context.TODO(), invite)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+				_, err = inviteusecase.AcceptInvite(context.TODO(), &model.AcceptInviteRequest{Token: "token", Nickname: "newcomer", Password: "secret"})
+				assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+				inviteRepoMock.AssertNotCalled(t, "AcceptInvite", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func newInviteRoleGrantUsecase() IRoleGrantUsecase {
+	roleGrantRepoMock := &RoleGrantRepositoryMock{}
+	roleGrantRepoMock.On("FindActiveRoleGrantsByUserID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.RoleGrant{}, nil)
+	return NewRoleGrantUsecase(nil, roleGrantRepoMock, nil, nil, &model.RoleGrantPolicy{})
+}
+
+func TestInviteUsecase_AcceptInvite_NotPending(t *testing.T) {
+	now := time.Now()
+	invites := map[string]*model.Invite{
+		"expired":  {InviteID: uuid.New(), ExpiresAt: now.Add(-time.Minute)},
+		"revoked":  {InviteID: uuid.New(), ExpiresAt: now.Add(time.Hour), RevokedAt: &now},
+		"accepted": {InviteID: uuid.New(), ExpiresAt: now.Add(time.Hour), AcceptedAt: &now},
+	}
+	for name, invite := range invites {
+		t.Run(name, func(t *testing.T) {
+			inviteRepoMock := &InviteRepositoryMock{}
+			inviteRepoMock.On("FindInviteByTokenHash", mock.Anything, mock.Anything).Return(invite, nil)
+
+			_, err := NewInviteUsecase(nil, nil, nil, inviteRepoMock, newAuditRepoMock(), nil, nil, nil, inviteTestPolicy).AcceptInvite(context.TODO(), &model.AcceptInviteRequest{Token: "token", Nickname: "newcomer", Password: "secret"})
+			assert.Assert(t, apperrors.Is(err, &apperrors.InviteUsecaseAcceptInviteNotPending), err)
+			assert.Equal(t, invite.SetStatus(now).Status, name)
+		})
+	}
+}
+
+func TestInviteUsecase_RevokeInvite(t *testing.T) {
+	inviter := &model.User{UserID: uuid.New(), Role: model.RoleModerator}
+	stranger := &model.User{UserID: uuid.New(), Role: model.RoleModerator}
+	admin := &model.User{UserID: uuid.New(), Role: model.RoleAdmin}
+	invite := &model.Invite{InviteID: uuid.New(), InvitedByID: inviter.UserID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	inviteRepoMock := &InviteRepositoryMock{}
+	inviteRepoMock.On("FindInviteByID", mock.Anything, invite.InviteID).Return(invite, nil)
+	inviteRepoMock.On("RevokeInvite", mock.Anything, invite.InviteID, mock.Anything).Return(nil)
+	inviteusecase := NewInviteUsecase(nil, nil, nil, inviteRepoMock, newAuditRepoMock(), nil, nil, nil, inviteTestPolicy)
+
+	_, err := inviteusecase.RevokeInvite(context.TODO(), stranger, invite.InviteID)
+	assert.Assert(t, apperrors.Is(err, &apperrors.InviteUsecaseRevokeInviteNotInviter), err)
+	for _, actor := range []*model.User{inviter, admin} {
+		revokedInvite, err := inviteusecase.RevokeInvite(context.TODO(), actor, invite.InviteID)
+		assert.NilError(t, err)
+		assert.Equal(t, revokedInvite.Status, model.InviteStatusRevoked)
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+)
+
+type ILoginEventUsecase interface {
+	RecordLogin(ctx context.Context, user *model.User, loginEvent *model.LoginEvent) (*model.LoginEvent, error)
+	GetUserLogins(ctx context.Context, loginEventQuery *model.LoginEventQuery) (*model.LoginEvents, error)
+}
+
+type LoginEventUsecase struct {
+	UserRepo       repository.UserRepository
+	UserRedisRepo  repository.UserRedisRepository
+	LoginEventRepo repository.LoginEventRepository
+	EventRedisRepo repository.EventRedisRepository
+}
+
+func NewLoginEventUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, loginEventRepo repository.LoginEventRepository, eventRedisRepo repository.EventRedisRepository) ILoginEventUsecase {
+	return &LoginEventUsecase{
+		UserRepo:       userRepo,
+		UserRedisRepo:  userRedisRepo,
+		LoginEventRepo: loginEventRepo,
+		EventRedisRepo: eventRedisRepo,
+	}
+}
+
+// RecordLogin stores the login attempt. The user is nil when nobody goes by the login that
+// was tried. A successful login moves the login date of the user, and one from a client the
+// user never logged in with before raises a login.new_client event.
+func (ls *LoginEventUsecase) RecordLogin(ctx context.Context, user *model.User, loginEvent *model.LoginEvent) (*model.LoginEvent, error) {
+	loginEvent.CreatedAt = time.Now()
+	loginEvent.UserID = nil
+	if user != nil {
+		userID := user.UserID
+		loginEvent.UserID = &userID
+	}
+
+	var history *model.LoginClientHistory
+	if user != nil && loginEvent.Success {
+		var err error
+		history, err = ls.LoginEventRepo.GetLoginClientHistory(ctx, user.UserID, loginEvent.IP, loginEvent.UserAgent)
+		if err != nil {
+			return nil, apperrors.LoginEventUsecaseRecordLoginGetHistory.AppendMessage(err)
+		}
+		loginEvent.NewClient = history.IsNewClient()
+	}
+
+	savedLoginEvent, err := ls.LoginEventRepo.SaveLoginEvent(ctx, loginEvent)
+	if err != nil {
+		return nil, apperrors.LoginEventUsecaseRecordLoginSave.AppendMessage(err)
+	}
+	if user == nil || !loginEvent.Success {
+		return savedLoginEvent, nil
+	}
+
+	err = ls.UserRepo.UpdateLoginDate(ctx, user.UserID, loginEvent.CreatedAt)
+	if err != nil {
+		return nil, apperrors.LoginEventUsecaseRecordLoginUpdateLoginDate.AppendMessage(err)
+	}
+	err = ls.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err == nil {
+		err = ls.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return nil, apperrors.LoginEventUsecaseRecordLoginDropUserCache.AppendMessage(err)
+	}
+
+	if savedLoginEvent.NewClient {
+		err = ls.EventRedisRepo.Publish(ctx, model.NewEvent(model.EventLoginNewClient, user.UserID, &model.LoginNewClientPayload{
+			LoginEventID:   savedLoginEvent.LoginEventID,
+			IP:             savedLoginEvent.IP,
+			UserAgent:      savedLoginEvent.UserAgent,
+			KnownIP:        history.KnownIP,
+			KnownUserAgent: history.KnownUserAgent,
+		}))
+		if err != nil {
+			return nil, apperrors.LoginEventUsecaseRecordLoginPublish.AppendMessage(err)
+		}
+	}
+
+	return savedLoginEvent, nil
+}
+
+func (ls *LoginEventUsecase) GetUserLogins(ctx context.Context, loginEventQuery *model.LoginEventQuery) (*model.LoginEvents, error) {
+	loginEvents, err := ls.LoginEventRepo.FindLoginEvents(ctx, loginEventQuery)
+	if err != nil {
+		return nil, apperrors.LoginEventUsecaseGetUserLogins.AppendMessage(err)
+	}
+
+	logins := &model.LoginEvents{Logins: loginEvents}
+	if len(loginEvents) > 0 && len(loginEvents) == loginEventQuery.Limit {
+		logins.NextBefore = loginEvents[len(loginEvents)-1].LoginEventID
+	}
+	return logins, nil
+}
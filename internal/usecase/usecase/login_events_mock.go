@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginEventRepositoryMock struct {
+	mock.Mock
+}
+
+func (lerm *LoginEventRepositoryMock) SaveLoginEvent(ctx context.Context, loginEvent *model.LoginEvent) (*model.LoginEvent, error) {
+	args := lerm.Called(ctx, loginEvent)
+	return args.Get(0).(*model.LoginEvent), args.Error(1)
+}
+
+func (lerm *LoginEventRepositoryMock) FindLoginEvents(ctx context.Context, loginEventQuery *model.LoginEventQuery) ([]*model.LoginEvent, error) {
+	args := lerm.Called(ctx, loginEventQuery)
+	return args.Get(0).([]*model.LoginEvent), args.Error(1)
+}
+
+func (lerm *LoginEventRepositoryMock) GetLoginEventsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.LoginEvent, error) {
+	args := lerm.Called(ctx, userID)
+	return args.Get(0).([]*model.LoginEvent), args.Error(1)
+}
+
+func (lerm *LoginEventRepositoryMock) GetLoginClientHistory(ctx context.Context, userID uuid.UUID, ip string, userAgent string) (*model.LoginClientHistory, error) {
+	args := lerm.Called(ctx, userID, ip, userAgent)
+	return args.Get(0).(*model.LoginClientHistory), args.Error(1)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoginEventUsecase_RecordLogin_NewClient(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname"}
+	tests := []struct {
+		name          string
+		history       *model.LoginClientHistory
+		wantNewClient bool
+	}{
+		{"first login", &model.LoginClientHistory{}, false},
+		{"known client", &model.LoginClientHistory{Logins: 3, KnownIP: true, KnownUserAgent: true}, false},
+		{"new ip", &model.LoginClientHistory{Logins: 3, KnownUserAgent: true}, true},
+		{"new user agent", &model.LoginClientHistory{Logins: 3, KnownIP: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loginEventRepoMock := &LoginEventRepositoryMock{}
+			loginEventRepoMock.On("GetLoginClientHistory", mock.Anything, user.UserID, "10.0.0.1", "curl").Return(tt.history, nil)
+			loginEventRepoMock.On("SaveLoginEvent", mock.Anything, mock.Anything).Return(&model.LoginEvent{LoginEventID: 7, IP: "10.0.0.1", UserAgent: "curl", Success: true, NewClient: tt.wantNewClient}, nil)
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("UpdateLoginDate", mock.Anything, user.UserID, mock.Anything).Return(nil)
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "nickname").Return(nil)
+			eventRedisRepoMock := &EventRedisRepositoryMock{}
+			eventRedisRepoMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+			logineventusecase := NewLoginEventUsecase(userRepoMock, userRedisRepoMock, loginEventRepoMock, eventRedisRepoMock)
+			_, err := logineventusecase.RecordLogin(context.TODO(), user, &model.LoginEvent{Login: "nickname", Method: model.LoginMethodNickname, Success: true, IP: "10.0.0.1", UserAgent: "curl"})
+			assert.NilError(t, err)
+
+			saved := loginEventRepoMock.Calls[1].Arguments.Get(1).(*model.LoginEvent)
+			assert.Equal(t, saved.NewClient, tt.wantNewClient)
+			assert.Equal(t, *saved.UserID, user.UserID)
+			userRepoMock.AssertCalled(t, "UpdateLoginDate", mock.Anything, user.UserID, saved.CreatedAt)
+			if !tt.wantNewClient {
+				eventRedisRepoMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+				return
+			}
+			event := eventRedisRepoMock.Calls[0].Arguments.Get(1).(*model.Event)
+			assert.Equal(t, event.Type, model.EventLoginNewClient)
+			assert.Equal(t, event.UserID, user.UserID)
+		})
+	}
+}
+
+func TestLoginEventUsecase_RecordLogin_Failed(t *testing.T) {
+	loginEventRepoMock := &LoginEventRepositoryMock{}
+	loginEventRepoMock.On("SaveLoginEvent", mock.Anything, mock.Anything).Return(&model.LoginEvent{LoginEventID: 1}, nil)
+	userRepoMock := &UserRepositoryMock{}
+
+	logineventusecase := NewLoginEventUsecase(userRepoMock, nil, loginEventRepoMock, nil)
+	_, err := logineventusecase.RecordLogin(context.TODO(), nil, &model.LoginEvent{Login: "nobody", Method: model.LoginMethodNickname, FailureReason: model.LoginFailureUnknownUser})
+	assert.NilError(t, err)
+
+	saved := loginEventRepoMock.Calls[0].Arguments.Get(1).(*model.LoginEvent)
+	assert.Assert(t, saved.UserID == nil)
+	assert.Assert(t, !saved.CreatedAt.IsZero())
+	loginEventRepoMock.AssertNotCalled(t, "GetLoginClientHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	userRepoMock.AssertNotCalled(t, "UpdateLoginDate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginEventUsecase_GetUserLogins(t *testing.T) {
+	query := &model.LoginEventQuery{UserID: uuid.New(), Limit: 2}
+	loginEventRepoMock := &LoginEventRepositoryMock{}
+	loginEventRepoMock.On("FindLoginEvents", mock.Anything, query).Return([]*model.LoginEvent{{LoginEventID: 9}, {LoginEventID: 4}}, nil)
+
+	logins, err := NewLoginEventUsecase(nil, nil, loginEventRepoMock, nil).GetUserLogins(context.TODO(), query)
+	assert.NilError(t, err)
+	assert.Equal(t, len(logins.Logins), 2)
+	assert.Equal(t, logins.NextBefore, int64(4))
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+type INicknameUsecase interface {
+	GetReservedNicknames(ctx context.Context) ([]*model.ReservedNickname, error)
+	ReserveNickname(ctx context.Context, reservedNickname *model.ReservedNickname) (*model.ReservedNickname, error)
+	ReleaseNickname(ctx context.Context, nickname string) error
+	ResolveNickname(ctx context.Context, nickname string) (*model.User, error)
+	GetNicknameHistory(ctx context.Context, userID uuid.UUID) ([]*model.NicknameChange, error)
+}
+
+type NicknameUsecase struct {
+	UserRepo     repository.UserRepository
+	NicknameRepo repository.NicknameRepository
+}
+
+func NewNicknameUsecase(userRepo repository.UserRepository, nicknameRepo repository.NicknameRepository) INicknameUsecase {
+	return &NicknameUsecase{
+		UserRepo:     userRepo,
+		NicknameRepo: nicknameRepo,
+	}
+}
+
+func (ns *NicknameUsecase) GetReservedNicknames(ctx context.Context) ([]*model.ReservedNickname, error) {
+	reservedNicknames, err := ns.NicknameRepo.GetReservedNicknames(ctx)
+	if err != nil {
+		return nil, apperrors.NicknameUsecaseGetReservedNicknames.AppendMessage(err)
+	}
+
+	return reservedNicknames, nil
+}
+
+// ReserveNickname blocks the nickname for new users and renames. A user that already has it keeps it.
+func (ns *NicknameUsecase) ReserveNickname(ctx context.Context, reservedNickname *model.ReservedNickname) (*model.ReservedNickname, error) {
+	reservedNickname.Nickname = strings.TrimSpace(reservedNickname.Nickname)
+	reservedNickname.NicknameKey = utils.NicknameKey(reservedNickname.Nickname)
+	if reservedNickname.NicknameKey == "" {
+		return nil, &apperrors.NicknameUsecaseReserveNicknameEmpty
+	}
+	reservedNickname.CreatedAt = time.Now()
+
+	savedNickname, err := ns.NicknameRepo.SaveReservedNickname(ctx, reservedNickname)
+	if err != nil {
+		return nil, apperrors.NicknameUsecaseReserveNickname.AppendMessage(err)
+	}
+
+	return savedNickname, nil
+}
+
+func (ns *NicknameUsecase) ReleaseNickname(ctx context.Context, nickname string) error {
+	err := ns.NicknameRepo.DeleteReservedNickname(ctx, utils.NicknameKey(nickname))
+	if err != nil {
+		if apperrors.Is(err, &apperrors.NicknameRepoDeleteReservedNicknameDataNotFound) {
+			return apperrors.NicknameUsecaseReleaseNicknameNotExist.AppendMessage(err)
+		}
+		return apperrors.NicknameUsecaseReleaseNickname.AppendMessage(err)
+	}
+
+	return nil
+}
+
+// ResolveNickname finds the user that has the nickname now, in any spelling that shares its key,
+// or else the user that gave it up most recently. Callers compare the nickname of the result
+// with the one asked for to tell the cases apart.
+func (ns *NicknameUsecase) ResolveNickname(ctx context.Context, nickname string) (*model.User, error) {
+	user, err := ns.UserRepo.FindUserByNickname(ctx, nickname)
+	if err == nil {
+		return user, nil
+	}
+	if !apperrors.Is(err, &apperrors.UserRepoFindUserByNicknameGetDataNotFound) {
+		return nil, apperrors.NicknameUsecaseResolveNicknameFindUser.AppendMessage(err)
+	}
+
+	nicknameChange, err := ns.NicknameRepo.FindLastNicknameChangeByKey(ctx, utils.NicknameKey(nickname))
+	if err != nil {
+		if apperrors.Is(err, &apperrors.NicknameRepoFindLastNicknameChangeGetDataNotFound) {
+			return nil, apperrors.NicknameUsecaseResolveNicknameNotExist.AppendMessage(nickname)
+		}
+		return nil, apperrors.NicknameUsecaseResolveNicknameFindNicknameChange.AppendMessage(err)
+	}
+
+	user, err = ns.UserRepo.FindUserByUUID(ctx, nicknameChange.UserID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.NicknameUsecaseResolveNicknameNotExist.AppendMessage(nickname)
+		}
+		return nil, apperrors.NicknameUsecaseResolveNicknameFindUser.AppendMessage(err)
+	}
+
+	return user, nil
+}
+
+func (ns *NicknameUsecase) GetNicknameHistory(ctx context.Context, userID uuid.UUID) ([]*model.NicknameChange, error) {
+	nicknameChanges, err := ns.NicknameRepo.GetNicknameChangesByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NicknameUsecaseGetNicknameHistory.AppendMessage(err)
+	}
+
+	return nicknameChanges, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var nicknameTestPolicy = &model.NicknamePolicy{ChangeCooldown: 24 * time.Hour}
+
+type NicknameRepositoryMock struct {
+	mock.Mock
+}
+
+func (nrm *NicknameRepositoryMock) FindReservedNickname(ctx context.Context, nicknameKey string) (*model.ReservedNickname, error) {
+	args := nrm.Called(ctx, nicknameKey)
+	return args.Get(0).(*model.ReservedNickname), args.Error(1)
+}
+
+func (nrm *NicknameRepositoryMock) GetReservedNicknames(ctx context.Context) ([]*model.ReservedNickname, error) {
+	args := nrm.Called(ctx)
+	return args.Get(0).([]*model.ReservedNickname), args.Error(1)
+}
+
+func (nrm *NicknameRepositoryMock) SaveReservedNickname(ctx context.Context, reservedNickname *model.ReservedNickname) (*model.ReservedNickname, error) {
+	args := nrm.Called(ctx, reservedNickname)
+	return args.Get(0).(*model.ReservedNickname), args.Error(1)
+}
+
+func (nrm *NicknameRepositoryMock) DeleteReservedNickname(ctx context.Context, nicknameKey string) error {
+	args := nrm.Called(ctx, nicknameKey)
+	return args.Error(0)
+}
+
+func (nrm *NicknameRepositoryMock) SaveNicknameChange(ctx context.Context, nicknameChange *model.NicknameChange) (*model.NicknameChange, error) {
+	args := nrm.Called(ctx, nicknameChange)
+	return args.Get(0).(*model.NicknameChange), args.Error(1)
+}
+
+func (nrm *NicknameRepositoryMock) FindLastNicknameChangeByUserID(ctx context.Context, userID uuid.UUID) (*model.NicknameChange, error) {
+	args := nrm.Called(ctx, userID)
+	return args.Get(0).(*model.NicknameChange), args.Error(1)
+}
+
+func (nrm *NicknameRepositoryMock) FindLastNicknameChangeByKey(ctx context.Context, nicknameKey string) (*model.NicknameChange, error) {
+	args := nrm.Called(ctx, nicknameKey)
+	return args.Get(0).(*model.NicknameChange), args.Error(1)
+}
+
+func (nrm *NicknameRepositoryMock) GetNicknameChangesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.NicknameChange, error) {
+	args := nrm.Called(ctx, userID)
+	return args.Get(0).([]*model.NicknameChange), args.Error(1)
+}
+
+// newNicknameRepoMock has no reserved nicknames and no nickname changes, and records new changes.
+func newNicknameRepoMock() *NicknameRepositoryMock {
+	nicknameRepoMock := &NicknameRepositoryMock{}
+	nicknameRepoMock.On("FindReservedNickname", mock.Anything, mock.Anything).Return((*model.ReservedNickname)(nil), &apperrors.NicknameRepoFindReservedNicknameGetDataNotFound)
+	nicknameRepoMock.On("FindLastNicknameChangeByUserID", mock.Anything, mock.Anything).Return((*model.NicknameChange)(nil), &apperrors.NicknameRepoFindLastNicknameChangeGetDataNotFound)
+	nicknameRepoMock.On("SaveNicknameChange", mock.Anything, mock.Anything).Return(&model.NicknameChange{}, nil)
+	return nicknameRepoMock
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestUserUsecase_CreateUser_ReservedNickname(t *testing.T) {
+	user := &model.User{Nickname: "Аdmin", FirstName: "fname", LastName: "lname"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByNickname", mock.Anything, user.Nickname).Return((*model.User)(nil), nil)
+	userRepoMock.On("SaveUser", mock.Anything, user).Return(user, nil)
+	nicknameRepoMock := &NicknameRepositoryMock{}
+	nicknameRepoMock.On("FindReservedNickname", mock.Anything, "admin").Return(&model.ReservedNickname{NicknameKey: "admin", Nickname: "admin"}, nil)
+
+	_, err := NewUserUsecase(userRepoMock, nil, nil, nil, newAttributeSchemaRepoMock(), nicknameRepoMock, newAuditRepoMock(), nicknameTestPolicy, nil).CreateUser(context.TODO(), user)
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserUsecaseNicknameReserved))
+	assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 409)
+	userRepoMock.AssertNotCalled(t, "SaveUser", mock.Anything, user)
+}
+
+func TestUserUsecase_PatchUser_Nickname(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname"}
+	tests := []struct {
+		name       string
+		nickname   string
+		lastChange *model.NicknameChange
+		wantErr    *apperrors.AppError
+		wantSaved  bool
+	}{
+		{"first change", "renamed", nil, nil, true},
+		{"change after cooldown", "renamed", &model.NicknameChange{ChangedAt: time.Now().Add(-48 * time.Hour)}, nil, true},
+		{"change within cooldown", "renamed", &model.NicknameChange{ChangedAt: time.Now().Add(-time.Hour)}, &apperrors.UserUsecaseNicknameCooldown, false},
+		{"same key within cooldown", "NickName", &model.NicknameChange{ChangedAt: time.Now().Add(-time.Hour)}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := map[string]any{"nickname": tt.nickname}
+			userRepoMock := &UserRepositoryMock{}
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			userRepoMock.On("FindUserByNickname", mock.Anything, tt.nickname).Return((*model.User)(nil), nil)
+			userRepoMock.On("PatchUser", mock.Anything, user.UserID, int64(0), changes).Return(&model.User{UserID: user.UserID, Nickname: tt.nickname}, nil)
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+			nicknameRepoMock := &NicknameRepositoryMock{}
+			nicknameRepoMock.On("FindReservedNickname", mock.Anything, mock.Anything).Return((*model.ReservedNickname)(nil), &apperrors.NicknameRepoFindReservedNicknameGetDataNotFound)
+			if tt.lastChange != nil {
+				nicknameRepoMock.On("FindLastNicknameChangeByUserID", mock.Anything, user.UserID).Return(tt.lastChange, nil)
+			} else {
+				nicknameRepoMock.On("FindLastNicknameChangeByUserID", mock.Anything, user.UserID).Return((*model.NicknameChange)(nil), &apperrors.NicknameRepoFindLastNicknameChangeGetDataNotFound)
+			}
+			nicknameRepoMock.On("SaveNicknameChange", mock.Anything, mock.Anything).Return(&model.NicknameChange{}, nil)
+
+			userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), nicknameRepoMock, newAuditRepoMock(), nicknameTestPolicy, nil)
+			_, err := userusecase.PatchUser(context.TODO(), user.UserID, 0, changes)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 429)
+				userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, user.UserID, int64(0), changes)
+				return
+			}
+			assert.NilError(t, err)
+			if !tt.wantSaved {
+				nicknameRepoMock.AssertNotCalled(t, "SaveNicknameChange", mock.Anything, mock.Anything)
+				return
+			}
+			nicknameRepoMock.AssertCalled(t, "SaveNicknameChange", mock.Anything, mock.MatchedBy(func(change *model.NicknameChange) bool {
+				return change.UserID == user.UserID && change.Nickname == "nickname" && change.NicknameKey == "nickname" && change.NewNickname == "renamed"
+			}))
+		})
+	}
+}
+
+func TestNicknameUsecase_ResolveNickname(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "renamed"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByNickname", mock.Anything, "renamed").Return(user, nil)
+	userRepoMock.On("FindUserByNickname", mock.Anything, mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoFindUserByNicknameGetDataNotFound)
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	nicknameRepoMock := &NicknameRepositoryMock{}
+	nicknameRepoMock.On("FindLastNicknameChangeByKey", mock.Anything, "nickname").Return(&model.NicknameChange{UserID: user.UserID, Nickname: "nickname"}, nil)
+	nicknameRepoMock.On("FindLastNicknameChangeByKey", mock.Anything, mock.Anything).Return((*model.NicknameChange)(nil), &apperrors.NicknameRepoFindLastNicknameChangeGetDataNotFound)
+	nicknameusecase := NewNicknameUsecase(userRepoMock, nicknameRepoMock)
+
+	got, err := nicknameusecase.ResolveNickname(context.TODO(), "renamed")
+	assert.NilError(t, err)
+	assert.Equal(t, got.UserID, user.UserID)
+
+	got, err = nicknameusecase.ResolveNickname(context.TODO(), "NickName")
+	assert.NilError(t, err)
+	assert.Equal(t, got.UserID, user.UserID)
+
+	_, err = nicknameusecase.ResolveNickname(context.TODO(), "unknown")
+	assert.Assert(t, apperrors.Is(err, &apperrors.NicknameUsecaseResolveNicknameNotExist))
+}
+
+func TestNicknameUsecase_ReserveNickname(t *testing.T) {
+	nicknameRepoMock := &NicknameRepositoryMock{}
+	nicknameRepoMock.On("SaveReservedNickname", mock.Anything, mock.Anything).Return(&model.ReservedNickname{NicknameKey: "staff"}, nil)
+	nicknameusecase := NewNicknameUsecase(&UserRepositoryMock{}, nicknameRepoMock)
+
+	_, err := nicknameusecase.ReserveNickname(context.TODO(), &model.ReservedNickname{Nickname: "  "})
+	assert.Assert(t, apperrors.Is(err, &apperrors.NicknameUsecaseReserveNicknameEmpty))
+	nicknameRepoMock.AssertNotCalled(t, "SaveReservedNickname", mock.Anything, mock.Anything)
+
+	reservedNickname := &model.ReservedNickname{Nickname: " Staff ", CreatedBy: "admin"}
+	_, err = nicknameusecase.ReserveNickname(context.TODO(), reservedNickname)
+	assert.NilError(t, err)
+	assert.Equal(t, reservedNickname.Nickname, "Staff")
+	assert.Equal(t, reservedNickname.NicknameKey, "staff")
+	assert.Assert(t, !reservedNickname.CreatedAt.IsZero())
+}
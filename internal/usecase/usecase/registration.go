@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+)
+
+const (
+	registrationVerifyPath = "/register/verify"
+
+	registrationActionChallenge = "challenge"
+	registrationActionRegister  = "register"
+	registrationActionResend    = "resend"
+
+	registrationUnverifiedReason = "email not verified"
+
+	registrationVerifySubject = "Verify your email"
+	registrationVerifyBody    = `Hello %s,
+
+Open the link below to verify your email and activate your account:
+
+%s
+
+The link expires at %s. If you didn't sign up, ignore this email.
+`
+)
+
+type IRegistrationUsecase interface {
+	CreateChallenge(ctx context.Context, ip string) (*model.RegistrationChallenge, error)
+	RegisterUser(ctx context.Context, ip string, registerUser *model.RegisterUserRequest) (*model.User, error)
+	VerifyRegistration(ctx context.Context, verifyRegistration *model.VerifyRegistrationRequest) (*model.User, error)
+	ResendVerification(ctx context.Context, ip string, resendVerification *model.ResendVerificationRequest) error
+}
+
+type RegistrationUsecase struct {
+	UserRepo              repository.UserRepository
+	UserRedisRepo         repository.UserRedisRepository
+	RegistrationRedisRepo repository.RegistrationRedisRepository
+	AuditRepo             repository.AuditRepository
+	Mailer                repository.Mailer
+	UserUsecase           IUserUsecase
+	Policy                *model.RegistrationPolicy
+}
+
+func NewRegistrationUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, registrationRedisRepo repository.RegistrationRedisRepository, auditRepo repository.AuditRepository, mailer repository.Mailer, userUsecase IUserUsecase, policy *model.RegistrationPolicy) IRegistrationUsecase {
+	return &RegistrationUsecase{
+		UserRepo:              userRepo,
+		UserRedisRepo:         userRedisRepo,
+		RegistrationRedisRepo: registrationRedisRepo,
+		AuditRepo:             auditRepo,
+		Mailer:                mailer,
+		UserUsecase:           userUsecase,
+		Policy:                policy,
+	}
+}
+
+// CreateChallenge hands out a proof of work challenge, which is kept until it expires or is
+// used by a registration.
+func (rs *RegistrationUsecase) CreateChallenge(ctx context.Context, ip string) (*model.RegistrationChallenge, error) {
+	err := rs.checkEnabled()
+	if err != nil {
+		return nil, err
+	}
+	err = rs.checkIPLimit(ctx, registrationActionChallenge, ip, rs.Policy.ChallengeIPLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := utils.GenerateToken()
+	if err != nil {
+		return nil, apperrors.RegistrationUsecaseCreateChallengeGenerateToken.AppendMessage(err)
+	}
+	err = rs.RegistrationRedisRepo.SaveChallenge(ctx, challenge, rs.Policy.ChallengeTtl)
+	if err != nil {
+		return nil, apperrors.RegistrationUsecaseCreateChallengeSave.AppendMessage(err)
+	}
+
+	return &model.RegistrationChallenge{
+		Challenge:  challenge,
+		Difficulty: rs.Policy.ChallengeDifficulty,
+		ExpiresAt:  time.Now().Add(rs.Policy.ChallengeTtl),
+	}, nil
+}
+
+// RegisterUser creates a user with the default role. Every attempt counts towards the limit
+// of the IP and uses up the challenge, a rejected one included. When emails have to be
+// verified the user stays unverified, and can't log in, until the link mailed to it is opened.
+func (rs *RegistrationUsecase) RegisterUser(ctx context.Context, ip string, registerUser *model.RegisterUserRequest) (*model.User, error) {
+	err := rs.checkEnabled()
+	if err != nil {
+		return nil, err
+	}
+	err = rs.checkIPLimit(ctx, registrationActionRegister, ip, rs.Policy.IPLimit)
+	if err != nil {
+		return nil, err
+	}
+	err = rs.takeChallenge(ctx, registerUser.Challenge, registerUser.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	email := strings.TrimSpace(registerUser.Email)
+	if rs.Policy.IsEmailDomainBlocked(email) {
+		return nil, apperrors.RegistrationUsecaseEmailDomainBlocked.AppendMessage(email)
+	}
+
+	user := &model.User{
+		Nickname:  registerUser.Nickname,
+		FirstName: registerUser.FirstName,
+		LastName:  registerUser.LastName,
+		Email:     email,
+		Password:  registerUser.Password,
+	}
+	if rs.Policy.VerifyEmail {
+		// the user is inserted unverified, so there is no moment it could log in unverified
+		user.UserStatus = model.UserStatus{Status: model.UserStatusUnverified, StatusReason: registrationUnverifiedReason}
+	}
+	user, err = rs.UserUsecase.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !rs.Policy.VerifyEmail {
+		return user, nil
+	}
+
+	err = rs.sendVerification(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (rs *RegistrationUsecase) VerifyRegistration(ctx context.Context, verifyRegistration *model.VerifyRegistrationRequest) (*model.User, error) {
+	err := rs.checkEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenHash := utils.HashToken(verifyRegistration.Token)
+	userID, err := rs.RegistrationRedisRepo.FindVerification(ctx, tokenHash)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.RegistrationRedisRepoFindVerificationGetDataNotFound) {
+			return nil, apperrors.RegistrationUsecaseVerifyRegistrationNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.RegistrationUsecaseVerifyRegistrationFind.AppendMessage(err)
+	}
+	user, err := rs.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.RegistrationUsecaseVerifyRegistrationNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.RegistrationUsecaseFindUser.AppendMessage(err)
+	}
+	if user.Status != model.UserStatusUnverified {
+		return nil, apperrors.RegistrationUsecaseVerifyRegistrationNotUnverified.AppendMessage(user.Status)
+	}
+
+	verifiedUser, err := rs.setStatus(ctx, user, model.UserStatus{Status: model.UserStatusActive})
+	if err != nil {
+		return nil, err
+	}
+	err = recordAudit(ctx, rs.AuditRepo, model.AuditActionUserVerify, user.UserID, model.DiffUsers(user, verifiedUser))
+	if err != nil {
+		return nil, apperrors.RegistrationUsecaseRecordAudit.AppendMessage(err)
+	}
+	err = rs.RegistrationRedisRepo.DeleteVerification(ctx, tokenHash)
+	if err != nil {
+		return nil, apperrors.RegistrationUsecaseVerifyRegistrationDelete.AppendMessage(err)
+	}
+
+	return verifiedUser, nil
+}
+
+// ResendVerification mails a new link to an unverified user. It answers the same whether the
+// address belongs to such a user or not, so it can't be used to find out who registered.
+func (rs *RegistrationUsecase) ResendVerification(ctx context.Context, ip string, resendVerification *model.ResendVerificationRequest) error {
+	err := rs.checkEnabled()
+	if err != nil {
+		return err
+	}
+	err = rs.checkIPLimit(ctx, registrationActionResend, ip, rs.Policy.IPLimit)
+	if err != nil {
+		return err
+	}
+	err = rs.takeChallenge(ctx, resendVerification.Challenge, resendVerification.Nonce)
+	if err != nil {
+		return err
+	}
+
+	user, err := rs.UserRepo.FindUserByEmail(ctx, strings.TrimSpace(resendVerification.Email))
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByEmailGetDataNotFound) {
+			return nil
+		}
+		return apperrors.RegistrationUsecaseFindUser.AppendMessage(err)
+	}
+	if !rs.Policy.VerifyEmail || user.Status != model.UserStatusUnverified {
+		return nil
+	}
+	return rs.sendVerification(ctx, user)
+}
+
+func (rs *RegistrationUsecase) checkEnabled() error {
+	if !rs.Policy.Enabled {
+		return &apperrors.RegistrationUsecaseDisabled
+	}
+	return nil
+}
+
+func (rs *RegistrationUsecase) checkIPLimit(ctx context.Context, action string, ip string, limit int) error {
+	hits, err := rs.RegistrationRedisRepo.IncrementIPHits(ctx, action, ip, rs.Policy.IPWindow)
+	if err != nil {
+		return apperrors.RegistrationUsecaseCheckIPLimit.AppendMessage(err)
+	}
+	if hits > int64(limit) {
+		return apperrors.RegistrationUsecaseIPLimitExceeded.AppendMessage(fmt.Sprintf("%d %s requests within %s", limit, action, rs.Policy.IPWindow))
+	}
+	return nil
+}
+
+// takeChallenge checks the solution before using the challenge up, checking costs a single hash.
+func (rs *RegistrationUsecase) takeChallenge(ctx context.Context, challenge string, nonce string) error {
+	if !utils.SolvesChallenge(challenge, nonce, rs.Policy.ChallengeDifficulty) {
+		return &apperrors.RegistrationUsecaseChallengeNotSolved
+	}
+	taken, err := rs.RegistrationRedisRepo.TakeChallenge(ctx, challenge)
+	if err != nil {
+		return apperrors.RegistrationUsecaseTakeChallenge.AppendMessage(err)
+	}
+	if !taken {
+		return &apperrors.RegistrationUsecaseChallengeNotExist
+	}
+	return nil
+}
+
+func (rs *RegistrationUsecase) sendVerification(ctx context.Context, user *model.User) error {
+	token, err := utils.GenerateToken()
+	if err != nil {
+		return apperrors.RegistrationUsecaseSendVerificationGenerateToken.AppendMessage(err)
+	}
+	err = rs.RegistrationRedisRepo.SaveVerification(ctx, utils.HashToken(token), user.UserID, rs.Policy.VerifyTtl)
+	if err != nil {
+		return apperrors.RegistrationUsecaseSendVerificationSave.AppendMessage(err)
+	}
+
+	err = rs.Mailer.Send(ctx, &repository.Mail{
+		To:      user.Email,
+		Subject: registrationVerifySubject,
+		Body:    fmt.Sprintf(registrationVerifyBody, user.Nickname, rs.link(token), time.Now().Add(rs.Policy.VerifyTtl).UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return apperrors.RegistrationUsecaseSendVerificationSend.AppendMessage(err)
+	}
+	return nil
+}
+
+// setStatus stores the status and drops the cached user, which could still be the active one
+// CreateUser saved.
+func (rs *RegistrationUsecase) setStatus(ctx context.Context, user *model.User, status model.UserStatus) (*model.User, error) {
+	updatedUser, err := rs.UserRepo.PatchUser(ctx, user.UserID, 0, map[string]any{
+		"status":        status.Status,
+		"status_reason": status.StatusReason,
+		"status_by":     status.StatusBy,
+		"status_until":  status.StatusUntil,
+	})
+	if err != nil {
+		return nil, apperrors.RegistrationUsecaseSetStatusPatchUser.AppendMessage(err)
+	}
+
+	err = rs.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err == nil {
+		err = rs.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return nil, apperrors.RegistrationUsecaseSetStatusDropUserCache.AppendMessage(err)
+	}
+
+	return updatedUser, nil
+}
+
+func (rs *RegistrationUsecase) link(token string) string {
+	return strings.TrimSuffix(rs.Policy.LinkBaseURL, "/") + registrationVerifyPath + "?token=" + url.QueryEscape(token)
+}
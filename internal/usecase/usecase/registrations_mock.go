@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRegistrationTestPolicy() *model.RegistrationPolicy {
+	return &model.RegistrationPolicy{
+		Enabled:             true,
+		ChallengeDifficulty: 4,
+		ChallengeTtl:        5 * time.Minute,
+		ChallengeIPLimit:    10,
+		IPLimit:             3,
+		IPWindow:            time.Hour,
+		BlockedDomains:      []string{"blocked.test"},
+		VerifyEmail:         true,
+		VerifyTtl:           24 * time.Hour,
+		LinkBaseURL:         "https://app.test",
+	}
+}
+
+type RegistrationRedisRepositoryMock struct {
+	mock.Mock
+}
+
+func (rrm *RegistrationRedisRepositoryMock) SaveChallenge(ctx context.Context, challenge string, ttl time.Duration) error {
+	args := rrm.Called(ctx, challenge, ttl)
+	return args.Error(0)
+}
+
+func (rrm *RegistrationRedisRepositoryMock) TakeChallenge(ctx context.Context, challenge string) (bool, error) {
+	args := rrm.Called(ctx, challenge)
+	return args.Bool(0), args.Error(1)
+}
+
+func (rrm *RegistrationRedisRepositoryMock) IncrementIPHits(ctx context.Context, action string, ip string, window time.Duration) (int64, error) {
+	args := rrm.Called(ctx, action, ip, window)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (rrm *RegistrationRedisRepositoryMock) SaveVerification(ctx context.Context, tokenHash string, userID uuid.UUID, ttl time.Duration) error {
+	args := rrm.Called(ctx, tokenHash, userID, ttl)
+	return args.Error(0)
+}
+
+func (rrm *RegistrationRedisRepositoryMock) FindVerification(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	args := rrm.Called(ctx, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (rrm *RegistrationRedisRepositoryMock) DeleteVerification(ctx context.Context, tokenHash string) error {
+	args := rrm.Called(ctx, tokenHash)
+	return args.Error(0)
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var registrationTestLink = regexp.MustCompile(`https://app\.test/register/verify\?token=(\S+)`)
+
+func solveTestChallenge(t *testing.T, challenge string, difficulty int) string {
+	for i := 0; i < 1<<16; i++ {
+		if utils.SolvesChallenge(challenge, strconv.Itoa(i), difficulty) {
+			return strconv.Itoa(i)
+		}
+	}
+	t.Fatalf("no nonce solves %s", challenge)
+	return ""
+}
+
+func newRegistrationRedisRepoMock(hits int64) *RegistrationRedisRepositoryMock {
+	registrationRedisRepoMock := &RegistrationRedisRepositoryMock{}
+	registrationRedisRepoMock.On("IncrementIPHits", mock.Anything, mock.Anything, "10.0.0.1", mock.Anything).Return(hits, nil)
+	return registrationRedisRepoMock
+}
+
+func TestRegistrationUsecase_CreateChallenge(t *testing.T) {
+	registrationRedisRepoMock := newRegistrationRedisRepoMock(1)
+	registrationRedisRepoMock.On("SaveChallenge", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	policy := newRegistrationTestPolicy()
+
+	challenge, err := NewRegistrationUsecase(nil, nil, registrationRedisRepoMock, nil, nil, nil, policy).CreateChallenge(context.TODO(), "10.0.0.1")
+	assert.NilError(t, err)
+	assert.Equal(t, challenge.Difficulty, 4)
+	registrationRedisRepoMock.AssertCalled(t, "IncrementIPHits", mock.Anything, registrationActionChallenge, "10.0.0.1", policy.IPWindow)
+	registrationRedisRepoMock.AssertCalled(t, "SaveChallenge", mock.Anything, challenge.Challenge, policy.ChallengeTtl)
+}
+
+func TestRegistrationUsecase_CreateChallenge_Rejected(t *testing.T) {
+	disabledPolicy := newRegistrationTestPolicy()
+	disabledPolicy.Enabled = false
+	tests := []struct {
+		name    string
+		policy  *model.RegistrationPolicy
+		hits    int64
+		wantErr *apperrors.AppError
+	}{
+		{"disabled", disabledPolicy, 1, &apperrors.RegistrationUsecaseDisabled},
+		{"over the ip limit", newRegistrationTestPolicy(), 11, &apperrors.RegistrationUsecaseIPLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registrationRedisRepoMock := newRegistrationRedisRepoMock(tt.hits)
+
+			_, err := NewRegistrationUsecase(nil, nil, registrationRedisRepoMock, nil, nil, nil, tt.policy).CreateChallenge(context.TODO(), "10.0.0.1")
+			assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+			registrationRedisRepoMock.AssertNotCalled(t, "SaveChallenge", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegistrationUsecase_RegisterUser(t *testing.T) {
+	savedUser := &model.User{UserID: uuid.New(), Nickname: "newcomer", Email: "new@test.test", Role: model.RoleUser}
+	savedUser.Status = model.UserStatusUnverified
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByEmail", mock.Anything, "new@test.test").Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	userRepoMock.On("FindUserByNickname", mock.Anything, "newcomer").Return((*model.User)(nil), &apperrors.UserRepoFindUserByNicknameGetDataNotFound)
+	userRepoMock.On("SaveUser", mock.Anything, mock.Anything).Return(savedUser, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	registrationRedisRepoMock := newRegistrationRedisRepoMock(1)
+	registrationRedisRepoMock.On("TakeChallenge", mock.Anything, "challenge").Return(true, nil)
+	registrationRedisRepoMock.On("SaveVerification", mock.Anything, mock.Anything, savedUser.UserID, mock.Anything).Return(nil)
+	mailerMock := &MailerMock{}
+	mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+
+	registrationusecase := NewRegistrationUsecase(userRepoMock, userRedisRepoMock, registrationRedisRepoMock, nil, mailerMock, userusecase, newRegistrationTestPolicy())
+	user, err := registrationusecase.RegisterUser(context.TODO(), "10.0.0.1", &model.RegisterUserRequest{
+		Challenge: "challenge",
+		Nonce:     solveTestChallenge(t, "challenge", 4),
+		Nickname:  "newcomer",
+		Email:     " new@test.test ",
+		Password:  "secret",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, user.Status, model.UserStatusUnverified)
+	createdUser := userRepoMock.Calls[2].Arguments.Get(1).(*model.User)
+	assert.Equal(t, createdUser.Role, model.RoleUser)
+	assert.Equal(t, createdUser.Email, "new@test.test")
+	assert.Equal(t, createdUser.Status, model.UserStatusUnverified)
+	assert.Equal(t, createdUser.StatusReason, registrationUnverifiedReason)
+	userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	mail := mailerMock.Calls[0].Arguments.Get(1).(*repository.Mail)
+	assert.Equal(t, mail.To, "new@test.test")
+	match := registrationTestLink.FindStringSubmatch(mail.Body)
+	assert.Assert(t, match != nil, mail.Body)
+	token, err := url.QueryUnescape(match[1])
+	assert.NilError(t, err)
+	registrationRedisRepoMock.AssertCalled(t, "SaveVerification", mock.Anything, utils.HashToken(token), savedUser.UserID, 24*time.Hour)
+}
+
+func TestRegistrationUsecase_RegisterUser_Rejected(t *testing.T) {
+	nonce := solveTestChallenge(t, "challenge", 4)
+	unsolved := "0"
+	for utils.SolvesChallenge("challenge", unsolved, 4) {
+		unsolved += "0"
+	}
+	tests := []struct {
+		name    string
+		hits    int64
+		nonce   string
+		taken   bool
+		email   string
+		wantErr *apperrors.AppError
+	}{
+		{"over the ip limit", 4, nonce, true, "new@test.test", &apperrors.RegistrationUsecaseIPLimitExceeded},
+		{"unsolved challenge", 1, unsolved, true, "new@test.test", &apperrors.RegistrationUsecaseChallengeNotSolved},
+		{"used challenge", 1, nonce, false, "new@test.test", &apperrors.RegistrationUsecaseChallengeNotExist},
+		{"disposable domain", 1, nonce, true, "new@mailinator.com", &apperrors.RegistrationUsecaseEmailDomainBlocked},
+		{"subdomain of a blocked domain", 1, nonce, true, "new@mx.Blocked.test", &apperrors.RegistrationUsecaseEmailDomainBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			registrationRedisRepoMock := newRegistrationRedisRepoMock(tt.hits)
+			registrationRedisRepoMock.On("TakeChallenge", mock.Anything, "challenge").Return(tt.taken, nil)
+
+			registrationusecase := NewRegistrationUsecase(userRepoMock, nil, registrationRedisRepoMock, nil, nil, nil, newRegistrationTestPolicy())
+			_, err := registrationusecase.RegisterUser(context.TODO(), "10.0.0.1", &model.RegisterUserRequest{Challenge: "challenge", Nonce: tt.nonce, Nickname: "newcomer", Email: tt.email, Password: "secret"})
+			assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+			userRepoMock.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegistrationUsecase_VerifyRegistration(t *testing.T) {
+	unverifiedUser := &model.User{UserID: uuid.New(), Nickname: "newcomer"}
+	unverifiedUser.Status = model.UserStatusUnverified
+	verifiedUser := &model.User{UserID: unverifiedUser.UserID, Nickname: "newcomer"}
+	verifiedUser.Status = model.UserStatusActive
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, unverifiedUser.UserID).Return(unverifiedUser, nil).Once()
+	userRepoMock.On("FindUserByUUID", mock.Anything, unverifiedUser.UserID).Return(verifiedUser, nil)
+	userRepoMock.On("PatchUser", mock.Anything, unverifiedUser.UserID, int64(0), mock.Anything).Return(verifiedUser, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, unverifiedUser.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "newcomer").Return(nil)
+	registrationRedisRepoMock := &RegistrationRedisRepositoryMock{}
+	registrationRedisRepoMock.On("FindVerification", mock.Anything, utils.HashToken("token")).Return(unverifiedUser.UserID, nil)
+	registrationRedisRepoMock.On("DeleteVerification", mock.Anything, utils.HashToken("token")).Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	registrationusecase := NewRegistrationUsecase(userRepoMock, userRedisRepoMock, registrationRedisRepoMock, auditRepoMock, nil, nil, newRegistrationTestPolicy())
+	user, err := registrationusecase.VerifyRegistration(context.TODO(), &model.VerifyRegistrationRequest{Token: "token"})
+	assert.NilError(t, err)
+	assert.NilError(t, user.CheckActive(time.Now()))
+	changes := userRepoMock.Calls[1].Arguments.Get(3).(map[string]any)
+	assert.Equal(t, changes["status"], model.UserStatusActive)
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserVerify)
+	registrationRedisRepoMock.AssertCalled(t, "DeleteVerification", mock.Anything, utils.HashToken("token"))
+
+	_, err = registrationusecase.VerifyRegistration(context.TODO(), &model.VerifyRegistrationRequest{Token: "token"})
+	assert.Assert(t, apperrors.Is(err, &apperrors.RegistrationUsecaseVerifyRegistrationNotUnverified), err)
+}
+
+func TestRegistrationUsecase_ResendVerification_UnknownEmail(t *testing.T) {
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByEmail", mock.Anything, "nobody@test.test").Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	registrationRedisRepoMock := newRegistrationRedisRepoMock(1)
+	registrationRedisRepoMock.On("TakeChallenge", mock.Anything, "challenge").Return(true, nil)
+	mailerMock := &MailerMock{}
+
+	registrationusecase := NewRegistrationUsecase(userRepoMock, nil, registrationRedisRepoMock, nil, mailerMock, nil, newRegistrationTestPolicy())
+	err := registrationusecase.ResendVerification(context.TODO(), "10.0.0.1", &model.ResendVerificationRequest{Challenge: "challenge", Nonce: solveTestChallenge(t, "challenge", 4), Email: "nobody@test.test"})
+	assert.NilError(t, err)
+	mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestUser_CheckActive_Unverified(t *testing.T) {
+	user := &model.User{UserID: uuid.New()}
+	user.Status = model.UserStatusUnverified
+	err := user.CheckActive(time.Now())
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserCheckActiveUnverified), err)
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IRoleUsecase interface {
+	AssignRole(ctx context.Context, authUser *model.User, userID uuid.UUID, assignRole *model.AssignRoleRequest) (*model.User, *model.RoleRequest, error)
+	GetRoleRequests(ctx context.Context, status string) ([]*model.RoleRequest, error)
+	ApproveRoleRequest(ctx context.Context, authUser *model.User, requestID uuid.UUID, reason string) (*model.RoleRequest, error)
+	RejectRoleRequest(ctx context.Context, authUser *model.User, requestID uuid.UUID, reason string) (*model.RoleRequest, error)
+	GetRoleHistory(ctx context.Context, userID uuid.UUID) ([]*model.RoleHistory, error)
+}
+
+type RoleUsecase struct {
+	UserRepo              repository.UserRepository
+	UserRedisRepo         repository.UserRedisRepository
+	RoleRepo              repository.RoleRepository
+	AuditRepo             repository.AuditRepository
+	AdminApprovalRequired bool
+}
+
+func NewRoleUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, roleRepo repository.RoleRepository, auditRepo repository.AuditRepository, adminApprovalRequired bool) IRoleUsecase {
+	return &RoleUsecase{
+		UserRepo:              userRepo,
+		UserRedisRepo:         userRedisRepo,
+		RoleRepo:              roleRepo,
+		AuditRepo:             auditRepo,
+		AdminApprovalRequired: adminApprovalRequired,
+	}
+}
+
+func (rs *RoleUsecase) AssignRole(ctx context.Context, authUser *model.User, userID uuid.UUID, assignRole *model.AssignRoleRequest) (*model.User, *model.RoleRequest, error) {
+	err := authUser.HasPermissionsToAssignRole()
+	if err != nil {
+		return nil, nil, apperrors.RoleUsecaseAssignRoleHasPermissions.AppendMessage(err)
+	}
+	if !authUser.IsRoleExist(assignRole.Role) {
+		return nil, nil, apperrors.RoleUsecaseAssignRoleRoleNotExist.AppendMessage(assignRole.Role)
+	}
+	if authUser.UserID == userID {
+		return nil, nil, apperrors.RoleUsecaseAssignRoleYourself.AppendMessage(userID)
+	}
+
+	user, err := rs.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, nil, apperrors.RoleUsecaseAssignRoleUserNotExist.AppendMessage(err)
+		}
+		return nil, nil, apperrors.RoleUsecaseAssignRoleFindUserByUUID.AppendMessage(err)
+	}
+	if user.Role == assignRole.Role {
+		return nil, nil, apperrors.RoleUsecaseAssignRoleRoleAlreadySet.AppendMessage(assignRole.Role)
+	}
+
+	if assignRole.Role == model.RoleAdmin && rs.AdminApprovalRequired {
+		roleRequest := &model.RoleRequest{
+			RequestID:    uuid.New(),
+			UserID:       user.UserID,
+			Role:         assignRole.Role,
+			PreviousRole: user.Role,
+			Reason:       assignRole.Reason,
+			Status:       model.RoleRequestStatusPending,
+			RequestedBy:  authUser.UserID,
+			CreatedAt:    time.Now(),
+		}
+		roleRequest, err = rs.RoleRepo.SaveRoleRequest(ctx, roleRequest)
+		if err != nil {
+			return nil, nil, apperrors.RoleUsecaseAssignRoleSaveRoleRequest.AppendMessage(err)
+		}
+		return nil, roleRequest, nil
+	}
+
+	roleHistory := &model.RoleHistory{
+		UserID:       user.UserID,
+		PreviousRole: user.Role,
+		Role:         assignRole.Role,
+		ChangedBy:    authUser.UserID,
+		Reason:       assignRole.Reason,
+		CreatedAt:    time.Now(),
+	}
+	_, err = rs.RoleRepo.ChangeUserRole(ctx, roleHistory)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.RoleRepoChangeUserRoleEmptyRowsAffected) {
+			return nil, nil, apperrors.RoleUsecaseAssignRoleUserNotExist.AppendMessage(err)
+		}
+		return nil, nil, apperrors.RoleUsecaseAssignRoleChangeUserRole.AppendMessage(err)
+	}
+
+	previousUser := *user
+	user.Role = roleHistory.Role
+	user.UpdatedAt = &roleHistory.CreatedAt
+	err = rs.dropUserCache(ctx, user)
+	if err != nil {
+		return nil, nil, apperrors.RoleUsecaseAssignRoleDropUserCache.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, rs.AuditRepo, model.AuditActionUserRoleAssign, user.UserID, model.DiffUsers(&previousUser, user))
+	if err != nil {
+		return nil, nil, apperrors.RoleUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return user, nil, nil
+}
+
+func (rs *RoleUsecase) GetRoleRequests(ctx context.Context, status string) ([]*model.RoleRequest, error) {
+	if status == "" {
+		status = model.RoleRequestStatusPending
+	}
+
+	roleRequests, err := rs.RoleRepo.FindRoleRequestsByStatus(ctx, status)
+	if err != nil {
+		return nil, apperrors.RoleUsecaseGetRoleRequests.AppendMessage(err)
+	}
+	return roleRequests, nil
+}
+
+func (rs *RoleUsecase) ApproveRoleRequest(ctx context.Context, authUser *model.User, requestID uuid.UUID, reason string) (*model.RoleRequest, error) {
+	roleRequest, err := rs.findPendingRoleRequest(ctx, authUser, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if roleRequest.RequestedBy == authUser.UserID {
+		return nil, apperrors.RoleUsecaseApproveRoleRequestSameAdmin.AppendMessage(requestID)
+	}
+
+	user, err := rs.UserRepo.FindUserByUUID(ctx, roleRequest.UserID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.RoleUsecaseApproveRoleRequestUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.RoleUsecaseApproveRoleRequestFindUserByUUID.AppendMessage(err)
+	}
+
+	reviewedAt := time.Now()
+	roleRequest.Status = model.RoleRequestStatusApproved
+	roleRequest.ReviewedBy = &authUser.UserID
+	roleRequest.ReviewReason = reason
+	roleRequest.ReviewedAt = &reviewedAt
+	roleHistory := roleRequest.MapRoleRequestToRoleHistory(authUser.UserID)
+	roleHistory.PreviousRole = user.Role
+
+	roleRequest, err = rs.RoleRepo.ApproveRoleRequest(ctx, roleRequest, roleHistory)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.RoleRepoChangeUserRoleEmptyRowsAffected) {
+			return nil, apperrors.RoleUsecaseApproveRoleRequestUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.RoleUsecaseApproveRoleRequestApprove.AppendMessage(err)
+	}
+
+	err = rs.dropUserCache(ctx, user)
+	if err != nil {
+		return nil, apperrors.RoleUsecaseApproveRoleRequestDropUserCache.AppendMessage(err)
+	}
+
+	approvedUser := *user
+	approvedUser.Role = roleRequest.Role
+	err = recordAudit(ctx, rs.AuditRepo, model.AuditActionUserRoleApprove, user.UserID, model.DiffUsers(user, &approvedUser))
+	if err != nil {
+		return nil, apperrors.RoleUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return roleRequest, nil
+}
+
+func (rs *RoleUsecase) RejectRoleRequest(ctx context.Context, authUser *model.User, requestID uuid.UUID, reason string) (*model.RoleRequest, error) {
+	roleRequest, err := rs.findPendingRoleRequest(ctx, authUser, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewedAt := time.Now()
+	roleRequest.Status = model.RoleRequestStatusRejected
+	roleRequest.ReviewedBy = &authUser.UserID
+	roleRequest.ReviewReason = reason
+	roleRequest.ReviewedAt = &reviewedAt
+
+	roleRequest, err = rs.RoleRepo.UpdateRoleRequest(ctx, roleRequest)
+	if err != nil {
+		return nil, apperrors.RoleUsecaseRejectRoleRequestUpdateRoleRequest.AppendMessage(err)
+	}
+
+	return roleRequest, nil
+}
+
+func (rs *RoleUsecase) GetRoleHistory(ctx context.Context, userID uuid.UUID) ([]*model.RoleHistory, error) {
+	roleHistory, err := rs.RoleRepo.FindRoleHistoryByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.RoleUsecaseGetRoleHistory.AppendMessage(err)
+	}
+	return roleHistory, nil
+}
+
+func (rs *RoleUsecase) findPendingRoleRequest(ctx context.Context, authUser *model.User, requestID uuid.UUID) (*model.RoleRequest, error) {
+	err := authUser.HasPermissionsToAssignRole()
+	if err != nil {
+		return nil, apperrors.RoleUsecaseReviewRoleRequestHasPermissions.AppendMessage(err)
+	}
+
+	roleRequest, err := rs.RoleRepo.FindRoleRequestByID(ctx, requestID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.RoleRepoFindRoleRequestByIDGetDataNotFound) {
+			return nil, apperrors.RoleUsecaseReviewRoleRequestNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.RoleUsecaseReviewRoleRequestFindRoleRequestByID.AppendMessage(err)
+	}
+	if !roleRequest.IsPending() {
+		return nil, apperrors.RoleUsecaseReviewRoleRequestNotPending.AppendMessage(roleRequest.Status)
+	}
+
+	return roleRequest, nil
+}
+
+func (rs *RoleUsecase) dropUserCache(ctx context.Context, user *model.User) error {
+	err := rs.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	return rs.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IRoleGrantUsecase interface {
+	RequestRoleGrant(ctx context.Context, authUser *model.User, request *model.RequestRoleGrantRequest) (*model.RoleGrant, error)
+	ApproveRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error)
+	RejectRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error)
+	RevokeRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error)
+	GetRoleGrants(ctx context.Context, status string) ([]*model.RoleGrant, error)
+	GetUserRoleGrants(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error)
+	ApplyRoleGrants(ctx context.Context, user *model.User) (*model.RoleGrant, error)
+	RevokeExpiredRoleGrants(ctx context.Context) ([]*model.RoleGrant, error)
+}
+
+type RoleGrantUsecase struct {
+	UserRepo       repository.UserRepository
+	RoleGrantRepo  repository.RoleGrantRepository
+	EventRedisRepo repository.EventRedisRepository
+	AuditRepo      repository.AuditRepository
+	Policy         *model.RoleGrantPolicy
+}
+
+func NewRoleGrantUsecase(userRepo repository.UserRepository, roleGrantRepo repository.RoleGrantRepository, eventRedisRepo repository.EventRedisRepository, auditRepo repository.AuditRepository, policy *model.RoleGrantPolicy) IRoleGrantUsecase {
+	return &RoleGrantUsecase{
+		UserRepo:       userRepo,
+		RoleGrantRepo:  roleGrantRepo,
+		EventRedisRepo: eventRedisRepo,
+		AuditRepo:      auditRepo,
+		Policy:         policy,
+	}
+}
+
+func (rgs *RoleGrantUsecase) RequestRoleGrant(ctx context.Context, authUser *model.User, request *model.RequestRoleGrantRequest) (*model.RoleGrant, error) {
+	if !authUser.IsRoleExist(request.Role) {
+		return nil, apperrors.RoleGrantUsecaseRequestRoleGrantRoleNotExist.AppendMessage(request.Role)
+	}
+
+	user, err := rgs.UserRepo.FindUserByUUID(ctx, authUser.UserID)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRequestRoleGrantFindUserByUUID.AppendMessage(err)
+	}
+	if !user.IsRoleHigher(request.Role) {
+		return nil, apperrors.RoleGrantUsecaseRequestRoleGrantRoleNotHigher.AppendMessage(request.Role)
+	}
+
+	roleGrant := model.MapRequestRoleGrantRequestToRoleGrant(request, user.UserID)
+	if !rgs.Policy.IsDurationAllowed(roleGrant) {
+		return nil, apperrors.RoleGrantUsecaseRequestRoleGrantDuration.AppendMessage(roleGrant.Duration)
+	}
+
+	userRoleGrants, err := rgs.RoleGrantRepo.FindRoleGrantsByUserID(ctx, user.UserID)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRequestRoleGrantFindRoleGrantsByUserID.AppendMessage(err)
+	}
+	now := time.Now()
+	for _, userRoleGrant := range userRoleGrants {
+		if userRoleGrant.Role == roleGrant.Role && (userRoleGrant.IsPending() || userRoleGrant.IsActive(now)) {
+			return nil, apperrors.RoleGrantUsecaseRequestRoleGrantAlreadyExist.AppendMessage(userRoleGrant.GrantID)
+		}
+	}
+
+	if rgs.Policy.CanAutoApprove(roleGrant) {
+		roleGrant.AutoApproved = true
+		roleGrant.Activate(nil, now)
+	}
+
+	roleGrant, err = rgs.RoleGrantRepo.SaveRoleGrant(ctx, roleGrant)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRequestRoleGrantSaveRoleGrant.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, rgs.AuditRepo, model.AuditActionUserRoleGrant, roleGrant.UserID, model.DiffRoleGrant("", roleGrant))
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	if roleGrant.AutoApproved {
+		err = rgs.publish(ctx, model.EventRoleGrantActivated, roleGrant)
+		if err != nil {
+			return nil, apperrors.RoleGrantUsecaseRequestRoleGrantPublish.AppendMessage(err)
+		}
+	}
+
+	return roleGrant, nil
+}
+
+func (rgs *RoleGrantUsecase) ApproveRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error) {
+	roleGrant, err := rgs.findPendingRoleGrant(ctx, authUser, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if roleGrant.UserID == authUser.UserID {
+		return nil, apperrors.RoleGrantUsecaseApproveRoleGrantYourself.AppendMessage(grantID)
+	}
+
+	roleGrant.Activate(&authUser.UserID, time.Now())
+	roleGrant, err = rgs.RoleGrantRepo.UpdateRoleGrant(ctx, roleGrant, model.RoleGrantStatusPending)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseApproveRoleGrantUpdateRoleGrant.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, rgs.AuditRepo, model.AuditActionUserRoleGrant, roleGrant.UserID, model.DiffRoleGrant(model.RoleGrantStatusPending, roleGrant))
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	err = rgs.publish(ctx, model.EventRoleGrantActivated, roleGrant)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseApproveRoleGrantPublish.AppendMessage(err)
+	}
+
+	return roleGrant, nil
+}
+
+func (rgs *RoleGrantUsecase) RejectRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error) {
+	roleGrant, err := rgs.findPendingRoleGrant(ctx, authUser, grantID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewedAt := time.Now()
+	roleGrant.Status = model.RoleGrantStatusRejected
+	roleGrant.ReviewedBy = &authUser.UserID
+	roleGrant.ReviewedAt = &reviewedAt
+	roleGrant, err = rgs.RoleGrantRepo.UpdateRoleGrant(ctx, roleGrant, model.RoleGrantStatusPending)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRejectRoleGrantUpdateRoleGrant.AppendMessage(err)
+	}
+
+	return roleGrant, nil
+}
+
+// RevokeRoleGrant lets the grantee drop an elevation early; anyone else needs the role.assign right.
+func (rgs *RoleGrantUsecase) RevokeRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error) {
+	roleGrant, err := rgs.findRoleGrant(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if roleGrant.UserID != authUser.UserID {
+		err = authUser.HasPermissionsToAssignRole()
+		if err != nil {
+			return nil, apperrors.RoleGrantUsecaseRevokeRoleGrantHasPermissions.AppendMessage(err)
+		}
+	}
+
+	now := time.Now()
+	if !roleGrant.IsPending() && !roleGrant.IsActive(now) {
+		return nil, apperrors.RoleGrantUsecaseRevokeRoleGrantNotRevocable.AppendMessage(roleGrant.Status)
+	}
+
+	previousStatus := roleGrant.Status
+	roleGrant.Status = model.RoleGrantStatusRevoked
+	roleGrant.RevokedBy = &authUser.UserID
+	roleGrant.RevokedAt = &now
+	roleGrant, err = rgs.RoleGrantRepo.UpdateRoleGrant(ctx, roleGrant, previousStatus)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRevokeRoleGrantUpdateRoleGrant.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, rgs.AuditRepo, model.AuditActionUserRoleRevoke, roleGrant.UserID, model.DiffRoleGrant(previousStatus, roleGrant))
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	err = rgs.publish(ctx, model.EventRoleGrantRevoked, roleGrant)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRevokeRoleGrantPublish.AppendMessage(err)
+	}
+
+	return roleGrant, nil
+}
+
+func (rgs *RoleGrantUsecase) GetRoleGrants(ctx context.Context, status string) ([]*model.RoleGrant, error) {
+	if status == "" {
+		status = model.RoleGrantStatusPending
+	}
+
+	roleGrants, err := rgs.RoleGrantRepo.FindRoleGrantsByStatus(ctx, status)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseGetRoleGrants.AppendMessage(err)
+	}
+	return roleGrants, nil
+}
+
+func (rgs *RoleGrantUsecase) GetUserRoleGrants(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error) {
+	roleGrants, err := rgs.RoleGrantRepo.FindRoleGrantsByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseGetUserRoleGrants.AppendMessage(err)
+	}
+	return roleGrants, nil
+}
+
+// ApplyRoleGrants raises user.Role to the highest role granted by an active grant
+// and returns that grant, or nil when the user has no elevation.
+func (rgs *RoleGrantUsecase) ApplyRoleGrants(ctx context.Context, user *model.User) (*model.RoleGrant, error) {
+	roleGrants, err := rgs.RoleGrantRepo.FindActiveRoleGrantsByUserID(ctx, user.UserID, time.Now())
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseApplyRoleGrantsFindActiveRoleGrantsByUserID.AppendMessage(err)
+	}
+
+	var appliedRoleGrant *model.RoleGrant
+	for _, roleGrant := range roleGrants {
+		if user.IsRoleHigher(roleGrant.Role) {
+			user.ApplyRoleGrant(roleGrant)
+			appliedRoleGrant = roleGrant
+		}
+	}
+
+	return appliedRoleGrant, nil
+}
+
+func (rgs *RoleGrantUsecase) RevokeExpiredRoleGrants(ctx context.Context) ([]*model.RoleGrant, error) {
+	roleGrants, err := rgs.RoleGrantRepo.ExpireRoleGrants(ctx, time.Now())
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseRevokeExpiredRoleGrantsExpireRoleGrants.AppendMessage(err)
+	}
+
+	for _, roleGrant := range roleGrants {
+		err = rgs.publish(ctx, model.EventRoleGrantExpired, roleGrant)
+		if err != nil {
+			return roleGrants, apperrors.RoleGrantUsecaseRevokeExpiredRoleGrantsPublish.AppendMessage(err)
+		}
+	}
+
+	return roleGrants, nil
+}
+
+func (rgs *RoleGrantUsecase) findRoleGrant(ctx context.Context, grantID uuid.UUID) (*model.RoleGrant, error) {
+	roleGrant, err := rgs.RoleGrantRepo.FindRoleGrantByID(ctx, grantID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.RoleGrantRepoFindRoleGrantByIDGetDataNotFound) {
+			return nil, apperrors.RoleGrantUsecaseFindRoleGrantNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.RoleGrantUsecaseFindRoleGrantFindRoleGrantByID.AppendMessage(err)
+	}
+	return roleGrant, nil
+}
+
+func (rgs *RoleGrantUsecase) findPendingRoleGrant(ctx context.Context, authUser *model.User, grantID uuid.UUID) (*model.RoleGrant, error) {
+	err := authUser.HasPermissionsToAssignRole()
+	if err != nil {
+		return nil, apperrors.RoleGrantUsecaseReviewRoleGrantHasPermissions.AppendMessage(err)
+	}
+
+	roleGrant, err := rgs.findRoleGrant(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if !roleGrant.IsPending() {
+		return nil, apperrors.RoleGrantUsecaseReviewRoleGrantNotPending.AppendMessage(roleGrant.Status)
+	}
+
+	return roleGrant, nil
+}
+
+func (rgs *RoleGrantUsecase) publish(ctx context.Context, eventType string, roleGrant *model.RoleGrant) error {
+	return rgs.EventRedisRepo.Publish(ctx, model.NewEvent(eventType, roleGrant.UserID, roleGrant))
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleGrantRepositoryMock struct {
+	mock.Mock
+}
+
+func (rgrm *RoleGrantRepositoryMock) SaveRoleGrant(ctx context.Context, roleGrant *model.RoleGrant) (*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, roleGrant)
+	return args.Get(0).(*model.RoleGrant), args.Error(1)
+}
+
+func (rgrm *RoleGrantRepositoryMock) UpdateRoleGrant(ctx context.Context, roleGrant *model.RoleGrant, status string) (*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, roleGrant, status)
+	return args.Get(0).(*model.RoleGrant), args.Error(1)
+}
+
+func (rgrm *RoleGrantRepositoryMock) FindRoleGrantByID(ctx context.Context, grantID uuid.UUID) (*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, grantID)
+	return args.Get(0).(*model.RoleGrant), args.Error(1)
+}
+
+func (rgrm *RoleGrantRepositoryMock) FindRoleGrantsByStatus(ctx context.Context, status string) ([]*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, status)
+	return args.Get(0).([]*model.RoleGrant), args.Error(1)
+}
+
+func (rgrm *RoleGrantRepositoryMock) FindRoleGrantsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, userID)
+	return args.Get(0).([]*model.RoleGrant), args.Error(1)
+}
+
+func (rgrm *RoleGrantRepositoryMock) FindActiveRoleGrantsByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, userID, now)
+	return args.Get(0).([]*model.RoleGrant), args.Error(1)
+}
+
+func (rgrm *RoleGrantRepositoryMock) ExpireRoleGrants(ctx context.Context, now time.Time) ([]*model.RoleGrant, error) {
+	args := rgrm.Called(ctx, now)
+	return args.Get(0).([]*model.RoleGrant), args.Error(1)
+}
+
+type EventRedisRepositoryMock struct {
+	mock.Mock
+}
+
+func (errm *EventRedisRepositoryMock) Publish(ctx context.Context, event *model.Event) error {
+	args := errm.Called(ctx, event)
+	return args.Error(0)
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestRoleGrantUsecase_RequestRoleGrant(t *testing.T) {
+	policy := &model.RoleGrantPolicy{MaxDuration: 480, AutoApproveRoles: []string{model.RoleModerator}, AutoApproveMaxDuration: 60}
+	tests := []struct {
+		name       string
+		role       string
+		duration   int
+		wantStatus string
+		wantErr    *apperrors.AppError
+	}{
+		{"auto approved by policy", model.RoleModerator, 60, model.RoleGrantStatusActive, nil},
+		{"too long for auto approve", model.RoleModerator, 120, model.RoleGrantStatusPending, nil},
+		{"role not in auto approve list", model.RoleAdmin, 60, model.RoleGrantStatusPending, nil},
+		{"duration exceeds maximum", model.RoleAdmin, 600, "", &apperrors.RoleGrantUsecaseRequestRoleGrantDuration},
+		{"role not higher", model.RoleUser, 60, "", &apperrors.RoleGrantUsecaseRequestRoleGrantRoleNotHigher},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{UserID: uuid.New(), Nickname: "oncall", Role: model.RoleUser}
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			roleGrantRepoMock := &RoleGrantRepositoryMock{}
+			roleGrantRepoMock.On("FindRoleGrantsByUserID", mock.Anything, user.UserID).Return([]*model.RoleGrant{}, nil)
+			savedRoleGrant := &model.RoleGrant{}
+			roleGrantRepoMock.On("SaveRoleGrant", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				*savedRoleGrant = *args.Get(1).(*model.RoleGrant)
+			}).Return(savedRoleGrant, nil)
+			eventRedisRepoMock := &EventRedisRepositoryMock{}
+			eventRedisRepoMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+			auditRepoMock := newAuditRepoMock()
+
+			roleGrantUsecase := NewRoleGrantUsecase(userRepoMock, roleGrantRepoMock, eventRedisRepoMock, auditRepoMock, policy)
+			request := &model.RequestRoleGrantRequest{Role: tt.role, Justification: "incident on call", Duration: tt.duration}
+			got, err := roleGrantUsecase.RequestRoleGrant(context.TODO(), user, request)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got.Status, tt.wantStatus)
+			assert.Equal(t, got.AutoApproved, tt.wantStatus == model.RoleGrantStatusActive)
+			auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+				return entry.Action == model.AuditActionUserRoleGrant && entry.TargetID == user.UserID && entry.Changes["status"].After == tt.wantStatus
+			}))
+			if got.AutoApproved {
+				assert.Assert(t, got.ExpiresAt.After(time.Now()))
+				eventRedisRepoMock.AssertNumberOfCalls(t, "Publish", 1)
+			}
+		})
+	}
+}
+
+func TestRoleGrantUsecase_ApplyRoleGrants(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	user := &model.User{UserID: uuid.New(), Nickname: "oncall", Role: model.RoleUser}
+	adminGrant := &model.RoleGrant{UserID: user.UserID, Role: model.RoleAdmin, Status: model.RoleGrantStatusActive, ExpiresAt: &expiresAt}
+	moderatorGrant := &model.RoleGrant{UserID: user.UserID, Role: model.RoleModerator, Status: model.RoleGrantStatusActive, ExpiresAt: &expiresAt}
+	roleGrantRepoMock := &RoleGrantRepositoryMock{}
+	roleGrantRepoMock.On("FindActiveRoleGrantsByUserID", mock.Anything, user.UserID, mock.Anything).Return([]*model.RoleGrant{moderatorGrant, adminGrant}, nil)
+
+	roleGrantUsecase := NewRoleGrantUsecase(&UserRepositoryMock{}, roleGrantRepoMock, &EventRedisRepositoryMock{}, newAuditRepoMock(), &model.RoleGrantPolicy{})
+	got, err := roleGrantUsecase.ApplyRoleGrants(context.TODO(), user)
+	assert.NilError(t, err)
+	assert.Equal(t, got, adminGrant)
+	assert.Equal(t, user.Role, model.RoleAdmin)
+}
+
+func TestRoleGrantUsecase_RevokeExpiredRoleGrants(t *testing.T) {
+	expiredGrants := []*model.RoleGrant{
+		{GrantID: uuid.New(), UserID: uuid.New(), Role: model.RoleAdmin, Status: model.RoleGrantStatusExpired},
+		{GrantID: uuid.New(), UserID: uuid.New(), Role: model.RoleModerator, Status: model.RoleGrantStatusExpired},
+	}
+	roleGrantRepoMock := &RoleGrantRepositoryMock{}
+	roleGrantRepoMock.On("ExpireRoleGrants", mock.Anything, mock.Anything).Return(expiredGrants, nil)
+	eventRedisRepoMock := &EventRedisRepositoryMock{}
+	eventRedisRepoMock.On("Publish", mock.Anything, mock.MatchedBy(func(event *model.Event) bool {
+		return event.Type == model.EventRoleGrantExpired
+	})).Return(nil)
+
+	roleGrantUsecase := NewRoleGrantUsecase(&UserRepositoryMock{}, roleGrantRepoMock, eventRedisRepoMock, newAuditRepoMock(), &model.RoleGrantPolicy{})
+	got, err := roleGrantUsecase.RevokeExpiredRoleGrants(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, len(got), len(expiredGrants))
+	eventRedisRepoMock.AssertNumberOfCalls(t, "Publish", len(expiredGrants))
+}
+
+func TestRoleGrantUsecase_RevokeRoleGrant(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	admin := &model.User{UserID: uuid.New(), Nickname: "admin", Role: model.RoleAdmin}
+	roleGrant := &model.RoleGrant{GrantID: uuid.New(), UserID: uuid.New(), Role: model.RoleModerator, Status: model.RoleGrantStatusActive, ExpiresAt: &expiresAt}
+	roleGrantRepoMock := &RoleGrantRepositoryMock{}
+	roleGrantRepoMock.On("FindRoleGrantByID", mock.Anything, roleGrant.GrantID).Return(roleGrant, nil)
+	roleGrantRepoMock.On("UpdateRoleGrant", mock.Anything, roleGrant, model.RoleGrantStatusActive).Return(roleGrant, nil)
+	eventRedisRepoMock := &EventRedisRepositoryMock{}
+	eventRedisRepoMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	roleGrantUsecase := NewRoleGrantUsecase(&UserRepositoryMock{}, roleGrantRepoMock, eventRedisRepoMock, auditRepoMock, &model.RoleGrantPolicy{})
+	got, err := roleGrantUsecase.RevokeRoleGrant(context.TODO(), admin, roleGrant.GrantID)
+	assert.NilError(t, err)
+	assert.Equal(t, got.Status, model.RoleGrantStatusRevoked)
+	auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditActionUserRoleRevoke && entry.TargetID == roleGrant.UserID &&
+			entry.Changes["status"] == model.AuditChange{Before: model.RoleGrantStatusActive, After: model.RoleGrantStatusRevoked}
+	}))
+}
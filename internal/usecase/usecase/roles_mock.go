@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleRepositoryMock struct {
+	mock.Mock
+}
+
+func (rrm *RoleRepositoryMock) ChangeUserRole(ctx context.Context, roleHistory *model.RoleHistory) (*model.RoleHistory, error) {
+	args := rrm.Called(ctx, roleHistory)
+	return args.Get(0).(*model.RoleHistory), args.Error(1)
+}
+
+func (rrm *RoleRepositoryMock) FindRoleHistoryByUserID(ctx context.Context, userID uuid.UUID) ([]*model.RoleHistory, error) {
+	args := rrm.Called(ctx, userID)
+	return args.Get(0).([]*model.RoleHistory), args.Error(1)
+}
+
+func (rrm *RoleRepositoryMock) SaveRoleRequest(ctx context.Context, roleRequest *model.RoleRequest) (*model.RoleRequest, error) {
+	args := rrm.Called(ctx, roleRequest)
+	return args.Get(0).(*model.RoleRequest), args.Error(1)
+}
+
+func (rrm *RoleRepositoryMock) UpdateRoleRequest(ctx context.Context, roleRequest *model.RoleRequest) (*model.RoleRequest, error) {
+	args := rrm.Called(ctx, roleRequest)
+	return args.Get(0).(*model.RoleRequest), args.Error(1)
+}
+
+func (rrm *RoleRepositoryMock) ApproveRoleRequest(ctx context.Context, roleRequest *model.RoleRequest, roleHistory *model.RoleHistory) (*model.RoleRequest, error) {
+	args := rrm.Called(ctx, roleRequest, roleHistory)
+	return args.Get(0).(*model.RoleRequest), args.Error(1)
+}
+
+func (rrm *RoleRepositoryMock) FindRoleRequestByID(ctx context.Context, requestID uuid.UUID) (*model.RoleRequest, error) {
+	args := rrm.Called(ctx, requestID)
+	return args.Get(0).(*model.RoleRequest), args.Error(1)
+}
+
+func (rrm *RoleRepositoryMock) FindRoleRequestsByStatus(ctx context.Context, status string) ([]*model.RoleRequest, error) {
+	args := rrm.Called(ctx, status)
+	return args.Get(0).([]*model.RoleRequest), args.Error(1)
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestRoleUsecase_AssignRole(t *testing.T) {
+	admin := &model.User{UserID: uuid.New(), Nickname: "admin", Role: model.RoleAdmin}
+	moderator := &model.User{UserID: uuid.New(), Nickname: "moderator", Role: model.RoleModerator}
+	tests := []struct {
+		name                  string
+		authUser              *model.User
+		role                  string
+		adminApprovalRequired bool
+		wantRole              string
+		wantPending           bool
+		wantErr               *apperrors.AppError
+	}{
+		{"assign moderator", admin, model.RoleModerator, false, model.RoleModerator, false, nil},
+		{"assign admin without approval", admin, model.RoleAdmin, false, model.RoleAdmin, false, nil},
+		{"assign admin with approval", admin, model.RoleAdmin, true, "", true, nil},
+		{"assign unknown role", admin, "root", false, "", false, &apperrors.RoleUsecaseAssignRoleRoleNotExist},
+		{"assign already set role", admin, model.RoleUser, false, "", false, &apperrors.RoleUsecaseAssignRoleRoleAlreadySet},
+		{"assign without permission", moderator, model.RoleModerator, false, "", false, &apperrors.RoleUsecaseAssignRoleHasPermissions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{UserID: uuid.New(), Nickname: "nickname", Role: model.RoleUser}
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+			roleRepoMock := &RoleRepositoryMock{}
+			roleRepoMock.On("ChangeUserRole", mock.Anything, mock.Anything).Return(&model.RoleHistory{}, nil)
+			roleRepoMock.On("SaveRoleRequest", mock.Anything, mock.Anything).Return(&model.RoleRequest{Status: model.RoleRequestStatusPending}, nil)
+
+			auditRepoMock := newAuditRepoMock()
+
+			roleUsecase := NewRoleUsecase(userRepoMock, userRedisRepoMock, roleRepoMock, auditRepoMock, tt.adminApprovalRequired)
+			got, roleRequest, err := roleUsecase.AssignRole(context.TODO(), tt.authUser, user.UserID, &model.AssignRoleRequest{Role: tt.role})
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, roleRequest != nil, tt.wantPending)
+			if tt.wantPending {
+				assert.Assert(t, got == nil)
+				roleRepoMock.AssertNotCalled(t, "ChangeUserRole", mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, got.Role, tt.wantRole)
+			userRedisRepoMock.AssertExpectations(t)
+			auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+				return entry.Action == model.AuditActionUserRoleAssign && entry.TargetID == user.UserID && entry.Changes["user_role"] == model.AuditChange{Before: model.RoleUser, After: tt.wantRole}
+			}))
+		})
+	}
+}
+
+func TestRoleUsecase_AssignRole_DeletedUser(t *testing.T) {
+	admin := &model.User{UserID: uuid.New(), Nickname: "admin", Role: model.RoleAdmin}
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Role: model.RoleUser}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	roleRepoMock := &RoleRepositoryMock{}
+	roleRepoMock.On("ChangeUserRole", mock.Anything, mock.Anything).Return((*model.RoleHistory)(nil), &apperrors.RoleRepoChangeUserRoleEmptyRowsAffected)
+	auditRepoMock := newAuditRepoMock()
+
+	roleUsecase := NewRoleUsecase(userRepoMock, &UserRedisRepositoryMock{}, roleRepoMock, auditRepoMock, false)
+	_, _, err := roleUsecase.AssignRole(context.TODO(), admin, user.UserID, &model.AssignRoleRequest{Role: model.RoleModerator})
+	assert.Assert(t, apperrors.Is(err, &apperrors.RoleUsecaseAssignRoleUserNotExist), err)
+	auditRepoMock.AssertNotCalled(t, "SaveAuditEntry", mock.Anything, mock.Anything)
+}
+
+func TestRoleUsecase_ApproveRoleRequest(t *testing.T) {
+	requester := &model.User{UserID: uuid.New(), Nickname: "requester", Role: model.RoleAdmin}
+	approver := &model.User{UserID: uuid.New(), Nickname: "approver", Role: model.RoleAdmin}
+	tests := []struct {
+		name     string
+		authUser *model.User
+		status   string
+		wantErr  *apperrors.AppError
+	}{
+		{"approve by second admin", approver, model.RoleRequestStatusPending, nil},
+		{"approve by requester", requester, model.RoleRequestStatusPending, &apperrors.RoleUsecaseApproveRoleRequestSameAdmin},
+		{"approve reviewed request", approver, model.RoleRequestStatusRejected, &apperrors.RoleUsecaseReviewRoleRequestNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{UserID: uuid.New(), Nickname: "nickname", Role: model.RoleUser}
+			roleRequest := &model.RoleRequest{
+				RequestID:    uuid.New(),
+				UserID:       user.UserID,
+				Role:         model.RoleAdmin,
+				PreviousRole: model.RoleUser,
+				Status:       tt.status,
+				RequestedBy:  requester.UserID,
+			}
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+			roleRepoMock := &RoleRepositoryMock{}
+			roleRepoMock.On("FindRoleRequestByID", mock.Anything, roleRequest.RequestID).Return(roleRequest, nil)
+			roleRepoMock.On("ApproveRoleRequest", mock.Anything, roleRequest, mock.Anything).Return(roleRequest, nil)
+
+			auditRepoMock := newAuditRepoMock()
+
+			roleUsecase := NewRoleUsecase(userRepoMock, userRedisRepoMock, roleRepoMock, auditRepoMock, true)
+			got, err := roleUsecase.ApproveRoleRequest(context.TODO(), tt.authUser, roleRequest.RequestID, "")
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				roleRepoMock.AssertNotCalled(t, "ApproveRoleRequest", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got.Status, model.RoleRequestStatusApproved)
+			assert.Equal(t, *got.ReviewedBy, approver.UserID)
+			auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+				return entry.Action == model.AuditActionUserRoleApprove && entry.Changes["user_role"] == model.AuditChange{Before: model.RoleUser, After: model.RoleAdmin}
+			}))
+		})
+	}
+}
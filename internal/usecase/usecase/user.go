@@ -23,9 +23,13 @@ const (
 type IUserUsecase interface {
 	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
 	UpdateUser(ctx context.Context, user *model.User) (*model.User, error)
-	DeleteUser(ctx context.Context, userID *uuid.UUID) error
+	PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error)
+	DeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error
+	HardDeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error
+	RestoreUser(ctx context.Context, userID uuid.UUID) (*model.User, error)
 	GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error)
 	GetUsersByPaginationQuery(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error)
+	SearchUsers(ctx context.Context, viewer *model.User, query string, paginationQuery *utils.PaginationQuery) (*model.Users, error)
 	GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*model.User, error)
 	GetUserByNickname(ctx context.Context, nickname string) (*model.User, error)
@@ -40,18 +44,28 @@ type IUserUsecase interface {
 }
 
 type UserUsecase struct {
-	UserRepo      repository.UserRepository
-	UserRedisRepo repository.UserRedisRepository
-	VoteRepo      repository.VoteRepository
-	VoteRedisRepo repository.VoteRedisRepository
+	UserRepo            repository.UserRepository
+	UserRedisRepo       repository.UserRedisRepository
+	VoteRepo            repository.VoteRepository
+	VoteRedisRepo       repository.VoteRedisRepository
+	AttributeSchemaRepo repository.UserAttributeSchemaRepository
+	NicknameRepo        repository.NicknameRepository
+	AuditRepo           repository.AuditRepository
+	NicknamePolicy      *model.NicknamePolicy
+	BlobStore           repository.BlobStore
 }
 
-func NewUserUsecase(userRepo repository.UserRepository, voteRepo repository.VoteRepository, userRedisRepo repository.UserRedisRepository, voteRedisRepo repository.VoteRedisRepository) IUserUsecase {
+func NewUserUsecase(userRepo repository.UserRepository, voteRepo repository.VoteRepository, userRedisRepo repository.UserRedisRepository, voteRedisRepo repository.VoteRedisRepository, attributeSchemaRepo repository.UserAttributeSchemaRepository, nicknameRepo repository.NicknameRepository, auditRepo repository.AuditRepository, nicknamePolicy *model.NicknamePolicy, blobStore repository.BlobStore) IUserUsecase {
 	return &UserUsecase{
-		UserRepo:      userRepo,
-		VoteRepo:      voteRepo,
-		UserRedisRepo: userRedisRepo,
-		VoteRedisRepo: voteRedisRepo,
+		UserRepo:            userRepo,
+		VoteRepo:            voteRepo,
+		UserRedisRepo:       userRedisRepo,
+		VoteRedisRepo:       voteRedisRepo,
+		AttributeSchemaRepo: attributeSchemaRepo,
+		NicknameRepo:        nicknameRepo,
+		AuditRepo:           auditRepo,
+		NicknamePolicy:      nicknamePolicy,
+		BlobStore:           blobStore,
 	}
 }
 
@@ -59,7 +73,19 @@ func (us *UserUsecase) CreateUser(ctx context.Context, user *model.User) (*model
 	user.Created.At = time.Now()
 	user.UserID = uuid.New()
 	user.Role = user.GetDefaultRole()
-	err := user.HashPassword()
+	err := us.validateAttributes(ctx, user.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	err = us.checkNicknameReserved(ctx, user.Nickname)
+	if err != nil {
+		return nil, err
+	}
+	err = us.checkEmailFree(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	err = user.HashPassword()
 	if err != nil {
 		return nil, apperrors.UserUsecaseCreateUserHashPassword.AppendMessage(err)
 	}
@@ -80,18 +106,147 @@ func (us *UserUsecase) CreateUser(ctx context.Context, user *model.User) (*model
 		return nil, apperrors.UserUsecaseCreateUserSaveUser.AppendMessage(err)
 	}
 
+	err = us.recordAudit(ctx, model.AuditActionUserCreate, user.UserID, model.DiffUsers(nil, savedUser))
+	if err != nil {
+		return nil, err
+	}
+
 	return savedUser, nil
 }
 
+// UpdateUser treats user.Version as the version the caller has seen; zero overwrites unconditionally.
+// Nil attributes keep the stored ones.
 func (us *UserUsecase) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	previousUser, err := us.UserRepo.FindUserByUUID(ctx, user.UserID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserUsecaseUpdateUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserUsecaseUpdateUserFindUser.AppendMessage(err)
+	}
+
+	if user.Attributes != nil {
+		err = us.validateAttributes(ctx, user.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isEmailChange(previousUser.Email, user.Email) {
+		return nil, apperrors.UserUsecaseEmailChangeNotConfirmed.AppendMessage(user.Email)
+	}
+	nicknameChanged := isNicknameChange(previousUser.Nickname, user.Nickname)
+	if nicknameChanged {
+		err = us.checkNicknameChange(ctx, user.UserID, user.Nickname)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	updatedUser, err := us.UserRepo.UpdateUser(ctx, user)
 	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoUpdateUserVersionMismatch) {
+			return nil, apperrors.UserUsecaseUpdateUserVersionMismatch.AppendMessage(err)
+		}
+		if apperrors.Is(err, &apperrors.UserRepoUpdateUserDataNotFound) {
+			return nil, apperrors.UserUsecaseUpdateUserNotExist.AppendMessage(err)
+		}
 		return nil, apperrors.UserUsecaseUpdateUserUpdateUser.AppendMessage(err)
 	}
 
+	if nicknameChanged {
+		err = us.saveNicknameChange(ctx, previousUser, user.Nickname)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = us.recordAudit(ctx, model.AuditActionUserUpdate, user.UserID, model.DiffUsers(previousUser, updatedUser))
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.dropUserCache(ctx, previousUser)
+	if err != nil {
+		return nil, apperrors.UserUsecaseUpdateUserDropUserCache.AppendMessage(err)
+	}
+
 	return updatedUser, nil
 }
 
+// PatchUser writes only the given columns. A new password is hashed before it is stored.
+func (us *UserUsecase) PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error) {
+	user, err := us.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserUsecasePatchUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserUsecasePatchUserFindUserByUUID.AppendMessage(err)
+	}
+
+	if nickname, ok := changes["nickname"].(string); ok {
+		_, err = us.CheckUserByNickname(ctx, &model.User{UserID: userID, Nickname: nickname})
+		if err != nil {
+			if apperrors.Is(err, &apperrors.UserUsecaseCheckProfileByNickBusy) {
+				return nil, apperrors.UserUsecasePatchUserNicknameBusy.AppendMessage(nickname)
+			}
+			return nil, apperrors.UserUsecasePatchUserCheckUserByNickname.AppendMessage(err)
+		}
+		if isNicknameChange(user.Nickname, nickname) {
+			err = us.checkNicknameChange(ctx, userID, nickname)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if email, ok := changes["email"].(string); ok && isEmailChange(user.Email, email) {
+		return nil, apperrors.UserUsecaseEmailChangeNotConfirmed.AppendMessage(email)
+	}
+
+	if attributes, ok := changes["attributes"].(model.UserAttributes); ok {
+		err = us.validateAttributes(ctx, attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if password, ok := changes["password"].(string); ok {
+		passwordUser := &model.User{Password: password}
+		if err = passwordUser.HashPassword(); err != nil {
+			return nil, apperrors.UserUsecasePatchUserHashPassword.AppendMessage(err)
+		}
+		changes["password"] = passwordUser.Password
+	}
+
+	patchedUser, err := us.UserRepo.PatchUser(ctx, userID, expectedVersion, changes)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoPatchUserVersionMismatch) {
+			return nil, apperrors.UserUsecasePatchUserVersionMismatch.AppendMessage(err)
+		}
+		return nil, apperrors.UserUsecasePatchUser.AppendMessage(err)
+	}
+
+	if isNicknameChange(user.Nickname, patchedUser.Nickname) {
+		err = us.saveNicknameChange(ctx, user, patchedUser.Nickname)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = us.recordAudit(ctx, model.AuditActionUserPatch, userID, model.DiffUsers(user, patchedUser))
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.dropUserCache(ctx, user)
+	if err != nil {
+		return nil, apperrors.UserUsecasePatchUserDropUserCache.AppendMessage(err)
+	}
+
+	return patchedUser, nil
+}
+
 func (us *UserUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
 	user, err := us.UserRedisRepo.FindUserByUUID(ctx, userID)
 	if err != nil {
@@ -112,6 +267,10 @@ func (us *UserUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*model.Us
 			return nil, apperrors.UserUsecaseGetUserLoad.AppendMessage(err)
 		}
 	}
+	// A missing or soft-deleted user isn't cached, a restore would otherwise find it gone.
+	if user == nil {
+		return nil, nil
+	}
 
 	err = us.UserRedisRepo.SetFindUserByUUID(ctx, userID, user)
 	if err != nil {
@@ -135,15 +294,115 @@ func (us *UserUsecase) GetUserByID(ctx context.Context, userID uuid.UUID) (*mode
 	return user, nil
 }
 
-func (us *UserUsecase) DeleteUser(ctx context.Context, userID *uuid.UUID) error {
-	err := us.UserRepo.DeleteUserByUserID(ctx, userID)
+func (us *UserUsecase) DeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error {
+	user, err := us.UserRepo.SoftDeleteUserByUserID(ctx, *userID, expectedVersion)
 	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoSoftDeleteUserByUserIDVersionMismatch) {
+			return apperrors.UserUsecaseDeleteUserVersionMismatch.AppendMessage(err)
+		}
 		return apperrors.UserUsecaseDeleteUser.AppendMessage(err)
 	}
 
+	err = us.recordAudit(ctx, model.AuditActionUserDelete, user.UserID, model.AuditChanges{"deleted_at": {Before: nil, After: user.DeletedAt}})
+	if err != nil {
+		return err
+	}
+
+	err = us.dropUserCache(ctx, user)
+	if err != nil {
+		return apperrors.UserUsecaseDeleteUserDropUserCache.AppendMessage(err)
+	}
+
 	return nil
 }
 
+func (us *UserUsecase) HardDeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64) error {
+	user, err := us.UserRepo.FindUserByUUID(ctx, *userID)
+	if err != nil && apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+		user, err = us.UserRepo.FindDeletedUserByUUID(ctx, *userID)
+	}
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindDeletedUserByUUIDGetDataNotFound) {
+			return apperrors.UserUsecaseHardDeleteUserNotExist.AppendMessage(err)
+		}
+		return apperrors.UserUsecaseHardDeleteUserFindUser.AppendMessage(err)
+	}
+	if expectedVersion != 0 && user.Version != expectedVersion {
+		return apperrors.UserUsecaseHardDeleteUserVersionMismatch.AppendMessage(user.Version)
+	}
+
+	blobKeys, err := us.UserRepo.DeleteUserByUserID(ctx, userID)
+	if err != nil {
+		return apperrors.UserUsecaseHardDeleteUser.AppendMessage(err)
+	}
+	for _, key := range blobKeys {
+		_ = us.BlobStore.Delete(ctx, key)
+	}
+
+	err = us.recordAudit(ctx, model.AuditActionUserHardDelete, user.UserID, model.DiffUsers(user, nil))
+	if err != nil {
+		return err
+	}
+
+	err = us.dropUserCache(ctx, user)
+	if err != nil {
+		return apperrors.UserUsecaseHardDeleteUserDropUserCache.AppendMessage(err)
+	}
+
+	return nil
+}
+
+// RestoreUser brings a soft-deleted user back unless someone took the nickname meanwhile.
+func (us *UserUsecase) RestoreUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	deletedUser, err := us.UserRepo.FindDeletedUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindDeletedUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserUsecaseRestoreUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserUsecaseRestoreUserFindDeletedUserByUUID.AppendMessage(err)
+	}
+
+	_, err = us.UserRepo.FindUserByNickname(ctx, deletedUser.Nickname)
+	if err == nil {
+		return nil, apperrors.UserUsecaseRestoreUserNicknameBusy.AppendMessage(deletedUser.Nickname)
+	}
+	if !apperrors.Is(err, &apperrors.UserRepoFindUserByNicknameGetDataNotFound) {
+		return nil, apperrors.UserUsecaseRestoreUserFindUserByNickname.AppendMessage(err)
+	}
+	err = us.checkEmailFree(ctx, deletedUser.Email)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserUsecaseEmailBusy) {
+			return nil, apperrors.UserUsecaseRestoreUserEmailBusy.AppendMessage(deletedUser.Email)
+		}
+		return nil, err
+	}
+
+	restoredUser, err := us.UserRepo.RestoreUserByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.UserUsecaseRestoreUser.AppendMessage(err)
+	}
+
+	err = us.recordAudit(ctx, model.AuditActionUserRestore, userID, model.AuditChanges{"deleted_at": {Before: deletedUser.DeletedAt, After: nil}})
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.dropUserCache(ctx, restoredUser)
+	if err != nil {
+		return nil, apperrors.UserUsecaseRestoreUserDropUserCache.AppendMessage(err)
+	}
+
+	return restoredUser, nil
+}
+
+func (us *UserUsecase) dropUserCache(ctx context.Context, user *model.User) error {
+	err := us.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	return us.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+}
+
 func (us *UserUsecase) CheckUserByNickname(ctx context.Context, user *model.User) (bool, error) {
 	checkedUser, err := us.UserRepo.FindUserByNickname(ctx, user.Nickname)
 	if err != nil {
@@ -161,7 +420,7 @@ func (us *UserUsecase) CheckUserByNickname(ctx context.Context, user *model.User
 
 func (us *UserUsecase) GetUserByNickname(ctx context.Context, nickname string) (*model.User, error) {
 	user, err := us.UserRedisRepo.FindUserByNickname(ctx, nickname)
-	if err != nil {
+	if err != nil && !apperrors.Is(err, &apperrors.UserRedisRepoFindUserByNicknameGetDataNotFound) {
 		return nil, apperrors.UserUsecaseGetUserByNicknameUserRedisRepoFindUserByNickname.AppendMessage(err)
 	}
 	if user != nil {
@@ -172,6 +431,10 @@ func (us *UserUsecase) GetUserByNickname(ctx context.Context, nickname string) (
 	if err != nil {
 		return nil, apperrors.UserUsecaseGetUserByNickname.AppendMessage(err)
 	}
+	// Only the exact spelling is cached, since dropUserCache knows no other.
+	if user.Nickname != nickname {
+		return user, nil
+	}
 
 	err = us.UserRedisRepo.SetFindUserByNickname(ctx, nickname, user)
 	if err != nil {
@@ -195,6 +458,9 @@ func (us *UserUsecase) GetUsers(ctx context.Context, paginationQuery *utils.Pagi
 
 	users, err = us.UserRepo.GetUsers(ctx, paginationQuery)
 	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoGetUsersDecodeCursor) || apperrors.Is(err, &apperrors.UserRepoGetUsersBuildQuery) {
+			return nil, apperrors.UserUsecaseGetUsersInvalidQuery.AppendMessage(err)
+		}
 		return nil, apperrors.UserUsecaseGetUsers.AppendMessage(err)
 	}
 
@@ -209,6 +475,9 @@ func (us *UserUsecase) GetUsers(ctx context.Context, paginationQuery *utils.Pagi
 func (us *UserUsecase) GetUsersByPaginationQuery(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	users, err := us.GetUsers(ctx, paginationQuery)
 	if err != nil {
+		if apperrors.Is(err, &apperrors.UserUsecaseGetUsersInvalidQuery) {
+			return nil, err
+		}
 		return nil, apperrors.UserUsecaseGetUsers.AppendMessage(err)
 	}
 
@@ -220,7 +489,34 @@ func (us *UserUsecase) GetUsersByPaginationQuery(ctx context.Context, pagination
 	return users, nil
 }
 
+func (us *UserUsecase) SearchUsers(ctx context.Context, viewer *model.User, query string, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
+	searchQuery := model.NewSearchUsersQuery(viewer, query, paginationQuery)
+	if !searchQuery.IsValid() {
+		return nil, apperrors.UserUsecaseSearchUsersQueryTooShort.AppendMessage(query)
+	}
+
+	users, err := us.UserRepo.SearchUsers(ctx, searchQuery)
+	if err != nil {
+		return nil, apperrors.UserUsecaseSearchUsers.AppendMessage(err)
+	}
+
+	users.Users, err = us.LoadVotesToUsers(ctx, users)
+	if err != nil {
+		return nil, apperrors.UserUsecaseSearchUsersLoadVotesToUsers.AppendMessage(err)
+	}
+
+	return users, nil
+}
+
 func (us *UserUsecase) VoteUser(ctx context.Context, vote *model.Vote, userVote *model.UserVote) (*model.Vote, *model.UserVote, error) {
+	err := us.checkVotingUsers(ctx, vote, userVote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return us.voteUser(ctx, vote, userVote)
+}
+
+func (us *UserUsecase) voteUser(ctx context.Context, vote *model.Vote, userVote *model.UserVote) (*model.Vote, *model.UserVote, error) {
 	voteExist, userVoteExist, err := us.FindExistVoting(ctx, &userVote.UserID, &vote.CreatedUserID)
 	if err != nil {
 		return nil, nil, apperrors.UserUsecaseVoteUserFindExistVoting.AppendMessage(err)
@@ -229,11 +525,16 @@ func (us *UserUsecase) VoteUser(ctx context.Context, vote *model.Vote, userVote
 		if vote.Vote == voteExist.Vote {
 			return nil, nil, apperrors.UserUsecaseVoteUserVotingExist.AppendMessage(err)
 		}
+		previousVote := voteExist.Vote
 		voteExist.Vote = vote.Vote
 		updatedVote, err := us.VoteRepo.UpdateVote(ctx, voteExist)
 		if err != nil {
 			return nil, nil, apperrors.UserUsecaseVoteUserUpdateVote.AppendMessage(err)
 		}
+		err = us.recordAudit(ctx, model.AuditActionUserVote, userVoteExist.UserID, model.AuditChanges{"vote": {Before: previousVote, After: updatedVote.Vote}})
+		if err != nil {
+			return nil, nil, err
+		}
 
 		return updatedVote, userVoteExist, nil
 	} else {
@@ -246,12 +547,24 @@ func (us *UserUsecase) VoteUser(ctx context.Context, vote *model.Vote, userVote
 		if err != nil {
 			return nil, nil, apperrors.UserUsecaseVoteUserSaveUserVote.AppendMessage(err)
 		}
+		err = us.recordAudit(ctx, model.AuditActionUserVote, savedUserVote.UserID, model.AuditChanges{"vote": {Before: nil, After: savedVote.Vote}})
+		if err != nil {
+			return nil, nil, err
+		}
 
 		return savedVote, savedUserVote, nil
 	}
 }
 
 func (us *UserUsecase) VoteUserWithdraw(ctx context.Context, vote *model.Vote, userVote *model.UserVote) (*model.Vote, *model.UserVote, error) {
+	err := us.checkVotingUsers(ctx, vote, userVote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return us.voteUserWithdraw(ctx, vote, userVote)
+}
+
+func (us *UserUsecase) voteUserWithdraw(ctx context.Context, vote *model.Vote, userVote *model.UserVote) (*model.Vote, *model.UserVote, error) {
 	voteExist, userVoteExist, err := us.FindExistVoting(ctx, &userVote.UserID, &vote.CreatedUserID)
 	if err != nil {
 		return nil, nil, apperrors.UserUsecaseVoteUserWithdrawFindExistVoting.AppendMessage(err)
@@ -266,7 +579,10 @@ func (us *UserUsecase) VoteUserWithdraw(ctx context.Context, vote *model.Vote, u
 		if err != nil {
 			return nil, nil, apperrors.UserUsecaseVoteUserWithdrawDeleteUserVote.AppendMessage(err)
 		}
-
+		err = us.recordAudit(ctx, model.AuditActionUserVote, userVoteExist.UserID, model.AuditChanges{"vote": {Before: voteExist.Vote, After: nil}})
+		if err != nil {
+			return nil, nil, err
+		}
 	} else {
 		return nil, nil, apperrors.UserUsecaseVoteUserWithdrawVoteNotExist.AppendMessage(err)
 	}
@@ -350,21 +666,25 @@ func (us *UserUsecase) GetLastVoteForUser(ctx context.Context, userID *uuid.UUID
 }
 
 func (us *UserUsecase) Vote(ctx context.Context, vote *model.Vote, userVote *model.UserVote) (*model.Vote, *model.UserVote, error) {
+	err := us.checkVotingUsers(ctx, vote, userVote)
+	if err != nil {
+		return nil, nil, err
+	}
 	switch vote.Vote {
 	case votePositive:
-		vote, userVote, err := us.VoteUser(ctx, vote, userVote)
+		vote, userVote, err := us.voteUser(ctx, vote, userVote)
 		if err != nil {
 			return nil, nil, apperrors.UserUsecaseVotePositiveVoteUser.AppendMessage(err)
 		}
 		return vote, userVote, nil
 	case voteNegative:
-		vote, userVote, err := us.VoteUser(ctx, vote, userVote)
+		vote, userVote, err := us.voteUser(ctx, vote, userVote)
 		if err != nil {
 			return nil, nil, apperrors.UserUsecaseVoteNegativeVoteUser.AppendMessage(err)
 		}
 		return vote, userVote, nil
 	case voteWithdraw:
-		vote, userVote, err := us.VoteUserWithdraw(ctx, vote, userVote)
+		vote, userVote, err := us.voteUserWithdraw(ctx, vote, userVote)
 		if err != nil {
 			return nil, nil, apperrors.UserUsecaseVoteWithdrawVoteUser.AppendMessage(err)
 		}
@@ -374,3 +694,123 @@ func (us *UserUsecase) Vote(ctx context.Context, vote *model.Vote, userVote *mod
 		return nil, nil, apperrors.UserControllerVoteUserValueOfVoteIsNotRight.AppendMessage(appError.Error())
 	}
 }
+
+// checkVotingUsers keeps suspended and banned users from voting and from being voted on.
+func (us *UserUsecase) checkVotingUsers(ctx context.Context, vote *model.Vote, userVote *model.UserVote) error {
+	now := time.Now()
+	voter, err := us.GetUser(ctx, vote.CreatedUserID)
+	if err != nil {
+		return apperrors.UserUsecaseVoteGetUser.AppendMessage(err)
+	}
+	if voter != nil && voter.StatusAt(now) != model.UserStatusActive {
+		return apperrors.UserUsecaseVoteVoterInactive.AppendMessage(voter.StatusAt(now))
+	}
+	user, err := us.GetUser(ctx, userVote.UserID)
+	if err != nil {
+		return apperrors.UserUsecaseVoteGetUser.AppendMessage(err)
+	}
+	if user != nil && user.StatusAt(now) != model.UserStatusActive {
+		return apperrors.UserUsecaseVoteUserInactive.AppendMessage(user.StatusAt(now))
+	}
+	return nil
+}
+
+// validateAttributes checks the attributes against the schema in force. Its errors are
+// returned as they are by the callers, so a bad value stays a 400.
+func (us *UserUsecase) validateAttributes(ctx context.Context, attributes model.UserAttributes) error {
+	schema, err := us.AttributeSchemaRepo.FindSchema(ctx)
+	if err != nil {
+		return apperrors.UserUsecaseValidateAttributesFindSchema.AppendMessage(err)
+	}
+
+	err = schema.ValidateAttributes(attributes)
+	if err != nil {
+		return apperrors.UserUsecaseValidateAttributes.AppendMessage(err)
+	}
+
+	return nil
+}
+
+// isNicknameChange tells a new nickname from another spelling of the same one.
+func isNicknameChange(previousNickname string, nickname string) bool {
+	return utils.NicknameKey(previousNickname) != utils.NicknameKey(nickname)
+}
+
+// isEmailChange tells a new email from another spelling of the same one. A new email only
+// takes effect through a confirmed email change.
+func isEmailChange(previousEmail string, email string) bool {
+	return utils.EmailKey(previousEmail) != utils.EmailKey(email)
+}
+
+func (us *UserUsecase) checkEmailFree(ctx context.Context, email string) error {
+	if utils.EmailKey(email) == "" {
+		return nil
+	}
+	_, err := us.UserRepo.FindUserByEmail(ctx, email)
+	if err == nil {
+		return apperrors.UserUsecaseEmailBusy.AppendMessage(email)
+	}
+	if !apperrors.Is(err, &apperrors.UserRepoFindUserByEmailGetDataNotFound) {
+		return apperrors.UserUsecaseFindUserByEmail.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (us *UserUsecase) checkNicknameReserved(ctx context.Context, nickname string) error {
+	_, err := us.NicknameRepo.FindReservedNickname(ctx, utils.NicknameKey(nickname))
+	if err == nil {
+		return apperrors.UserUsecaseNicknameReserved.AppendMessage(nickname)
+	}
+	if !apperrors.Is(err, &apperrors.NicknameRepoFindReservedNicknameGetDataNotFound) {
+		return apperrors.UserUsecaseFindReservedNickname.AppendMessage(err)
+	}
+
+	return nil
+}
+
+// checkNicknameChange allows a user one new nickname per cooldown period, and never a reserved one.
+func (us *UserUsecase) checkNicknameChange(ctx context.Context, userID uuid.UUID, nickname string) error {
+	err := us.checkNicknameReserved(ctx, nickname)
+	if err != nil {
+		return err
+	}
+
+	lastChange, err := us.NicknameRepo.FindLastNicknameChangeByUserID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.NicknameRepoFindLastNicknameChangeGetDataNotFound) {
+			return nil
+		}
+		return apperrors.UserUsecaseFindLastNicknameChange.AppendMessage(err)
+	}
+	nextChangeAt := lastChange.ChangedAt.Add(us.NicknamePolicy.ChangeCooldown)
+	if time.Now().Before(nextChangeAt) {
+		return apperrors.UserUsecaseNicknameCooldown.AppendMessage(nextChangeAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (us *UserUsecase) saveNicknameChange(ctx context.Context, previousUser *model.User, nickname string) error {
+	_, err := us.NicknameRepo.SaveNicknameChange(ctx, &model.NicknameChange{
+		UserID:      previousUser.UserID,
+		Nickname:    previousUser.Nickname,
+		NicknameKey: utils.NicknameKey(previousUser.Nickname),
+		NewNickname: nickname,
+		ChangedAt:   time.Now(),
+	})
+	if err != nil {
+		return apperrors.UserUsecaseSaveNicknameChange.AppendMessage(err)
+	}
+
+	return nil
+}
+
+func (us *UserUsecase) recordAudit(ctx context.Context, action string, targetID uuid.UUID, changes model.AuditChanges) error {
+	err := recordAudit(ctx, us.AuditRepo, action, targetID, changes)
+	if err != nil {
+		return apperrors.UserUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return nil
+}
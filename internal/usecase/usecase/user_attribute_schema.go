@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+)
+
+type IUserAttributeSchemaUsecase interface {
+	GetSchema(ctx context.Context) (*model.UserAttributeSchema, error)
+	SetSchema(ctx context.Context, schema *model.UserAttributeSchema) (*model.UserAttributeSchema, error)
+}
+
+type UserAttributeSchemaUsecase struct {
+	AttributeSchemaRepo repository.UserAttributeSchemaRepository
+}
+
+func NewUserAttributeSchemaUsecase(attributeSchemaRepo repository.UserAttributeSchemaRepository) IUserAttributeSchemaUsecase {
+	return &UserAttributeSchemaUsecase{AttributeSchemaRepo: attributeSchemaRepo}
+}
+
+func (uas *UserAttributeSchemaUsecase) GetSchema(ctx context.Context) (*model.UserAttributeSchema, error) {
+	schema, err := uas.AttributeSchemaRepo.FindSchema(ctx)
+	if err != nil {
+		return nil, apperrors.UserAttributeSchemaUsecaseGetSchemaFindSchema.AppendMessage(err)
+	}
+
+	return schema, nil
+}
+
+// SetSchema puts a new schema in force. Stored attributes aren't checked against it,
+// each user is validated the next time their attributes are written.
+func (uas *UserAttributeSchemaUsecase) SetSchema(ctx context.Context, schema *model.UserAttributeSchema) (*model.UserAttributeSchema, error) {
+	_, err := schema.Compile()
+	if err != nil {
+		return nil, apperrors.UserAttributeSchemaUsecaseSetSchemaCompile.AppendMessage(err)
+	}
+
+	schema.CreatedAt = time.Now()
+	schema, err = uas.AttributeSchemaRepo.SaveSchema(ctx, schema)
+	if err != nil {
+		return nil, apperrors.UserAttributeSchemaUsecaseSetSchemaSaveSchema.AppendMessage(err)
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type UserAttributeSchemaRepositoryMock struct {
+	mock.Mock
+}
+
+func (uasm *UserAttributeSchemaRepositoryMock) FindSchema(ctx context.Context) (*model.UserAttributeSchema, error) {
+	args := uasm.Called(ctx)
+	return args.Get(0).(*model.UserAttributeSchema), args.Error(1)
+}
+
+func (uasm *UserAttributeSchemaRepositoryMock) SaveSchema(ctx context.Context, schema *model.UserAttributeSchema) (*model.UserAttributeSchema, error) {
+	args := uasm.Called(ctx, schema)
+	return args.Get(0).(*model.UserAttributeSchema), args.Error(1)
+}
+
+// newAttributeSchemaRepoMock serves a schema that accepts any attributes.
+func newAttributeSchemaRepoMock() *UserAttributeSchemaRepositoryMock {
+	attributeSchemaRepoMock := &UserAttributeSchemaRepositoryMock{}
+	attributeSchemaRepoMock.On("FindSchema", mock.Anything).Return(&model.UserAttributeSchema{SchemaID: 1, Schema: json.RawMessage(`{"type": "object"}`)}, nil)
+	return attributeSchemaRepoMock
+}
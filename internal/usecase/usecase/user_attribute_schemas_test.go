@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+const attributeSchemaTestSchema = `{
+	"type": "object",
+	"properties": {"team": {"type": "string", "enum": ["core", "ops"]}},
+	"additionalProperties": false
+}`
+
+func TestUserUsecase_CreateUser_Attributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes model.UserAttributes
+		wantErr    *apperrors.AppError
+	}{
+		{"matching attributes", model.UserAttributes{"team": "core"}, nil},
+		{"no attributes", nil, nil},
+		{"unknown attribute", model.UserAttributes{"shoe_size": 42.0}, &apperrors.UserUsecaseValidateAttributes},
+		{"wrong value", model.UserAttributes{"team": "sales"}, &apperrors.UserUsecaseValidateAttributes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{Nickname: "nickname", FirstName: "fname", LastName: "lname", Attributes: tt.attributes}
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByNickname", mock.Anything, user.Nickname).Return((*model.User)(nil), nil)
+			userRepoMock.On("SaveUser", mock.Anything, user).Return(user, nil)
+			attributeSchemaRepoMock := &UserAttributeSchemaRepositoryMock{}
+			attributeSchemaRepoMock.On("FindSchema", mock.Anything).Return(&model.UserAttributeSchema{Schema: json.RawMessage(attributeSchemaTestSchema)}, nil)
+
+			_, err := NewUserUsecase(userRepoMock, nil, nil, nil, attributeSchemaRepoMock, newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil).CreateUser(context.TODO(), user)
+			if tt.wantErr == nil {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Assert(t, apperrors.Is(err, tt.wantErr))
+			userRepoMock.AssertNotCalled(t, "SaveUser", mock.Anything, user)
+		})
+	}
+}
+
+func TestUserAttributeSchemaUsecase_SetSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"object schema", attributeSchemaTestSchema, false},
+		{"not an object", `{"type": "string"}`, true},
+		{"unknown keyword", `{"type": "object", "if": {}}`, true},
+		{"not json", `{"type":`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &model.UserAttributeSchema{Schema: json.RawMessage(tt.schema), CreatedBy: "admin"}
+			attributeSchemaRepoMock := &UserAttributeSchemaRepositoryMock{}
+			attributeSchemaRepoMock.On("SaveSchema", mock.Anything, schema).Return(schema, nil)
+
+			got, err := NewUserAttributeSchemaUsecase(attributeSchemaRepoMock).SetSchema(context.TODO(), schema)
+			if tt.wantErr {
+				assert.Assert(t, apperrors.Is(err, &apperrors.UserAttributeSchemaUsecaseSetSchemaCompile))
+				attributeSchemaRepoMock.AssertNotCalled(t, "SaveSchema", mock.Anything, schema)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, !got.CreatedAt.IsZero())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"strconv"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+const userAvatarKeyPrefix = "avatars/"
+
+var avatarExtensions = map[string]string{
+	utils.ImageFormatJPEG: ".jpg",
+	utils.ImageFormatPNG:  ".png",
+}
+
+type IUserAvatarUsecase interface {
+	SetAvatar(ctx context.Context, userID uuid.UUID, expectedVersion int64, data []byte) (*model.User, error)
+	DeleteAvatar(ctx context.Context, userID uuid.UUID, expectedVersion int64) (*model.User, error)
+	GetAvatarFile(ctx context.Context, path string) (*repository.Blob, error)
+}
+
+type UserAvatarUsecase struct {
+	UserRepo      repository.UserRepository
+	UserRedisRepo repository.UserRedisRepository
+	AuditRepo     repository.AuditRepository
+	BlobStore     repository.BlobStore
+	Policy        *model.UserAvatarPolicy
+}
+
+func NewUserAvatarUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, auditRepo repository.AuditRepository, blobStore repository.BlobStore, policy *model.UserAvatarPolicy) IUserAvatarUsecase {
+	return &UserAvatarUsecase{
+		UserRepo:      userRepo,
+		UserRedisRepo: userRedisRepo,
+		AuditRepo:     auditRepo,
+		BlobStore:     blobStore,
+		Policy:        policy,
+	}
+}
+
+// SetAvatar re-encodes the image, which drops its metadata, and stores it next to a square
+// thumbnail for every configured size. Each upload gets new keys, so cached URLs of the
+// previous avatar never serve the new image; the previous files are removed afterwards.
+func (uas *UserAvatarUsecase) SetAvatar(ctx context.Context, userID uuid.UUID, expectedVersion int64, data []byte) (*model.User, error) {
+	user, err := uas.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserAvatarUsecaseSetAvatarNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserAvatarUsecaseSetAvatarFindUser.AppendMessage(err)
+	}
+
+	img, format, err := utils.DecodeImage(data, uas.Policy.MaxPixels)
+	if err != nil {
+		if errors.Is(err, utils.ErrImageTooLarge) {
+			return nil, apperrors.UserAvatarUsecaseSetAvatarTooLarge.AppendMessage(err)
+		}
+		return nil, apperrors.UserAvatarUsecaseSetAvatarDecode.AppendMessage(err)
+	}
+
+	avatar, blobs, err := uas.encodeAvatar(userID, img, format)
+	if err != nil {
+		return nil, apperrors.UserAvatarUsecaseSetAvatarEncode.AppendMessage(err)
+	}
+	for i, key := range avatar.Keys {
+		err = uas.BlobStore.Put(ctx, key, blobs[i])
+		if err != nil {
+			uas.deleteBlobs(ctx, avatar.Keys[:i])
+			return nil, apperrors.UserAvatarUsecaseSetAvatarPut.AppendMessage(err)
+		}
+	}
+
+	updatedUser, err := uas.UserRepo.PatchUser(ctx, userID, expectedVersion, map[string]any{"avatar": avatar})
+	if err != nil {
+		uas.deleteBlobs(ctx, avatar.Keys)
+		if apperrors.Is(err, &apperrors.UserRepoPatchUserVersionMismatch) {
+			return nil, apperrors.UserAvatarUsecaseSetAvatarVersionMismatch.AppendMessage(err)
+		}
+		if apperrors.Is(err, &apperrors.UserRepoPatchUserDataNotFound) {
+			return nil, apperrors.UserAvatarUsecaseSetAvatarNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserAvatarUsecaseSetAvatarPatchUser.AppendMessage(err)
+	}
+	if user.Avatar != nil {
+		uas.deleteBlobs(ctx, user.Avatar.Keys)
+	}
+
+	err = uas.dropUserCache(ctx, updatedUser)
+	if err != nil {
+		return nil, apperrors.UserAvatarUsecaseSetAvatarDropUserCache.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, uas.AuditRepo, model.AuditActionUserAvatar, userID, model.DiffUsers(user, updatedUser))
+	if err != nil {
+		return nil, apperrors.UserAvatarUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return updatedUser, nil
+}
+
+func (uas *UserAvatarUsecase) DeleteAvatar(ctx context.Context, userID uuid.UUID, expectedVersion int64) (*model.User, error) {
+	user, err := uas.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserAvatarUsecaseDeleteAvatarNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserAvatarUsecaseDeleteAvatarFindUser.AppendMessage(err)
+	}
+	if user.Avatar == nil {
+		return user, nil
+	}
+
+	updatedUser, err := uas.UserRepo.PatchUser(ctx, userID, expectedVersion, map[string]any{"avatar": (*model.UserAvatar)(nil)})
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoPatchUserVersionMismatch) {
+			return nil, apperrors.UserAvatarUsecaseDeleteAvatarVersionMismatch.AppendMessage(err)
+		}
+		if apperrors.Is(err, &apperrors.UserRepoPatchUserDataNotFound) {
+			return nil, apperrors.UserAvatarUsecaseDeleteAvatarNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserAvatarUsecaseDeleteAvatarPatchUser.AppendMessage(err)
+	}
+	uas.deleteBlobs(ctx, user.Avatar.Keys)
+
+	err = uas.dropUserCache(ctx, updatedUser)
+	if err != nil {
+		return nil, apperrors.UserAvatarUsecaseDeleteAvatarDropUserCache.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, uas.AuditRepo, model.AuditActionUserAvatar, userID, model.DiffUsers(user, updatedUser))
+	if err != nil {
+		return nil, apperrors.UserAvatarUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return updatedUser, nil
+}
+
+// GetAvatarFile reads a stored avatar file by its path under /avatars/.
+func (uas *UserAvatarUsecase) GetAvatarFile(ctx context.Context, path string) (*repository.Blob, error) {
+	blob, err := uas.BlobStore.Get(ctx, userAvatarKeyPrefix+path)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.BlobStoreGetNotFound) {
+			return nil, apperrors.UserAvatarUsecaseGetAvatarFileNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserAvatarUsecaseGetAvatarFileGet.AppendMessage(err)
+	}
+
+	return blob, nil
+}
+
+// encodeAvatar builds the original, bounded to MaxSide, and the thumbnails. Keys and blobs
+// come in the same order.
+func (uas *UserAvatarUsecase) encodeAvatar(userID uuid.UUID, img image.Image, format string) (*model.UserAvatar, []*repository.Blob, error) {
+	prefix := fmt.Sprintf("%s%s/%s/", userAvatarKeyPrefix, userID, uuid.New())
+	avatar := &model.UserAvatar{Thumbnails: map[string]string{}}
+	blobs := make([]*repository.Blob, 0, len(uas.Policy.Sizes)+1)
+	add := func(name string, img image.Image) (string, error) {
+		data, encodedFormat, err := utils.EncodeImage(img, format)
+		if err != nil {
+			return "", err
+		}
+		key := prefix + name + avatarExtensions[encodedFormat]
+		avatar.Keys = append(avatar.Keys, key)
+		blobs = append(blobs, &repository.Blob{Data: data, ContentType: "image/" + encodedFormat})
+		return uas.BlobStore.URL(key), nil
+	}
+
+	var err error
+	avatar.URL, err = add("original", utils.FitImage(img, uas.Policy.MaxSide))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, size := range uas.Policy.Sizes {
+		name := strconv.Itoa(size)
+		avatar.Thumbnails[name], err = add(name, utils.SquareThumbnail(img, size))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return avatar, blobs, nil
+}
+
+// deleteBlobs removes files that are no longer referenced. It's best effort: a file left
+// behind costs storage but is never served in place of another avatar.
+func (uas *UserAvatarUsecase) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		_ = uas.BlobStore.Delete(ctx, key)
+	}
+}
+
+func (uas *UserAvatarUsecase) dropUserCache(ctx context.Context, user *model.User) error {
+	err := uas.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	return uas.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+}
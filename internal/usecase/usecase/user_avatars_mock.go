@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"usermanager/internal/interface/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BlobStoreMock struct {
+	mock.Mock
+}
+
+func (bsm *BlobStoreMock) Put(ctx context.Context, key string, blob *repository.Blob) error {
+	args := bsm.Called(ctx, key, blob)
+	return args.Error(0)
+}
+
+func (bsm *BlobStoreMock) Get(ctx context.Context, key string) (*repository.Blob, error) {
+	args := bsm.Called(ctx, key)
+	return args.Get(0).(*repository.Blob), args.Error(1)
+}
+
+func (bsm *BlobStoreMock) Delete(ctx context.Context, key string) error {
+	args := bsm.Called(ctx, key)
+	return args.Error(0)
+}
+
+// URL doesn't record the call: it's derived from the key, which is all tests check.
+func (bsm *BlobStoreMock) URL(key string) string {
+	return "/" + key
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var avatarTestPolicy = &model.UserAvatarPolicy{MaxPixels: 10000, MaxSide: 32, Sizes: []int{8, 16}}
+
+func avatarTestPNG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	assert.NilError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+func newAvatarBlobStoreMock() *BlobStoreMock {
+	blobStoreMock := &BlobStoreMock{}
+	blobStoreMock.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	blobStoreMock.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	return blobStoreMock
+}
+
+func TestUserAvatarUsecase_SetAvatar(t *testing.T) {
+	userID := uuid.New()
+	oldAvatar := &model.UserAvatar{URL: "/old", Keys: []string{"avatars/old/original.png", "avatars/old/8.png"}}
+	user := &model.User{UserID: userID, Nickname: "nickname", Version: 3, Avatar: oldAvatar}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, userID).Return(user, nil)
+	var savedAvatar *model.UserAvatar
+	userRepoMock.On("PatchUser", mock.Anything, userID, int64(3), mock.Anything).Return(&model.User{UserID: userID, Nickname: "nickname", Version: 4}, nil).
+		Run(func(args mock.Arguments) { savedAvatar = args.Get(3).(map[string]any)["avatar"].(*model.UserAvatar) })
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, userID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "nickname").Return(nil)
+	blobStoreMock := newAvatarBlobStoreMock()
+	auditRepoMock := newAuditRepoMock()
+
+	_, err := NewUserAvatarUsecase(userRepoMock, userRedisRepoMock, auditRepoMock, blobStoreMock, avatarTestPolicy).SetAvatar(context.TODO(), userID, 3, avatarTestPNG(t, 64, 40))
+	assert.NilError(t, err)
+	auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditActionUserAvatar && entry.TargetID == userID
+	}))
+
+	assert.Equal(t, len(savedAvatar.Keys), 3)
+	assert.Assert(t, strings.HasPrefix(savedAvatar.Keys[0], "avatars/"+userID.String()+"/"))
+	assert.Assert(t, strings.HasSuffix(savedAvatar.Keys[0], "/original.png"))
+	assert.Equal(t, savedAvatar.URL, "/"+savedAvatar.Keys[0])
+	assert.Equal(t, savedAvatar.Thumbnails["8"], "/"+savedAvatar.Keys[1])
+	assert.Equal(t, savedAvatar.Thumbnails["16"], "/"+savedAvatar.Keys[2])
+	for _, key := range savedAvatar.Keys {
+		blobStoreMock.AssertCalled(t, "Put", mock.Anything, key, mock.Anything)
+	}
+	for _, key := range oldAvatar.Keys {
+		blobStoreMock.AssertCalled(t, "Delete", mock.Anything, key)
+	}
+	userRedisRepoMock.AssertExpectations(t)
+}
+
+func TestUserAvatarUsecase_SetAvatar_Errors(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name     string
+		data     []byte
+		patchErr error
+		wantErr  *apperrors.AppError
+	}{
+		{"not an image", []byte("plain text"), nil, &apperrors.UserAvatarUsecaseSetAvatarDecode},
+		{"too many pixels", avatarTestPNG(t, 200, 200), nil, &apperrors.UserAvatarUsecaseSetAvatarTooLarge},
+		{"version mismatch", avatarTestPNG(t, 20, 20), &apperrors.UserRepoPatchUserVersionMismatch, &apperrors.UserAvatarUsecaseSetAvatarVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, userID).Return(&model.User{UserID: userID, Version: 1}, nil)
+			userRepoMock.On("PatchUser", mock.Anything, userID, int64(1), mock.Anything).Return((*model.User)(nil), tt.patchErr)
+			blobStoreMock := newAvatarBlobStoreMock()
+
+			_, err := NewUserAvatarUsecase(userRepoMock, &UserRedisRepositoryMock{}, newAuditRepoMock(), blobStoreMock, avatarTestPolicy).SetAvatar(context.TODO(), userID, 1, tt.data)
+			assert.Assert(t, apperrors.Is(err, tt.wantErr))
+			if tt.patchErr == nil {
+				blobStoreMock.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			blobStoreMock.AssertNumberOfCalls(t, "Put", 3)
+			blobStoreMock.AssertNumberOfCalls(t, "Delete", 3)
+		})
+	}
+}
+
+func TestUserAvatarUsecase_DeleteAvatar(t *testing.T) {
+	userID := uuid.New()
+	avatar := &model.UserAvatar{URL: "/a", Keys: []string{"avatars/a/original.jpg", "avatars/a/64.jpg"}}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, userID).Return(&model.User{UserID: userID, Nickname: "nickname", Version: 2, Avatar: avatar}, nil)
+	userRepoMock.On("PatchUser", mock.Anything, userID, int64(2), map[string]any{"avatar": (*model.UserAvatar)(nil)}).Return(&model.User{UserID: userID, Nickname: "nickname", Version: 3}, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, userID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "nickname").Return(nil)
+	blobStoreMock := newAvatarBlobStoreMock()
+
+	user, err := NewUserAvatarUsecase(userRepoMock, userRedisRepoMock, newAuditRepoMock(), blobStoreMock, avatarTestPolicy).DeleteAvatar(context.TODO(), userID, 2)
+	assert.NilError(t, err)
+	assert.Assert(t, user.Avatar == nil)
+	for _, key := range avatar.Keys {
+		blobStoreMock.AssertCalled(t, "Delete", mock.Anything, key)
+	}
+}
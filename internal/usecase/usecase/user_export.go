@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+)
+
+type IUserExportUsecase interface {
+	ExportUsers(ctx context.Context, exportQuery *model.UserExportQuery, w io.Writer) (int, error)
+}
+
+type UserExportUsecase struct {
+	UserRepo repository.UserRepository
+}
+
+func NewUserExportUsecase(userRepo repository.UserRepository) IUserExportUsecase {
+	return &UserExportUsecase{UserRepo: userRepo}
+}
+
+// ExportUsers writes the listed users to w as they are read from the database and
+// returns how many were written.
+func (ues *UserExportUsecase) ExportUsers(ctx context.Context, exportQuery *model.UserExportQuery, w io.Writer) (int, error) {
+	exportWriter := model.NewUserExportWriter(w, exportQuery)
+	exported := 0
+	err := ues.UserRepo.StreamUsers(ctx, exportQuery, func(user *model.User) error {
+		if err := exportWriter.Write(user); err != nil {
+			return apperrors.UserExportUsecaseExportUsersWrite.AppendMessage(err)
+		}
+		exported++
+		return nil
+	})
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserExportUsecaseExportUsersWrite) {
+			return exported, err
+		}
+		return exported, apperrors.UserExportUsecaseExportUsersStreamUsers.AppendMessage(err)
+	}
+
+	err = exportWriter.Close()
+	if err != nil {
+		return exported, apperrors.UserExportUsecaseExportUsersClose.AppendMessage(err)
+	}
+
+	return exported, nil
+}
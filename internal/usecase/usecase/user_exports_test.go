@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func exportTestUsers() []*model.User {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*model.User{
+		{UserID: uuid.New(), Nickname: "jdoe", FirstName: "John", LastName: "Doe", Password: "hash", IsPublic: true, Created: model.Created{At: createdAt}, Votes: []*model.Vote{{VoteID: 7, Vote: 1}}},
+		{UserID: uuid.New(), Nickname: "asmith", FirstName: "Anna", LastName: "Smith, Jr.", Password: "hash", Created: model.Created{At: createdAt}, Votes: []*model.Vote{}},
+	}
+}
+
+func TestUserExportUsecase_ExportUsers(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		fields string
+		want   string
+	}{
+		{"csv", model.UserExportFormatCSV, "nickname,last_name,created_at", "nickname,last_name,created_at\njdoe,Doe,2024-01-02T03:04:05Z\nasmith,\"Smith, Jr.\",2024-01-02T03:04:05Z\n"},
+		{"ndjson", model.UserExportFormatNDJSON, "nickname,is_public,updated_at", `{"nickname":"jdoe","is_public":true,"updated_at":null}` + "\n" + `{"nickname":"asmith","is_public":false,"updated_at":null}` + "\n"},
+		{"ndjson with votes", model.UserExportFormatNDJSON, "nickname,votes", `{"nickname":"jdoe","votes":[{"vote_id":7,"vote":1,"created_user_id":"00000000-0000-0000-0000-000000000000"}]}` + "\n" + `{"nickname":"asmith","votes":[]}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportQuery, err := model.NewUserExportQuery(tt.format, tt.fields, nil)
+			assert.NilError(t, err)
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("StreamUsers", mock.Anything, exportQuery).Return(exportTestUsers(), nil)
+
+			var buf bytes.Buffer
+			exported, err := NewUserExportUsecase(userRepoMock).ExportUsers(context.TODO(), exportQuery, &buf)
+			assert.NilError(t, err)
+			assert.Equal(t, exported, 2)
+			assert.Equal(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestUserExportUsecase_ExportUsers_DefaultFields(t *testing.T) {
+	exportQuery, err := model.NewUserExportQuery(model.UserExportFormatCSV, "", nil)
+	assert.NilError(t, err)
+	assert.Assert(t, !exportQuery.WithVotes())
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("StreamUsers", mock.Anything, exportQuery).Return(exportTestUsers(), nil)
+
+	var buf bytes.Buffer
+	_, err = NewUserExportUsecase(userRepoMock).ExportUsers(context.TODO(), exportQuery, &buf)
+	assert.NilError(t, err)
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	assert.Equal(t, header, "user_id,nickname,first_name,last_name,email,is_public,user_role,created_by,created_at,updated_at,login_date,version,attributes")
+	assert.Assert(t, !strings.Contains(buf.String(), "hash"))
+
+	_, err = model.NewUserExportQuery(model.UserExportFormatCSV, "nickname,password", nil)
+	assert.ErrorIs(t, err, model.ErrUserExportField)
+}
+
+func TestUserExportUsecase_ExportUsers_StreamUsersError(t *testing.T) {
+	exportQuery, err := model.NewUserExportQuery(model.UserExportFormatParquet, "nickname", nil)
+	assert.NilError(t, err)
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("StreamUsers", mock.Anything, exportQuery).Return([]*model.User{}, apperrors.UserRepoStreamUsersQueryxContext.AppendMessage(fmt.Errorf("connection refused")))
+
+	_, err = NewUserExportUsecase(userRepoMock).ExportUsers(context.TODO(), exportQuery, &bytes.Buffer{})
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserExportUsecaseExportUsersStreamUsers))
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var hierarchyTestPolicy = &model.UserHierarchyPolicy{MaxDepth: 4, OnDelete: model.HierarchyPolicyBlock, OnSuspend: model.HierarchyPolicyCascade}
+
+func TestUserHierarchyUsecase_SetUserParent(t *testing.T) {
+	owner := &model.User{UserID: uuid.New(), Nickname: "owner", Role: model.RoleUser}
+	newOwner := &model.User{UserID: uuid.New(), Nickname: "new owner", Role: model.RoleUser}
+	child := &model.User{UserID: uuid.New(), Nickname: "child", Role: model.RoleUser, ParentID: &owner.UserID}
+	transferredChild := &model.User{UserID: child.UserID, Nickname: "child", Role: model.RoleUser, ParentID: &newOwner.UserID}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, child.UserID).Return(child, nil)
+	userRepoMock.On("FindUserByUUID", mock.Anything, newOwner.UserID).Return(newOwner, nil)
+	userRepoMock.On("GetUserAncestors", mock.Anything, child.UserID, 4).Return([]*model.UserTreeNode{{User: *owner, Depth: 1}}, nil)
+	userRepoMock.On("GetUserAncestors", mock.Anything, newOwner.UserID, 4).Return([]*model.UserTreeNode{{User: *owner, Depth: 1}}, nil)
+	userRepoMock.On("GetUserDescendants", mock.Anything, child.UserID, 4).Return([]*model.UserTreeNode{}, nil)
+	userRepoMock.On("PatchUser", mock.Anything, child.UserID, int64(0), map[string]any{"parent_id": &newOwner.UserID}).Return(transferredChild, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, child.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "child").Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	userhierarchyusecase := NewUserHierarchyUsecase(userRepoMock, userRedisRepoMock, auditRepoMock, nil, hierarchyTestPolicy)
+	updatedUser, err := userhierarchyusecase.SetUserParent(context.TODO(), child.UserID, &newOwner.UserID, owner)
+	assert.NilError(t, err)
+	assert.Equal(t, *updatedUser.ParentID, newOwner.UserID)
+	userRedisRepoMock.AssertExpectations(t)
+
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserTransfer)
+	assert.DeepEqual(t, entry.Changes["parent_id"], model.AuditChange{Before: &owner.UserID, After: &newOwner.UserID})
+}
+
+func TestUserHierarchyUsecase_SetUserParent_Errors(t *testing.T) {
+	admin := &model.User{UserID: uuid.New(), Role: model.RoleAdmin}
+	user := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	grandchild := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	stranger := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	tests := []struct {
+		name     string
+		parentID *uuid.UUID
+		actor    *model.User
+		wantErr  *apperrors.AppError
+	}{
+		{"the user leaves its parent", nil, user, &apperrors.UserHierarchyUsecaseNotOwner},
+		{"a stranger takes the user", &stranger.UserID, stranger, &apperrors.UserHierarchyUsecaseNotOwner},
+		{"under itself", &user.UserID, admin, &apperrors.UserHierarchyUsecaseSetUserParentCycle},
+		{"under its grandchild", &grandchild.UserID, admin, &apperrors.UserHierarchyUsecaseSetUserParentCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, mock.Anything).Return(user, nil)
+			userRepoMock.On("GetUserAncestors", mock.Anything, user.UserID, 4).Return([]*model.UserTreeNode{{User: *admin, Depth: 1}}, nil)
+			userRepoMock.On("GetUserAncestors", mock.Anything, grandchild.UserID, 4).Return([]*model.UserTreeNode{{User: model.User{UserID: uuid.New()}, Depth: 1}, {User: *user, Depth: 2}}, nil)
+
+			userhierarchyusecase := NewUserHierarchyUsecase(userRepoMock, nil, nil, nil, hierarchyTestPolicy)
+			_, err := userhierarchyusecase.SetUserParent(context.TODO(), user.UserID, tt.parentID, tt.actor)
+			assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+			userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserHierarchyUsecase_CreateUserChild_TooDeep(t *testing.T) {
+	parent := &model.User{UserID: uuid.New()}
+	ancestors := []*model.UserTreeNode{{Depth: 1}, {Depth: 2}, {Depth: 3}, {Depth: 4}}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, parent.UserID).Return(parent, nil)
+	userRepoMock.On("GetUserAncestors", mock.Anything, parent.UserID, 4).Return(ancestors, nil)
+
+	userhierarchyusecase := NewUserHierarchyUsecase(userRepoMock, nil, nil, nil, hierarchyTestPolicy)
+	_, err := userhierarchyusecase.CreateUserChild(context.TODO(), parent.UserID, &model.User{Nickname: "child"})
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserHierarchyUsecaseTooDeep), err)
+}
+
+func TestUserHierarchyUsecase_DeleteUser(t *testing.T) {
+	parent := &model.User{UserID: uuid.New(), Nickname: "parent"}
+	child := &model.User{UserID: uuid.New(), Nickname: "child"}
+	grandchild := &model.User{UserID: uuid.New(), Nickname: "grandchild"}
+	descendants := []*model.UserTreeNode{{User: *child, Depth: 1}, {User: *grandchild, Depth: 2}}
+
+	t.Run("blocked", func(t *testing.T) {
+		userRepoMock := &UserRepositoryMock{}
+		userRepoMock.On("GetUserDescendants", mock.Anything, parent.UserID, 4).Return(descendants, nil)
+
+		userhierarchyusecase := NewUserHierarchyUsecase(userRepoMock, nil, nil, nil, hierarchyTestPolicy)
+		err := userhierarchyusecase.DeleteUser(context.TODO(), &parent.UserID, 0, false)
+		assert.Assert(t, apperrors.Is(err, &apperrors.UserHierarchyUsecaseDeleteUserHasChildren), err)
+		userRepoMock.AssertNotCalled(t, "SoftDeleteUserByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		var deleted []uuid.UUID
+		userRepoMock := &UserRepositoryMock{}
+		userRepoMock.On("GetUserDescendants", mock.Anything, parent.UserID, 4).Return(descendants, nil)
+		userRedisRepoMock := &UserRedisRepositoryMock{}
+		for _, user := range []*model.User{parent, child, grandchild} {
+			userRepoMock.On("SoftDeleteUserByUserID", mock.Anything, user.UserID, int64(0)).Return(user, nil).Run(func(args mock.Arguments) {
+				deleted = append(deleted, args.Get(1).(uuid.UUID))
+			})
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+		}
+		userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+		cascadePolicy := &model.UserHierarchyPolicy{MaxDepth: 4, OnDelete: model.HierarchyPolicyCascade}
+
+		userhierarchyusecase := NewUserHierarchyUsecase(userRepoMock, userRedisRepoMock, nil, userusecase, cascadePolicy)
+		err := userhierarchyusecase.DeleteUser(context.TODO(), &parent.UserID, 0, false)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, deleted, []uuid.UUID{grandchild.UserID, child.UserID, parent.UserID})
+	})
+}
+
+func TestUserStatusUsecase_SuspendUser_Children(t *testing.T) {
+	moderator := &model.User{UserID: uuid.New(), Role: model.RoleModerator}
+	parent := &model.User{UserID: uuid.New(), Nickname: "parent", Role: model.RoleUser}
+	child := &model.User{UserID: uuid.New(), Nickname: "child", Role: model.RoleUser}
+	banned := &model.User{UserID: uuid.New(), Nickname: "banned", Role: model.RoleUser, UserStatus: model.UserStatus{Status: model.UserStatusBanned}}
+	descendants := []*model.UserTreeNode{{User: *child, Depth: 1}, {User: *banned, Depth: 1}}
+
+	t.Run("blocked", func(t *testing.T) {
+		userRepoMock := &UserRepositoryMock{}
+		userRepoMock.On("FindUserByUUID", mock.Anything, parent.UserID).Return(parent, nil)
+		userRepoMock.On("GetUserDescendants", mock.Anything, parent.UserID, 4).Return(descendants, nil)
+		blockPolicy := &model.UserHierarchyPolicy{MaxDepth: 4, OnSuspend: model.HierarchyPolicyBlock}
+
+		_, err := NewUserStatusUsecase(userRepoMock, nil, nil, blockPolicy).SuspendUser(context.TODO(), parent.UserID, &model.SuspendUserRequest{Reason: "spam"}, moderator)
+		assert.Assert(t, apperrors.Is(err, &apperrors.UserStatusUsecaseSuspendUserHasChildren), err)
+		userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		userRepoMock := &UserRepositoryMock{}
+		userRepoMock.On("FindUserByUUID", mock.Anything, parent.UserID).Return(parent, nil)
+		userRepoMock.On("GetUserDescendants", mock.Anything, parent.UserID, 4).Return(descendants, nil)
+		userRepoMock.On("PatchUser", mock.Anything, mock.Anything, int64(0), mock.Anything).Return(&model.User{UserStatus: model.UserStatus{Status: model.UserStatusSuspended}}, nil)
+		userRedisRepoMock := &UserRedisRepositoryMock{}
+		userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, mock.Anything).Return(nil)
+		userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := NewUserStatusUsecase(userRepoMock, userRedisRepoMock, newAuditRepoMock(), hierarchyTestPolicy).SuspendUser(context.TODO(), parent.UserID, &model.SuspendUserRequest{Reason: "spam"}, moderator)
+		assert.NilError(t, err)
+		userRepoMock.AssertCalled(t, "PatchUser", mock.Anything, child.UserID, int64(0), mock.Anything)
+		userRepoMock.AssertCalled(t, "PatchUser", mock.Anything, parent.UserID, int64(0), mock.Anything)
+		userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, banned.UserID, int64(0), mock.Anything)
+	})
+}
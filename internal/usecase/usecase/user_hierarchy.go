@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IUserHierarchyUsecase interface {
+	GetUserChildren(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error)
+	GetUserDescendants(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error)
+	GetUserAncestors(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error)
+	IsUserAncestor(ctx context.Context, ancestorID uuid.UUID, userID uuid.UUID) (bool, error)
+	CreateUserChild(ctx context.Context, parentID uuid.UUID, user *model.User) (*model.User, error)
+	SetUserParent(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID, actor *model.User) (*model.User, error)
+	DeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64, hard bool) error
+}
+
+type UserHierarchyUsecase struct {
+	UserRepo        repository.UserRepository
+	UserRedisRepo   repository.UserRedisRepository
+	AuditRepo       repository.AuditRepository
+	UserUsecase     IUserUsecase
+	HierarchyPolicy *model.UserHierarchyPolicy
+}
+
+func NewUserHierarchyUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, auditRepo repository.AuditRepository, userUsecase IUserUsecase, hierarchyPolicy *model.UserHierarchyPolicy) IUserHierarchyUsecase {
+	return &UserHierarchyUsecase{
+		UserRepo:        userRepo,
+		UserRedisRepo:   userRedisRepo,
+		AuditRepo:       auditRepo,
+		UserUsecase:     userUsecase,
+		HierarchyPolicy: hierarchyPolicy,
+	}
+}
+
+func (hs *UserHierarchyUsecase) GetUserChildren(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	_, err := hs.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	children, err := hs.UserRepo.GetUserDescendants(ctx, userID, 1)
+	if err != nil {
+		return nil, apperrors.UserHierarchyUsecaseGetUserDescendants.AppendMessage(err)
+	}
+	return children, nil
+}
+
+func (hs *UserHierarchyUsecase) GetUserDescendants(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	_, err := hs.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return hs.getDescendants(ctx, userID)
+}
+
+func (hs *UserHierarchyUsecase) GetUserAncestors(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	_, err := hs.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return hs.getAncestors(ctx, userID)
+}
+
+// IsUserAncestor tells whether the user is owned by ancestorID, directly or further up. A user
+// isn't its own ancestor.
+func (hs *UserHierarchyUsecase) IsUserAncestor(ctx context.Context, ancestorID uuid.UUID, userID uuid.UUID) (bool, error) {
+	if ancestorID == userID {
+		return false, nil
+	}
+	ancestors, err := hs.getAncestors(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.UserID == ancestorID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateUserChild creates the user as a child account of parentID.
+func (hs *UserHierarchyUsecase) CreateUserChild(ctx context.Context, parentID uuid.UUID, user *model.User) (*model.User, error) {
+	_, err := hs.findUser(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	ancestors, err := hs.getAncestors(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ancestors)+1 > hs.HierarchyPolicy.MaxDepth {
+		return nil, apperrors.UserHierarchyUsecaseTooDeep.AppendMessage(hs.HierarchyPolicy.MaxDepth)
+	}
+
+	user.ParentID = &parentID
+	return hs.UserUsecase.CreateUser(ctx, user)
+}
+
+// SetUserParent transfers the user, with its own children, to parentID or detaches it when
+// parentID is nil. The actor must be allowed to manage both the user and the new parent, and
+// a user can't be moved under itself or one of its descendants.
+func (hs *UserHierarchyUsecase) SetUserParent(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID, actor *model.User) (*model.User, error) {
+	user, err := hs.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = hs.checkManages(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		if *parentID == userID {
+			return nil, apperrors.UserHierarchyUsecaseSetUserParentCycle.AppendMessage(userID)
+		}
+		_, err = hs.findUser(ctx, *parentID)
+		if err != nil {
+			return nil, err
+		}
+		if actor.UserID != *parentID {
+			err = hs.checkManages(ctx, actor, *parentID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ancestors, err := hs.getAncestors(ctx, *parentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ancestor := range ancestors {
+			if ancestor.UserID == userID {
+				return nil, apperrors.UserHierarchyUsecaseSetUserParentCycle.AppendMessage(*parentID)
+			}
+		}
+		descendants, err := hs.getDescendants(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		height := 0
+		for _, descendant := range descendants {
+			if descendant.Depth > height {
+				height = descendant.Depth
+			}
+		}
+		if len(ancestors)+1+height > hs.HierarchyPolicy.MaxDepth {
+			return nil, apperrors.UserHierarchyUsecaseTooDeep.AppendMessage(hs.HierarchyPolicy.MaxDepth)
+		}
+	}
+
+	updatedUser, err := hs.UserRepo.PatchUser(ctx, userID, 0, map[string]any{"parent_id": parentID})
+	if err != nil {
+		return nil, apperrors.UserHierarchyUsecaseSetUserParentPatchUser.AppendMessage(err)
+	}
+
+	err = hs.UserRedisRepo.DeleteFindUserByUUID(ctx, userID)
+	if err == nil {
+		err = hs.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return nil, apperrors.UserHierarchyUsecaseSetUserParentDropUserCache.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, hs.AuditRepo, model.AuditActionUserTransfer, userID, model.DiffUsers(user, updatedUser))
+	if err != nil {
+		return nil, apperrors.UserHierarchyUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return updatedUser, nil
+}
+
+// DeleteUser deletes the user the way the hierarchy policy wants: refused while it owns
+// children, or together with all of them, the deepest first.
+func (hs *UserHierarchyUsecase) DeleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64, hard bool) error {
+	descendants, err := hs.getDescendants(ctx, *userID)
+	if err != nil {
+		return err
+	}
+
+	if len(descendants) > 0 {
+		if hs.HierarchyPolicy.OnDelete != model.HierarchyPolicyCascade {
+			return apperrors.UserHierarchyUsecaseDeleteUserHasChildren.AppendMessage(len(descendants))
+		}
+		// the version is checked before the children go, the delete below checks it again
+		if expectedVersion != 0 {
+			user, err := hs.findUser(ctx, *userID)
+			if err != nil {
+				return err
+			}
+			if user.Version != expectedVersion {
+				return apperrors.UserUsecaseDeleteUserVersionMismatch.AppendMessage(user.Version)
+			}
+		}
+		for i := len(descendants) - 1; i >= 0; i-- {
+			err = hs.deleteUser(ctx, &descendants[i].UserID, 0, hard)
+			if err != nil {
+				return apperrors.UserHierarchyUsecaseDeleteUserDescendant.AppendMessage(err)
+			}
+		}
+	}
+
+	return hs.deleteUser(ctx, userID, expectedVersion, hard)
+}
+
+func (hs *UserHierarchyUsecase) deleteUser(ctx context.Context, userID *uuid.UUID, expectedVersion int64, hard bool) error {
+	if hard {
+		return hs.UserUsecase.HardDeleteUser(ctx, userID, expectedVersion)
+	}
+	return hs.UserUsecase.DeleteUser(ctx, userID, expectedVersion)
+}
+
+// checkManages lets admins and the ancestors of the user through.
+func (hs *UserHierarchyUsecase) checkManages(ctx context.Context, actor *model.User, userID uuid.UUID) error {
+	if actor.HasPermissionsToUpdateUser() == nil {
+		return nil
+	}
+	isAncestor, err := hs.IsUserAncestor(ctx, actor.UserID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAncestor {
+		return apperrors.UserHierarchyUsecaseNotOwner.AppendMessage(userID)
+	}
+	return nil
+}
+
+func (hs *UserHierarchyUsecase) getAncestors(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	ancestors, err := hs.UserRepo.GetUserAncestors(ctx, userID, hs.HierarchyPolicy.MaxDepth)
+	if err != nil {
+		return nil, apperrors.UserHierarchyUsecaseGetUserAncestors.AppendMessage(err)
+	}
+	return ancestors, nil
+}
+
+func (hs *UserHierarchyUsecase) getDescendants(ctx context.Context, userID uuid.UUID) ([]*model.UserTreeNode, error) {
+	descendants, err := hs.UserRepo.GetUserDescendants(ctx, userID, hs.HierarchyPolicy.MaxDepth)
+	if err != nil {
+		return nil, apperrors.UserHierarchyUsecaseGetUserDescendants.AppendMessage(err)
+	}
+	return descendants, nil
+}
+
+func (hs *UserHierarchyUsecase) findUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := hs.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserHierarchyUsecaseFindUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserHierarchyUsecaseFindUser.AppendMessage(err)
+	}
+	return user, nil
+}
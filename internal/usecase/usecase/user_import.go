@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+	"usermanager/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// userImportProgressEvery is how many rows are imported between two progress saves.
+const userImportProgressEvery = 100
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type IUserImportUsecase interface {
+	CreateImportJob(ctx context.Context, job *model.UserImportJob, payload []byte) (*model.UserImportJob, error)
+	GetImportJob(ctx context.Context, jobID uuid.UUID) (*model.UserImportJob, error)
+	RunNextImportJob(ctx context.Context) (*model.UserImportJob, error)
+	RunImportJob(ctx context.Context, job *model.UserImportJob, payload []byte) (*model.UserImportJob, error)
+}
+
+type UserImportUsecase struct {
+	UserRepo            repository.UserRepository
+	UserRedisRepo       repository.UserRedisRepository
+	UserImportRedisRepo repository.UserImportRedisRepository
+	NicknameRepo        repository.NicknameRepository
+	AttributeSchemaRepo repository.UserAttributeSchemaRepository
+	AuditRepo           repository.AuditRepository
+	Validator           Validator
+	Policy              *model.UserImportPolicy
+}
+
+func NewUserImportUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, userImportRedisRepo repository.UserImportRedisRepository, nicknameRepo repository.NicknameRepository, attributeSchemaRepo repository.UserAttributeSchemaRepository, auditRepo repository.AuditRepository, validator Validator, policy *model.UserImportPolicy) IUserImportUsecase {
+	return &UserImportUsecase{
+		UserRepo:            userRepo,
+		UserRedisRepo:       userRedisRepo,
+		UserImportRedisRepo: userImportRedisRepo,
+		NicknameRepo:        nicknameRepo,
+		AttributeSchemaRepo: attributeSchemaRepo,
+		AuditRepo:           auditRepo,
+		Validator:           validator,
+		Policy:              policy,
+	}
+}
+
+// CreateImportJob checks that the file can be read and queues it for the import worker.
+func (uis *UserImportUsecase) CreateImportJob(ctx context.Context, job *model.UserImportJob, payload []byte) (*model.UserImportJob, error) {
+	rows, err := model.ParseUserImportRows(job.Format, payload, uis.Policy.MaxRows)
+	if err != nil {
+		return nil, apperrors.UserImportUsecaseCreateImportJobParse.AppendMessage(err)
+	}
+	job.Total = len(rows)
+	job.KeepAuditContext(ctx)
+
+	err = uis.UserImportRedisRepo.EnqueueJob(ctx, job, payload)
+	if err != nil {
+		return nil, apperrors.UserImportUsecaseCreateImportJobEnqueueJob.AppendMessage(err)
+	}
+
+	return job, nil
+}
+
+func (uis *UserImportUsecase) GetImportJob(ctx context.Context, jobID uuid.UUID) (*model.UserImportJob, error) {
+	job, err := uis.UserImportRedisRepo.FindJob(ctx, jobID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserImportRedisRepoFindJobGetDataNotFound) {
+			return nil, apperrors.UserImportUsecaseGetImportJobNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserImportUsecaseGetImportJobFindJob.AppendMessage(err)
+	}
+
+	return job, nil
+}
+
+// RunNextImportJob runs the oldest queued job. It returns nil when there is nothing to run.
+func (uis *UserImportUsecase) RunNextImportJob(ctx context.Context) (*model.UserImportJob, error) {
+	job, payload, err := uis.UserImportRedisRepo.DequeueJob(ctx)
+	if err != nil {
+		return nil, apperrors.UserImportUsecaseRunNextImportJobDequeueJob.AppendMessage(err)
+	}
+	if job == nil {
+		return nil, nil
+	}
+
+	return uis.RunImportJob(ctx, job, payload)
+}
+
+// RunImportJob imports the rows one by one; a bad row is reported and doesn't stop the job.
+// Progress is saved every userImportProgressEvery rows so it can be polled.
+func (uis *UserImportUsecase) RunImportJob(ctx context.Context, job *model.UserImportJob, payload []byte) (*model.UserImportJob, error) {
+	ctx = job.AuditContext(ctx)
+	startedAt := time.Now()
+	job.Status = model.UserImportStatusRunning
+	job.StartedAt = &startedAt
+	job.Report = []*model.UserImportRowResult{}
+
+	rows, err := model.ParseUserImportRows(job.Format, payload, uis.Policy.MaxRows)
+	if err != nil {
+		job.Error = err.Error()
+		if saveErr := uis.finishImportJob(ctx, job, model.UserImportStatusFailed); saveErr != nil {
+			return nil, apperrors.UserImportUsecaseRunImportJobSaveJob.AppendMessage(saveErr)
+		}
+		return job, apperrors.UserImportUsecaseRunImportJobParse.AppendMessage(err)
+	}
+	job.Total = len(rows)
+	err = uis.UserImportRedisRepo.SaveJob(ctx, job)
+	if err != nil {
+		return nil, apperrors.UserImportUsecaseRunImportJobSaveJob.AppendMessage(err)
+	}
+
+	nicknameLines, emailLines := map[string]int{}, map[string]int{}
+	for i, row := range rows {
+		job.AddResult(uis.importRow(ctx, job, row, nicknameLines, emailLines))
+		if (i+1)%userImportProgressEvery != 0 {
+			continue
+		}
+		err = uis.UserImportRedisRepo.SaveJob(ctx, job)
+		if err != nil {
+			return nil, apperrors.UserImportUsecaseRunImportJobSaveJob.AppendMessage(err)
+		}
+	}
+
+	err = uis.finishImportJob(ctx, job, model.UserImportStatusCompleted)
+	if err != nil {
+		return nil, apperrors.UserImportUsecaseRunImportJobSaveJob.AppendMessage(err)
+	}
+
+	return job, nil
+}
+
+func (uis *UserImportUsecase) finishImportJob(ctx context.Context, job *model.UserImportJob, status string) error {
+	finishedAt := time.Now()
+	job.Status = status
+	job.FinishedAt = &finishedAt
+
+	return uis.UserImportRedisRepo.SaveJob(ctx, job)
+}
+
+// importRow creates the user of a row, or updates the user with the same nickname when the
+// job upserts. Nothing is written on a dry run, the result only tells what would happen.
+// An updated user keeps the stored email, which only changes through a confirmed email change.
+func (uis *UserImportUsecase) importRow(ctx context.Context, job *model.UserImportJob, row *model.UserImportRow, nicknameLines map[string]int, emailLines map[string]int) *model.UserImportRowResult {
+	result := &model.UserImportRowResult{Line: row.Line, Nickname: row.Nickname}
+	fail := func(appError *apperrors.AppError) *model.UserImportRowResult {
+		result.Status = model.UserImportRowFailed
+		result.Error = appError.Error()
+		return result
+	}
+
+	if row.ParseError != "" {
+		return fail(apperrors.UserImportUsecaseRowParse.AppendMessage(row.ParseError))
+	}
+	nicknameKey := utils.NicknameKey(row.Nickname)
+	if line, ok := nicknameLines[nicknameKey]; ok {
+		return fail(apperrors.UserImportUsecaseRowNicknameRepeated.AppendMessage(line))
+	}
+	nicknameLines[nicknameKey] = row.Line
+
+	user := row.MapUserImportRowToUserModel()
+	user.Role = user.GetDefaultRole()
+	existingUser, err := uis.UserRepo.FindUserByNickname(ctx, row.Nickname)
+	if err != nil && !apperrors.Is(err, &apperrors.UserRepoFindUserByNicknameGetDataNotFound) {
+		return fail(apperrors.UserImportUsecaseRowFindUserByNickname.AppendMessage(err))
+	}
+	if existingUser != nil && !job.Options.Upsert {
+		return fail(apperrors.UserImportUsecaseRowNicknameBusy.AppendMessage(row.Nickname))
+	}
+	if existingUser == nil {
+		_, err = uis.NicknameRepo.FindReservedNickname(ctx, nicknameKey)
+		if err == nil {
+			return fail(apperrors.UserImportUsecaseRowNicknameReserved.AppendMessage(row.Nickname))
+		}
+		if !apperrors.Is(err, &apperrors.NicknameRepoFindReservedNicknameGetDataNotFound) {
+			return fail(apperrors.UserImportUsecaseRowFindReservedNickname.AppendMessage(err))
+		}
+
+		emailKey := utils.EmailKey(row.Email)
+		if line, ok := emailLines[emailKey]; ok && emailKey != "" {
+			return fail(apperrors.UserImportUsecaseRowEmailRepeated.AppendMessage(line))
+		}
+		emailLines[emailKey] = row.Line
+		_, err = uis.UserRepo.FindUserByEmail(ctx, row.Email)
+		if err == nil {
+			return fail(apperrors.UserImportUsecaseRowEmailBusy.AppendMessage(row.Email))
+		}
+		if !apperrors.Is(err, &apperrors.UserRepoFindUserByEmailGetDataNotFound) {
+			return fail(apperrors.UserImportUsecaseRowFindUserByEmail.AppendMessage(err))
+		}
+	}
+
+	generatedPassword := ""
+	if existingUser == nil && user.Password == "" {
+		if !job.Options.GeneratePasswords {
+			return fail(&apperrors.UserImportUsecaseRowPasswordRequired)
+		}
+		generatedPassword, err = utils.GeneratePassword(uis.Policy.PasswordLength)
+		if err != nil {
+			return fail(apperrors.UserImportUsecaseRowGeneratePassword.AppendMessage(err))
+		}
+		user.Password = generatedPassword
+	}
+	if existingUser != nil {
+		// The row may spell the nickname differently; the stored spelling stays.
+		user.Nickname = existingUser.Nickname
+		user.Email = existingUser.Email
+		user.Role = existingUser.Role
+	}
+	err = uis.Validator.Validate(user)
+	if err != nil {
+		return fail(apperrors.UserImportUsecaseRowValidate.AppendMessage(err))
+	}
+	if existingUser == nil {
+		// Rows carry no attributes, an updated user keeps the stored ones.
+		schema, err := uis.AttributeSchemaRepo.FindSchema(ctx)
+		if err != nil {
+			return fail(apperrors.UserImportUsecaseRowFindSchema.AppendMessage(err))
+		}
+		err = schema.ValidateAttributes(user.Attributes)
+		if err != nil {
+			return fail(apperrors.UserImportUsecaseRowAttributes.AppendMessage(err))
+		}
+	}
+
+	result.Status = model.UserImportRowCreated
+	if existingUser != nil {
+		result.Status = model.UserImportRowUpdated
+		result.UserID = &existingUser.UserID
+	}
+	if job.Options.DryRun {
+		return result
+	}
+
+	if user.Password == "" {
+		user.Password = existingUser.Password
+	} else if err = user.HashPassword(); err != nil {
+		return fail(apperrors.UserImportUsecaseRowHashPassword.AppendMessage(err))
+	}
+
+	if existingUser == nil {
+		user.UserID = uuid.New()
+		user.Created.At = time.Now()
+		user.Created.By = job.CreatedBy
+		savedUser, err := uis.UserRepo.SaveUser(ctx, user)
+		if err != nil {
+			return fail(apperrors.UserImportUsecaseRowSaveUser.AppendMessage(err))
+		}
+		result.UserID = &user.UserID
+		result.Password = generatedPassword
+
+		err = recordAudit(ctx, uis.AuditRepo, model.AuditActionUserCreate, user.UserID, model.DiffUsers(nil, savedUser))
+		if err != nil {
+			return fail(apperrors.UserImportUsecaseRowRecordAudit.AppendMessage(err))
+		}
+		return result
+	}
+
+	updatedAt := time.Now()
+	user.UserID = existingUser.UserID
+	user.Created = existingUser.Created
+	user.LoginDate = existingUser.LoginDate
+	user.UpdatedAt = &updatedAt
+	user.Version = existingUser.Version
+	updatedUser, err := uis.UserRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return fail(apperrors.UserImportUsecaseRowUpdateUser.AppendMessage(err))
+	}
+	err = uis.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err == nil {
+		err = uis.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return fail(apperrors.UserImportUsecaseRowDropUserCache.AppendMessage(err))
+	}
+
+	err = recordAudit(ctx, uis.AuditRepo, model.AuditActionUserUpdate, user.UserID, model.DiffUsers(existingUser, updatedUser))
+	if err != nil {
+		return fail(apperrors.UserImportUsecaseRowRecordAudit.AppendMessage(err))
+	}
+
+	return result
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserImportRedisRepositoryMock struct {
+	mock.Mock
+}
+
+func (uirm *UserImportRedisRepositoryMock) SaveJob(ctx context.Context, job *model.UserImportJob) error {
+	args := uirm.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (uirm *UserImportRedisRepositoryMock) FindJob(ctx context.Context, jobID uuid.UUID) (*model.UserImportJob, error) {
+	args := uirm.Called(ctx, jobID)
+	return args.Get(0).(*model.UserImportJob), args.Error(1)
+}
+
+func (uirm *UserImportRedisRepositoryMock) EnqueueJob(ctx context.Context, job *model.UserImportJob, payload []byte) error {
+	args := uirm.Called(ctx, job, payload)
+	return args.Error(0)
+}
+
+func (uirm *UserImportRedisRepositoryMock) DequeueJob(ctx context.Context) (*model.UserImportJob, []byte, error) {
+	args := uirm.Called(ctx)
+	return args.Get(0).(*model.UserImportJob), args.Get(1).([]byte), args.Error(2)
+}
+
+type structValidator struct {
+	validator *validator.Validate
+}
+
+func (sv *structValidator) Validate(i interface{}) error {
+	return sv.validator.Struct(i)
+}
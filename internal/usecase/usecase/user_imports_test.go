@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+var userImportPolicy = &model.UserImportPolicy{MaxRows: 100, PasswordLength: 12}
+
+func newUserImportUsecase(userRepoMock *UserRepositoryMock, userRedisRepoMock *UserRedisRepositoryMock, userImportRedisRepoMock *UserImportRedisRepositoryMock) IUserImportUsecase {
+	return NewUserImportUsecase(userRepoMock, userRedisRepoMock, userImportRedisRepoMock, newNicknameRepoMock(), newAttributeSchemaRepoMock(), newAuditRepoMock(), &structValidator{validator: validator.New()}, userImportPolicy)
+}
+
+func TestUserImportUsecase_RunImportJob(t *testing.T) {
+	payload := []byte("nickname,first_name,last_name,email,password\n" +
+		"jdoe,John,Doe,jdoe@test.test,password\n" +
+		"asmith,Anna,Smith,asmith@test.test,\n" +
+		"taken,Tom,Taken,taken@test.test,password\n" +
+		"jdoe,Jane,Doe,jane@test.test,password\n" +
+		"bad,Bad,Email,not-an-email,password\n" +
+		"short,Only,Two\n")
+	notFound := apperrors.UserRepoFindUserByNicknameGetDataNotFound.AppendMessage(fmt.Errorf("no rows"))
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByNickname", mock.Anything, "taken").Return(&model.User{UserID: uuid.New(), Nickname: "taken"}, nil)
+	userRepoMock.On("FindUserByNickname", mock.Anything, mock.Anything).Return((*model.User)(nil), notFound)
+	userRepoMock.On("FindUserByEmail", mock.Anything, mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	userRepoMock.On("SaveUser", mock.Anything, mock.Anything).Return(&model.User{}, nil)
+	userImportRedisRepoMock := &UserImportRedisRepositoryMock{}
+	userImportRedisRepoMock.On("SaveJob", mock.Anything, mock.Anything).Return(nil)
+
+	userImportUsecase := newUserImportUsecase(userRepoMock, &UserRedisRepositoryMock{}, userImportRedisRepoMock)
+	job := model.NewUserImportJob(model.UserImportFormatCSV, model.UserImportOptions{GeneratePasswords: true}, "")
+	job, err := userImportUsecase.RunImportJob(context.TODO(), job, payload)
+	assert.NilError(t, err)
+	assert.Equal(t, job.Status, model.UserImportStatusCompleted)
+	assert.Equal(t, job.Total, 6)
+	assert.Equal(t, job.Succeeded, 2)
+	assert.Equal(t, job.Failed, 4)
+
+	wantErrors := []*apperrors.AppError{
+		nil,
+		nil,
+		&apperrors.UserImportUsecaseRowNicknameBusy,
+		&apperrors.UserImportUsecaseRowNicknameRepeated,
+		&apperrors.UserImportUsecaseRowValidate,
+		&apperrors.UserImportUsecaseRowParse,
+	}
+	for i, result := range job.Report {
+		assert.Equal(t, result.Line, i+2)
+		if wantErrors[i] == nil {
+			assert.Equal(t, result.Status, model.UserImportRowCreated)
+			assert.Assert(t, result.UserID != nil)
+			continue
+		}
+		assert.Equal(t, result.Status, model.UserImportRowFailed)
+		assert.Assert(t, len(result.Error) > len(wantErrors[i].Code) && result.Error[:len(wantErrors[i].Code)] == wantErrors[i].Code, result.Error)
+	}
+	assert.Equal(t, job.Report[0].Password, "")
+	assert.Equal(t, len(job.Report[1].Password), userImportPolicy.PasswordLength)
+	userRepoMock.AssertNumberOfCalls(t, "SaveUser", 2)
+}
+
+func TestUserImportUsecase_RunImportJob_UpsertDryRun(t *testing.T) {
+	payload := []byte(`{"nickname":"taken","first_name":"Tom","last_name":"Taken","email":"taken@test.test"}` + "\n")
+	existingUser := &model.User{UserID: uuid.New(), Nickname: "taken", Email: "tom@test.test", Role: model.RoleModerator}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByNickname", mock.Anything, "taken").Return(existingUser, nil)
+	userImportRedisRepoMock := &UserImportRedisRepositoryMock{}
+	userImportRedisRepoMock.On("SaveJob", mock.Anything, mock.Anything).Return(nil)
+
+	userImportUsecase := newUserImportUsecase(userRepoMock, &UserRedisRepositoryMock{}, userImportRedisRepoMock)
+	job := model.NewUserImportJob(model.UserImportFormatNDJSON, model.UserImportOptions{DryRun: true, Upsert: true}, "")
+	job, err := userImportUsecase.RunImportJob(context.TODO(), job, payload)
+	assert.NilError(t, err)
+	assert.Equal(t, job.Succeeded, 1)
+	assert.Equal(t, job.Report[0].Status, model.UserImportRowUpdated)
+	assert.Equal(t, *job.Report[0].UserID, existingUser.UserID)
+	userRepoMock.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestUserImportUsecase_CreateImportJob(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		payload   string
+		wantTotal int
+		wantErr   *apperrors.AppError
+	}{
+		{"csv", model.UserImportFormatCSV, "nickname,first_name,last_name\njdoe,John,Doe\n", 1, nil},
+		{"ndjson", model.UserImportFormatNDJSON, "{\"nickname\":\"jdoe\"}\n\n{\"nickname\":\"asmith\"}\n", 2, nil},
+		{"csv without header", model.UserImportFormatCSV, "jdoe,John,Doe\n", 0, &apperrors.UserImportUsecaseCreateImportJobParse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userImportRedisRepoMock := &UserImportRedisRepositoryMock{}
+			userImportRedisRepoMock.On("EnqueueJob", mock.Anything, mock.Anything, []byte(tt.payload)).Return(nil)
+
+			userImportUsecase := newUserImportUsecase(&UserRepositoryMock{}, &UserRedisRepositoryMock{}, userImportRedisRepoMock)
+			job, err := userImportUsecase.CreateImportJob(context.TODO(), model.NewUserImportJob(tt.format, model.UserImportOptions{}, ""), []byte(tt.payload))
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				userImportRedisRepoMock.AssertNotCalled(t, "EnqueueJob", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, job.Total, tt.wantTotal)
+			assert.Equal(t, job.Status, model.UserImportStatusQueued)
+		})
+	}
+}
+
+func TestUserImportUsecase_RunImportJob_Audit(t *testing.T) {
+	payload := []byte("nickname,first_name,last_name,email,password\njdoe,John,Doe,jdoe@test.test,password\n")
+	admin := &model.User{UserID: uuid.New(), Nickname: "admin"}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByNickname", mock.Anything, mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoFindUserByNicknameGetDataNotFound)
+	userRepoMock.On("FindUserByEmail", mock.Anything, mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	userRepoMock.On("SaveUser", mock.Anything, mock.Anything).Return(&model.User{Nickname: "jdoe"}, nil)
+	userImportRedisRepoMock := &UserImportRedisRepositoryMock{}
+	userImportRedisRepoMock.On("EnqueueJob", mock.Anything, mock.Anything, payload).Return(nil)
+	userImportRedisRepoMock.On("SaveJob", mock.Anything, mock.Anything).Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	userImportUsecase := NewUserImportUsecase(userRepoMock, &UserRedisRepositoryMock{}, userImportRedisRepoMock, newNicknameRepoMock(), newAttributeSchemaRepoMock(), auditRepoMock, &structValidator{validator: validator.New()}, userImportPolicy)
+	ctx := model.ContextWithAuditRequest(model.ContextWithAuditActor(context.TODO(), admin), &model.AuditRequest{IP: "10.0.0.1", RequestID: "request"})
+	job, err := userImportUsecase.CreateImportJob(ctx, model.NewUserImportJob(model.UserImportFormatCSV, model.UserImportOptions{}, admin.UserID.String()), payload)
+	assert.NilError(t, err)
+
+	// The worker runs the job without the request context.
+	job, err = userImportUsecase.RunImportJob(context.TODO(), job, payload)
+	assert.NilError(t, err)
+	assert.Equal(t, job.Succeeded, 1)
+	auditRepoMock.AssertCalled(t, "SaveAuditEntry", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditActionUserCreate && *entry.ActorID == admin.UserID && entry.ActorNickname == admin.Nickname &&
+			entry.IP == "10.0.0.1" && entry.RequestID == "request" && entry.Changes["nickname"].After == "jdoe"
+	}))
+}
+
+func TestUserImportUsecase_RunImportJob_Attributes(t *testing.T) {
+	payload := []byte("nickname,first_name,last_name,email,password\njdoe,John,Doe,jdoe@test.test,password\n")
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByNickname", mock.Anything, mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoFindUserByNicknameGetDataNotFound)
+	userRepoMock.On("FindUserByEmail", mock.Anything, mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoFindUserByEmailGetDataNotFound)
+	userImportRedisRepoMock := &UserImportRedisRepositoryMock{}
+	userImportRedisRepoMock.On("SaveJob", mock.Anything, mock.Anything).Return(nil)
+	attributeSchemaRepoMock := &UserAttributeSchemaRepositoryMock{}
+	attributeSchemaRepoMock.On("FindSchema", mock.Anything).Return(&model.UserAttributeSchema{SchemaID: 1, Schema: json.RawMessage(`{"type": "object", "required": ["department"]}`)}, nil)
+
+	userImportUsecase := NewUserImportUsecase(userRepoMock, &UserRedisRepositoryMock{}, userImportRedisRepoMock, newNicknameRepoMock(), attributeSchemaRepoMock, newAuditRepoMock(), &structValidator{validator: validator.New()}, userImportPolicy)
+	job, err := userImportUsecase.RunImportJob(context.TODO(), model.NewUserImportJob(model.UserImportFormatCSV, model.UserImportOptions{}, ""), payload)
+	assert.NilError(t, err)
+	assert.Equal(t, job.Failed, 1)
+	assert.Assert(t, len(job.Report[0].Error) > 0 && job.Report[0].Error[:len(apperrors.UserImportUsecaseRowAttributes.Code)] == apperrors.UserImportUsecaseRowAttributes.Code, job.Report[0].Error)
+	userRepoMock.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/interface/repository"
+)
+
+type IUserRetentionUsecase interface {
+	PurgeDeletedUsers(ctx context.Context) (int64, error)
+}
+
+type UserRetentionUsecase struct {
+	UserRepo  repository.UserRepository
+	BlobStore repository.BlobStore
+	Retention time.Duration
+}
+
+func NewUserRetentionUsecase(userRepo repository.UserRepository, blobStore repository.BlobStore, retention time.Duration) IUserRetentionUsecase {
+	return &UserRetentionUsecase{
+		UserRepo:  userRepo,
+		BlobStore: blobStore,
+		Retention: retention,
+	}
+}
+
+func (urs *UserRetentionUsecase) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().Add(-urs.Retention)
+	purged, blobKeys, err := urs.UserRepo.PurgeDeletedUsers(ctx, deletedBefore)
+	if err != nil {
+		return 0, apperrors.UserRetentionUsecasePurgeDeletedUsers.AppendMessage(err)
+	}
+	for _, key := range blobKeys {
+		_ = urs.BlobStore.Delete(ctx, key)
+	}
+
+	return purged, nil
+}
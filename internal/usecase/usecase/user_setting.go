@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IUserSettingUsecase interface {
+	GetUserSettings(ctx context.Context, userID uuid.UUID) (*model.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any) (*model.UserSettings, error)
+}
+
+type UserSettingUsecase struct {
+	UserRepo             repository.UserRepository
+	UserSettingRepo      repository.UserSettingRepository
+	UserSettingRedisRepo repository.UserSettingRedisRepository
+	AuditRepo            repository.AuditRepository
+}
+
+func NewUserSettingUsecase(userRepo repository.UserRepository, userSettingRepo repository.UserSettingRepository, userSettingRedisRepo repository.UserSettingRedisRepository, auditRepo repository.AuditRepository) IUserSettingUsecase {
+	return &UserSettingUsecase{
+		UserRepo:             userRepo,
+		UserSettingRepo:      userSettingRepo,
+		UserSettingRedisRepo: userSettingRedisRepo,
+		AuditRepo:            auditRepo,
+	}
+}
+
+func (ss *UserSettingUsecase) GetUserSettings(ctx context.Context, userID uuid.UUID) (*model.UserSettings, error) {
+	err := ss.checkUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := ss.getStoredSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewUserSettings(userID, stored), nil
+}
+
+// UpdateUserSettings changes only the given settings, a nil value resets one to its default.
+// Every setting whose value in force changed is audited under "settings.<key>".
+func (ss *UserSettingUsecase) UpdateUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any) (*model.UserSettings, error) {
+	err := ss.checkUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err = model.ParseUserSettingChanges(changes)
+	if err != nil {
+		return nil, apperrors.UserSettingUsecaseUpdateUserSettingsInvalid.AppendMessage(err)
+	}
+
+	stored, err := ss.getStoredSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	previousSettings := model.NewUserSettings(userID, stored)
+
+	err = ss.UserSettingRepo.SaveUserSettings(ctx, userID, changes, time.Now())
+	if err != nil {
+		return nil, apperrors.UserSettingUsecaseUpdateUserSettingsSave.AppendMessage(err)
+	}
+	err = ss.UserSettingRedisRepo.DeleteUserSettings(ctx, userID)
+	if err != nil {
+		return nil, apperrors.UserSettingUsecaseUpdateUserSettingsDropCache.AppendMessage(err)
+	}
+
+	stored, err = ss.getStoredSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings := model.NewUserSettings(userID, stored)
+
+	auditChanges := model.AuditChanges{}
+	for key := range changes {
+		if previousSettings.Settings[key] != settings.Settings[key] {
+			auditChanges["settings."+key] = model.AuditChange{Before: previousSettings.Settings[key], After: settings.Settings[key]}
+		}
+	}
+	if len(auditChanges) > 0 {
+		err = recordAudit(ctx, ss.AuditRepo, model.AuditActionUserSettings, userID, auditChanges)
+		if err != nil {
+			return nil, apperrors.UserSettingUsecaseRecordAudit.AppendMessage(err)
+		}
+	}
+
+	return settings, nil
+}
+
+func (ss *UserSettingUsecase) getStoredSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error) {
+	stored, err := ss.UserSettingRedisRepo.GetUserSettings(ctx, userID)
+	if err == nil {
+		return stored, nil
+	}
+	if !apperrors.Is(err, &apperrors.UserSettingRedisRepoGetUserSettingsGetDataNotFound) {
+		return nil, apperrors.UserSettingUsecaseGetUserSettingsCache.AppendMessage(err)
+	}
+
+	stored, err = ss.UserSettingRepo.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, apperrors.UserSettingUsecaseGetUserSettings.AppendMessage(err)
+	}
+	err = ss.UserSettingRedisRepo.SetUserSettings(ctx, userID, stored)
+	if err != nil {
+		return nil, apperrors.UserSettingUsecaseSetUserSettingsCache.AppendMessage(err)
+	}
+	return stored, nil
+}
+
+func (ss *UserSettingUsecase) checkUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := ss.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return apperrors.UserSettingUsecaseFindUserNotExist.AppendMessage(err)
+		}
+		return apperrors.UserSettingUsecaseFindUser.AppendMessage(err)
+	}
+	return nil
+}
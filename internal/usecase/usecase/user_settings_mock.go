@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserSettingRepositoryMock struct {
+	mock.Mock
+}
+
+func (usrm *UserSettingRepositoryMock) GetUserSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error) {
+	args := usrm.Called(ctx, userID)
+	return args.Get(0).([]*model.UserSetting), args.Error(1)
+}
+
+func (usrm *UserSettingRepositoryMock) SaveUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]any, updatedAt time.Time) error {
+	args := usrm.Called(ctx, userID, changes, updatedAt)
+	return args.Error(0)
+}
+
+type UserSettingRedisRepositoryMock struct {
+	mock.Mock
+}
+
+func (usrrm *UserSettingRedisRepositoryMock) GetUserSettings(ctx context.Context, userID uuid.UUID) ([]*model.UserSetting, error) {
+	args := usrrm.Called(ctx, userID)
+	return args.Get(0).([]*model.UserSetting), args.Error(1)
+}
+
+func (usrrm *UserSettingRedisRepositoryMock) SetUserSettings(ctx context.Context, userID uuid.UUID, settings []*model.UserSetting) error {
+	args := usrrm.Called(ctx, userID, settings)
+	return args.Error(0)
+}
+
+func (usrrm *UserSettingRedisRepositoryMock) DeleteUserSettings(ctx context.Context, userID uuid.UUID) error {
+	args := usrrm.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestUserSettingUsecase_GetUserSettings(t *testing.T) {
+	user := &model.User{UserID: uuid.New()}
+	stored := []*model.UserSetting{
+		{UserID: user.UserID, Key: model.SettingTheme, Value: []byte(`"dark"`)},
+		{UserID: user.UserID, Key: model.SettingItemsPerPage, Value: []byte(`50`)},
+		{UserID: user.UserID, Key: "dropped", Value: []byte(`1`)},
+	}
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userSettingRepoMock := &UserSettingRepositoryMock{}
+	userSettingRepoMock.On("GetUserSettings", mock.Anything, user.UserID).Return(stored, nil)
+	userSettingRedisRepoMock := &UserSettingRedisRepositoryMock{}
+	userSettingRedisRepoMock.On("GetUserSettings", mock.Anything, user.UserID).Return(([]*model.UserSetting)(nil), &apperrors.UserSettingRedisRepoGetUserSettingsGetDataNotFound)
+	userSettingRedisRepoMock.On("SetUserSettings", mock.Anything, user.UserID, stored).Return(nil)
+
+	usersettingusecase := NewUserSettingUsecase(userRepoMock, userSettingRepoMock, userSettingRedisRepoMock, nil)
+	settings, err := usersettingusecase.GetUserSettings(context.TODO(), user.UserID)
+	assert.NilError(t, err)
+	assert.Equal(t, settings.Settings[model.SettingTheme], "dark")
+	assert.Equal(t, settings.Settings[model.SettingItemsPerPage], 50)
+	assert.Equal(t, settings.Settings[model.SettingLanguage], "en")
+	assert.DeepEqual(t, settings.Overrides, []string{model.SettingItemsPerPage, model.SettingTheme})
+	userSettingRedisRepoMock.AssertExpectations(t)
+}
+
+func TestUserSettingUsecase_UpdateUserSettings(t *testing.T) {
+	user := &model.User{UserID: uuid.New()}
+	before := []*model.UserSetting{{UserID: user.UserID, Key: model.SettingTheme, Value: []byte(`"dark"`)}}
+	after := []*model.UserSetting{{UserID: user.UserID, Key: model.SettingTimezone, Value: []byte(`"Europe/Berlin"`)}}
+	changes := map[string]any{model.SettingTheme: nil, model.SettingTimezone: "Europe/Berlin", model.SettingNotificationsPush: true}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userSettingRepoMock := &UserSettingRepositoryMock{}
+	userSettingRepoMock.On("SaveUserSettings", mock.Anything, user.UserID, changes, mock.Anything).Return(nil)
+	userSettingRepoMock.On("GetUserSettings", mock.Anything, user.UserID).Return(after, nil)
+	userSettingRedisRepoMock := &UserSettingRedisRepositoryMock{}
+	userSettingRedisRepoMock.On("GetUserSettings", mock.Anything, user.UserID).Return(before, nil).Once()
+	userSettingRedisRepoMock.On("GetUserSettings", mock.Anything, user.UserID).Return(([]*model.UserSetting)(nil), &apperrors.UserSettingRedisRepoGetUserSettingsGetDataNotFound)
+	userSettingRedisRepoMock.On("DeleteUserSettings", mock.Anything, user.UserID).Return(nil)
+	userSettingRedisRepoMock.On("SetUserSettings", mock.Anything, user.UserID, after).Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	usersettingusecase := NewUserSettingUsecase(userRepoMock, userSettingRepoMock, userSettingRedisRepoMock, auditRepoMock)
+	settings, err := usersettingusecase.UpdateUserSettings(context.TODO(), user.UserID, changes)
+	assert.NilError(t, err)
+	assert.Equal(t, settings.Settings[model.SettingTheme], "system")
+	assert.Equal(t, settings.Settings[model.SettingTimezone], "Europe/Berlin")
+
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserSettings)
+	assert.DeepEqual(t, entry.Changes, model.AuditChanges{
+		"settings.theme":    {Before: "dark", After: "system"},
+		"settings.timezone": {Before: "UTC", After: "Europe/Berlin"},
+	})
+}
+
+func TestUserSettingUsecase_UpdateUserSettings_Invalid(t *testing.T) {
+	user := &model.User{UserID: uuid.New()}
+	tests := []struct {
+		name    string
+		changes map[string]any
+	}{
+		{"no settings", map[string]any{}},
+		{"unknown key", map[string]any{"font": "serif"}},
+		{"wrong type", map[string]any{model.SettingNotificationsEmail: "yes"}},
+		{"not an option", map[string]any{model.SettingTheme: "pink"}},
+		{"out of range", map[string]any{model.SettingItemsPerPage: float64(1000)}},
+		{"fraction", map[string]any{model.SettingItemsPerPage: 10.5}},
+		{"bad timezone", map[string]any{model.SettingTimezone: "Mars/Olympus"}},
+		{"bad language", map[string]any{model.SettingLanguage: "English"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			userSettingRepoMock := &UserSettingRepositoryMock{}
+
+			usersettingusecase := NewUserSettingUsecase(userRepoMock, userSettingRepoMock, nil, nil)
+			_, err := usersettingusecase.UpdateUserSettings(context.TODO(), user.UserID, tt.changes)
+			assert.Assert(t, apperrors.Is(err, &apperrors.UserSettingUsecaseUpdateUserSettingsInvalid), err)
+			userSettingRepoMock.AssertNotCalled(t, "SaveUserSettings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
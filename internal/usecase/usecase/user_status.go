@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+	"usermanager/internal/interface/repository"
+
+	"github.com/google/uuid"
+)
+
+type IUserStatusUsecase interface {
+	SuspendUser(ctx context.Context, userID uuid.UUID, suspendUser *model.SuspendUserRequest, actor *model.User) (*model.User, error)
+	UnsuspendUser(ctx context.Context, userID uuid.UUID, actor *model.User) (*model.User, error)
+	LiftExpiredSuspensions(ctx context.Context) (int, error)
+}
+
+type UserStatusUsecase struct {
+	UserRepo        repository.UserRepository
+	UserRedisRepo   repository.UserRedisRepository
+	AuditRepo       repository.AuditRepository
+	HierarchyPolicy *model.UserHierarchyPolicy
+}
+
+func NewUserStatusUsecase(userRepo repository.UserRepository, userRedisRepo repository.UserRedisRepository, auditRepo repository.AuditRepository, hierarchyPolicy *model.UserHierarchyPolicy) IUserStatusUsecase {
+	return &UserStatusUsecase{
+		UserRepo:        userRepo,
+		UserRedisRepo:   userRedisRepo,
+		AuditRepo:       auditRepo,
+		HierarchyPolicy: hierarchyPolicy,
+	}
+}
+
+// SuspendUser suspends or bans the user. The actor must outrank the user, so moderators can
+// only suspend plain users and nobody can suspend themselves. The active child accounts of the
+// user are suspended with it or keep it from being suspended, as the hierarchy policy says.
+func (ss *UserStatusUsecase) SuspendUser(ctx context.Context, userID uuid.UUID, suspendUser *model.SuspendUserRequest, actor *model.User) (*model.User, error) {
+	status := suspendUser.Status
+	if status == "" {
+		status = model.UserStatusSuspended
+	}
+	if suspendUser.Until != nil && !suspendUser.Until.After(time.Now()) {
+		return nil, apperrors.UserStatusUsecaseSuspendUserUntilPassed.AppendMessage(suspendUser.Until)
+	}
+	user, err := ss.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsRoleHigher(actor.Role) {
+		return nil, apperrors.UserStatusUsecaseSuspendUserOutranked.AppendMessage(user.Role)
+	}
+	children, err := ss.activeDescendants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(children) > 0 && ss.HierarchyPolicy.OnSuspend != model.HierarchyPolicyCascade {
+		return nil, apperrors.UserStatusUsecaseSuspendUserHasChildren.AppendMessage(len(children))
+	}
+	for _, child := range children {
+		if !child.IsRoleHigher(actor.Role) {
+			return nil, apperrors.UserStatusUsecaseSuspendUserOutranked.AppendMessage(child.UserID)
+		}
+	}
+
+	actorID := actor.UserID
+	userStatus := model.UserStatus{
+		Status:       status,
+		StatusReason: suspendUser.Reason,
+		StatusBy:     &actorID,
+		StatusUntil:  suspendUser.Until,
+	}
+	for _, child := range children {
+		_, err = ss.setStatus(ctx, child, 0, model.AuditActionUserSuspend, userStatus)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ss.setStatus(ctx, user, 0, model.AuditActionUserSuspend, userStatus)
+}
+
+func (ss *UserStatusUsecase) UnsuspendUser(ctx context.Context, userID uuid.UUID, actor *model.User) (*model.User, error) {
+	user, err := ss.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.StatusAt(time.Now()) == model.UserStatusActive {
+		return nil, apperrors.UserStatusUsecaseUnsuspendUserNotSuspended.AppendMessage(userID)
+	}
+	if !user.IsRoleHigher(actor.Role) {
+		return nil, apperrors.UserStatusUsecaseSuspendUserOutranked.AppendMessage(user.Role)
+	}
+
+	return ss.setStatus(ctx, user, 0, model.AuditActionUserUnsuspend, model.UserStatus{Status: model.UserStatusActive})
+}
+
+// LiftExpiredSuspensions makes the users whose suspension is over active again and returns how
+// many were lifted. A user suspended again in the meantime is left alone.
+func (ss *UserStatusUsecase) LiftExpiredSuspensions(ctx context.Context) (int, error) {
+	users, err := ss.UserRepo.GetUsersWithExpiredStatus(ctx, time.Now())
+	if err != nil {
+		return 0, apperrors.UserStatusUsecaseLiftExpiredSuspensionsGetUsers.AppendMessage(err)
+	}
+
+	lifted := 0
+	for _, user := range users {
+		_, err = ss.setStatus(ctx, user, user.Version, model.AuditActionUserUnsuspend, model.UserStatus{Status: model.UserStatusActive})
+		if err != nil {
+			if apperrors.Is(err, &apperrors.UserStatusUsecaseSetStatusVersionMismatch) {
+				continue
+			}
+			return lifted, err
+		}
+		lifted++
+	}
+
+	return lifted, nil
+}
+
+// activeDescendants leaves out the child accounts already suspended or banned, their own
+// status is kept.
+func (ss *UserStatusUsecase) activeDescendants(ctx context.Context, userID uuid.UUID) ([]*model.User, error) {
+	descendants, err := ss.UserRepo.GetUserDescendants(ctx, userID, ss.HierarchyPolicy.MaxDepth)
+	if err != nil {
+		return nil, apperrors.UserStatusUsecaseSuspendUserGetDescendants.AppendMessage(err)
+	}
+	now := time.Now()
+	users := []*model.User{}
+	for _, descendant := range descendants {
+		if descendant.StatusAt(now) == model.UserStatusActive {
+			users = append(users, &descendant.User)
+		}
+	}
+	return users, nil
+}
+
+func (ss *UserStatusUsecase) findUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := ss.UserRepo.FindUserByUUID(ctx, userID)
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoFindUserByUUIDGetDataNotFound) {
+			return nil, apperrors.UserStatusUsecaseFindUserNotExist.AppendMessage(err)
+		}
+		return nil, apperrors.UserStatusUsecaseFindUser.AppendMessage(err)
+	}
+	return user, nil
+}
+
+// setStatus stores the status, drops the cached user and records the change.
+func (ss *UserStatusUsecase) setStatus(ctx context.Context, user *model.User, expectedVersion int64, action string, status model.UserStatus) (*model.User, error) {
+	updatedUser, err := ss.UserRepo.PatchUser(ctx, user.UserID, expectedVersion, map[string]any{
+		"status":        status.Status,
+		"status_reason": status.StatusReason,
+		"status_by":     status.StatusBy,
+		"status_until":  status.StatusUntil,
+	})
+	if err != nil {
+		if apperrors.Is(err, &apperrors.UserRepoPatchUserVersionMismatch) {
+			return nil, apperrors.UserStatusUsecaseSetStatusVersionMismatch.AppendMessage(err)
+		}
+		return nil, apperrors.UserStatusUsecaseSetStatusPatchUser.AppendMessage(err)
+	}
+
+	err = ss.UserRedisRepo.DeleteFindUserByUUID(ctx, user.UserID)
+	if err == nil {
+		err = ss.UserRedisRepo.DeleteFindUserByNickname(ctx, user.Nickname)
+	}
+	if err != nil {
+		return nil, apperrors.UserStatusUsecaseSetStatusDropUserCache.AppendMessage(err)
+	}
+
+	err = recordAudit(ctx, ss.AuditRepo, action, user.UserID, model.DiffUsers(user, updatedUser))
+	if err != nil {
+		return nil, apperrors.UserStatusUsecaseRecordAudit.AppendMessage(err)
+	}
+
+	return updatedUser, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"usermanager/internal/apperrors"
+	"usermanager/internal/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestUserStatusUsecase_SuspendUser(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Role: model.RoleUser, UserStatus: model.UserStatus{Status: model.UserStatusActive}}
+	suspendedUser := &model.User{UserID: user.UserID, Nickname: "nickname", Role: model.RoleUser, UserStatus: model.UserStatus{Status: model.UserStatusSuspended, StatusReason: "spam", StatusUntil: &until}}
+	moderator := &model.User{UserID: uuid.New(), Nickname: "moderator", Role: model.RoleModerator}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userRepoMock.On("PatchUser", mock.Anything, user.UserID, int64(0), map[string]any{
+		"status":        model.UserStatusSuspended,
+		"status_reason": "spam",
+		"status_by":     &moderator.UserID,
+		"status_until":  &until,
+	}).Return(suspendedUser, nil)
+	userRepoMock.On("GetUserDescendants", mock.Anything, user.UserID, hierarchyTestPolicy.MaxDepth).Return([]*model.UserTreeNode{}, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "nickname").Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	userstatususecase := NewUserStatusUsecase(userRepoMock, userRedisRepoMock, auditRepoMock, hierarchyTestPolicy)
+	updatedUser, err := userstatususecase.SuspendUser(context.TODO(), user.UserID, &model.SuspendUserRequest{Reason: "spam", Until: &until}, moderator)
+	assert.NilError(t, err)
+	assert.Equal(t, updatedUser.StatusAt(time.Now()), model.UserStatusSuspended)
+	assert.Equal(t, updatedUser.StatusAt(until), model.UserStatusActive)
+	userRedisRepoMock.AssertExpectations(t)
+
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserSuspend)
+	assert.DeepEqual(t, entry.Changes["status"], model.AuditChange{Before: model.UserStatusActive, After: model.UserStatusSuspended})
+}
+
+func TestUserStatusUsecase_SuspendUser_Errors(t *testing.T) {
+	admin := &model.User{UserID: uuid.New(), Role: model.RoleAdmin}
+	moderator := &model.User{UserID: uuid.New(), Role: model.RoleModerator}
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name    string
+		user    *model.User
+		actor   *model.User
+		until   *time.Time
+		wantErr *apperrors.AppError
+	}{
+		{"moderator suspends an admin", admin, moderator, nil, &apperrors.UserStatusUsecaseSuspendUserOutranked},
+		{"moderator suspends a moderator", &model.User{UserID: uuid.New(), Role: model.RoleModerator}, moderator, nil, &apperrors.UserStatusUsecaseSuspendUserOutranked},
+		{"admin suspends itself", admin, admin, nil, &apperrors.UserStatusUsecaseSuspendUserOutranked},
+		{"until has passed", &model.User{UserID: uuid.New(), Role: model.RoleUser}, admin, &past, &apperrors.UserStatusUsecaseSuspendUserUntilPassed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, tt.user.UserID).Return(tt.user, nil)
+
+			userstatususecase := NewUserStatusUsecase(userRepoMock, nil, nil, hierarchyTestPolicy)
+			_, err := userstatususecase.SuspendUser(context.TODO(), tt.user.UserID, &model.SuspendUserRequest{Reason: "reason", Until: tt.until}, tt.actor)
+			assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+			userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserStatusUsecase_LiftExpiredSuspensions(t *testing.T) {
+	until := time.Now().Add(-time.Minute)
+	expired := &model.User{UserID: uuid.New(), Nickname: "expired", Version: 4, UserStatus: model.UserStatus{Status: model.UserStatusBanned, StatusUntil: &until}}
+	suspendedAgain := &model.User{UserID: uuid.New(), Nickname: "again", Version: 7, UserStatus: model.UserStatus{Status: model.UserStatusSuspended, StatusUntil: &until}}
+
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("GetUsersWithExpiredStatus", mock.Anything, mock.Anything).Return([]*model.User{expired, suspendedAgain}, nil)
+	userRepoMock.On("PatchUser", mock.Anything, expired.UserID, int64(4), mock.Anything).Return(&model.User{UserID: expired.UserID, UserStatus: model.UserStatus{Status: model.UserStatusActive}}, nil)
+	userRepoMock.On("PatchUser", mock.Anything, suspendedAgain.UserID, int64(7), mock.Anything).Return((*model.User)(nil), &apperrors.UserRepoPatchUserVersionMismatch)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, expired.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, "expired").Return(nil)
+	auditRepoMock := newAuditRepoMock()
+
+	lifted, err := NewUserStatusUsecase(userRepoMock, userRedisRepoMock, auditRepoMock, hierarchyTestPolicy).LiftExpiredSuspensions(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, lifted, 1)
+	entry := auditRepoMock.Calls[0].Arguments.Get(1).(*model.AuditEntry)
+	assert.Equal(t, entry.Action, model.AuditActionUserUnsuspend)
+	assert.Assert(t, entry.ActorID == nil)
+}
+
+func TestUserUsecase_VoteUser_Suspended(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	active := &model.User{UserID: uuid.New(), UserStatus: model.UserStatus{Status: model.UserStatusActive}}
+	suspended := &model.User{UserID: uuid.New(), UserStatus: model.UserStatus{Status: model.UserStatusSuspended, StatusUntil: &until}}
+	tests := []struct {
+		name    string
+		voter   *model.User
+		user    *model.User
+		wantErr *apperrors.AppError
+	}{
+		{"suspended voter", suspended, active, &apperrors.UserUsecaseVoteVoterInactive},
+		{"suspended user", active, suspended, &apperrors.UserUsecaseVoteUserInactive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("FindUserByUUID", mock.Anything, tt.voter.UserID).Return(tt.voter, nil)
+			userRedisRepoMock.On("FindUserByUUID", mock.Anything, tt.user.UserID).Return(tt.user, nil)
+			voteRepoMock := &VoteRepositoryMock{}
+
+			userusecase := NewUserUsecase(nil, voteRepoMock, userRedisRepoMock, nil, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+			_, _, err := userusecase.Vote(context.TODO(), &model.Vote{Vote: 1, CreatedUserID: tt.voter.UserID}, &model.UserVote{UserID: tt.user.UserID})
+			assert.Assert(t, apperrors.Is(err, tt.wantErr), err)
+			voteRepoMock.AssertNotCalled(t, "SaveVote", mock.Anything, mock.Anything)
+		})
+	}
+}
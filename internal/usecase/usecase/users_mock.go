@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"usermanager/internal/domain/model"
 	"usermanager/internal/utils"
@@ -24,11 +25,31 @@ func (urm *UserRepositoryMock) FindUserByNickname(ctx context.Context, nickname
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
+func (urm *UserRepositoryMock) FindUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	args := urm.Called(ctx, email)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
 func (urm *UserRepositoryMock) GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	args := urm.Called(ctx, paginationQuery)
 	return args.Get(0).(*model.Users), args.Error(1)
 }
 
+func (urm *UserRepositoryMock) SearchUsers(ctx context.Context, searchQuery *model.SearchUsersQuery) (*model.Users, error) {
+	args := urm.Called(ctx, searchQuery)
+	return args.Get(0).(*model.Users), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) StreamUsers(ctx context.Context, exportQuery *model.UserExportQuery, fn func(user *model.User) error) error {
+	args := urm.Called(ctx, exportQuery)
+	for _, user := range args.Get(0).([]*model.User) {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (urm *UserRepositoryMock) SaveUser(ctx context.Context, user *model.User) (*model.User, error) {
 	args := urm.Called(ctx, user)
 	return args.Get(0).(*model.User), args.Error(1)
@@ -39,14 +60,59 @@ func (urm *UserRepositoryMock) UpdateUser(ctx context.Context, user *model.User)
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (urm *UserRepositoryMock) SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+func (urm *UserRepositoryMock) SoftDeleteUserByUserID(ctx context.Context, userID uuid.UUID, expectedVersion int64) (*model.User, error) {
+	args := urm.Called(ctx, userID, expectedVersion)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) DeleteUserByUserID(ctx context.Context, userID *uuid.UUID) ([]string, error) {
+	args := urm.Called(ctx, userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) EraseUser(ctx context.Context, userID uuid.UUID, nickname string) (*model.User, error) {
+	args := urm.Called(ctx, userID, nickname)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) GetUsersWithExpiredStatus(ctx context.Context, now time.Time) ([]*model.User, error) {
+	args := urm.Called(ctx, now)
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) GetUserAncestors(ctx context.Context, userID uuid.UUID, maxDepth int) ([]*model.UserTreeNode, error) {
+	args := urm.Called(ctx, userID, maxDepth)
+	return args.Get(0).([]*model.UserTreeNode), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) GetUserDescendants(ctx context.Context, userID uuid.UUID, maxDepth int) ([]*model.UserTreeNode, error) {
+	args := urm.Called(ctx, userID, maxDepth)
+	return args.Get(0).([]*model.UserTreeNode), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) UpdateLoginDate(ctx context.Context, userID uuid.UUID, loginDate time.Time) error {
+	args := urm.Called(ctx, userID, loginDate)
+	return args.Error(0)
+}
+
+func (urm *UserRepositoryMock) FindDeletedUserByUUID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
 	args := urm.Called(ctx, userID)
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (urm *UserRepositoryMock) DeleteUserByUserID(ctx context.Context, userID *uuid.UUID) error {
+func (urm *UserRepositoryMock) RestoreUserByUserID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
 	args := urm.Called(ctx, userID)
-	return args.Error(1)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) PatchUser(ctx context.Context, userID uuid.UUID, expectedVersion int64, changes map[string]any) (*model.User, error) {
+	args := urm.Called(ctx, userID, expectedVersion, changes)
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (urm *UserRepositoryMock) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, []string, error) {
+	args := urm.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Get(1).([]string), args.Error(2)
 }
 
 type UserRedisRepositoryMock struct {
@@ -73,6 +139,16 @@ func (urrm *UserRedisRepositoryMock) SetFindUserByNickname(ctx context.Context,
 	return args.Error(0)
 }
 
+func (urrm *UserRedisRepositoryMock) DeleteFindUserByUUID(ctx context.Context, userID uuid.UUID) error {
+	args := urrm.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (urrm *UserRedisRepositoryMock) DeleteFindUserByNickname(ctx context.Context, nickname string) error {
+	args := urrm.Called(ctx, nickname)
+	return args.Error(0)
+}
+
 func (urrm *UserRedisRepositoryMock) GetUsers(ctx context.Context, paginationQuery *utils.PaginationQuery) (*model.Users, error) {
 	args := urrm.Called(ctx, paginationQuery)
 	return args.Get(0).(*model.Users), args.Error(1)
@@ -43,7 +43,7 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.CreateUser(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -78,7 +78,7 @@ func TestUserUsecase_CreateUser_Error_ExistUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.CreateUser(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, (err == nil), tt.wantErr)
@@ -113,7 +113,7 @@ func TestUserUsecase_CreateUser_Error_SaveUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.CreateUser(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, (err == nil), tt.wantErr)
@@ -124,7 +124,11 @@ func TestUserUsecase_CreateUser_Error_SaveUser(t *testing.T) {
 func TestUserUsecase_UpdateUser(t *testing.T) {
 	userRepoMock := &UserRepositoryMock{}
 	user := &model.User{Nickname: "nickname", FirstName: "fname", LastName: "lname"}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
 	userRepoMock.On("UpdateUser", mock.Anything, user).Return(user, nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
 	type fields struct {
 		UserRepo      repository.UserRepository
 		VoteRepo      repository.VoteRepository
@@ -142,11 +146,11 @@ func TestUserUsecase_UpdateUser(t *testing.T) {
 		want    *model.User
 		wantErr bool
 	}{
-		{"update profile", fields{UserRepo: userRepoMock}, args{ctx: context.TODO(), user: user}, user, false},
+		{"update profile", fields{UserRepo: userRepoMock, UserRedisRepo: userRedisRepoMock}, args{ctx: context.TODO(), user: user}, user, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.UpdateUser(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -157,7 +161,11 @@ func TestUserUsecase_UpdateUser(t *testing.T) {
 func TestUserUsecase_UpdateUser_Error(t *testing.T) {
 	userRepoMock := &UserRepositoryMock{}
 	user := &model.User{Nickname: "nickname", FirstName: "fname", LastName: "lname"}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
 	userRepoMock.On("UpdateUser", mock.Anything, user).Return((*model.User)(nil), nil)
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
 	type fields struct {
 		UserRepo      repository.UserRepository
 		VoteRepo      repository.VoteRepository
@@ -175,11 +183,11 @@ func TestUserUsecase_UpdateUser_Error(t *testing.T) {
 		want    *model.User
 		wantErr bool
 	}{
-		{"update profile", fields{UserRepo: userRepoMock}, args{ctx: context.TODO(), user: user}, nil, false},
+		{"update profile", fields{UserRepo: userRepoMock, UserRedisRepo: userRedisRepoMock}, args{ctx: context.TODO(), user: user}, nil, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.UpdateUser(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -219,7 +227,7 @@ func TestUserUsecase_GetUsers(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.GetUsers(tt.args.ctx, tt.args.paginationQuery)
 			assert.Equal(t, !(err == nil), tt.wantErr)
 			assert.Equal(t, users, got)
@@ -257,7 +265,7 @@ func TestUserUsecase_GetUsers_Error(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.GetUsers(tt.args.ctx, tt.args.paginationQuery)
 			assert.Equal(t, !(err == nil), tt.wantErr)
 			assert.Equal(t, tt.want, got)
@@ -295,7 +303,7 @@ func TestUserUsecase_GetUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.GetUser(tt.args.ctx, tt.args.userID)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -303,6 +311,20 @@ func TestUserUsecase_GetUser(t *testing.T) {
 	}
 }
 
+func TestUserUsecase_GetUser_NotExist(t *testing.T) {
+	userID := uuid.New()
+	userRepoMock := &UserRepositoryMock{}
+	userRepoMock.On("FindUserByUUID", mock.Anything, userID).Return((*model.User)(nil), apperrors.UserRepoFindUserByUUIDGetDataNotFound.AppendMessage(fmt.Errorf("no rows")))
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	userRedisRepoMock.On("FindUserByUUID", mock.Anything, userID).Return((*model.User)(nil), nil)
+
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+	got, err := userusecase.GetUser(context.TODO(), userID)
+	assert.NilError(t, err)
+	assert.Assert(t, got == nil)
+	userRedisRepoMock.AssertNotCalled(t, "SetFindUserByUUID", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUserUsecase_GetUser_Error(t *testing.T) {
 	userRepoMock := &UserRepositoryMock{}
 	userRedisRepoMock := &UserRedisRepositoryMock{}
@@ -333,7 +355,7 @@ func TestUserUsecase_GetUser_Error(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.GetUser(tt.args.ctx, tt.args.userID)
 			fmt.Println("TestUserUsecase_GetUser_Error ERROR", err)
 			assert.Equal(t, got, tt.want)
@@ -372,7 +394,7 @@ func TestUserUsecase_GetUserByNickname(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.GetUserByNickname(tt.args.ctx, tt.args.user.Nickname)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -410,7 +432,7 @@ func TestUserUsecase_GetUserByNickname_Error(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.GetUserByNickname(tt.args.ctx, tt.args.user.Nickname)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -443,7 +465,7 @@ func TestUserUsecase_CheckUserByNickname(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.CheckUserByNickname(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -476,7 +498,7 @@ func TestUserUsecase_CheckUserByNickname_Error(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.CheckUserByNickname(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -510,7 +532,7 @@ func TestUserUsecase_CheckUserByNickname_Error_NicknameExist(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.CheckUserByNickname(tt.args.ctx, tt.args.user)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
@@ -596,7 +618,7 @@ func TestUserUsecase_FindExistVoting(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			gotVote, gotUserVote, err := userusecase.FindExistVoting(tt.args.ctx, tt.args.userID, tt.args.voterID)
 			assert.DeepEqual(t, gotVote, tt.wantVote)
 			assert.DeepEqual(t, gotUserVote, tt.wantUserVote)
@@ -646,10 +668,201 @@ func TestUserUsecase_FindVotesForUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo)
+			userusecase := NewUserUsecase(tt.fields.UserRepo, tt.fields.VoteRepo, tt.fields.UserRedisRepo, tt.fields.VoteRedisRepo, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
 			got, err := userusecase.FindVotesForUser(tt.args.ctx, tt.args.userID)
 			assert.DeepEqual(t, got, tt.want)
 			assert.Equal(t, !(err == nil), tt.wantErr)
 		})
 	}
 }
+
+func TestUserUsecase_DeleteUser(t *testing.T) {
+	userRepoMock := &UserRepositoryMock{}
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname"}
+	userRepoMock.On("SoftDeleteUserByUserID", mock.Anything, user.UserID, int64(0)).Return(user, nil)
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+	err := userusecase.DeleteUser(context.TODO(), &user.UserID, 0)
+	assert.NilError(t, err)
+	userRedisRepoMock.AssertExpectations(t)
+}
+
+func TestUserUsecase_HardDeleteUser_DeletedUser(t *testing.T) {
+	userRepoMock := &UserRepositoryMock{}
+	userRedisRepoMock := &UserRedisRepositoryMock{}
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname"}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return((*model.User)(nil), apperrors.UserRepoFindUserByUUIDGetDataNotFound.AppendMessage(fmt.Errorf("no rows")))
+	userRepoMock.On("FindDeletedUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userRepoMock.On("DeleteUserByUserID", mock.Anything, &user.UserID).Return([]string{"avatars/original", "exports/archive.zip"}, nil)
+	userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+	userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+	blobStoreMock := &BlobStoreMock{}
+	blobStoreMock.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, blobStoreMock)
+	err := userusecase.HardDeleteUser(context.TODO(), &user.UserID, 0)
+	assert.NilError(t, err)
+	userRepoMock.AssertExpectations(t)
+	blobStoreMock.AssertCalled(t, "Delete", mock.Anything, "avatars/original")
+	blobStoreMock.AssertCalled(t, "Delete", mock.Anything, "exports/archive.zip")
+}
+
+func TestUserUsecase_RestoreUser(t *testing.T) {
+	userID := uuid.New()
+	deletedUser := &model.User{UserID: userID, Nickname: "nickname"}
+	tests := []struct {
+		name     string
+		taken    *model.User
+		takenErr error
+		wantErr  *apperrors.AppError
+	}{
+		{"restore user", nil, apperrors.UserRepoFindUserByNicknameGetDataNotFound.AppendMessage(fmt.Errorf("no rows")), nil},
+		{"nickname busy", &model.User{UserID: uuid.New(), Nickname: "nickname"}, nil, &apperrors.UserUsecaseRestoreUserNicknameBusy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRepoMock.On("FindDeletedUserByUUID", mock.Anything, userID).Return(deletedUser, nil)
+			userRepoMock.On("FindUserByNickname", mock.Anything, deletedUser.Nickname).Return(tt.taken, tt.takenErr)
+			userRepoMock.On("RestoreUserByUserID", mock.Anything, userID).Return(deletedUser, nil)
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, userID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, deletedUser.Nickname).Return(nil)
+
+			userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+			got, err := userusecase.RestoreUser(context.TODO(), userID)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 409)
+				userRepoMock.AssertNotCalled(t, "RestoreUserByUserID", mock.Anything, userID)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, deletedUser)
+			userRedisRepoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserRetentionUsecase_PurgeDeletedUsers(t *testing.T) {
+	userRepoMock := &UserRepositoryMock{}
+	retention := 30 * 24 * time.Hour
+	userRepoMock.On("PurgeDeletedUsers", mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		return time.Since(deletedBefore) >= retention && time.Since(deletedBefore) < retention+time.Minute
+	})).Return(int64(2), []string{"avatars/original"}, nil)
+	blobStoreMock := &BlobStoreMock{}
+	blobStoreMock.On("Delete", mock.Anything, "avatars/original").Return(nil)
+
+	userRetentionUsecase := NewUserRetentionUsecase(userRepoMock, blobStoreMock, retention)
+	purged, err := userRetentionUsecase.PurgeDeletedUsers(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, purged, int64(2))
+	blobStoreMock.AssertExpectations(t)
+}
+
+func TestUserUsecase_SearchUsers(t *testing.T) {
+	paginationQuery := &utils.PaginationQuery{Size: 10, Page: 1}
+	users := &model.Users{Page: 1, Users: []*model.User{}}
+	admin := &model.User{UserID: uuid.New(), Role: model.RoleAdmin}
+	member := &model.User{UserID: uuid.New(), Role: model.RoleUser}
+	tests := []struct {
+		name               string
+		viewer             *model.User
+		query              string
+		wantIncludePrivate bool
+		wantErr            *apperrors.AppError
+	}{
+		{"anonymous viewer", nil, " jon ", false, nil},
+		{"member viewer", member, "jon", false, nil},
+		{"admin viewer", admin, "jon", true, nil},
+		{"query too short", member, " j ", false, &apperrors.UserUsecaseSearchUsersQueryTooShort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			voteRepoMock := &VoteRepositoryMock{}
+			userRepoMock.On("SearchUsers", mock.Anything, mock.MatchedBy(func(searchQuery *model.SearchUsersQuery) bool {
+				return searchQuery.Query == "jon" && searchQuery.IncludePrivate == tt.wantIncludePrivate
+			})).Return(users, nil)
+			voteRepoMock.On("FindVotesByUserIDs", mock.Anything, mock.Anything).Return([]*model.Vote{}, nil)
+
+			userusecase := NewUserUsecase(userRepoMock, voteRepoMock, &UserRedisRepositoryMock{}, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+			got, err := userusecase.SearchUsers(context.TODO(), tt.viewer, tt.query, paginationQuery)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				userRepoMock.AssertNotCalled(t, "SearchUsers", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, users)
+			userRepoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_PatchUser(t *testing.T) {
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Password: "hash"}
+	tests := []struct {
+		name    string
+		changes map[string]any
+		taken   *model.User
+		wantErr *apperrors.AppError
+	}{
+		{"patch first name", map[string]any{"first_name": "First"}, nil, nil},
+		{"patch password", map[string]any{"password": "password"}, nil, nil},
+		{"nickname busy", map[string]any{"nickname": "taken"}, &model.User{UserID: uuid.New(), Nickname: "taken"}, &apperrors.UserUsecasePatchUserNicknameBusy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &UserRepositoryMock{}
+			userRedisRepoMock := &UserRedisRepositoryMock{}
+			userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+			userRepoMock.On("FindUserByNickname", mock.Anything, "taken").Return(tt.taken, nil)
+			userRepoMock.On("PatchUser", mock.Anything, user.UserID, int64(0), tt.changes).Return(user, nil)
+			userRedisRepoMock.On("DeleteFindUserByUUID", mock.Anything, user.UserID).Return(nil)
+			userRedisRepoMock.On("DeleteFindUserByNickname", mock.Anything, user.Nickname).Return(nil)
+
+			userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, userRedisRepoMock, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+			got, err := userusecase.PatchUser(context.TODO(), user.UserID, 0, tt.changes)
+			if tt.wantErr != nil {
+				assert.Assert(t, apperrors.Is(err, tt.wantErr))
+				assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 409)
+				userRepoMock.AssertNotCalled(t, "PatchUser", mock.Anything, user.UserID, int64(0), tt.changes)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, user)
+			if password, ok := tt.changes["password"].(string); ok {
+				hashedUser := &model.User{Password: password}
+				assert.NilError(t, hashedUser.ComparePasswords("password"))
+			}
+			userRedisRepoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_UpdateUser_VersionMismatch(t *testing.T) {
+	userRepoMock := &UserRepositoryMock{}
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Version: 2}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+	userRepoMock.On("UpdateUser", mock.Anything, user).Return((*model.User)(nil), apperrors.UserRepoUpdateUserVersionMismatch.AppendMessage(fmt.Errorf("no rows")))
+
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, &UserRedisRepositoryMock{}, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+	_, err := userusecase.UpdateUser(context.TODO(), user)
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserUsecaseUpdateUserVersionMismatch))
+	assert.Equal(t, err.(*apperrors.AppError).HTTPCode, 412)
+}
+
+func TestUserUsecase_HardDeleteUser_VersionMismatch(t *testing.T) {
+	userRepoMock := &UserRepositoryMock{}
+	user := &model.User{UserID: uuid.New(), Nickname: "nickname", Version: 3}
+	userRepoMock.On("FindUserByUUID", mock.Anything, user.UserID).Return(user, nil)
+
+	userusecase := NewUserUsecase(userRepoMock, &VoteRepositoryMock{}, &UserRedisRepositoryMock{}, &VoteRedisRepositoryMock{}, newAttributeSchemaRepoMock(), newNicknameRepoMock(), newAuditRepoMock(), nicknameTestPolicy, nil)
+	err := userusecase.HardDeleteUser(context.TODO(), &user.UserID, 2)
+	assert.Assert(t, apperrors.Is(err, &apperrors.UserUsecaseHardDeleteUserVersionMismatch))
+	userRepoMock.AssertNotCalled(t, "DeleteUserByUserID", mock.Anything, &user.UserID)
+}
@@ -58,6 +58,16 @@ func (vrm *VoteRepositoryMock) DeleteVote(ctx context.Context, vote *model.Vote)
 	return args.Error(1)
 }
 
+func (vrm *VoteRepositoryMock) GetVotesCastByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExportVote, error) {
+	args := vrm.Called(ctx, userID)
+	return args.Get(0).([]*model.DataExportVote), args.Error(1)
+}
+
+func (vrm *VoteRepositoryMock) GetVotesReceivedByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExportVote, error) {
+	args := vrm.Called(ctx, userID)
+	return args.Get(0).([]*model.DataExportVote), args.Error(1)
+}
+
 func (vrm *VoteRepositoryMock) FindVotesByUserIDs(ctx context.Context, userIDs []*uuid.UUID) ([]*model.Vote, error) {
 	args := vrm.Called(ctx, userIDs)
 	return args.Get(0).([]*model.Vote), args.Error(1)
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"math/bits"
+)
+
+// SolvesChallenge tells whether sha256(challenge + ":" + nonce) starts with at least
+// difficulty zero bits. Finding such a nonce takes about 2^difficulty hashes, checking it one.
+func SolvesChallenge(challenge string, nonce string, difficulty int) bool {
+	sum := sha256.Sum256([]byte(challenge + ":" + nonce))
+	return leadingZeroBits(sum[:]) >= difficulty
+}
+
+func leadingZeroBits(hash []byte) int {
+	zeros := 0
+	for _, b := range hash {
+		if b != 0 {
+			return zeros + bits.LeadingZeros8(b)
+		}
+		zeros += 8
+	}
+	return zeros
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeadingZeroBits(t *testing.T) {
+	assert.Equal(t, 0, leadingZeroBits([]byte{0x80, 0x00}))
+	assert.Equal(t, 3, leadingZeroBits([]byte{0x10, 0xff}))
+	assert.Equal(t, 12, leadingZeroBits([]byte{0x00, 0x08}))
+	assert.Equal(t, 16, leadingZeroBits([]byte{0x00, 0x00}))
+}
+
+func TestSolvesChallenge(t *testing.T) {
+	nonce := ""
+	for i := 0; i < 1<<16; i++ {
+		if SolvesChallenge("challenge", strconv.Itoa(i), 8) {
+			nonce = strconv.Itoa(i)
+			break
+		}
+	}
+	assert.NotEmpty(t, nonce)
+	assert.True(t, SolvesChallenge("challenge", nonce, 0))
+	assert.False(t, SolvesChallenge("challenge", nonce, 257))
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at the boundary row of a page: its sort key values and id tie-breaker.
+type Cursor struct {
+	OrderBy  string   `json:"o,omitempty"`
+	Values   []string `json:"v"`
+	ID       string   `json:"id"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret string) *CursorCodec {
+	return &CursorCodec{secret: []byte(secret)}
+}
+
+// Encode returns an opaque token made of the cursor payload and its HMAC signature.
+func (cc *CursorCodec) Encode(cursor *Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(cc.sign(encodedPayload)), nil
+}
+
+func (cc *CursorCodec) Decode(token string) (*Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, cc.sign(encodedPayload)) {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	if err = json.Unmarshal(payload, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (cc *CursorCodec) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, cc.secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
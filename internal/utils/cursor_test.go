@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	cursor := &Cursor{OrderBy: "nickname:desc", Values: []string{"john"}, ID: "1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed", Backward: true}
+
+	token, err := codec.Encode(cursor)
+	assert.NoError(t, err)
+
+	got, err := codec.Decode(token)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, got)
+
+	_, err = NewCursorCodec("other").Decode(token)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = codec.Decode("x" + token)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = codec.Decode("garbage")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
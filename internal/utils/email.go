@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// EmailKey is the form emails are compared in: NFKC and lower case, the whole address
+// included, since mailboxes that differ only in case are the same mailbox in practice.
+// The migration that added users.email_key backfills it the same way.
+func EmailKey(email string) string {
+	return strings.ToLower(norm.NFKC.String(strings.TrimSpace(email)))
+}
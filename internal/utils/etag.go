@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+func FormatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// FormatVariantETag formats the ETag of one variant of a resource version.
+func FormatVariantETag(version int64, variant string) string {
+	return `"` + strconv.FormatInt(version, 10) + "-" + variant + `"`
+}
+
+// WithBodyDigest extends an ETag with a digest of the body, for representations that carry
+// data the version doesn't cover. Such an ETag still names the version for If-Match, see
+// ETagMatchesVersion.
+func WithBodyDigest(etag string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strings.TrimSuffix(etag, `"`) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// ETagMatchesVersion is the strong comparison of an If-Match header against etag, which also
+// accepts etag extended by WithBodyDigest: a write is conditional on the version only.
+func ETagMatchesVersion(header string, etag string) bool {
+	if ETagMatches(header, etag, false) {
+		return true
+	}
+	prefix := strings.TrimSuffix(etag, `"`) + "-"
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, prefix) && strings.HasSuffix(candidate, `"`) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ETagMatches reports whether an If-Match or If-None-Match header lists etag.
+// If-Match needs the strong comparison, If-None-Match the weak one (RFC 9110 13.1).
+func ETagMatches(header string, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
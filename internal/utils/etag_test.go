@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETagMatches(t *testing.T) {
+	etag := FormatETag(3)
+	tests := []struct {
+		name   string
+		header string
+		weak   bool
+		want   bool
+	}{
+		{"same version", `"3"`, false, true},
+		{"other version", `"2"`, false, false},
+		{"list", `"1", "3"`, false, true},
+		{"any", "*", false, true},
+		{"weak tag in If-Match", `W/"3"`, false, false},
+		{"weak tag in If-None-Match", `W/"3"`, true, true},
+		{"empty", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ETagMatches(tt.header, etag, tt.weak))
+		})
+	}
+}
+
+func TestFormatVariantETag(t *testing.T) {
+	assert.Equal(t, `"3-card"`, FormatVariantETag(3, "card"))
+	assert.False(t, ETagMatches(FormatVariantETag(3, "card"), FormatVariantETag(3, "full"), true))
+}
+
+func TestETagMatchesVersion(t *testing.T) {
+	etag := FormatVariantETag(3, "full")
+	digestETag := WithBodyDigest(etag, []byte(`{"followers_count":1}`))
+	assert.NotEqual(t, digestETag, WithBodyDigest(etag, []byte(`{"followers_count":2}`)))
+	assert.True(t, ETagMatchesVersion(digestETag, etag))
+	assert.True(t, ETagMatchesVersion(etag, etag))
+	assert.False(t, ETagMatchesVersion(WithBodyDigest(FormatVariantETag(2, "full"), nil), etag))
+	assert.False(t, ETagMatchesVersion("W/"+digestETag, etag))
+	assert.False(t, ETagMatchesVersion(WithBodyDigest(FormatVariantETag(3, "masked"), nil), etag))
+}
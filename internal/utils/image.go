@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ImageFormatPNG  = "png"
+	ImageFormatJPEG = "jpeg"
+	ImageFormatWebP = "webp"
+
+	imageJPEGQuality = 90
+)
+
+var (
+	ErrImageFormat   = errors.New("unsupported image format")
+	ErrImageTooLarge = errors.New("image is too large")
+)
+
+// imageContentTypes maps the sniffed content type to the formats DecodeImage accepts.
+var imageContentTypes = map[string]string{
+	"image/png":  ImageFormatPNG,
+	"image/jpeg": ImageFormatJPEG,
+	"image/webp": ImageFormatWebP,
+}
+
+// DecodeImage decodes a PNG, JPEG or WebP image, whatever the file claims to be. The size is
+// checked from the header before decoding, so a small file can't expand into a huge bitmap.
+// The EXIF orientation of a JPEG is applied, since re-encoding drops it.
+func DecodeImage(data []byte, maxPixels int) (image.Image, string, error) {
+	format, ok := imageContentTypes[http.DetectContentType(data)]
+	if !ok {
+		return nil, "", ErrImageFormat
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrImageFormat, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrImageFormat, err)
+	}
+	if format == ImageFormatJPEG {
+		img = orientImage(img, jpegOrientation(data))
+	}
+
+	return img, format, nil
+}
+
+// EncodeImage writes the image without any metadata. JPEG stays JPEG and PNG stays PNG; WebP,
+// which can't be encoded here, becomes JPEG or PNG depending on transparency.
+// It returns the format that was written.
+func EncodeImage(img image.Image, sourceFormat string) ([]byte, string, error) {
+	format := sourceFormat
+	if format == ImageFormatWebP {
+		format = ImageFormatPNG
+		if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+			format = ImageFormatJPEG
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if format == ImageFormatJPEG {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: imageJPEGQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), format, nil
+}
+
+// FitImage scales the image down so that neither side is longer than maxSide. Smaller images
+// are returned as they are.
+func FitImage(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxSide && h <= maxSide {
+		return img
+	}
+	if w >= h {
+		w, h = maxSide, h*maxSide/w
+	} else {
+		w, h = w*maxSide/h, maxSide
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	fitted := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(fitted, fitted.Bounds(), img, bounds, draw.Src, nil)
+
+	return fitted
+}
+
+// SquareThumbnail crops the centered square of the image and scales it to size x size.
+func SquareThumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), img, image.Rect(x, y, x+side, y+side), draw.Src, nil)
+
+	return thumbnail
+}
+
+// jpegOrientation reads the orientation tag from the EXIF segment of a JPEG, 1 when there is none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		marker := data[i+1]
+		if data[i] != 0xFF || marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != 0x0112 {
+			continue
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+// orientImage turns the image upright for an EXIF orientation from 2 to 8.
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	oriented := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			default:
+				sx, sy = w-1-y, x
+			}
+			oriented.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+
+	return oriented
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 255 / w), G: uint8(y * 255 / h), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// withExifOrientation inserts an APP1 segment with a little endian orientation tag after SOI.
+func withExifOrientation(jpegData []byte, orientation uint16) []byte {
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00")
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry[0:], 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	header := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+	data := append([]byte{}, jpegData[:2]...)
+	data = append(append(data, header...), segment...)
+	return append(data, jpegData[2:]...)
+}
+
+func TestDecodeImage(t *testing.T) {
+	var pngData, jpegData bytes.Buffer
+	assert.NoError(t, png.Encode(&pngData, testImage(40, 20)))
+	assert.NoError(t, jpeg.Encode(&jpegData, testImage(40, 20), nil))
+
+	img, format, err := DecodeImage(pngData.Bytes(), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, ImageFormatPNG, format)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+
+	img, format, err = DecodeImage(withExifOrientation(jpegData.Bytes(), 6), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, ImageFormatJPEG, format)
+	assert.Equal(t, image.Rect(0, 0, 20, 40), img.Bounds(), "orientation 6 is rotated upright")
+
+	_, _, err = DecodeImage(pngData.Bytes(), 799)
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+	_, _, err = DecodeImage([]byte("GIF89a not an allowed image"), 1000)
+	assert.ErrorIs(t, err, ErrImageFormat)
+	_, _, err = DecodeImage(pngData.Bytes()[:30], 1000)
+	assert.ErrorIs(t, err, ErrImageFormat)
+}
+
+func TestEncodeImage_StripsMetadata(t *testing.T) {
+	var jpegData bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&jpegData, testImage(8, 8), nil))
+	img, format, err := DecodeImage(withExifOrientation(jpegData.Bytes(), 3), 1000)
+	assert.NoError(t, err)
+
+	encoded, encodedFormat, err := EncodeImage(img, format)
+	assert.NoError(t, err)
+	assert.Equal(t, ImageFormatJPEG, encodedFormat)
+	assert.False(t, bytes.Contains(encoded, []byte("Exif")))
+	assert.Equal(t, 1, jpegOrientation(encoded))
+
+	_, encodedFormat, err = EncodeImage(testImage(8, 8), ImageFormatWebP)
+	assert.NoError(t, err)
+	assert.Equal(t, ImageFormatJPEG, encodedFormat, "opaque WebP becomes JPEG")
+	transparent := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	_, encodedFormat, err = EncodeImage(transparent, ImageFormatWebP)
+	assert.NoError(t, err)
+	assert.Equal(t, ImageFormatPNG, encodedFormat, "transparent WebP becomes PNG")
+}
+
+func TestFitImage(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 100, 50), FitImage(testImage(200, 100), 100).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 50, 100), FitImage(testImage(100, 200), 100).Bounds())
+	small := testImage(40, 20)
+	assert.Same(t, small, FitImage(small, 100))
+}
+
+func TestSquareThumbnail(t *testing.T) {
+	for _, size := range []int{16, 64} {
+		thumbnail := SquareThumbnail(testImage(60, 30), size)
+		assert.Equal(t, image.Rect(0, 0, size, size), thumbnail.Bounds())
+	}
+}
+
+func TestOrientImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	a := color.NRGBA{R: 255, A: 255}
+	b := color.NRGBA{B: 255, A: 255}
+	img.Set(0, 0, a)
+	img.Set(1, 0, b)
+
+	tests := []struct {
+		orientation int
+		top         color.NRGBA
+		bounds      image.Rectangle
+	}{
+		{1, a, image.Rect(0, 0, 2, 1)},
+		{2, b, image.Rect(0, 0, 2, 1)},
+		{5, a, image.Rect(0, 0, 1, 2)},
+		{6, a, image.Rect(0, 0, 1, 2)},
+		{7, b, image.Rect(0, 0, 1, 2)},
+		{8, b, image.Rect(0, 0, 1, 2)},
+	}
+	for _, tt := range tests {
+		oriented := orientImage(img, tt.orientation)
+		assert.Equal(t, tt.bounds, oriented.Bounds(), "orientation %d", tt.orientation)
+		assert.Equal(t, color.NRGBAModel.Convert(tt.top), color.NRGBAModel.Convert(oriented.At(0, 0)), "orientation %d", tt.orientation)
+	}
+}
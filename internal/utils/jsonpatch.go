@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	ErrJSONPatchInvalid    = errors.New("invalid json patch")
+	ErrJSONPatchTestFailed = errors.New("json patch test operation failed")
+)
+
+type JSONPatchOperation struct {
+	Op    string           `json:"op"`
+	Path  string           `json:"path"`
+	From  string           `json:"from,omitempty"`
+	Value *json.RawMessage `json:"value,omitempty"`
+}
+
+// MergePatch applies an RFC 7396 merge patch to the JSON document.
+func MergePatch(document []byte, patch []byte) ([]byte, error) {
+	var target, patchValue any
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJSONPatchInvalid, err)
+	}
+
+	return json.Marshal(mergeValue(target, patchValue))
+}
+
+func mergeValue(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// ApplyJSONPatch applies an RFC 6902 patch to the JSON document. Operations are applied
+// in order and the whole patch fails if any of them does.
+func ApplyJSONPatch(document []byte, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, err
+	}
+	operations := []JSONPatchOperation{}
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJSONPatchInvalid, err)
+	}
+
+	var err error
+	for i, operation := range operations {
+		target, err = applyJSONPatchOperation(target, &operation)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyJSONPatchOperation(target any, operation *JSONPatchOperation) (any, error) {
+	path, err := parseJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, fmt.Errorf("%w: %s requires a value", ErrJSONPatchInvalid, operation.Op)
+		}
+		var value any
+		if err = json.Unmarshal(*operation.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrJSONPatchInvalid, err)
+		}
+		switch operation.Op {
+		case "add":
+			return addJSONValue(target, path, value)
+		case "replace":
+			if target, err = removeJSONValue(target, path); err != nil {
+				return nil, err
+			}
+			return addJSONValue(target, path, value)
+		default:
+			current, err := getJSONValue(target, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("%w: %s", ErrJSONPatchTestFailed, operation.Path)
+			}
+			return target, nil
+		}
+	case "remove":
+		return removeJSONValue(target, path)
+	case "move", "copy":
+		from, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getJSONValue(target, from)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "move" {
+			if strings.HasPrefix(operation.Path, operation.From+"/") {
+				return nil, fmt.Errorf("%w: cannot move %s into itself", ErrJSONPatchInvalid, operation.From)
+			}
+			if target, err = removeJSONValue(target, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = copyJSONValue(value)
+		}
+		return addJSONValue(target, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrJSONPatchInvalid, operation.Op)
+	}
+}
+
+// parseJSONPointer splits an RFC 6901 pointer into unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: bad pointer %q", ErrJSONPatchInvalid, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func getJSONValue(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch container := node.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path %q not found", ErrJSONPatchInvalid, token)
+			}
+			node = value
+		case []any:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = container[index]
+		default:
+			return nil, fmt.Errorf("%w: path %q not found", ErrJSONPatchInvalid, token)
+		}
+	}
+
+	return node, nil
+}
+
+func addJSONValue(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateJSONParent(node, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			if token == "-" {
+				return append(container, value), nil
+			}
+			index, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("%w: cannot add %q", ErrJSONPatchInvalid, token)
+		}
+	})
+}
+
+func removeJSONValue(node any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	return updateJSONParent(node, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("%w: path %q not found", ErrJSONPatchInvalid, token)
+			}
+			delete(container, token)
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(container[:index], container[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: cannot remove %q", ErrJSONPatchInvalid, token)
+		}
+	})
+}
+
+// updateJSONParent walks to the parent of the last token, lets apply change it and
+// stores the result back, since appending to a slice may return a new one.
+func updateJSONParent(node any, path []string, apply func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return apply(node, path[0])
+	}
+
+	child, err := getJSONValue(node, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateJSONParent(child, path[1:], apply)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := node.(type) {
+	case map[string]any:
+		container[path[0]] = child
+	case []any:
+		index, _ := arrayIndex(path[0], len(container)-1)
+		container[index] = child
+	}
+
+	return node, nil
+}
+
+func arrayIndex(token string, maxIndex int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > maxIndex || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: bad array index %q", ErrJSONPatchInvalid, token)
+	}
+
+	return index, nil
+}
+
+func copyJSONValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(typed))
+		for key, item := range typed {
+			copied[key] = copyJSONValue(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(typed))
+		for i, item := range typed {
+			copied[i] = copyJSONValue(item)
+		}
+		return copied
+	default:
+		return value
+	}
+}
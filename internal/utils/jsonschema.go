@@ -0,0 +1,367 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrJSONSchemaInvalid   = errors.New("invalid json schema")
+	ErrJSONSchemaViolation = errors.New("value doesn't match json schema")
+)
+
+// jsonSchemaAnnotations are keywords that are accepted but don't constrain anything.
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+var jsonSchemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// JSONSchema is a compiled subset of JSON Schema: type, enum, const, properties, required,
+// additionalProperties, items, min/maxItems, uniqueItems, min/maxLength, pattern, format
+// (email, date, date-time, uuid) and the numeric bounds. Any other keyword is rejected
+// when compiling, so a schema never looks stricter than it is.
+type JSONSchema struct {
+	types                []string
+	enum                 []any
+	constValue           any
+	hasConst             bool
+	properties           map[string]*JSONSchema
+	required             []string
+	additionalProperties *JSONSchema
+	noAdditional         bool
+	items                *JSONSchema
+	minItems, maxItems   *int
+	uniqueItems          bool
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	format               string
+	minimum, maximum     *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+}
+
+func CompileJSONSchema(data []byte) (*JSONSchema, error) {
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJSONSchemaInvalid, err)
+	}
+
+	return compileJSONSchema(document, "")
+}
+
+func compileJSONSchema(document any, path string) (*JSONSchema, error) {
+	fail := func(format string, args ...any) (*JSONSchema, error) {
+		return nil, fmt.Errorf("%w: %s: %s", ErrJSONSchemaInvalid, pathOrRoot(path), fmt.Sprintf(format, args...))
+	}
+	object, ok := document.(map[string]any)
+	if !ok {
+		return fail("schema must be an object")
+	}
+
+	schema := &JSONSchema{}
+	var err error
+	for keyword, value := range object {
+		switch keyword {
+		case "type":
+			switch typed := value.(type) {
+			case string:
+				schema.types = []string{typed}
+			case []any:
+				for _, item := range typed {
+					name, ok := item.(string)
+					if !ok {
+						return fail("type must be a string or a list of strings")
+					}
+					schema.types = append(schema.types, name)
+				}
+			default:
+				return fail("type must be a string or a list of strings")
+			}
+			for _, name := range schema.types {
+				if !jsonSchemaTypes[name] {
+					return fail("unknown type %q", name)
+				}
+			}
+		case "enum":
+			enum, ok := value.([]any)
+			if !ok || len(enum) == 0 {
+				return fail("enum must be a non-empty list")
+			}
+			schema.enum = enum
+		case "const":
+			schema.constValue, schema.hasConst = value, true
+		case "properties":
+			properties, ok := value.(map[string]any)
+			if !ok {
+				return fail("properties must be an object")
+			}
+			schema.properties = make(map[string]*JSONSchema, len(properties))
+			for name, property := range properties {
+				if schema.properties[name], err = compileJSONSchema(property, path+"/"+name); err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			required, ok := value.([]any)
+			if !ok {
+				return fail("required must be a list of strings")
+			}
+			for _, item := range required {
+				name, ok := item.(string)
+				if !ok {
+					return fail("required must be a list of strings")
+				}
+				schema.required = append(schema.required, name)
+			}
+		case "additionalProperties":
+			if allowed, ok := value.(bool); ok {
+				schema.noAdditional = !allowed
+				continue
+			}
+			if schema.additionalProperties, err = compileJSONSchema(value, path+"/additionalProperties"); err != nil {
+				return nil, err
+			}
+		case "items":
+			if schema.items, err = compileJSONSchema(value, path+"/items"); err != nil {
+				return nil, err
+			}
+		case "uniqueItems":
+			if schema.uniqueItems, ok = value.(bool); !ok {
+				return fail("uniqueItems must be a boolean")
+			}
+		case "minItems", "maxItems", "minLength", "maxLength":
+			number, ok := value.(float64)
+			if !ok || number < 0 || number != math.Trunc(number) {
+				return fail("%s must be a non-negative integer", keyword)
+			}
+			n := int(number)
+			switch keyword {
+			case "minItems":
+				schema.minItems = &n
+			case "maxItems":
+				schema.maxItems = &n
+			case "minLength":
+				schema.minLength = &n
+			default:
+				schema.maxLength = &n
+			}
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			number, ok := value.(float64)
+			if !ok {
+				return fail("%s must be a number", keyword)
+			}
+			switch keyword {
+			case "minimum":
+				schema.minimum = &number
+			case "maximum":
+				schema.maximum = &number
+			case "exclusiveMinimum":
+				schema.exclusiveMinimum = &number
+			default:
+				schema.exclusiveMaximum = &number
+			}
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return fail("pattern must be a string")
+			}
+			if schema.pattern, err = regexp.Compile(pattern); err != nil {
+				return fail("pattern: %v", err)
+			}
+		case "format":
+			format, ok := value.(string)
+			if !ok || jsonSchemaFormats[format] == nil {
+				return fail("unsupported format %v", value)
+			}
+			schema.format = format
+		default:
+			if !jsonSchemaAnnotations[keyword] {
+				return fail("unsupported keyword %q", keyword)
+			}
+		}
+	}
+
+	return schema, nil
+}
+
+// IsObject reports whether the schema only accepts objects.
+func (s *JSONSchema) IsObject() bool {
+	return len(s.types) == 1 && s.types[0] == "object"
+}
+
+// Validate checks a value decoded by encoding/json and reports every violation at once.
+func (s *JSONSchema) Validate(value any) error {
+	violations := s.validate(value, "", nil)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrJSONSchemaViolation, strings.Join(violations, "; "))
+}
+
+func (s *JSONSchema) validate(value any, path string, violations []string) []string {
+	violate := func(format string, args ...any) []string {
+		return append(violations, pathOrRoot(path)+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(s.types) > 0 && !s.hasType(value) {
+		return violate("expected %s", strings.Join(s.types, " or "))
+	}
+	if s.hasConst && !reflect.DeepEqual(value, s.constValue) {
+		violations = violate("must be %v", s.constValue)
+	}
+	if s.enum != nil && !containsJSONValue(s.enum, value) {
+		violations = violate("must be one of %v", s.enum)
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := typed[name]; !ok {
+				violations = append(violations, path+"/"+name+": is required")
+			}
+		}
+		names := make([]string, 0, len(typed))
+		for name := range typed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.properties[name]
+			switch {
+			case ok:
+				violations = property.validate(typed[name], path+"/"+name, violations)
+			case s.additionalProperties != nil:
+				violations = s.additionalProperties.validate(typed[name], path+"/"+name, violations)
+			case s.noAdditional:
+				violations = append(violations, path+"/"+name+": is not allowed")
+			}
+		}
+	case []any:
+		if s.minItems != nil && len(typed) < *s.minItems {
+			violations = violate("must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(typed) > *s.maxItems {
+			violations = violate("must have at most %d items", *s.maxItems)
+		}
+		for i, item := range typed {
+			if s.uniqueItems && containsJSONValue(typed[:i], item) {
+				violations = violate("items must be unique")
+			}
+			if s.items != nil {
+				violations = s.items.validate(item, fmt.Sprintf("%s/%d", path, i), violations)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(typed)
+		if s.minLength != nil && length < *s.minLength {
+			violations = violate("must be at least %d characters long", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			violations = violate("must be at most %d characters long", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(typed) {
+			violations = violate("must match %s", s.pattern)
+		}
+		if s.format != "" && !jsonSchemaFormats[s.format](typed) {
+			violations = violate("must be a valid %s", s.format)
+		}
+	case float64:
+		if s.minimum != nil && typed < *s.minimum {
+			violations = violate("must be at least %v", *s.minimum)
+		}
+		if s.maximum != nil && typed > *s.maximum {
+			violations = violate("must be at most %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && typed <= *s.exclusiveMinimum {
+			violations = violate("must be greater than %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && typed >= *s.exclusiveMaximum {
+			violations = violate("must be less than %v", *s.exclusiveMaximum)
+		}
+	}
+
+	return violations
+}
+
+func (s *JSONSchema) hasType(value any) bool {
+	for _, name := range s.types {
+		switch typed := value.(type) {
+		case map[string]any:
+			if name == "object" {
+				return true
+			}
+		case []any:
+			if name == "array" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case float64:
+			if name == "number" || (name == "integer" && typed == math.Trunc(typed)) {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case nil:
+			if name == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var jsonSchemaFormats = map[string]func(value string) bool{
+	"email": func(value string) bool {
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	},
+	"date": func(value string) bool {
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	},
+	"date-time": func(value string) bool {
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	},
+	"uuid": func(value string) bool {
+		_, err := uuid.Parse(value)
+		return err == nil
+	},
+}
+
+func containsJSONValue(values []any, value any) bool {
+	for _, item := range values {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
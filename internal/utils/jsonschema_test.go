@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAttributesSchema = `{
+	"type": "object",
+	"properties": {
+		"department": {"type": "string", "enum": ["sales", "engineering"]},
+		"employee_id": {"type": "string", "pattern": "^E[0-9]{4}$"},
+		"floor": {"type": "integer", "minimum": 0, "maximum": 40},
+		"skills": {"type": "array", "items": {"type": "string", "minLength": 1}, "uniqueItems": true},
+		"manager_email": {"type": ["string", "null"], "format": "email"}
+	},
+	"required": ["department"],
+	"additionalProperties": false
+}`
+
+func TestJSONSchema_Validate(t *testing.T) {
+	schema, err := CompileJSONSchema([]byte(testAttributesSchema))
+	assert.NoError(t, err)
+	assert.True(t, schema.IsObject())
+
+	tests := []struct {
+		name      string
+		value     string
+		wantError string
+	}{
+		{"valid", `{"department": "sales", "employee_id": "E0042", "floor": 3, "skills": ["go"], "manager_email": null}`, ""},
+		{"missing required", `{"floor": 3}`, "/department: is required"},
+		{"enum", `{"department": "hr"}`, "/department: must be one of"},
+		{"pattern", `{"department": "sales", "employee_id": "42"}`, "/employee_id: must match"},
+		{"integer", `{"department": "sales", "floor": 2.5}`, "/floor: expected integer"},
+		{"maximum", `{"department": "sales", "floor": 41}`, "/floor: must be at most 40"},
+		{"unique items", `{"department": "sales", "skills": ["go", "go"]}`, "/skills: items must be unique"},
+		{"item", `{"department": "sales", "skills": [""]}`, "/skills/0: must be at least 1 characters long"},
+		{"format", `{"department": "sales", "manager_email": "boss"}`, "/manager_email: must be a valid email"},
+		{"additional", `{"department": "sales", "location": "Kyiv"}`, "/location: is not allowed"},
+		{"not an object", `[]`, "/: expected object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			assert.NoError(t, json.Unmarshal([]byte(tt.value), &value))
+			err := schema.Validate(value)
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrJSONSchemaViolation)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestCompileJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"annotations", `{"type": "object", "title": "Profile", "description": "extra fields"}`, false},
+		{"not json", `{`, true},
+		{"not an object", `"object"`, true},
+		{"unknown type", `{"type": "date"}`, true},
+		{"unsupported keyword", `{"type": "object", "oneOf": []}`, true},
+		{"unsupported format", `{"type": "string", "format": "hostname"}`, true},
+		{"bad pattern", `{"type": "string", "pattern": "("}`, true},
+		{"negative length", `{"type": "string", "maxLength": -1}`, true},
+		{"nested error", `{"type": "object", "properties": {"a": {"minimum": "1"}}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileJSONSchema([]byte(tt.schema))
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrJSONSchemaInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type PageInfo struct {
+	Page       int
+	HasMore    bool
+	NextCursor string
+	PrevCursor string
+	TotalPages *int
+}
+
+// BuildLinkHeader renders RFC 8288 first, prev, next and last links for a listing served at requestURL.
+// Cursor requests link through cursors and have no last link.
+func BuildLinkHeader(requestURL *url.URL, pageInfo *PageInfo) string {
+	links := make([]string, 0, 4)
+	addLink := func(rel string, set func(query url.Values)) {
+		linkURL := *requestURL
+		query := linkURL.Query()
+		query.Del("page")
+		query.Del("after")
+		set(query)
+		linkURL.RawQuery = query.Encode()
+		links = append(links, "<"+linkURL.String()+`>; rel="`+rel+`"`)
+	}
+	setPage := func(page int) func(query url.Values) {
+		return func(query url.Values) { query.Set("page", strconv.Itoa(page)) }
+	}
+	setCursor := func(cursor string) func(query url.Values) {
+		return func(query url.Values) { query.Set("after", cursor) }
+	}
+
+	addLink("first", setPage(1))
+	if requestURL.Query().Get("after") != "" {
+		if pageInfo.PrevCursor != "" {
+			addLink("prev", setCursor(pageInfo.PrevCursor))
+		}
+		if pageInfo.HasMore && pageInfo.NextCursor != "" {
+			addLink("next", setCursor(pageInfo.NextCursor))
+		}
+		return strings.Join(links, ", ")
+	}
+
+	page := pageInfo.Page
+	if page < 1 {
+		page = 1
+	}
+	if page > 1 {
+		addLink("prev", setPage(page-1))
+	}
+	if pageInfo.HasMore {
+		addLink("next", setPage(page+1))
+	}
+	if pageInfo.TotalPages != nil && *pageInfo.TotalPages > 0 {
+		addLink("last", setPage(*pageInfo.TotalPages))
+	}
+
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLinkHeader(t *testing.T) {
+	totalPages := 40
+	tests := []struct {
+		name       string
+		requestURL string
+		pageInfo   *PageInfo
+		want       string
+	}{
+		{
+			"first page",
+			"http://localhost/users?size=10",
+			&PageInfo{Page: 0, HasMore: true},
+			`<http://localhost/users?page=1&size=10>; rel="first", <http://localhost/users?page=2&size=10>; rel="next"`,
+		},
+		{
+			"middle page with total",
+			"http://localhost/users?page=3&size=10&count=exact",
+			&PageInfo{Page: 3, HasMore: true, TotalPages: &totalPages},
+			`<http://localhost/users?count=exact&page=1&size=10>; rel="first", ` +
+				`<http://localhost/users?count=exact&page=2&size=10>; rel="prev", ` +
+				`<http://localhost/users?count=exact&page=4&size=10>; rel="next", ` +
+				`<http://localhost/users?count=exact&page=40&size=10>; rel="last"`,
+		},
+		{
+			"cursor page",
+			"http://localhost/users?after=abc",
+			&PageInfo{HasMore: true, NextCursor: "def", PrevCursor: "ghi", TotalPages: &totalPages},
+			`<http://localhost/users?page=1>; rel="first", <http://localhost/users?after=ghi>; rel="prev", <http://localhost/users?after=def>; rel="next"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestURL, err := url.Parse(tt.requestURL)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, BuildLinkHeader(requestURL, tt.pageInfo))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// nicknameConfusablesFrom and nicknameConfusablesTo pair look-alike runes with the Latin
+// letter they are mistaken for, rune by rune. The migration that added users.nickname_key
+// backfills it with the same pairs through translate(), so keep both in sync.
+const (
+	nicknameConfusablesFrom = "01|ıаеорсухіјѕԁһԛԝѵαονρικχυ"
+	nicknameConfusablesTo   = "olliaeopcyxijsdhqwvaovpikxu"
+)
+
+var nicknameConfusables = buildNicknameConfusables()
+
+func buildNicknameConfusables() map[rune]rune {
+	from, to := []rune(nicknameConfusablesFrom), []rune(nicknameConfusablesTo)
+	confusables := make(map[rune]rune, len(from))
+	for i := range from {
+		confusables[from[i]] = to[i]
+	}
+	return confusables
+}
+
+// NicknameKey is the form nicknames are compared in: NFKC, case folded, with look-alike
+// characters replaced, so "Admin", "ＡＤＭＩＮ" and "аdmin" with a Cyrillic "а" share one key.
+func NicknameKey(nickname string) string {
+	folded := norm.NFKC.String(cases.Fold().String(norm.NFKC.String(strings.TrimSpace(nickname))))
+	return strings.Map(func(r rune) rune {
+		if confusable, ok := nicknameConfusables[r]; ok {
+			return confusable
+		}
+		return r
+	}, folded)
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNicknameKey(t *testing.T) {
+	tests := []struct {
+		nickname string
+		want     string
+	}{
+		{"admin", "admin"},
+		{"Admin", "admin"},
+		{"ＡＤＭＩＮ", "admin"},
+		{"аdmin", "admin"},
+		{"АDMIN", "admin"},
+		{"r00t", "root"},
+		{"pau1", "paul"},
+		{" john ", "john"},
+		{"Straße", "strasse"},
+		{"ｊｏｈｎ＿ｄｏｅ", "john_doe"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NicknameKey(tt.nickname), tt.nickname)
+	}
+	assert.NotEqual(t, NicknameKey("john"), NicknameKey("jane"))
+}
+
+func TestNicknameConfusables(t *testing.T) {
+	assert.Equal(t, utf8.RuneCountInString(nicknameConfusablesFrom), utf8.RuneCountInString(nicknameConfusablesTo))
+	for from, to := range nicknameConfusables {
+		assert.Equal(t, string(to), NicknameKey(string(from)), "%q", from)
+	}
+}
@@ -1,18 +1,24 @@
 package utils
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 )
 
 const (
-	defaultSize = 2
+	defaultSize    = 2
+	CountExact     = "exact"
+	CountEstimated = "estimated"
 )
 
 type PaginationQuery struct {
-	Size    int    `json:"size,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	OrderBy string `json:"orderBy,omitempty"`
+	Size    int         `json:"size,omitempty"`
+	Page    int         `json:"page,omitempty"`
+	OrderBy string      `json:"orderBy,omitempty"`
+	Filter  *UserFilter `json:"filter,omitempty"`
+	Cursor  string      `json:"cursor,omitempty"`
+	Count   string      `json:"count,omitempty"`
 }
 
 func GetPaginationFromCtx(page string, size string, orderBy string) (*PaginationQuery, error) {
@@ -40,6 +46,19 @@ func GetHasMore(currentPage int, totalCount int, pageSize int) bool {
 	return currentPage < totalCount/pageSize
 }
 
+func (q *PaginationQuery) SetCount(countQuery string) error {
+	if countQuery != "" && countQuery != CountExact && countQuery != CountEstimated {
+		return fmt.Errorf("count: unknown mode %q", countQuery)
+	}
+	q.Count = countQuery
+
+	return nil
+}
+
+func (q *PaginationQuery) IsKeyset() bool {
+	return q.Cursor != ""
+}
+
 func (q *PaginationQuery) GetSize() int {
 	return q.Size
 }
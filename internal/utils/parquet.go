@@ -0,0 +1,386 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Parquet physical, converted and thrift compact types used by ParquetWriter.
+const (
+	parquetTypeBoolean   = 0
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMicros = 10
+
+	parquetRepetitionOptional = 1
+	parquetEncodingPlain      = 0
+	parquetEncodingRLE        = 3
+	parquetCodecUncompressed  = 0
+	parquetPageData           = 0
+
+	thriftTypeI32    = 5
+	thriftTypeI64    = 6
+	thriftTypeBinary = 8
+	thriftTypeList   = 9
+	thriftTypeStruct = 12
+)
+
+const parquetMagic = "PAR1"
+
+type ParquetType int
+
+const (
+	ParquetString ParquetType = iota
+	ParquetInt64
+	ParquetBool
+	ParquetTimestamp
+)
+
+type ParquetColumn struct {
+	Name string
+	Type ParquetType
+}
+
+type parquetColumnChunk struct {
+	offset int64
+	size   int64
+}
+
+type parquetRowGroup struct {
+	numRows int64
+	chunks  []parquetColumnChunk
+}
+
+// ParquetWriter writes rows as an uncompressed Parquet file with optional, plain encoded
+// columns. Rows are kept in memory only until rowGroupSize of them make a row group.
+type ParquetWriter struct {
+	w            io.Writer
+	offset       int64
+	columns      []ParquetColumn
+	rowGroupSize int
+	rows         [][]any
+	rowGroups    []parquetRowGroup
+	numRows      int64
+}
+
+func NewParquetWriter(w io.Writer, columns []ParquetColumn, rowGroupSize int) *ParquetWriter {
+	return &ParquetWriter{
+		w:            w,
+		columns:      columns,
+		rowGroupSize: rowGroupSize,
+		rows:         make([][]any, 0, rowGroupSize),
+	}
+}
+
+// WriteRow adds a row with one value per column: string, int64, bool, time.Time or nil for null.
+func (pw *ParquetWriter) WriteRow(values []any) error {
+	if len(values) != len(pw.columns) {
+		return fmt.Errorf("parquet: expected %d values, got %d", len(pw.columns), len(values))
+	}
+	if pw.offset == 0 {
+		if err := pw.write([]byte(parquetMagic)); err != nil {
+			return err
+		}
+	}
+	pw.rows = append(pw.rows, values)
+	if len(pw.rows) >= pw.rowGroupSize {
+		return pw.flushRowGroup()
+	}
+
+	return nil
+}
+
+// Close writes the last row group and the footer. It doesn't close the underlying writer.
+func (pw *ParquetWriter) Close() error {
+	if pw.offset == 0 {
+		if err := pw.write([]byte(parquetMagic)); err != nil {
+			return err
+		}
+	}
+	if err := pw.flushRowGroup(); err != nil {
+		return err
+	}
+
+	footer := pw.fileMetaData()
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(footer)))
+	for _, part := range [][]byte{footer, length, []byte(parquetMagic)} {
+		if err := pw.write(part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pw *ParquetWriter) write(p []byte) error {
+	n, err := pw.w.Write(p)
+	pw.offset += int64(n)
+	return err
+}
+
+func (pw *ParquetWriter) flushRowGroup() error {
+	if len(pw.rows) == 0 {
+		return nil
+	}
+
+	rowGroup := parquetRowGroup{numRows: int64(len(pw.rows)), chunks: make([]parquetColumnChunk, 0, len(pw.columns))}
+	for i, column := range pw.columns {
+		page, err := encodeParquetPage(column, pw.rows, i)
+		if err != nil {
+			return err
+		}
+		header := parquetPageHeader(len(pw.rows), len(page))
+		chunk := parquetColumnChunk{offset: pw.offset, size: int64(len(header) + len(page))}
+		if err = pw.write(header); err != nil {
+			return err
+		}
+		if err = pw.write(page); err != nil {
+			return err
+		}
+		rowGroup.chunks = append(rowGroup.chunks, chunk)
+	}
+	pw.rowGroups = append(pw.rowGroups, rowGroup)
+	pw.numRows += rowGroup.numRows
+	pw.rows = pw.rows[:0]
+
+	return nil
+}
+
+// encodeParquetPage builds a v1 data page: definition levels as bit-packed runs of width 1
+// behind their length, followed by the plain encoded non-null values.
+func encodeParquetPage(column ParquetColumn, rows [][]any, index int) ([]byte, error) {
+	levels := make([]byte, (len(rows)+7)/8)
+	var values bytes.Buffer
+	var booleans []bool
+	for i, row := range rows {
+		value := row[index]
+		if value == nil {
+			continue
+		}
+		levels[i/8] |= 1 << (i % 8)
+
+		switch column.Type {
+		case ParquetString:
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("parquet: column %s expects a string, got %T", column.Name, value)
+			}
+			_ = binary.Write(&values, binary.LittleEndian, uint32(len(s)))
+			values.WriteString(s)
+		case ParquetInt64:
+			n, ok := value.(int64)
+			if !ok {
+				return nil, fmt.Errorf("parquet: column %s expects an int64, got %T", column.Name, value)
+			}
+			_ = binary.Write(&values, binary.LittleEndian, n)
+		case ParquetTimestamp:
+			t, ok := value.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("parquet: column %s expects a time.Time, got %T", column.Name, value)
+			}
+			_ = binary.Write(&values, binary.LittleEndian, t.UnixMicro())
+		case ParquetBool:
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("parquet: column %s expects a bool, got %T", column.Name, value)
+			}
+			booleans = append(booleans, b)
+		}
+	}
+	if column.Type == ParquetBool {
+		packed := make([]byte, (len(booleans)+7)/8)
+		for i, b := range booleans {
+			if b {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		values.Write(packed)
+	}
+
+	var levelRuns bytes.Buffer
+	writeUvarint(&levelRuns, uint64(len(levels))<<1|1)
+	levelRuns.Write(levels)
+
+	var page bytes.Buffer
+	_ = binary.Write(&page, binary.LittleEndian, uint32(levelRuns.Len()))
+	page.Write(levelRuns.Bytes())
+	page.Write(values.Bytes())
+	if page.Len() > math.MaxInt32 {
+		return nil, fmt.Errorf("parquet: column %s page is too large", column.Name)
+	}
+
+	return page.Bytes(), nil
+}
+
+func parquetPageHeader(numValues int, pageSize int) []byte {
+	t := newThriftWriter()
+	t.i32(1, parquetPageData)
+	t.i32(2, int32(pageSize))
+	t.i32(3, int32(pageSize))
+	t.structField(5)
+	t.i32(1, int32(numValues))
+	t.i32(2, parquetEncodingPlain)
+	t.i32(3, parquetEncodingRLE)
+	t.i32(4, parquetEncodingRLE)
+	t.end()
+	t.end()
+
+	return t.buf.Bytes()
+}
+
+func (pw *ParquetWriter) fileMetaData() []byte {
+	t := newThriftWriter()
+	t.i32(1, 1)
+
+	t.list(2, thriftTypeStruct, len(pw.columns)+1)
+	t.begin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(pw.columns)))
+	t.end()
+	for _, column := range pw.columns {
+		physicalType, convertedType := parquetTypes(column.Type)
+		t.begin()
+		t.i32(1, physicalType)
+		t.i32(3, parquetRepetitionOptional)
+		t.binary(4, column.Name)
+		if convertedType >= 0 {
+			t.i32(6, convertedType)
+		}
+		t.end()
+	}
+
+	t.i64(3, pw.numRows)
+
+	t.list(4, thriftTypeStruct, len(pw.rowGroups))
+	for _, rowGroup := range pw.rowGroups {
+		t.begin()
+		t.list(1, thriftTypeStruct, len(rowGroup.chunks))
+		var totalSize int64
+		for i, chunk := range rowGroup.chunks {
+			physicalType, _ := parquetTypes(pw.columns[i].Type)
+			totalSize += chunk.size
+			t.begin()
+			t.i64(2, chunk.offset)
+			t.structField(3)
+			t.i32(1, physicalType)
+			t.list(2, thriftTypeI32, 2)
+			t.listI32(parquetEncodingPlain)
+			t.listI32(parquetEncodingRLE)
+			t.list(3, thriftTypeBinary, 1)
+			t.listBinary(pw.columns[i].Name)
+			t.i32(4, parquetCodecUncompressed)
+			t.i64(5, rowGroup.numRows)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.end()
+			t.end()
+		}
+		t.i64(2, totalSize)
+		t.i64(3, rowGroup.numRows)
+		t.end()
+	}
+
+	t.binary(6, "usermanager")
+	t.end()
+
+	return t.buf.Bytes()
+}
+
+func parquetTypes(columnType ParquetType) (int32, int32) {
+	switch columnType {
+	case ParquetInt64:
+		return parquetTypeInt64, -1
+	case ParquetBool:
+		return parquetTypeBoolean, -1
+	case ParquetTimestamp:
+		return parquetTypeInt64, parquetConvertedTimestampMicros
+	default:
+		return parquetTypeByteArray, parquetConvertedUTF8
+	}
+}
+
+// thriftWriter encodes the thrift compact protocol, which Parquet uses for its metadata.
+type thriftWriter struct {
+	buf        bytes.Buffer
+	lastFields []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastFields: []int16{0}}
+}
+
+func (t *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	last := &t.lastFields[len(t.lastFields)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		t.buf.WriteByte(fieldType)
+		writeUvarint(&t.buf, zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) i32(id int16, value int32) {
+	t.fieldHeader(id, thriftTypeI32)
+	t.listI32(value)
+}
+
+func (t *thriftWriter) i64(id int16, value int64) {
+	t.fieldHeader(id, thriftTypeI64)
+	writeUvarint(&t.buf, zigzag(value))
+}
+
+func (t *thriftWriter) binary(id int16, value string) {
+	t.fieldHeader(id, thriftTypeBinary)
+	t.listBinary(value)
+}
+
+func (t *thriftWriter) list(id int16, elementType byte, size int) {
+	t.fieldHeader(id, thriftTypeList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	t.buf.WriteByte(0xf0 | elementType)
+	writeUvarint(&t.buf, uint64(size))
+}
+
+func (t *thriftWriter) listI32(value int32) {
+	writeUvarint(&t.buf, zigzag(int64(value)))
+}
+
+func (t *thriftWriter) listBinary(value string) {
+	writeUvarint(&t.buf, uint64(len(value)))
+	t.buf.WriteString(value)
+}
+
+func (t *thriftWriter) structField(id int16) {
+	t.fieldHeader(id, thriftTypeStruct)
+	t.begin()
+}
+
+func (t *thriftWriter) begin() {
+	t.lastFields = append(t.lastFields, 0)
+}
+
+func (t *thriftWriter) end() {
+	t.buf.WriteByte(0)
+	t.lastFields = t.lastFields[:len(t.lastFields)-1]
+}
+
+func zigzag(value int64) uint64 {
+	return uint64((value << 1) ^ (value >> 63))
+}
+
+func writeUvarint(buf *bytes.Buffer, value uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], value)])
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parquetTestColumns = []ParquetColumn{
+	{Name: "nickname", Type: ParquetString},
+	{Name: "version", Type: ParquetInt64},
+	{Name: "is_public", Type: ParquetBool},
+	{Name: "created_at", Type: ParquetTimestamp},
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	pw := NewParquetWriter(&buf, parquetTestColumns, 2)
+	now := time.Now()
+	assert.NoError(t, pw.WriteRow([]any{"jdoe", int64(1), true, now}))
+	assert.Equal(t, 4, buf.Len(), "rows stay buffered until the row group is full")
+	assert.NoError(t, pw.WriteRow([]any{nil, int64(2), false, nil}))
+	rowGroupEnd := buf.Len()
+	assert.Greater(t, rowGroupEnd, 4)
+	assert.NoError(t, pw.WriteRow([]any{"asmith", nil, nil, now}))
+	assert.NoError(t, pw.Close())
+
+	file := buf.Bytes()
+	assert.Equal(t, parquetMagic, string(file[:4]))
+	assert.Equal(t, parquetMagic, string(file[len(file)-4:]))
+	footerLength := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := file[len(file)-8-footerLength : len(file)-8]
+	assert.Greater(t, len(file)-8-footerLength, rowGroupEnd)
+	for _, column := range parquetTestColumns {
+		assert.Contains(t, string(footer), column.Name)
+	}
+	assert.Contains(t, string(file[:rowGroupEnd]), "jdoe")
+	assert.Contains(t, string(file[rowGroupEnd:]), "asmith")
+}
+
+func TestParquetWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, NewParquetWriter(&buf, parquetTestColumns, 10).Close())
+	assert.Equal(t, parquetMagic, string(buf.Bytes()[:4]))
+	assert.Equal(t, parquetMagic, string(buf.Bytes()[buf.Len()-4:]))
+}
+
+func TestParquetWriter_BadRow(t *testing.T) {
+	pw := NewParquetWriter(&bytes.Buffer{}, parquetTestColumns, 1)
+	assert.Error(t, pw.WriteRow([]any{"jdoe"}))
+	assert.Error(t, pw.WriteRow([]any{"jdoe", 1, true, time.Now()}))
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateToken(t *testing.T) {
+	token, err := GenerateToken()
+	assert.NoError(t, err)
+	other, err := GenerateToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.NotEqual(t, token, other)
+	assert.Equal(t, HashToken(token), HashToken(token))
+	assert.NotEqual(t, HashToken(token), HashToken(other))
+}
@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+var UserSortFields = []string{"nickname", "first_name", "last_name", "user_role", "created_at", "updated_at", "login_date"}
+
+// privateUserSortFields order by data that is hidden from other users.
+var privateUserSortFields = []string{"login_date"}
+
+type SortField struct {
+	Field     string
+	Direction string
+}
+
+type UserFilter struct {
+	NicknamePrefix string     `json:"nicknamePrefix,omitempty"`
+	Role           string     `json:"role,omitempty"`
+	IsPublic       *bool      `json:"isPublic,omitempty"`
+	CreatedFrom    *time.Time `json:"createdFrom,omitempty"`
+	CreatedTo      *time.Time `json:"createdTo,omitempty"`
+	UpdatedFrom    *time.Time `json:"updatedFrom,omitempty"`
+	UpdatedTo      *time.Time `json:"updatedTo,omitempty"`
+	LoginFrom      *time.Time `json:"loginFrom,omitempty"`
+	LoginTo        *time.Time `json:"loginTo,omitempty"`
+	EmailDomain    string     `json:"emailDomain,omitempty"`
+	// Attributes come from attributes.<key>=<value> params and must all match.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// PublicOrUserID isn't read from the params, it leaves out the private profiles
+	// other than the one of that user. uuid.Nil leaves out all of them.
+	PublicOrUserID *uuid.UUID `json:"publicOrUserId,omitempty"`
+}
+
+const userFilterAttributesPrefix = "attributes."
+
+// GetUsersQueryFromCtx reads pagination, sorting and filters of a users listing.
+func GetUsersQueryFromCtx(params url.Values) (*PaginationQuery, error) {
+	q, err := GetPaginationFromCtx(params.Get("page"), params.Get("size"), params.Get("orderBy"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = q.GetSort(UserSortFields); err != nil {
+		return nil, err
+	}
+	q.Cursor = params.Get("after")
+	if err = q.SetCount(params.Get("count")); err != nil {
+		return nil, err
+	}
+
+	filter, err := GetUserFilterFromCtx(params)
+	if err != nil {
+		return nil, err
+	}
+	if !filter.IsEmpty() {
+		q.Filter = filter
+	}
+
+	return q, nil
+}
+
+func GetUserFilterFromCtx(params url.Values) (*UserFilter, error) {
+	filter := &UserFilter{
+		NicknamePrefix: params.Get("nicknamePrefix"),
+		Role:           params.Get("role"),
+		EmailDomain:    strings.ToLower(strings.TrimPrefix(params.Get("emailDomain"), "@")),
+	}
+
+	if isPublic := params.Get("isPublic"); isPublic != "" {
+		b, err := strconv.ParseBool(isPublic)
+		if err != nil {
+			return nil, fmt.Errorf("isPublic: %w", err)
+		}
+		filter.IsPublic = &b
+	}
+
+	dates := []struct {
+		name  string
+		dest  **time.Time
+		isEnd bool
+	}{
+		{"createdFrom", &filter.CreatedFrom, false},
+		{"createdTo", &filter.CreatedTo, true},
+		{"updatedFrom", &filter.UpdatedFrom, false},
+		{"updatedTo", &filter.UpdatedTo, true},
+		{"loginFrom", &filter.LoginFrom, false},
+		{"loginTo", &filter.LoginTo, true},
+	}
+	for _, date := range dates {
+		value := params.Get(date.name)
+		if value == "" {
+			continue
+		}
+		t, err := parseFilterDate(value, date.isEnd)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", date.name, err)
+		}
+		*date.dest = &t
+	}
+
+	for name, values := range params {
+		key, ok := strings.CutPrefix(name, userFilterAttributesPrefix)
+		if !ok {
+			continue
+		}
+		if key == "" {
+			return nil, fmt.Errorf("%s: attribute name is missing", name)
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = map[string]string{}
+		}
+		filter.Attributes[key] = values[0]
+	}
+
+	return filter, nil
+}
+
+func (f *UserFilter) IsEmpty() bool {
+	withoutAttributes := *f
+	withoutAttributes.Attributes = nil
+	return reflect.DeepEqual(withoutAttributes, UserFilter{}) && len(f.Attributes) == 0
+}
+
+// HasPrivateFields reports whether the filter looks at data that is hidden from other users.
+func (f *UserFilter) HasPrivateFields() bool {
+	return f.EmailDomain != "" || f.LoginFrom != nil || f.LoginTo != nil || len(f.Attributes) > 0
+}
+
+// HasPrivateSort reports whether the listing is ordered by data that is hidden from other users.
+func (q *PaginationQuery) HasPrivateSort() bool {
+	sortFields, _ := q.GetSort(UserSortFields)
+	for _, sortField := range sortFields {
+		if isAllowedField(sortField.Field, privateUserSortFields) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSort parses OrderBy as a comma separated list of field[:asc|desc].
+func (q *PaginationQuery) GetSort(allowedFields []string) ([]SortField, error) {
+	if q.OrderBy == "" {
+		return nil, nil
+	}
+
+	sortFields := make([]SortField, 0)
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(q.OrderBy, ",") {
+		field, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		direction = strings.ToLower(direction)
+		if direction == "" {
+			direction = SortAsc
+		}
+		if direction != SortAsc && direction != SortDesc {
+			return nil, fmt.Errorf("orderBy: unknown direction %q", direction)
+		}
+		if !isAllowedField(field, allowedFields) {
+			return nil, fmt.Errorf("orderBy: unknown field %q", field)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("orderBy: duplicate field %q", field)
+		}
+		seen[field] = true
+		sortFields = append(sortFields, SortField{Field: field, Direction: direction})
+	}
+
+	return sortFields, nil
+}
+
+func isAllowedField(field string, allowedFields []string) bool {
+	for _, allowedField := range allowedFields {
+		if field == allowedField {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFilterDate accepts RFC3339 or a plain date. Range ends are exclusive,
+// so a plain end date is moved to the next day to cover the whole day.
+func parseFilterDate(value string, isEnd bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isEnd {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
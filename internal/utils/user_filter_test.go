@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationQuery_GetSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderBy string
+		want    []SortField
+		wantErr bool
+	}{
+		{"Empty", "", nil, false},
+		{"Default direction", "nickname", []SortField{{Field: "nickname", Direction: SortAsc}}, false},
+		{"Multiple fields", "user_role:desc,created_at:asc", []SortField{{Field: "user_role", Direction: SortDesc}, {Field: "created_at", Direction: SortAsc}}, false},
+		{"Unknown field", "password", nil, true},
+		{"Unknown direction", "nickname:up", nil, true},
+		{"Duplicate field", "nickname,nickname:desc", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &PaginationQuery{OrderBy: tt.orderBy}
+			got, err := q.GetSort(UserSortFields)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGetUserFilterFromCtx(t *testing.T) {
+	isPublic := true
+	createdFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	loginFrom := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		params  url.Values
+		want    *UserFilter
+		wantErr bool
+	}{
+		{"Empty", url.Values{}, &UserFilter{}, false},
+		{
+			"All filters",
+			url.Values{
+				"nicknamePrefix": {"jo"},
+				"role":           {"admin"},
+				"isPublic":       {"true"},
+				"createdFrom":    {"2026-01-01"},
+				"createdTo":      {"2026-01-31"},
+				"loginFrom":      {"2026-01-15T10:30:00Z"},
+				"emailDomain":    {"@Example.com"},
+			},
+			&UserFilter{
+				NicknamePrefix: "jo",
+				Role:           "admin",
+				IsPublic:       &isPublic,
+				CreatedFrom:    &createdFrom,
+				CreatedTo:      &createdTo,
+				LoginFrom:      &loginFrom,
+				EmailDomain:    "example.com",
+			},
+			false,
+		},
+		{
+			"Attributes",
+			url.Values{"attributes.team": {"core"}, "attributes.level": {"3"}},
+			&UserFilter{Attributes: map[string]string{"team": "core", "level": "3"}},
+			false,
+		},
+		{"Attribute without name", url.Values{"attributes.": {"core"}}, nil, true},
+		{"Invalid isPublic", url.Values{"isPublic": {"maybe"}}, nil, true},
+		{"Invalid date", url.Values{"updatedTo": {"yesterday"}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUserFilterFromCtx(tt.params)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGetUsersQueryFromCtx(t *testing.T) {
+	q, err := GetUsersQueryFromCtx(url.Values{"page": {"1"}, "orderBy": {"nickname:desc"}})
+	assert.NoError(t, err)
+	assert.Nil(t, q.Filter)
+	assert.Equal(t, "nickname:desc", q.OrderBy)
+	assert.False(t, q.HasPrivateSort())
+
+	q, err = GetUsersQueryFromCtx(url.Values{"orderBy": {"nickname,login_date:desc"}})
+	assert.NoError(t, err)
+	assert.True(t, q.HasPrivateSort())
+
+	_, err = GetUsersQueryFromCtx(url.Values{"orderBy": {"email"}})
+	assert.Error(t, err)
+
+	q, err = GetUsersQueryFromCtx(url.Values{"attributes.team": {"core"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "core"}, q.Filter.Attributes)
+	assert.True(t, q.Filter.HasPrivateFields())
+}
@@ -1,7 +1,20 @@
 package utils
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 func ParseStrToTime(str string) (time.Time, error) {
 	return time.Parse(time.RFC3339, str)
 }
+
+// MaskEmail keeps the first character of the local part and the domain,
+// e.g. john@example.com becomes j***@example.com.
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return ""
+	}
+	return email[:1] + "***" + email[at:]
+}
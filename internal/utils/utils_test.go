@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{"Normal", "john@example.com", "j***@example.com"},
+		{"Short local part", "j@example.com", "j***@example.com"},
+		{"Empty", "", ""},
+		{"Not an email", "john", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MaskEmail(tt.email)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}